@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// exportPageSize is the number of audit events read from the database at once during an export.
+const exportPageSize = 1000
+
+// ExportSpace writes the audit events of a space to the writer as JSON lines.
+func (c *Controller) ExportSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	filter *types.AuditEventFilter,
+	w io.Writer,
+) error {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	filter.SpacePath = space.Path
+
+	return c.export(ctx, filter, w)
+}
+
+// Export writes the audit events of the whole system to the writer as JSON lines. Only admins can export them.
+func (c *Controller) Export(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.AuditEventFilter,
+	w io.Writer,
+) error {
+	if !session.IsSystemAdmin() {
+		return usererror.ErrForbidden
+	}
+
+	return c.export(ctx, filter, w)
+}
+
+func (c *Controller) export(
+	ctx context.Context,
+	filter *types.AuditEventFilter,
+	w io.Writer,
+) error {
+	if err := checkFilter(filter); err != nil {
+		return err
+	}
+
+	// Events recorded while the export is running must not shift the pages that are yet to be read.
+	now := time.Now().UnixMilli()
+	if filter.To <= 0 || filter.To > now {
+		filter.To = now
+	}
+
+	filter.Size = exportPageSize
+
+	enc := json.NewEncoder(w)
+
+	for filter.Page = 1; ; filter.Page++ {
+		events, err := c.auditEventStore.List(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list audit events: %w", err)
+		}
+
+		for _, event := range events {
+			if err := enc.Encode(event); err != nil {
+				return fmt.Errorf("failed to write audit event: %w", err)
+			}
+		}
+
+		if len(events) < exportPageSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListSpace lists the audit events of a space.
+// If the filter is recursive, the audit events of all subspaces are included as well.
+func (c *Controller) ListSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	filter *types.AuditEventFilter,
+) ([]*types.AuditEvent, int64, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	filter.SpacePath = space.Path
+
+	return c.list(ctx, filter)
+}
+
+// List lists the audit events of the whole system. Only admins can access them.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.AuditEventFilter,
+) ([]*types.AuditEvent, int64, error) {
+	if !session.IsSystemAdmin() {
+		return nil, 0, usererror.ErrForbidden
+	}
+
+	return c.list(ctx, filter)
+}
+
+func (c *Controller) list(
+	ctx context.Context,
+	filter *types.AuditEventFilter,
+) ([]*types.AuditEvent, int64, error) {
+	if err := checkFilter(filter); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.auditEventStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+
+	events, err := c.auditEventStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	return events, count, nil
+}
+
+func checkFilter(filter *types.AuditEventFilter) error {
+	if filter.From > 0 && filter.To > 0 && filter.From >= filter.To {
+		return usererror.BadRequest("The start of the time range must be before its end.")
+	}
+
+	return nil
+}
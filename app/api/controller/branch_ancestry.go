@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+)
+
+// BranchAncestryGIT is the subset of the git interface required to find branches that contain a commit.
+type BranchAncestryGIT interface {
+	ListBranches(ctx context.Context, params *git.ListBranchesParams) (*git.ListBranchesOutput, error)
+	IsAncestor(ctx context.Context, params git.IsAncestorParams) (git.IsAncestorOutput, error)
+}
+
+// IsCommitOnBranch reports whether the commit is reachable from any branch accepted by the branch filter.
+// The commit SHA can also be the SHA of an annotated tag.
+func IsCommitOnBranch(
+	ctx context.Context,
+	gitI BranchAncestryGIT,
+	readParams git.ReadParams,
+	commitSHA sha.SHA,
+	branchFilter func(branchName string) bool,
+) (bool, error) {
+	out, err := gitI.ListBranches(ctx, &git.ListBranchesParams{
+		ReadParams: readParams,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	for _, branch := range out.Branches {
+		if !branchFilter(branch.Name) {
+			continue
+		}
+
+		ancestry, err := gitI.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          readParams,
+			AncestorCommitSHA:   commitSHA,
+			DescendantCommitSHA: branch.SHA,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to check if the commit is on branch %q: %w", branch.Name, err)
+		}
+
+		if ancestry.Ancestor {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -42,6 +43,7 @@ type Controller struct {
 	git         git.Interface
 	sanitizers  map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error
 	sseStreamer sse.Streamer
+	reporter    *checkevents.Reporter
 }
 
 func NewController(
@@ -54,6 +56,7 @@ func NewController(
 	git git.Interface,
 	sanitizers map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error,
 	sseStreamer sse.Streamer,
+	reporter *checkevents.Reporter,
 ) *Controller {
 	return &Controller{
 		tx:          tx,
@@ -65,6 +68,7 @@ func NewController(
 		git:         git,
 		sanitizers:  sanitizers,
 		sseStreamer: sseStreamer,
+		reporter:    reporter,
 	}
 }
 
@@ -0,0 +1,314 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var artifactNameRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,255}$`)
+
+// ArtifactUploadInput holds the optional parameters of an artifact upload.
+type ArtifactUploadInput struct {
+	// StageNumber is the number of the stage that published the artifact, zero if not provided.
+	StageNumber int64
+	// RetentionDays is the number of days the artifact is kept, zero for the configured default.
+	RetentionDays int64
+}
+
+// ArtifactContent is the content of a pipeline artifact.
+type ArtifactContent struct {
+	Artifact *types.Artifact
+	Data     io.ReadCloser
+}
+
+// UploadArtifact stores an artifact of a running execution in the blob store.
+func (c *Controller) UploadArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	name string,
+	in *ArtifactUploadInput,
+	content io.Reader,
+) (*types.Artifact, error) {
+	if err := checkArtifactName(name); err != nil {
+		return nil, err
+	}
+
+	if content == nil {
+		return nil, usererror.BadRequest("No file provided.")
+	}
+
+	retention, err := c.sanitizeArtifactRetention(in.RetentionDays)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, execution, err := c.getExecutionCheckAccess(ctx, session, repoRef, pipelineIdentifier, executionNum,
+		enum.PermissionRepoPush)
+	if err != nil {
+		return nil, err
+	}
+
+	if execution.Status.IsDone() {
+		return nil, usererror.BadRequest("Artifacts can only be uploaded while the execution is running.")
+	}
+
+	if in.StageNumber != 0 {
+		if _, err = c.stageStore.FindByNumber(ctx, execution.ID, int(in.StageNumber)); err != nil {
+			return nil, fmt.Errorf("failed to find stage %d: %w", in.StageNumber, err)
+		}
+	}
+
+	_, err = c.artifactStore.Find(ctx, execution.ID, name)
+	if err == nil {
+		return nil, usererror.Conflict(fmt.Sprintf("Artifact %q already exists.", name))
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find artifact: %w", err)
+	}
+
+	maxSize := c.config.CI.ArtifactMaxSize
+	counter := &countingWriter{}
+
+	// read one byte more than allowed to be able to detect content that is too large.
+	reader := io.TeeReader(io.LimitReader(content, maxSize+1), counter)
+
+	// every upload gets its own blob, so concurrent uploads of the same artifact can't overwrite each other.
+	blobUID := uuid.New().String()
+	blobPath := ArtifactBlobPath(repo.ID, execution.ID, blobUID)
+
+	if err = c.blobStore.Upload(ctx, reader, blobPath); err != nil {
+		return nil, fmt.Errorf("failed to upload artifact: %w", err)
+	}
+
+	if counter.n > maxSize {
+		c.deleteBlob(ctx, blobPath)
+		return nil, usererror.RequestTooLargef("The artifact exceeds the maximum allowed size of %d bytes.", maxSize)
+	}
+
+	now := time.Now()
+	artifact := &types.Artifact{
+		RepoID:      repo.ID,
+		ExecutionID: execution.ID,
+		StageNumber: in.StageNumber,
+		Name:        name,
+		BlobUID:     blobUID,
+		Size:        counter.n,
+		CreatedBy:   session.Principal.ID,
+		Created:     now.UnixMilli(),
+		Expires:     now.Add(retention).UnixMilli(),
+	}
+
+	err = c.artifactStore.Create(ctx, artifact)
+	if errors.Is(err, store.ErrDuplicate) {
+		c.deleteBlob(ctx, blobPath)
+		return nil, usererror.Conflict(fmt.Sprintf("Artifact %q already exists.", name))
+	}
+	if err != nil {
+		c.deleteBlob(ctx, blobPath)
+		return nil, fmt.Errorf("failed to create artifact: %w", err)
+	}
+
+	return artifact, nil
+}
+
+// ListArtifacts lists the artifacts of an execution.
+func (c *Controller) ListArtifacts(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+) ([]*types.Artifact, error) {
+	_, execution, err := c.getExecutionCheckAccess(ctx, session, repoRef, pipelineIdentifier, executionNum,
+		enum.PermissionPipelineView)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := c.artifactStore.List(ctx, execution.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	return artifacts, nil
+}
+
+// DownloadArtifact returns the content of an artifact of an execution.
+func (c *Controller) DownloadArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	name string,
+) (*ArtifactContent, error) {
+	if err := checkArtifactName(name); err != nil {
+		return nil, err
+	}
+
+	repo, execution, err := c.getExecutionCheckAccess(ctx, session, repoRef, pipelineIdentifier, executionNum,
+		enum.PermissionPipelineView)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact, err := c.artifactStore.Find(ctx, execution.ID, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find artifact: %w", err)
+	}
+
+	data, err := c.blobStore.Download(ctx, ArtifactBlobPath(repo.ID, execution.ID, artifact.BlobUID))
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil, usererror.NotFoundf("Content of artifact %q not found.", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download artifact from blobstore: %w", err)
+	}
+
+	return &ArtifactContent{
+		Artifact: artifact,
+		Data:     data,
+	}, nil
+}
+
+// DeleteArtifact deletes an artifact of an execution.
+func (c *Controller) DeleteArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	name string,
+) error {
+	if err := checkArtifactName(name); err != nil {
+		return err
+	}
+
+	repo, execution, err := c.getExecutionCheckAccess(ctx, session, repoRef, pipelineIdentifier, executionNum,
+		enum.PermissionPipelineEdit)
+	if err != nil {
+		return err
+	}
+
+	artifact, err := c.artifactStore.Find(ctx, execution.ID, name)
+	if err != nil {
+		return fmt.Errorf("failed to find artifact: %w", err)
+	}
+
+	err = c.blobStore.Delete(ctx, ArtifactBlobPath(repo.ID, execution.ID, artifact.BlobUID))
+	if err != nil && !errors.Is(err, blob.ErrNotFound) {
+		return fmt.Errorf("failed to delete artifact from blobstore: %w", err)
+	}
+
+	if err = c.artifactStore.Delete(ctx, artifact.ID); err != nil {
+		return fmt.Errorf("failed to delete artifact: %w", err)
+	}
+
+	return nil
+}
+
+// ArtifactBlobPath returns the path of the artifact content in the blob store.
+func ArtifactBlobPath(repoID, executionID int64, blobUID string) string {
+	return fmt.Sprintf("pipelines/artifacts/%d/%d/%s", repoID, executionID, blobUID)
+}
+
+// getExecutionCheckAccess fetches the execution and checks if the current user
+// has the required permission on the pipeline it belongs to.
+func (c *Controller) getExecutionCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	reqPermission enum.Permission,
+) (*types.Repository, *types.Execution, error) {
+	repo, err := c.getRepoCheckPipelineAccess(ctx, session, repoRef, pipelineIdentifier, reqPermission)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	return repo, execution, nil
+}
+
+func (c *Controller) sanitizeArtifactRetention(days int64) (time.Duration, error) {
+	if days < 0 {
+		return 0, usererror.BadRequest("Artifact retention can't be negative.")
+	}
+
+	if days == 0 {
+		return c.config.CI.ArtifactRetention, nil
+	}
+
+	// compare the days to avoid overflows of the duration.
+	maxDays := int64(c.config.CI.ArtifactMaxRetention / (24 * time.Hour))
+	if days > maxDays {
+		return 0, usererror.BadRequestf("Artifact retention can't exceed %d days.", maxDays)
+	}
+
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
+func (c *Controller) deleteBlob(ctx context.Context, blobPath string) {
+	if err := c.blobStore.Delete(ctx, blobPath); err != nil && !errors.Is(err, blob.ErrNotFound) {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete artifact content %q", blobPath)
+	}
+}
+
+func checkArtifactName(name string) error {
+	if !artifactNameRegex.MatchString(name) || name == "." || name == ".." {
+		return usererror.BadRequest("Artifact name must be 1 to 255 characters long and may only contain " +
+			"letters, digits, dots, underscores and dashes.")
+	}
+
+	return nil
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+func Test_checkArtifactName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "simple", input: "build.tar.gz"},
+		{name: "dashes and underscores", input: "test-report_linux-amd64.xml"},
+		{name: "max length", input: strings.Repeat("a", 255)},
+		{name: "empty", input: "", wantErr: true},
+		{name: "too long", input: strings.Repeat("a", 256), wantErr: true},
+		{name: "dot", input: ".", wantErr: true},
+		{name: "dot dot", input: "..", wantErr: true},
+		{name: "slash", input: "dist/app", wantErr: true},
+		{name: "space", input: "my artifact", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := checkArtifactName(test.input); (err != nil) != test.wantErr {
+				t.Errorf("checkArtifactName(%q) error = %v, wantErr %v", test.input, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func Test_sanitizeArtifactRetention(t *testing.T) {
+	config := &types.Config{}
+	config.CI.ArtifactRetention = 30 * 24 * time.Hour
+	config.CI.ArtifactMaxRetention = 90 * 24 * time.Hour
+
+	c := &Controller{config: config}
+
+	tests := []struct {
+		name    string
+		days    int64
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "default", days: 0, want: 30 * 24 * time.Hour},
+		{name: "custom", days: 7, want: 7 * 24 * time.Hour},
+		{name: "max", days: 90, want: 90 * 24 * time.Hour},
+		{name: "above max", days: 91, wantErr: true},
+		{name: "overflow", days: math.MaxInt64 / int64(time.Hour), wantErr: true},
+		{name: "negative", days: -1, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := c.sanitizeArtifactRetention(test.days)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("sanitizeArtifactRetention(%d) error = %v, wantErr %v", test.days, err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("sanitizeArtifactRetention(%d) = %s, want %s", test.days, got, test.want)
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -42,6 +43,10 @@ type Controller struct {
 	stageStore     store.StageStore
 	pipelineStore  store.PipelineStore
 	repoFinder     refcache.RepoFinder
+
+	config        *types.Config
+	blobStore     blob.Store
+	artifactStore store.PipelineArtifactStore
 }
 
 func NewController(
@@ -55,6 +60,9 @@ func NewController(
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
 	repoFinder refcache.RepoFinder,
+	config *types.Config,
+	blobStore blob.Store,
+	artifactStore store.PipelineArtifactStore,
 ) *Controller {
 	return &Controller{
 		tx:             tx,
@@ -67,6 +75,9 @@ func NewController(
 		stageStore:     stageStore,
 		pipelineStore:  pipelineStore,
 		repoFinder:     repoFinder,
+		config:         config,
+		blobStore:      blobStore,
+		artifactStore:  artifactStore,
 	}
 }
 
@@ -21,7 +21,9 @@ import (
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -42,7 +44,11 @@ func ProvideController(
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
 	repoFinder refcache.RepoFinder,
+	config *types.Config,
+	blobStore blob.Store,
+	artifactStore store.PipelineArtifactStore,
 ) *Controller {
 	return NewController(tx, authorizer, executionStore, checkStore,
-		canceler, commitService, triggerer, stageStore, pipelineStore, repoFinder)
+		canceler, commitService, triggerer, stageStore, pipelineStore, repoFinder,
+		config, blobStore, artifactStore)
 }
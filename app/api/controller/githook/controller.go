@@ -25,6 +25,7 @@ import (
 	eventsgit "github.com/harness/gitness/app/events/git"
 	eventsrepo "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -54,6 +55,8 @@ type Controller struct {
 	updateExtender      UpdateExtender
 	postReceiveExtender PostReceiveExtender
 	sseStreamer         sse.Streamer
+	signatureVerifier   *publickey.SignatureVerifier
+	pullMirrorStore     store.PullMirrorStore
 }
 
 func NewController(
@@ -72,6 +75,8 @@ func NewController(
 	updateExtender UpdateExtender,
 	postReceiveExtender PostReceiveExtender,
 	sseStreamer sse.Streamer,
+	signatureVerifier *publickey.SignatureVerifier,
+	pullMirrorStore store.PullMirrorStore,
 ) *Controller {
 	return &Controller{
 		authorizer:          authorizer,
@@ -89,6 +94,8 @@ func NewController(
 		updateExtender:      updateExtender,
 		postReceiveExtender: postReceiveExtender,
 		sseStreamer:         sseStreamer,
+		signatureVerifier:   signatureVerifier,
+		pullMirrorStore:     pullMirrorStore,
 	}
 }
 
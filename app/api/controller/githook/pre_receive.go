@@ -16,6 +16,7 @@ package githook
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/git/hook"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -85,6 +87,17 @@ func (c *Controller) PreReceive(
 		return output, nil
 	}
 
+	// Branches and tags of pull mirrors are owned by the remote repository.
+	// The mirror sync fetches the references directly, so it doesn't go through this hook.
+	blocked, err := c.blockPullMirrorRefUpdate(ctx, repo, refUpdates)
+	if err != nil {
+		return hook.Output{}, err
+	}
+	if blocked {
+		output.Error = ptr.String(usererror.ErrPullMirrorRefsCantBeModified.Error())
+		return output, nil
+	}
+
 	// For internal calls - through the application interface (API) - no need to verify protection rules.
 	if !in.Internal && repo.State == enum.RepoStateActive {
 		// TODO: use store.PrincipalInfoCache once we abstracted principals.
@@ -95,7 +108,7 @@ func (c *Controller) PreReceive(
 
 		dummySession := &auth.Session{Principal: *principal, Metadata: nil}
 
-		err = c.checkProtectionRules(ctx, dummySession, repo, refUpdates, &output)
+		err = c.checkProtectionRules(ctx, rgit, dummySession, repo, in, refUpdates, &output)
 		if output.Error != nil {
 			return output, nil
 		}
@@ -146,10 +159,32 @@ func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs, state enum.Re
 		slices.ContainsFunc(refUpdates.other.forced, fn)
 }
 
+func (c *Controller) blockPullMirrorRefUpdate(
+	ctx context.Context,
+	repo *types.Repository,
+	refUpdates changedRefs,
+) (bool, error) {
+	if refUpdates.branches.isEmpty() && refUpdates.tags.isEmpty() {
+		return false, nil
+	}
+
+	mirror, err := c.pullMirrorStore.Find(ctx, repo.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find pull mirror: %w", err)
+	}
+
+	return mirror.Enabled, nil
+}
+
 func (c *Controller) checkProtectionRules(
 	ctx context.Context,
+	rgit RestrictedGIT,
 	session *auth.Session,
 	repo *types.Repository,
+	in types.GithookPreReceiveInput,
 	refUpdates changedRefs,
 	output *hook.Output,
 ) error {
@@ -166,20 +201,21 @@ func (c *Controller) checkProtectionRules(
 	var ruleViolations []types.RuleViolations
 	var errCheckAction error
 
-	//nolint:unparam
 	checkAction := func(refAction protection.RefAction, refType protection.RefType, names []string) {
 		if errCheckAction != nil || len(names) == 0 {
 			return
 		}
 
 		violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
-			Actor:       &session.Principal,
-			AllowBypass: true,
-			IsRepoOwner: isRepoOwner,
-			Repo:        repo,
-			RefAction:   refAction,
-			RefType:     refType,
-			RefNames:    names,
+			Actor:                 &session.Principal,
+			AllowBypass:           true,
+			IsRepoOwner:           isRepoOwner,
+			Repo:                  repo,
+			RefAction:             refAction,
+			RefType:               refType,
+			RefNames:              names,
+			FindUnverifiedCommits: c.findUnverifiedBranchCommitsFunc(rgit, repo, in),
+			IsTagOnBranch:         isPushedTagOnBranchFunc(rgit, repo, in),
 		})
 		if err != nil {
 			errCheckAction = fmt.Errorf("failed to verify protection rules for git push: %w", err)
@@ -193,6 +229,9 @@ func (c *Controller) checkProtectionRules(
 	checkAction(protection.RefActionDelete, protection.RefTypeBranch, refUpdates.branches.deleted)
 	checkAction(protection.RefActionUpdate, protection.RefTypeBranch, refUpdates.branches.updated)
 	checkAction(protection.RefActionUpdateForce, protection.RefTypeBranch, refUpdates.branches.forced)
+	checkAction(protection.RefActionCreate, protection.RefTypeTag, refUpdates.tags.created)
+	checkAction(protection.RefActionDelete, protection.RefTypeTag, refUpdates.tags.deleted)
+	checkAction(protection.RefActionUpdate, protection.RefTypeTag, refUpdates.tags.updated)
 
 	if errCheckAction != nil {
 		return errCheckAction
@@ -227,6 +266,10 @@ type changes struct {
 	forced  []string
 }
 
+func (c *changes) isEmpty() bool {
+	return len(c.created)+len(c.deleted)+len(c.updated)+len(c.forced) == 0
+}
+
 func (c *changes) groupByAction(
 	refUpdate hook.ReferenceUpdate,
 	name string,
@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types"
+)
+
+// findUnverifiedBranchCommitsFunc returns a function that finds all commits pushed to a branch
+// that don't carry a verified signature. Commits are considered pushed to the branch
+// if they aren't reachable from the old branch head (or the default branch, in case the branch is created).
+func (c *Controller) findUnverifiedBranchCommitsFunc(
+	rgit RestrictedGIT,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+) func(ctx context.Context, branchName string) ([]string, error) {
+	return func(ctx context.Context, branchName string) ([]string, error) {
+		var refUpdate hook.ReferenceUpdate
+		var found bool
+		for _, u := range in.RefUpdates {
+			if u.Ref == gitReferenceNamePrefixBranch+branchName {
+				refUpdate, found = u, true
+				break
+			}
+		}
+
+		if !found || refUpdate.New.IsNil() {
+			return nil, nil
+		}
+
+		baseSHA, baseAvailable, err := GetBaseSHAForScanningChanges(
+			ctx,
+			rgit,
+			repo,
+			in.Environment,
+			in.RefUpdates,
+			refUpdate,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get base sha: %w", err)
+		}
+
+		var after string
+		if baseAvailable {
+			after = baseSHA.String()
+		}
+
+		unverified, err := c.signatureVerifier.FindUnverifiedCommits(ctx,
+			git.ReadParams{
+				RepoUID:             repo.GitUID,
+				AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+			},
+			refUpdate.New.String(),
+			after,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify commit signatures: %w", err)
+		}
+
+		return unverified, nil
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+// isPushedTagOnBranchFunc returns a function that reports whether the new commit of a pushed tag
+// is reachable from any of the branches accepted by the branch filter.
+// Branches are evaluated as they were before the push.
+func isPushedTagOnBranchFunc(
+	rgit RestrictedGIT,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+) func(ctx context.Context, tagName string, branchFilter func(string) bool) (bool, error) {
+	return func(ctx context.Context, tagName string, branchFilter func(string) bool) (bool, error) {
+		for _, refUpdate := range in.RefUpdates {
+			if refUpdate.Ref != gitReferenceNamePrefixTag+tagName || refUpdate.New.IsNil() {
+				continue
+			}
+
+			return controller.IsCommitOnBranch(ctx, rgit, git.ReadParams{
+				RepoUID:             repo.GitUID,
+				AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+			}, refUpdate.New, branchFilter)
+		}
+
+		// the tag isn't created or updated by the push
+		return true, nil
+	}
+}
@@ -20,6 +20,7 @@ import (
 	eventsgit "github.com/harness/gitness/app/events/git"
 	eventsrepo "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -58,6 +59,8 @@ func ProvideController(
 	updateExtender UpdateExtender,
 	postReceiveExtender PostReceiveExtender,
 	sseStreamer sse.Streamer,
+	signatureVerifier *publickey.SignatureVerifier,
+	pullMirrorStore store.PullMirrorStore,
 ) *Controller {
 	ctrl := NewController(
 		authorizer,
@@ -75,6 +78,8 @@ func ProvideController(
 		updateExtender,
 		postReceiveExtender,
 		sseStreamer,
+		signatureVerifier,
+		pullMirrorStore,
 	)
 
 	// TODO: improve wiring if possible
@@ -0,0 +1,172 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/git/parser"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+const (
+	actionDownload = "download"
+	actionUpload   = "upload"
+	actionVerify   = "verify"
+
+	hashAlgoSHA256 = "sha256"
+
+	// signedURLExpiresIn is the (approximate) validity of signed URLs returned by the blob store, in seconds.
+	signedURLExpiresIn = 3600
+)
+
+// Batch implements the git LFS batch API. It returns for every requested object the actions
+// the client has to take to download or upload it.
+func (c *Controller) Batch(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *BatchRequest,
+) (*BatchResponse, error) {
+	operation, ok := in.Operation.Sanitize()
+	if !ok {
+		return nil, usererror.BadRequestf("Git LFS operation %q is not supported.", in.Operation)
+	}
+
+	if len(in.Transfers) > 0 && !slices.Contains(in.Transfers, enum.GitLFSTransferTypeBasic) {
+		return nil, usererror.UnprocessableEntityf("Only the %q transfer adapter is supported.",
+			enum.GitLFSTransferTypeBasic)
+	}
+
+	if in.HashAlgo != "" && in.HashAlgo != hashAlgoSHA256 {
+		return nil, usererror.UnprocessableEntityf("Only the %q hash algorithm is supported.", hashAlgoSHA256)
+	}
+
+	permission := enum.PermissionRepoView
+	if operation == enum.GitLFSOperationTypeUpload {
+		permission = enum.PermissionRepoPush
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, permission)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	oids := make([]string, 0, len(in.Objects))
+	for _, obj := range in.Objects {
+		if parser.IsValidLFSObjectID(obj.OID) {
+			oids = append(oids, obj.OID)
+		}
+	}
+
+	existingObjects := map[string]*types.LFSObject{}
+	if len(oids) > 0 {
+		objects, err := c.lfsStore.FindMany(ctx, repo.ID, oids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find lfs objects: %w", err)
+		}
+
+		for _, obj := range objects {
+			existingObjects[obj.OID] = obj
+		}
+	}
+
+	objectsURL := c.urlProvider.GenerateGITCloneURL(ctx, repo.Path) + "/info/lfs/objects/"
+
+	out := &BatchResponse{
+		Transfer: enum.GitLFSTransferTypeBasic,
+		Objects:  make([]ObjectResponse, len(in.Objects)),
+		HashAlgo: hashAlgoSHA256,
+	}
+
+	for i, obj := range in.Objects {
+		out.Objects[i] = ObjectResponse{Pointer: obj}
+
+		if !parser.IsValidLFSObjectID(obj.OID) || obj.Size < 0 {
+			out.Objects[i].Error = &ObjectError{
+				Code:    http.StatusUnprocessableEntity,
+				Message: "Invalid object ID or size.",
+			}
+			continue
+		}
+
+		existing, exists := existingObjects[obj.OID]
+
+		switch operation {
+		case enum.GitLFSOperationTypeDownload:
+			if !exists {
+				out.Objects[i].Error = &ObjectError{
+					Code:    http.StatusNotFound,
+					Message: "Object does not exist.",
+				}
+				continue
+			}
+
+			out.Objects[i].Size = existing.Size
+
+			action, err := c.downloadAction(ctx, repo.ID, objectsURL, obj.OID)
+			if err != nil {
+				return nil, err
+			}
+
+			out.Objects[i].Actions = map[string]Action{actionDownload: action}
+
+		case enum.GitLFSOperationTypeUpload:
+			// objects that are already stored don't require any action from the client.
+			if exists && existing.Size == obj.Size {
+				continue
+			}
+
+			out.Objects[i].Actions = map[string]Action{
+				actionUpload: {Href: objectsURL + obj.OID + "/" + strconv.FormatInt(obj.Size, 10)},
+				actionVerify: {Href: objectsURL + "verify"},
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// downloadAction returns the download action for an object. If the blob store supports signed URLs,
+// the client downloads the object directly from the blob store.
+func (c *Controller) downloadAction(
+	ctx context.Context,
+	repoID int64,
+	objectsURL string,
+	oid string,
+) (Action, error) {
+	signedURL, err := c.blobStore.GetSignedURL(ctx, GetObjectBucketPath(repoID, oid))
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return Action{}, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if signedURL != "" {
+		return Action{
+			Href:      signedURL,
+			ExpiresIn: signedURLExpiresIn,
+		}, nil
+	}
+
+	return Action{Href: objectsURL + oid}, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git/parser"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Content is the content of an LFS object.
+type Content struct {
+	Data io.ReadCloser
+	Size int64
+}
+
+// Download returns the content of an LFS object of the repository.
+func (c *Controller) Download(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	oid string,
+) (*Content, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if !parser.IsValidLFSObjectID(oid) {
+		return nil, usererror.BadRequestf("Invalid object ID %q.", oid)
+	}
+
+	obj, err := c.lfsStore.Find(ctx, repo.ID, oid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find lfs object: %w", err)
+	}
+
+	file, err := c.blobStore.Download(ctx, GetObjectBucketPath(repo.ID, obj.OID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download lfs object from blobstore: %w", err)
+	}
+
+	return &Content{
+		Data: file,
+		Size: obj.Size,
+	}, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// Pointer identifies an LFS object.
+type Pointer struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// Reference is the git ref the LFS objects of a batch request belong to.
+type Reference struct {
+	Name string `json:"name"`
+}
+
+// BatchRequest is the request body of the git LFS batch API.
+type BatchRequest struct {
+	Operation enum.GitLFSOperationType  `json:"operation"`
+	Transfers []enum.GitLFSTransferType `json:"transfers,omitempty"`
+	Ref       *Reference                `json:"ref,omitempty"`
+	Objects   []Pointer                 `json:"objects"`
+	HashAlgo  string                    `json:"hash_algo,omitempty"`
+}
+
+// BatchResponse is the response body of the git LFS batch API.
+type BatchResponse struct {
+	Transfer enum.GitLFSTransferType `json:"transfer,omitempty"`
+	Objects  []ObjectResponse        `json:"objects"`
+	HashAlgo string                  `json:"hash_algo,omitempty"`
+}
+
+// ObjectResponse contains the actions that the client has to take for a single LFS object.
+type ObjectResponse struct {
+	Pointer
+	Authenticated bool              `json:"authenticated,omitempty"`
+	Actions       map[string]Action `json:"actions,omitempty"`
+	Error         *ObjectError      `json:"error,omitempty"`
+}
+
+// Action is a link to an endpoint the client has to call to transfer an LFS object.
+type Action struct {
+	Href      string            `json:"href"`
+	Header    map[string]string `json:"header,omitempty"`
+	ExpiresIn int64             `json:"expires_in,omitempty"`
+}
+
+// ObjectError describes why a single LFS object can't be transferred.
+type ObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
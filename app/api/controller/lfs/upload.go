@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git/parser"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Upload stores the content of an LFS object in the blob store.
+// The content is verified against the provided pointer before the object is recorded for the repository.
+func (c *Controller) Upload(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pointer Pointer,
+	file io.Reader,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if !parser.IsValidLFSObjectID(pointer.OID) || pointer.Size < 0 {
+		return usererror.UnprocessableEntityf("Invalid object ID or size.")
+	}
+
+	if file == nil {
+		return usererror.BadRequest("No file provided.")
+	}
+
+	_, err = c.lfsStore.Find(ctx, repo.ID, pointer.OID)
+	if err == nil {
+		// the object is already stored - nothing to do.
+		return nil
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find lfs object: %w", err)
+	}
+
+	// the content is verified in a temporary file before it's uploaded to the blob store,
+	// to never overwrite a valid object with invalid content (e.g. by a concurrent upload).
+	tmpFile, err := os.CreateTemp("", "lfs-object-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		_ = tmpFile.Close()
+		if rmErr := os.Remove(tmpFile.Name()); rmErr != nil {
+			log.Ctx(ctx).Warn().Err(rmErr).Msgf("failed to remove temporary lfs object file %q", tmpFile.Name())
+		}
+	}()
+
+	hasher := sha256.New()
+
+	// read one byte more than expected to be able to detect content that is too large.
+	size, err := io.Copy(io.MultiWriter(tmpFile, hasher), io.LimitReader(file, pointer.Size+1))
+	if err != nil {
+		return fmt.Errorf("failed to read lfs object: %w", err)
+	}
+
+	if size != pointer.Size {
+		return usererror.UnprocessableEntityf("Object size mismatch: expected %d, got %d.", pointer.Size, size)
+	}
+
+	if hex.EncodeToString(hasher.Sum(nil)) != pointer.OID {
+		return usererror.UnprocessableEntityf("Object content doesn't match object ID %q.", pointer.OID)
+	}
+
+	if _, err = tmpFile.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind temporary lfs object file: %w", err)
+	}
+
+	err = c.blobStore.Upload(ctx, tmpFile, GetObjectBucketPath(repo.ID, pointer.OID))
+	if err != nil {
+		return fmt.Errorf("failed to upload lfs object: %w", err)
+	}
+
+	err = c.lfsStore.Create(ctx, &types.LFSObject{
+		OID:       pointer.OID,
+		Size:      pointer.Size,
+		Created:   time.Now().UnixMilli(),
+		CreatedBy: session.Principal.ID,
+		RepoID:    repo.ID,
+	})
+	if err != nil && !errors.Is(err, store.ErrDuplicate) {
+		return fmt.Errorf("failed to create lfs object: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationpref
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	authorizer                  authz.Authorizer
+	spaceCache                  refcache.SpaceCache
+	repoFinder                  refcache.RepoFinder
+	pullReqStore                store.PullReqStore
+	notificationPreferenceStore store.NotificationPreferenceStore
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	spaceCache refcache.SpaceCache,
+	repoFinder refcache.RepoFinder,
+	pullReqStore store.PullReqStore,
+	notificationPreferenceStore store.NotificationPreferenceStore,
+) *Controller {
+	return &Controller{
+		authorizer:                  authorizer,
+		spaceCache:                  spaceCache,
+		repoFinder:                  repoFinder,
+		pullReqStore:                pullReqStore,
+		notificationPreferenceStore: notificationPreferenceStore,
+	}
+}
+
+func (c *Controller) getSpaceCheckAuth(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.Space, error) {
+	return space.GetSpaceCheckAuth(ctx, c.spaceCache, c.authorizer, session, spaceRef, enum.PermissionSpaceView)
+}
+
+func (c *Controller) getRepoCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.Repository, error) {
+	return repo.GetRepoCheckAccess(ctx, c.repoFinder, c.authorizer, session, repoRef, enum.PermissionRepoView)
+}
+
+// checkUser makes sure that the notification preferences are managed by a user,
+// other principals don't receive email notifications.
+func checkUser(session *auth.Session) error {
+	if auth.IsAnonymousSession(session) {
+		return usererror.ErrUnauthorized
+	}
+	if session.Principal.Type != enum.PrincipalTypeUser {
+		return usererror.Forbidden("Only users can manage notification preferences.")
+	}
+	return nil
+}
+
+// sanitizeEvents validates the notification events and removes the duplicates.
+func sanitizeEvents(events []enum.NotificationEvent) ([]enum.NotificationEvent, error) {
+	seen := make(map[enum.NotificationEvent]struct{}, len(events))
+	result := make([]enum.NotificationEvent, 0, len(events))
+	for _, event := range events {
+		sanitized, ok := event.Sanitize()
+		if !ok {
+			return nil, check.NewValidationErrorf("Notification event '%s' is not supported.", event)
+		}
+		if _, ok := seen[sanitized]; ok {
+			continue
+		}
+		seen[sanitized] = struct{}{}
+		result = append(result, sanitized)
+	}
+
+	return result, nil
+}
+
+func (c *Controller) findSettings(ctx context.Context, principalID int64) (*types.NotificationSettings, error) {
+	settings, err := c.notificationPreferenceStore.FindSettings(ctx, principalID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return &types.NotificationSettings{
+			PrincipalID:    principalID,
+			Delivery:       enum.NotificationDeliveryImmediate,
+			DisabledEvents: []enum.NotificationEvent{},
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification settings: %w", err)
+	}
+
+	return settings, nil
+}
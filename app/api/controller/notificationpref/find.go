@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationpref
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// Find returns all notification preferences of the current user.
+func (c *Controller) Find(
+	ctx context.Context,
+	session *auth.Session,
+) (*types.NotificationPreferences, error) {
+	if err := checkUser(session); err != nil {
+		return nil, err
+	}
+
+	settings, err := c.findSettings(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := c.notificationPreferenceStore.ListSubscriptions(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification subscriptions: %w", err)
+	}
+
+	return &types.NotificationPreferences{
+		Settings:      settings,
+		Subscriptions: subscriptions,
+	}, nil
+}
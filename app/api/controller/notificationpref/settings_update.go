@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationpref
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateSettings updates the user level notification settings of the current user.
+func (c *Controller) UpdateSettings(
+	ctx context.Context,
+	session *auth.Session,
+	in *types.NotificationSettingsInput,
+) (*types.NotificationSettings, error) {
+	if err := checkUser(session); err != nil {
+		return nil, err
+	}
+
+	if err := sanitizeSettingsInput(in); err != nil {
+		return nil, err
+	}
+
+	settings, err := c.findSettings(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Delivery != nil {
+		settings.Delivery = *in.Delivery
+	}
+	if in.DisabledEvents != nil {
+		settings.DisabledEvents = in.DisabledEvents
+	}
+	settings.Updated = time.Now().UnixMilli()
+
+	err = c.notificationPreferenceStore.UpsertSettings(ctx, settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update notification settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+func sanitizeSettingsInput(in *types.NotificationSettingsInput) error {
+	if in.Delivery != nil {
+		delivery, ok := in.Delivery.Sanitize()
+		if !ok {
+			return check.NewValidationErrorf("Notification delivery must be one of %v.",
+				enum.NotificationDelivery("").Enum())
+		}
+		in.Delivery = &delivery
+	}
+
+	if in.DisabledEvents != nil {
+		events, err := sanitizeEvents(in.DisabledEvents)
+		if err != nil {
+			return err
+		}
+		in.DisabledEvents = events
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationpref
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SetSpaceSubscription sets the notification subscription of the current user for a space and its subspaces.
+func (c *Controller) SetSpaceSubscription(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *types.NotificationSubscriptionInput,
+) (*types.NotificationSubscription, error) {
+	if err := checkUser(session); err != nil {
+		return nil, err
+	}
+
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	return c.setSubscription(ctx, session, enum.NotificationScopeSpace, space.ID, in)
+}
+
+// DeleteSpaceSubscription removes the notification subscription of the current user for a space.
+func (c *Controller) DeleteSpaceSubscription(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) error {
+	if err := checkUser(session); err != nil {
+		return err
+	}
+
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	return c.deleteSubscription(ctx, session, enum.NotificationScopeSpace, space.ID)
+}
+
+// SetRepoSubscription sets the notification subscription of the current user for a repository.
+func (c *Controller) SetRepoSubscription(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *types.NotificationSubscriptionInput,
+) (*types.NotificationSubscription, error) {
+	if err := checkUser(session); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	return c.setSubscription(ctx, session, enum.NotificationScopeRepo, repo.ID, in)
+}
+
+// DeleteRepoSubscription removes the notification subscription of the current user for a repository.
+func (c *Controller) DeleteRepoSubscription(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) error {
+	if err := checkUser(session); err != nil {
+		return err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	return c.deleteSubscription(ctx, session, enum.NotificationScopeRepo, repo.ID)
+}
+
+// SetPullReqSubscription sets the notification subscription of the current user for a pull request.
+// It's used for muting or watching a single pull request.
+func (c *Controller) SetPullReqSubscription(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *types.NotificationSubscriptionInput,
+) (*types.NotificationSubscription, error) {
+	if err := checkUser(session); err != nil {
+		return nil, err
+	}
+
+	pr, err := c.getPullReq(ctx, session, repoRef, pullreqNum)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.setSubscription(ctx, session, enum.NotificationScopePullReq, pr.ID, in)
+}
+
+// DeletePullReqSubscription removes the notification subscription of the current user for a pull request.
+func (c *Controller) DeletePullReqSubscription(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	if err := checkUser(session); err != nil {
+		return err
+	}
+
+	pr, err := c.getPullReq(ctx, session, repoRef, pullreqNum)
+	if err != nil {
+		return err
+	}
+
+	return c.deleteSubscription(ctx, session, enum.NotificationScopePullReq, pr.ID)
+}
+
+func (c *Controller) getPullReq(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.PullReq, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullReqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	return pr, nil
+}
+
+func (c *Controller) setSubscription(
+	ctx context.Context,
+	session *auth.Session,
+	scope enum.NotificationScope,
+	scopeID int64,
+	in *types.NotificationSubscriptionInput,
+) (*types.NotificationSubscription, error) {
+	if err := sanitizeSubscriptionInput(in); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	subscription := &types.NotificationSubscription{
+		PrincipalID:    session.Principal.ID,
+		Scope:          scope,
+		ScopeID:        scopeID,
+		Level:          in.Level,
+		DisabledEvents: in.DisabledEvents,
+		Created:        now,
+		Updated:        now,
+	}
+
+	err := c.notificationPreferenceStore.UpsertSubscription(ctx, subscription)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set notification subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func (c *Controller) deleteSubscription(
+	ctx context.Context,
+	session *auth.Session,
+	scope enum.NotificationScope,
+	scopeID int64,
+) error {
+	err := c.notificationPreferenceStore.DeleteSubscription(ctx, session.Principal.ID, scope, scopeID)
+	if err != nil {
+		return fmt.Errorf("failed to delete notification subscription: %w", err)
+	}
+
+	return nil
+}
+
+func sanitizeSubscriptionInput(in *types.NotificationSubscriptionInput) error {
+	level, ok := in.Level.Sanitize()
+	if !ok {
+		return check.NewValidationErrorf("Notification level must be one of %v.", enum.NotificationLevel("").Enum())
+	}
+	in.Level = level
+
+	if in.DisabledEvents == nil {
+		in.DisabledEvents = []enum.NotificationEvent{}
+	}
+
+	events, err := sanitizeEvents(in.DisabledEvents)
+	if err != nil {
+		return err
+	}
+	in.DisabledEvents = events
+
+	return nil
+}
@@ -0,0 +1,237 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var cacheKeyRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,255}$`)
+
+// CacheContent is the content of a pipeline build cache entry.
+type CacheContent struct {
+	Cache *types.PipelineCache
+	Data  io.ReadCloser
+}
+
+// DownloadCache returns the content of a build cache entry of a pipeline and marks the entry as used.
+func (c *Controller) DownloadCache(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	key string,
+) (*CacheContent, error) {
+	if err := checkCacheKey(key); err != nil {
+		return nil, err
+	}
+
+	repo, pipeline, err := c.getPipelineCheckAccess(ctx, session, repoRef, pipelineIdentifier,
+		enum.PermissionPipelineView)
+	if err != nil {
+		return nil, err
+	}
+
+	cache, err := c.cacheStore.Find(ctx, pipeline.ID, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find build cache: %w", err)
+	}
+
+	data, err := c.blobStore.Download(ctx, CacheBlobPath(repo.ID, pipeline.ID, cache.BlobUID))
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil, usererror.NotFoundf("Content of build cache %q not found.", key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download build cache from blobstore: %w", err)
+	}
+
+	cache.LastUsed = time.Now().UnixMilli()
+	if err = c.cacheStore.Touch(ctx, cache.ID, cache.LastUsed); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to update last used time of build cache %q", key)
+	}
+
+	return &CacheContent{
+		Cache: cache,
+		Data:  data,
+	}, nil
+}
+
+// UploadCache stores a build cache entry of a pipeline, replacing the existing entry with the same key.
+func (c *Controller) UploadCache(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	key string,
+	content io.Reader,
+) (*types.PipelineCache, error) {
+	if err := checkCacheKey(key); err != nil {
+		return nil, err
+	}
+
+	if content == nil {
+		return nil, usererror.BadRequest("No file provided.")
+	}
+
+	repo, pipeline, err := c.getPipelineCheckAccess(ctx, session, repoRef, pipelineIdentifier,
+		enum.PermissionRepoPush)
+	if err != nil {
+		return nil, err
+	}
+
+	maxSize := c.config.CI.CacheMaxSize
+	counter := &countingWriter{}
+
+	// read one byte more than allowed to be able to detect content that is too large.
+	reader := io.TeeReader(io.LimitReader(content, maxSize+1), counter)
+
+	// every upload gets its own blob, so the existing entry stays intact until its content is replaced.
+	blobUID := uuid.New().String()
+	blobPath := CacheBlobPath(repo.ID, pipeline.ID, blobUID)
+
+	if err = c.blobStore.Upload(ctx, reader, blobPath); err != nil {
+		return nil, fmt.Errorf("failed to upload build cache: %w", err)
+	}
+
+	if counter.n > maxSize {
+		c.deleteBlob(ctx, blobPath)
+		return nil, usererror.RequestTooLargef("The build cache exceeds the maximum allowed size of %d bytes.", maxSize)
+	}
+
+	now := time.Now().UnixMilli()
+	cache := &types.PipelineCache{
+		RepoID:     repo.ID,
+		PipelineID: pipeline.ID,
+		Key:        key,
+		BlobUID:    blobUID,
+		Size:       counter.n,
+		CreatedBy:  session.Principal.ID,
+		Created:    now,
+		Updated:    now,
+		LastUsed:   now,
+	}
+
+	replacedBlobUID, err := c.cacheStore.Upsert(ctx, cache)
+	if err != nil {
+		c.deleteBlob(ctx, blobPath)
+		return nil, fmt.Errorf("failed to store build cache: %w", err)
+	}
+
+	if replacedBlobUID != "" {
+		c.deleteBlob(ctx, CacheBlobPath(repo.ID, pipeline.ID, replacedBlobUID))
+	}
+
+	return cache, nil
+}
+
+// DeleteCache deletes a build cache entry of a pipeline.
+func (c *Controller) DeleteCache(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	key string,
+) error {
+	if err := checkCacheKey(key); err != nil {
+		return err
+	}
+
+	repo, pipeline, err := c.getPipelineCheckAccess(ctx, session, repoRef, pipelineIdentifier,
+		enum.PermissionPipelineEdit)
+	if err != nil {
+		return err
+	}
+
+	cache, err := c.cacheStore.Find(ctx, pipeline.ID, key)
+	if err != nil {
+		return fmt.Errorf("failed to find build cache: %w", err)
+	}
+
+	err = c.blobStore.Delete(ctx, CacheBlobPath(repo.ID, pipeline.ID, cache.BlobUID))
+	if err != nil && !errors.Is(err, blob.ErrNotFound) {
+		return fmt.Errorf("failed to delete build cache from blobstore: %w", err)
+	}
+
+	if err = c.cacheStore.Delete(ctx, cache.ID); err != nil {
+		return fmt.Errorf("failed to delete build cache: %w", err)
+	}
+
+	return nil
+}
+
+// CacheBlobPath returns the path of the build cache content in the blob store.
+func CacheBlobPath(repoID, pipelineID int64, blobUID string) string {
+	return fmt.Sprintf("pipelines/caches/%d/%d/%s", repoID, pipelineID, blobUID)
+}
+
+// getPipelineCheckAccess fetches the pipeline and checks if the current user has the required permission on it.
+func (c *Controller) getPipelineCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	reqPermission enum.Permission,
+) (*types.Repository, *types.Pipeline, error) {
+	repo, err := c.getRepoCheckPipelineAccess(ctx, session, repoRef, pipelineIdentifier, reqPermission)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	return repo, pipeline, nil
+}
+
+func (c *Controller) deleteBlob(ctx context.Context, blobPath string) {
+	if err := c.blobStore.Delete(ctx, blobPath); err != nil && !errors.Is(err, blob.ErrNotFound) {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete build cache content %q", blobPath)
+	}
+}
+
+func checkCacheKey(key string) error {
+	if !cacheKeyRegex.MatchString(key) || key == "." || key == ".." {
+		return usererror.BadRequest("Cache key must be 1 to 255 characters long and may only contain " +
+			"letters, digits, dots, underscores and dashes.")
+	}
+
+	return nil
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"strings"
+
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// pipelineMaxConcurrencyGroupLength defines the max allowed length of a pipeline concurrency group.
+const pipelineMaxConcurrencyGroupLength = 256
+
+// sanitizeConcurrency validates the concurrency group template and the concurrency policy of a pipeline.
+// The policy defaults to queueing if a concurrency group is provided, and is cleared otherwise.
+func sanitizeConcurrency(group *string, policy *enum.ConcurrencyPolicy) error {
+	*group = strings.TrimSpace(*group)
+	if len(*group) > pipelineMaxConcurrencyGroupLength {
+		return check.NewValidationErrorf("The concurrency group of a pipeline can be at most %d characters long.",
+			pipelineMaxConcurrencyGroupLength)
+	}
+
+	if *group == "" {
+		*policy = ""
+		return nil
+	}
+
+	if *policy == "" {
+		*policy = enum.ConcurrencyPolicyQueue
+		return nil
+	}
+
+	sanitized, ok := policy.Sanitize()
+	if !ok {
+		return check.NewValidationErrorf("The concurrency policy '%s' is invalid.", *policy)
+	}
+	*policy = sanitized
+
+	return nil
+}
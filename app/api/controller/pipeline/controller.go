@@ -24,6 +24,7 @@ import (
 	events "github.com/harness/gitness/app/events/pipeline"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -35,6 +36,10 @@ type Controller struct {
 	pipelineStore store.PipelineStore
 	reporter      events.Reporter
 	repoFinder    refcache.RepoFinder
+
+	config     *types.Config
+	blobStore  blob.Store
+	cacheStore store.PipelineCacheStore
 }
 
 func NewController(
@@ -43,6 +48,9 @@ func NewController(
 	pipelineStore store.PipelineStore,
 	reporter events.Reporter,
 	repoFinder refcache.RepoFinder,
+	config *types.Config,
+	blobStore blob.Store,
+	cacheStore store.PipelineCacheStore,
 ) *Controller {
 	return &Controller{
 		repoFinder:    repoFinder,
@@ -50,6 +58,9 @@ func NewController(
 		authorizer:    authorizer,
 		pipelineStore: pipelineStore,
 		reporter:      reporter,
+		config:        config,
+		blobStore:     blobStore,
+		cacheStore:    cacheStore,
 	}
 }
 
@@ -44,6 +44,10 @@ type CreateInput struct {
 	Disabled      bool   `json:"disabled"`
 	DefaultBranch string `json:"default_branch"`
 	ConfigPath    string `json:"config_path"`
+
+	// ConcurrencyGroup is the template of the concurrency group key, e.g. "deploy-${DRONE_BRANCH}".
+	ConcurrencyGroup  string                 `json:"concurrency_group"`
+	ConcurrencyPolicy enum.ConcurrencyPolicy `json:"concurrency_policy"`
 }
 
 func (c *Controller) Create(
@@ -75,6 +79,9 @@ func (c *Controller) Create(
 		Created:       now,
 		Updated:       now,
 		Version:       0,
+
+		ConcurrencyGroup:  in.ConcurrencyGroup,
+		ConcurrencyPolicy: in.ConcurrencyPolicy,
 	}
 	err = c.pipelineStore.Create(ctx, pipeline)
 	if err != nil {
@@ -131,5 +138,5 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		return errPipelineRequiresConfigPath
 	}
 
-	return nil
+	return sanitizeConcurrency(&in.ConcurrencyGroup, &in.ConcurrencyPolicy)
 }
@@ -33,6 +33,9 @@ type UpdateInput struct {
 	Description *string `json:"description"`
 	Disabled    *bool   `json:"disabled"`
 	ConfigPath  *string `json:"config_path"`
+
+	ConcurrencyGroup  *string                 `json:"concurrency_group"`
+	ConcurrencyPolicy *enum.ConcurrencyPolicy `json:"concurrency_policy"`
 }
 
 func (c *Controller) Update(
@@ -69,6 +72,21 @@ func (c *Controller) Update(
 		if in.Disabled != nil {
 			pipeline.Disabled = *in.Disabled
 		}
+		if in.ConcurrencyGroup != nil || in.ConcurrencyPolicy != nil {
+			group := pipeline.ConcurrencyGroup
+			if in.ConcurrencyGroup != nil {
+				group = *in.ConcurrencyGroup
+			}
+			policy := pipeline.ConcurrencyPolicy
+			if in.ConcurrencyPolicy != nil {
+				policy = *in.ConcurrencyPolicy
+			}
+			if err := sanitizeConcurrency(&group, &policy); err != nil {
+				return err
+			}
+			pipeline.ConcurrencyGroup = group
+			pipeline.ConcurrencyPolicy = policy
+		}
 
 		return nil
 	})
@@ -19,6 +19,8 @@ import (
 	events "github.com/harness/gitness/app/events/pipeline"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -34,6 +36,9 @@ func ProvideController(
 	pipelineStore store.PipelineStore,
 	reporter *events.Reporter,
 	repoFinder refcache.RepoFinder,
+	config *types.Config,
+	blobStore blob.Store,
+	cacheStore store.PipelineCacheStore,
 ) *Controller {
 	return NewController(
 		authorizer,
@@ -41,5 +46,8 @@ func ProvideController(
 		pipelineStore,
 		*reporter,
 		repoFinder,
+		config,
+		blobStore,
+		cacheStore,
 	)
 }
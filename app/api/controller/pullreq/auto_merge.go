@@ -0,0 +1,356 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AutoMergeInput struct {
+	Method  enum.MergeMethod `json:"method"`
+	Title   string           `json:"title"`
+	Message string           `json:"message"`
+}
+
+func (in *AutoMergeInput) sanitize() error {
+	method, ok := in.Method.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("unsupported merge method: %s", in.Method)
+	}
+
+	in.Method = method
+
+	// cleanup title / message (NOTE: git doesn't support white space only)
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if (in.Method == enum.MergeMethodRebase || in.Method == enum.MergeMethodFastForward) &&
+		(in.Title != "" || in.Message != "") {
+		return usererror.BadRequestf(
+			"merge method %q doesn't support customizing commit title and message", in.Method)
+	}
+
+	return nil
+}
+
+// AutoMergeEnable enables the auto-merge of a pull request. The pull request will be merged
+// with the provided options on behalf of the current user as soon as all merge requirements are satisfied.
+func (c *Controller) AutoMergeEnable(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *AutoMergeInput,
+) (*types.AutoMerge, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	if pr.IsDraft {
+		return nil, usererror.BadRequest("Auto-merge can't be enabled for draft pull requests.")
+	}
+
+	am := &types.AutoMerge{
+		PullReqID: pr.ID,
+		RepoID:    repo.ID,
+		Method:    in.Method,
+		Title:     in.Title,
+		Message:   in.Message,
+		CreatedBy: session.Principal.ID,
+		Created:   time.Now().UnixMilli(),
+	}
+
+	err = c.autoMergeStore.Upsert(ctx, am)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable auto-merge: %w", err)
+	}
+
+	c.writeAutoMergeActivity(ctx, repo, pr, session.Principal.ID,
+		&types.PullRequestActivityPayloadAutoMergeEnable{MergeMethod: am.Method})
+
+	// the merge requirements might already be satisfied, the pull request will be merged in the background.
+	c.eventReporter.AutoMergeEnabled(ctx, &pullreqevents.AutoMergeEnabledPayload{
+		Base:   eventBase(pr, &session.Principal),
+		Method: am.Method,
+	})
+
+	return am, nil
+}
+
+// AutoMergeFind returns the auto-merge options of a pull request.
+func (c *Controller) AutoMergeFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.AutoMerge, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	am, err := c.autoMergeStore.Find(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find auto-merge: %w", err)
+	}
+
+	return am, nil
+}
+
+// AutoMergeDisable disables the auto-merge of a pull request.
+func (c *Controller) AutoMergeDisable(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	err = c.autoMergeStore.Delete(ctx, pr.ID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return usererror.BadRequest("Auto-merge is not enabled for the pull request")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to disable auto-merge: %w", err)
+	}
+
+	c.writeAutoMergeActivity(ctx, repo, pr, session.Principal.ID,
+		&types.PullRequestActivityPayloadAutoMergeDisable{})
+
+	return nil
+}
+
+// AutoMergeNoAuth merges the pull request if it has auto-merge enabled and all merge requirements are satisfied.
+// The merge is performed on behalf of the user who enabled the auto-merge.
+// If the merge requirements aren't satisfied yet the function doesn't do anything.
+func (c *Controller) AutoMergeNoAuth(ctx context.Context, pullreqID int64) error {
+	am, err := c.autoMergeStore.Find(ctx, pullreqID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find auto-merge: %w", err)
+	}
+
+	pr, err := c.pullreqStore.Find(ctx, pullreqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return c.autoMergeDeleteNoAuth(ctx, pr.ID)
+	}
+
+	repo, err := c.repoStore.Find(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	merger, err := c.principalStore.Find(ctx, am.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to find principal who enabled auto-merge: %w", err)
+	}
+
+	if merger.Blocked {
+		return c.autoMergeCancelNoAuth(ctx, repo, pr,
+			"The user who enabled auto-merge is no longer permitted to merge the pull request.")
+	}
+
+	out, violations, err := c.Merge(ctx, &auth.Session{Principal: *merger}, repo.Path, pr.Number, &MergeInput{
+		Method:    am.Method,
+		SourceSHA: pr.SourceSHA,
+		Title:     am.Title,
+		Message:   am.Message,
+	})
+
+	return c.autoMergeHandleMergeResult(ctx, repo, pr, out, violations, err)
+}
+
+// autoMergeHandleMergeResult processes the outcome of an auto-merge attempt. The auto-merge is removed
+// if the pull request got merged, canceled if the merger lost the permission to merge,
+// and kept if the merge requirements aren't satisfied yet.
+func (c *Controller) autoMergeHandleMergeResult(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	out *types.MergeResponse,
+	violations *types.MergeViolations,
+	err error,
+) error {
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return c.autoMergeCancelNoAuth(ctx, repo, pr,
+			"The user who enabled auto-merge is no longer permitted to merge the pull request.")
+	}
+
+	var errUser *usererror.Error
+	if errors.As(err, &errUser) {
+		log.Ctx(ctx).Debug().Msgf("pull request #%d can't be auto-merged yet: %s", pr.Number, errUser.Message)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to auto-merge pull request: %w", err)
+	}
+
+	if violations != nil {
+		log.Ctx(ctx).Debug().Msgf("pull request #%d can't be auto-merged yet: %s", pr.Number, violations.Message)
+		return nil
+	}
+
+	if out.MergeQueued {
+		log.Ctx(ctx).Info().Msgf("pull request #%d auto-merge: added to the merge queue", pr.Number)
+	} else {
+		log.Ctx(ctx).Info().Msgf("pull request #%d auto-merged with merge commit %s", pr.Number, out.SHA)
+	}
+
+	return c.autoMergeDeleteNoAuth(ctx, pr.ID)
+}
+
+// AutoMergeOnBranchUpdateNoAuth re-evaluates the auto-merge of a pull request after its source branch got updated.
+// If the new commits were pushed by a user that isn't allowed to merge the pull request
+// the auto-merge is canceled to prevent merging of unreviewed changes on behalf of another user.
+func (c *Controller) AutoMergeOnBranchUpdateNoAuth(ctx context.Context, pullreqID int64, pusherID int64) error {
+	am, err := c.autoMergeStore.Find(ctx, pullreqID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find auto-merge: %w", err)
+	}
+
+	if pusherID != am.CreatedBy {
+		pr, err := c.pullreqStore.Find(ctx, pullreqID)
+		if err != nil {
+			return fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		repo, err := c.repoStore.Find(ctx, pr.TargetRepoID)
+		if err != nil {
+			return fmt.Errorf("failed to find repository: %w", err)
+		}
+
+		pusher, err := c.principalStore.Find(ctx, pusherID)
+		if err != nil {
+			return fmt.Errorf("failed to find principal who pushed to the source branch: %w", err)
+		}
+
+		err = apiauth.CheckRepo(ctx, c.authorizer, &auth.Session{Principal: *pusher}, repo, enum.PermissionRepoPush)
+		if errors.Is(err, apiauth.ErrNotAuthorized) {
+			return c.autoMergeCancelNoAuth(ctx, repo, pr,
+				"New commits were pushed by a user who isn't permitted to merge the pull request.")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check permissions of principal who pushed to the source branch: %w", err)
+		}
+	}
+
+	return c.AutoMergeNoAuth(ctx, pullreqID)
+}
+
+// autoMergeDeleteNoAuth silently removes the auto-merge of a pull request that is no longer needed.
+func (c *Controller) autoMergeDeleteNoAuth(ctx context.Context, pullreqID int64) error {
+	err := c.autoMergeStore.Delete(ctx, pullreqID)
+	if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to delete auto-merge: %w", err)
+	}
+
+	return nil
+}
+
+// autoMergeCancelNoAuth disables the auto-merge of a pull request and explains the reason with an activity.
+func (c *Controller) autoMergeCancelNoAuth(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	reason string,
+) error {
+	err := c.autoMergeStore.Delete(ctx, pr.ID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to disable auto-merge: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("pull request #%d auto-merge canceled: %s", pr.Number, reason)
+
+	c.writeAutoMergeActivity(ctx, repo, pr, bootstrap.NewSystemServiceSession().Principal.ID,
+		&types.PullRequestActivityPayloadAutoMergeDisable{Reason: reason})
+
+	return nil
+}
+
+func (c *Controller) writeAutoMergeActivity(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	principalID int64,
+	payload types.PullReqActivityPayload,
+) {
+	pr, err := c.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to update pull request activity sequence for auto-merge activity")
+		return
+	}
+
+	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, principalID, payload, nil); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull request auto-merge activity")
+	}
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+}
@@ -0,0 +1,430 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller/service"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestAutoMergeInputSanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   AutoMergeInput
+		want    AutoMergeInput
+		wantErr bool
+	}{
+		{
+			name:  "squash-trims-title-and-message",
+			input: AutoMergeInput{Method: "squash", Title: "  title ", Message: "\nmessage\n"},
+			want:  AutoMergeInput{Method: enum.MergeMethodSquash, Title: "title", Message: "message"},
+		},
+		{
+			name:  "rebase-without-title",
+			input: AutoMergeInput{Method: "rebase", Title: "   "},
+			want:  AutoMergeInput{Method: enum.MergeMethodRebase},
+		},
+		{
+			name:    "missing-method",
+			input:   AutoMergeInput{},
+			wantErr: true,
+		},
+		{
+			name:    "unknown-method",
+			input:   AutoMergeInput{Method: "octopus"},
+			wantErr: true,
+		},
+		{
+			name:    "fast-forward-with-title",
+			input:   AutoMergeInput{Method: "fast-forward", Title: "title"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := test.input
+			err := in.sanitize()
+			if test.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if in != test.want {
+				t.Errorf("want=%+v got=%+v", test.want, in)
+			}
+		})
+	}
+}
+
+type autoMergeStoreStub struct {
+	store.AutoMergeStore
+	autoMerges map[int64]*types.AutoMerge
+}
+
+func (s *autoMergeStoreStub) Find(_ context.Context, pullReqID int64) (*types.AutoMerge, error) {
+	am, ok := s.autoMerges[pullReqID]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return am, nil
+}
+
+func (s *autoMergeStoreStub) Delete(_ context.Context, pullReqID int64) error {
+	if _, ok := s.autoMerges[pullReqID]; !ok {
+		return gitness_store.ErrResourceNotFound
+	}
+	delete(s.autoMerges, pullReqID)
+	return nil
+}
+
+type pullReqStoreStub struct {
+	store.PullReqStore
+	pr *types.PullReq
+}
+
+func (s *pullReqStoreStub) Find(context.Context, int64) (*types.PullReq, error) {
+	return s.pr, nil
+}
+
+func (s *pullReqStoreStub) UpdateActivitySeq(_ context.Context, pr *types.PullReq) (*types.PullReq, error) {
+	return pr, nil
+}
+
+type repoStoreStub struct {
+	store.RepoStore
+	repo *types.Repository
+}
+
+func (s *repoStoreStub) Find(context.Context, int64) (*types.Repository, error) {
+	return s.repo, nil
+}
+
+type principalStoreStub struct {
+	store.PrincipalStore
+	principals map[int64]*types.Principal
+}
+
+func (s *principalStoreStub) FindServiceByUID(_ context.Context, uid string) (*types.Service, error) {
+	return &types.Service{ID: autoMergeTestSystemID, UID: uid, Admin: true}, nil
+}
+
+func (s *principalStoreStub) Find(_ context.Context, id int64) (*types.Principal, error) {
+	principal, ok := s.principals[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return principal, nil
+}
+
+type activityStoreStub struct {
+	store.PullReqActivityStore
+	payloads []types.PullReqActivityPayload
+}
+
+func (s *activityStoreStub) CreateWithPayload(
+	_ context.Context,
+	_ *types.PullReq,
+	_ int64,
+	payload types.PullReqActivityPayload,
+	_ *types.PullReqActivityMetadata,
+) (*types.PullReqActivity, error) {
+	s.payloads = append(s.payloads, payload)
+	return &types.PullReqActivity{}, nil
+}
+
+type sseStreamerStub struct {
+	sse.Streamer
+}
+
+func (sseStreamerStub) Publish(context.Context, int64, enum.SSEType, any) {}
+
+type authorizerStub struct {
+	authz.Authorizer
+	permitted bool
+}
+
+func (s authorizerStub) Check(
+	context.Context,
+	*auth.Session,
+	*types.Scope,
+	*types.Resource,
+	enum.Permission,
+) (bool, error) {
+	return s.permitted, nil
+}
+
+const (
+	autoMergeTestMergerID = 1
+	autoMergeTestPusherID = 2
+	autoMergeTestSystemID = 3
+)
+
+type autoMergeTest struct {
+	ctrl       *Controller
+	autoMerges *autoMergeStoreStub
+	activities *activityStoreStub
+	repo       *types.Repository
+	pr         *types.PullReq
+}
+
+func newAutoMergeTest(t *testing.T, pr *types.PullReq, merger *types.Principal, permitted bool) *autoMergeTest {
+	t.Helper()
+
+	repo := &types.Repository{ID: 1, ParentID: 1, Path: "space/repo"}
+	autoMerges := &autoMergeStoreStub{autoMerges: map[int64]*types.AutoMerge{
+		pr.ID: {PullReqID: pr.ID, RepoID: repo.ID, Method: enum.MergeMethodSquash, CreatedBy: merger.ID},
+	}}
+	activities := &activityStoreStub{}
+
+	principals := &principalStoreStub{principals: map[int64]*types.Principal{
+		merger.ID:             merger,
+		autoMergeTestPusherID: {ID: autoMergeTestPusherID},
+	}}
+
+	// the canceled auto-merge activity is written on behalf of the system service principal
+	config := &types.Config{}
+	config.Principal.System.UID = "gitness"
+	err := bootstrap.SystemService(context.Background(), config, service.NewController(nil, nil, principals))
+	if err != nil {
+		t.Fatalf("failed to setup system service: %s", err)
+	}
+
+	ctrl := &Controller{
+		authorizer:     authorizerStub{permitted: permitted},
+		pullreqStore:   &pullReqStoreStub{pr: pr},
+		activityStore:  activities,
+		repoStore:      &repoStoreStub{repo: repo},
+		principalStore: principals,
+		sseStreamer:    sseStreamerStub{},
+		autoMergeStore: autoMerges,
+	}
+
+	return &autoMergeTest{
+		ctrl:       ctrl,
+		autoMerges: autoMerges,
+		activities: activities,
+		repo:       repo,
+		pr:         pr,
+	}
+}
+
+func (test *autoMergeTest) enabled() bool {
+	_, ok := test.autoMerges.autoMerges[test.pr.ID]
+	return ok
+}
+
+// canceledReason returns the reason of the auto-merge cancellation activity, or an empty string if there's none.
+func (test *autoMergeTest) canceledReason() string {
+	for _, payload := range test.activities.payloads {
+		if p, ok := payload.(*types.PullRequestActivityPayloadAutoMergeDisable); ok {
+			return p.Reason
+		}
+	}
+	return ""
+}
+
+func TestAutoMergeHandleMergeResult(t *testing.T) {
+	tests := []struct {
+		name         string
+		out          *types.MergeResponse
+		violations   *types.MergeViolations
+		err          error
+		wantErr      bool
+		wantEnabled  bool
+		wantCanceled bool
+	}{
+		{
+			name: "merged",
+			out:  &types.MergeResponse{SHA: "abc"},
+		},
+		{
+			name: "merge-queued",
+			out:  &types.MergeResponse{MergeQueued: true},
+		},
+		{
+			name:        "rule-violations",
+			violations:  &types.MergeViolations{Message: "Failed to merge pull request because of rule violations."},
+			wantEnabled: true,
+		},
+		{
+			name:        "merge-conflict",
+			violations:  &types.MergeViolations{Message: "Merge blocked by conflicting files.", ConflictFiles: []string{"a"}},
+			wantEnabled: true,
+		},
+		{
+			name:        "outdated-source-sha",
+			err:         usererror.BadRequest("A newer commit is available. Only the latest commit can be merged."),
+			wantEnabled: true,
+		},
+		{
+			name:         "not-authorized",
+			err:          fmt.Errorf("failed to acquire access to target repo: %w", apiauth.ErrNotAuthorized),
+			wantCanceled: true,
+		},
+		{
+			name:        "internal-error",
+			err:         errors.New("failed to merge"),
+			wantErr:     true,
+			wantEnabled: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pr := &types.PullReq{ID: 1, Number: 1, State: enum.PullReqStateOpen}
+			amTest := newAutoMergeTest(t, pr, &types.Principal{ID: autoMergeTestMergerID}, true)
+
+			err := amTest.ctrl.autoMergeHandleMergeResult(context.Background(),
+				amTest.repo, pr, test.out, test.violations, test.err)
+			if test.wantErr && err == nil {
+				t.Error("expected an error")
+			}
+			if !test.wantErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+
+			if enabled := amTest.enabled(); enabled != test.wantEnabled {
+				t.Errorf("auto-merge enabled: want=%t got=%t", test.wantEnabled, enabled)
+			}
+
+			if canceled := amTest.canceledReason() != ""; canceled != test.wantCanceled {
+				t.Errorf("auto-merge canceled: want=%t got=%t", test.wantCanceled, canceled)
+			}
+		})
+	}
+}
+
+func TestAutoMergeRequirementsBecomeSatisfied(t *testing.T) {
+	ctx := context.Background()
+	pr := &types.PullReq{ID: 1, Number: 1, State: enum.PullReqStateOpen}
+	amTest := newAutoMergeTest(t, pr, &types.Principal{ID: autoMergeTestMergerID}, true)
+
+	// the pull request is still missing an approval
+	violations := &types.MergeViolations{Message: "Failed to merge pull request because of rule violations."}
+	if err := amTest.ctrl.autoMergeHandleMergeResult(ctx, amTest.repo, pr, nil, violations, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !amTest.enabled() {
+		t.Fatal("auto-merge must stay enabled while the merge requirements aren't satisfied")
+	}
+
+	// the pull request got approved and merged
+	out := &types.MergeResponse{SHA: "abc"}
+	if err := amTest.ctrl.autoMergeHandleMergeResult(ctx, amTest.repo, pr, out, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if amTest.enabled() {
+		t.Error("auto-merge must be removed after the pull request got merged")
+	}
+	if len(amTest.activities.payloads) != 0 {
+		t.Errorf("expected no activities, got %d", len(amTest.activities.payloads))
+	}
+}
+
+func TestAutoMergeNoAuth(t *testing.T) {
+	tests := []struct {
+		name         string
+		state        enum.PullReqState
+		blocked      bool
+		wantCanceled bool
+	}{
+		{
+			name:  "closed-pull-request",
+			state: enum.PullReqStateClosed,
+		},
+		{
+			name:  "merged-pull-request",
+			state: enum.PullReqStateMerged,
+		},
+		{
+			name:         "blocked-merger",
+			state:        enum.PullReqStateOpen,
+			blocked:      true,
+			wantCanceled: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pr := &types.PullReq{ID: 1, Number: 1, State: test.state}
+			merger := &types.Principal{ID: autoMergeTestMergerID, Blocked: test.blocked}
+			amTest := newAutoMergeTest(t, pr, merger, true)
+
+			if err := amTest.ctrl.AutoMergeNoAuth(context.Background(), pr.ID); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if amTest.enabled() {
+				t.Error("expected the auto-merge to be removed")
+			}
+
+			if canceled := amTest.canceledReason() != ""; canceled != test.wantCanceled {
+				t.Errorf("auto-merge canceled: want=%t got=%t", test.wantCanceled, canceled)
+			}
+		})
+	}
+}
+
+func TestAutoMergeNoAuthNotEnabled(t *testing.T) {
+	pr := &types.PullReq{ID: 1, Number: 1, State: enum.PullReqStateOpen}
+	amTest := newAutoMergeTest(t, pr, &types.Principal{ID: autoMergeTestMergerID}, true)
+	delete(amTest.autoMerges.autoMerges, pr.ID)
+
+	if err := amTest.ctrl.AutoMergeNoAuth(context.Background(), pr.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(amTest.activities.payloads) != 0 {
+		t.Errorf("expected no activities, got %d", len(amTest.activities.payloads))
+	}
+}
+
+func TestAutoMergeOnBranchUpdateNoAuthUnauthorizedPusher(t *testing.T) {
+	pr := &types.PullReq{ID: 1, Number: 1, State: enum.PullReqStateOpen}
+	amTest := newAutoMergeTest(t, pr, &types.Principal{ID: autoMergeTestMergerID}, false)
+
+	err := amTest.ctrl.AutoMergeOnBranchUpdateNoAuth(context.Background(), pr.ID, autoMergeTestPusherID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if amTest.enabled() {
+		t.Error("expected the auto-merge to be canceled")
+	}
+
+	const wantReason = "New commits were pushed by a user who isn't permitted to merge the pull request."
+	if reason := amTest.canceledReason(); reason != wantReason {
+		t.Errorf("want reason=%q got=%q", wantReason, reason)
+	}
+}
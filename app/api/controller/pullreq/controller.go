@@ -20,6 +20,7 @@ import (
 	"unicode/utf8"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
@@ -31,6 +32,7 @@ import (
 	locker "github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -79,6 +81,9 @@ type Controller struct {
 	labelSvc               *label.Service
 	instrumentation        instrument.Service
 	userGroupService       usergroup.SearchService
+	signatureVerifier      *publickey.SignatureVerifier
+	mergeQueueStore        store.MergeQueueStore
+	autoMergeStore         store.AutoMergeStore
 }
 
 func NewController(
@@ -113,6 +118,9 @@ func NewController(
 	labelSvc *label.Service,
 	instrumentation instrument.Service,
 	userGroupService usergroup.SearchService,
+	signatureVerifier *publickey.SignatureVerifier,
+	mergeQueueStore store.MergeQueueStore,
+	autoMergeStore store.AutoMergeStore,
 ) *Controller {
 	return &Controller{
 		tx:                     tx,
@@ -146,6 +154,9 @@ func NewController(
 		labelSvc:               labelSvc,
 		instrumentation:        instrumentation,
 		userGroupService:       userGroupService,
+		signatureVerifier:      signatureVerifier,
+		mergeQueueStore:        mergeQueueStore,
+		autoMergeStore:         autoMergeStore,
 	}
 }
 
@@ -175,6 +186,32 @@ func (c *Controller) verifyBranchExistence(ctx context.Context,
 	return ref.SHA, nil
 }
 
+// fetchSourceObjects copies the commit of the source branch of a pull request opened from a fork
+// to the target repository.
+func (c *Controller) fetchSourceObjects(
+	ctx context.Context,
+	session *auth.Session,
+	sourceRepo *types.Repository,
+	targetRepo *types.Repository,
+	sourceSHA sha.SHA,
+) error {
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: sourceRepo.GitUID,
+		ObjectSHAs:    []sha.SHA{sourceSHA},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch source branch commit to the target repository: %w", err)
+	}
+
+	return nil
+}
+
 func (c *Controller) getRepo(ctx context.Context, repoRef string) (*types.Repository, error) {
 	if repoRef == "" {
 		return nil, usererror.BadRequest("A valid repository reference must be provided.")
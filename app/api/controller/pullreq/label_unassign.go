@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	events "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -73,5 +74,22 @@ func (c *Controller) UnassignLabel(
 		log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity after label unassign")
 	}
 
+	var valueID *int64
+	if labelValue != nil {
+		valueID = &labelValue.ID
+	}
+
+	c.eventReporter.LabelUnassigned(ctx, &events.LabelUnassignedPayload{
+		Base: events.Base{
+			PullReqID:    pullreq.ID,
+			SourceRepoID: pullreq.SourceRepoID,
+			TargetRepoID: pullreq.TargetRepoID,
+			PrincipalID:  session.Principal.ID,
+			Number:       pullreq.Number,
+		},
+		LabelID: label.ID,
+		ValueID: valueID,
+	})
+
 	return nil
 }
@@ -93,6 +93,7 @@ func (in *MergeInput) sanitize() error {
 // return allowed merge methods. Rules can limit allowed merge methods.
 //
 // If the pull request has been successfully merged the function will return the SHA of the merge commit.
+// If the target branch requires the merge queue the pull request is added to the queue instead of being merged.
 //
 //nolint:gocognit,gocyclo,cyclop
 func (c *Controller) Merge(
@@ -166,11 +167,6 @@ func (c *Controller) Merge(
 		)
 	}
 
-	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load list of reviwers: %w", err)
-	}
-
 	targetWriteParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
@@ -179,46 +175,20 @@ func (c *Controller) Merge(
 	sourceRepo := targetRepo
 	sourceWriteParams := targetWriteParams
 	if pr.SourceRepoID != pr.TargetRepoID {
-		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
-		}
-
 		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
 		}
-	}
 
-	protectionRules, isRepoOwner, err := c.fetchRules(ctx, session, targetRepo)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to fetch rules: %w", err)
-	}
-
-	checkResults, err := c.checkStore.ListResults(ctx, targetRepo.ID, pr.SourceSHA)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to list status checks: %w", err)
-	}
-
-	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, sourceRepo, pr, reviewers)
-	// check for error and ignore if it is codeowners file not found else throw error
-	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
-		return nil, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+		}
 	}
 
-	ruleOut, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
-		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
-		Actor:              &session.Principal,
-		AllowBypass:        in.BypassRules,
-		IsRepoOwner:        isRepoOwner,
-		TargetRepo:         targetRepo,
-		SourceRepo:         sourceRepo,
-		PullReq:            pr,
-		Reviewers:          reviewers,
-		Method:             in.Method, // the method can be empty for dry run or dry run rules
-		CheckResults:       checkResults,
-		CodeOwners:         codeOwnerWithApproval,
-	})
+	ruleOut, violations, err := c.verifyMergeRules(ctx, session, targetRepo, sourceRepo, pr,
+		in.Method, // the method can be empty for dry run or dry run rules
+		in.BypassRules)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
 	}
@@ -234,6 +204,7 @@ func (c *Controller) Merge(
 			RequiresCodeOwnersApprovalLatest:    ruleOut.RequiresCodeOwnersApprovalLatest,
 			RequiresCommentResolution:           ruleOut.RequiresCommentResolution,
 			RequiresNoChangeRequests:            ruleOut.RequiresNoChangeRequests,
+			RequiresMergeQueue:                  ruleOut.RequiresMergeQueue,
 			MinimumRequiredApprovalsCount:       ruleOut.MinimumRequiredApprovalsCount,
 			MinimumRequiredApprovalsCountLatest: ruleOut.MinimumRequiredApprovalsCountLatest,
 		}, nil, nil
@@ -353,6 +324,7 @@ func (c *Controller) Merge(
 			RequiresCodeOwnersApprovalLatest:    ruleOut.RequiresCodeOwnersApprovalLatest,
 			RequiresCommentResolution:           ruleOut.RequiresCommentResolution,
 			RequiresNoChangeRequests:            ruleOut.RequiresNoChangeRequests,
+			RequiresMergeQueue:                  ruleOut.RequiresMergeQueue,
 			MinimumRequiredApprovalsCount:       ruleOut.MinimumRequiredApprovalsCount,
 			MinimumRequiredApprovalsCountLatest: ruleOut.MinimumRequiredApprovalsCountLatest,
 		}
@@ -385,40 +357,17 @@ func (c *Controller) Merge(
 		}, nil
 	}
 
-	// commit details: author, committer and message
-
-	var author *git.Identity
-
-	switch in.Method {
-	case enum.MergeMethodMerge:
-		author = controller.IdentityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
-	case enum.MergeMethodSquash:
-		author = controller.IdentityFromPrincipalInfo(pr.Author)
-	case enum.MergeMethodRebase, enum.MergeMethodFastForward:
-		author = nil // Not important for these merge methods: the author info in the commits will be preserved.
+	if ruleOut.RequiresMergeQueue {
+		return c.enqueue(ctx, session, targetRepo, sourceRepo, pr, in, ruleOut.DeleteSourceBranch, violations)
 	}
 
-	var committer *git.Identity
+	// commit details: author, committer and message
 
-	switch in.Method {
-	case enum.MergeMethodMerge, enum.MergeMethodSquash:
-		committer = controller.SystemServicePrincipalInfo()
-	case enum.MergeMethodRebase:
-		committer = controller.IdentityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
-	case enum.MergeMethodFastForward:
-		committer = nil // Not important for fast-forward merge
-	}
+	author, committer := mergeIdentities(in.Method, session.Principal.ToPrincipalInfo(), pr)
 
 	// backfill commit title if none provided
 	if in.Title == "" {
-		switch in.Method {
-		case enum.MergeMethodMerge:
-			in.Title = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
-		case enum.MergeMethodSquash:
-			in.Title = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
-		case enum.MergeMethodRebase, enum.MergeMethodFastForward:
-			// Not used.
-		}
+		in.Title = mergeTitle(in.Method, sourceRepo, pr)
 	}
 
 	// create merge commit(s)
@@ -483,17 +432,92 @@ func (c *Controller) Merge(
 
 	log.Ctx(ctx).Debug().Msgf("successfully merged PR")
 
-	mergedBy := session.Principal.ID
+	pr, branchDeleted, err := c.completeMerge(ctx, &session.Principal, targetRepo, sourceRepo, sourceWriteParams,
+		pr, in.Method, mergeOutput, now, ruleOut.DeleteSourceBranch, protection.IsBypassed(violations))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if protection.IsBypassed(violations) {
+		c.auditMergeBypass(ctx, session, targetRepo, pr, violations)
+	}
+
+	return &types.MergeResponse{
+		SHA:            mergeOutput.MergeSHA.String(),
+		BranchDeleted:  branchDeleted,
+		RuleViolations: violations,
+	}, nil, nil
+}
+
+// mergeIdentities returns the author and the committer of the merge commit(s) created with the provided method.
+func mergeIdentities(
+	method enum.MergeMethod,
+	merger *types.PrincipalInfo,
+	pr *types.PullReq,
+) (*git.Identity, *git.Identity) {
+	var author *git.Identity
+
+	switch method {
+	case enum.MergeMethodMerge:
+		author = controller.IdentityFromPrincipalInfo(*merger)
+	case enum.MergeMethodSquash:
+		author = controller.IdentityFromPrincipalInfo(pr.Author)
+	case enum.MergeMethodRebase, enum.MergeMethodFastForward:
+		author = nil // Not important for these merge methods: the author info in the commits will be preserved.
+	}
+
+	var committer *git.Identity
+
+	switch method {
+	case enum.MergeMethodMerge, enum.MergeMethodSquash:
+		committer = controller.SystemServicePrincipalInfo()
+	case enum.MergeMethodRebase:
+		committer = controller.IdentityFromPrincipalInfo(*merger)
+	case enum.MergeMethodFastForward:
+		committer = nil // Not important for fast-forward merge
+	}
 
+	return author, committer
+}
+
+// mergeTitle returns the default title of the commit created with the provided merge method.
+func mergeTitle(method enum.MergeMethod, sourceRepo *types.Repository, pr *types.PullReq) string {
+	switch method {
+	case enum.MergeMethodMerge:
+		return fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
+	case enum.MergeMethodSquash:
+		return fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+	case enum.MergeMethodRebase, enum.MergeMethodFastForward:
+		// Not used.
+	}
+
+	return ""
+}
+
+// completeMerge marks the pull request as merged once its changes have landed on the target branch.
+// It writes the merge activity, reports the merged event and, if requested, deletes the source branch.
+func (c *Controller) completeMerge(
+	ctx context.Context,
+	mergedBy *types.Principal,
+	targetRepo *types.Repository,
+	sourceRepo *types.Repository,
+	sourceWriteParams git.WriteParams,
+	pr *types.PullReq,
+	method enum.MergeMethod,
+	mergeOutput git.MergeOutput,
+	now time.Time,
+	deleteSourceBranch bool,
+	rulesBypassed bool,
+) (*types.PullReq, bool, error) {
 	var activitySeqMerge, activitySeqBranchDeleted int64
-	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+	pr, err := c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
 		pr.State = enum.PullReqStateMerged
 
 		nowMilli := now.UnixMilli()
 
 		pr.Merged = &nowMilli
-		pr.MergedBy = &mergedBy
-		pr.MergeMethod = &in.Method
+		pr.MergedBy = &mergedBy.ID
+		pr.MergeMethod = &method
 
 		// update all Merge specific information (might be empty if previous merge check failed)
 		// since this is the final operation on the PR, we update any sha that might've changed by now.
@@ -513,7 +537,7 @@ func (c *Controller) Merge(
 		pr.ActivitySeq++
 		activitySeqMerge = pr.ActivitySeq
 
-		if ruleOut.DeleteSourceBranch {
+		if deleteSourceBranch {
 			pr.ActivitySeq++
 			activitySeqBranchDeleted = pr.ActivitySeq
 		}
@@ -521,32 +545,32 @@ func (c *Controller) Merge(
 		return nil
 	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to update pull request: %w", err)
+		return nil, false, fmt.Errorf("failed to update pull request: %w", err)
 	}
 
 	pr.ActivitySeq = activitySeqMerge
 	activityPayload := &types.PullRequestActivityPayloadMerge{
-		MergeMethod:   in.Method,
+		MergeMethod:   method,
 		MergeSHA:      mergeOutput.MergeSHA.String(),
 		TargetSHA:     mergeOutput.BaseSHA.String(),
 		SourceSHA:     mergeOutput.HeadSHA.String(),
-		RulesBypassed: protection.IsBypassed(violations),
+		RulesBypassed: rulesBypassed,
 	}
-	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, mergedBy, activityPayload, nil); errAct != nil {
+	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, mergedBy.ID, activityPayload, nil); errAct != nil {
 		// non-critical error
 		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull req merge activity")
 	}
 
 	c.eventReporter.Merged(ctx, &pullreqevents.MergedPayload{
-		Base:        eventBase(pr, &session.Principal),
-		MergeMethod: in.Method,
+		Base:        eventBase(pr, mergedBy),
+		MergeMethod: method,
 		MergeSHA:    mergeOutput.MergeSHA.String(),
 		TargetSHA:   mergeOutput.BaseSHA.String(),
 		SourceSHA:   mergeOutput.HeadSHA.String(),
 	})
 
 	var branchDeleted bool
-	if ruleOut.DeleteSourceBranch {
+	if deleteSourceBranch {
 		errDelete := c.git.DeleteBranch(ctx, &git.DeleteBranchParams{
 			WriteParams: sourceWriteParams,
 			BranchName:  pr.SourceBranch,
@@ -560,8 +584,8 @@ func (c *Controller) Merge(
 			// NOTE: there is a chance someone pushed on the branch between merge and delete.
 			// Either way, we'll use the SHA that was merged with for the activity to be consistent from PR perspective.
 			pr.ActivitySeq = activitySeqBranchDeleted
-			if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, mergedBy,
-				&types.PullRequestActivityPayloadBranchDelete{SHA: in.SourceSHA}, nil); errAct != nil {
+			if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, mergedBy.ID,
+				&types.PullRequestActivityPayloadBranchDelete{SHA: mergeOutput.HeadSHA.String()}, nil); errAct != nil {
 				// non-critical error
 				log.Ctx(ctx).Err(errAct).
 					Msgf("failed to write pull request activity for successful automatic branch delete")
@@ -571,57 +595,111 @@ func (c *Controller) Merge(
 
 	c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullReqUpdated, pr)
 
-	if protection.IsBypassed(violations) {
-		err = c.auditService.Log(ctx,
-			session.Principal,
-			audit.NewResource(
-				audit.ResourceTypeRepository,
-				sourceRepo.Identifier,
-				audit.RepoPath,
-				sourceRepo.Path,
-				audit.BypassedResourceType,
-				audit.BypassedResourceTypePullRequest,
-				audit.BypassedResourceName,
-				strconv.FormatInt(pr.Number, 10),
-				audit.ResourceName,
-				fmt.Sprintf(
-					audit.BypassPullReqLabelFormat,
-					sourceRepo.Identifier,
-					strconv.FormatInt(pr.Number, 10),
-				),
-				audit.BypassAction,
-				audit.BypassActionMerged,
-			),
-			audit.ActionBypassed,
-			paths.Parent(sourceRepo.Path),
-			audit.WithNewObject(audit.PullRequestObject{
-				PullReq:        *pr,
-				RepoPath:       sourceRepo.Path,
-				RuleViolations: violations,
-			}),
-		)
-		if err != nil {
-			log.Ctx(ctx).Warn().Msgf("failed to insert audit log for merge pull request operation: %s", err)
-		}
-	}
-
 	err = c.instrumentation.Track(ctx, instrument.Event{
 		Type:      instrument.EventTypeMergePullRequest,
-		Principal: session.Principal.ToPrincipalInfo(),
-		Path:      sourceRepo.Path,
+		Principal: mergedBy.ToPrincipalInfo(),
+		Path:      targetRepo.Path,
 		Properties: map[instrument.Property]any{
-			instrument.PropertyRepositoryID:   sourceRepo.ID,
-			instrument.PropertyRepositoryName: sourceRepo.Identifier,
+			instrument.PropertyRepositoryID:   targetRepo.ID,
+			instrument.PropertyRepositoryName: targetRepo.Identifier,
 			instrument.PropertyPullRequestID:  pr.Number,
-			instrument.PropertyMergeStrategy:  in.Method,
+			instrument.PropertyMergeStrategy:  method,
 		},
 	})
 	if err != nil {
 		log.Ctx(ctx).Warn().Msgf("failed to insert instrumentation record for merge pr operation: %s", err)
 	}
-	return &types.MergeResponse{
-		SHA:            mergeOutput.MergeSHA.String(),
-		BranchDeleted:  branchDeleted,
-		RuleViolations: violations,
-	}, nil, nil
+
+	return pr, branchDeleted, nil
+}
+
+// verifyMergeRules verifies the protection rules of the target repository for merging the pull request.
+func (c *Controller) verifyMergeRules(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.Repository,
+	sourceRepo *types.Repository,
+	pr *types.PullReq,
+	method enum.MergeMethod,
+	allowBypass bool,
+) (protection.MergeVerifyOutput, []types.RuleViolations, error) {
+	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return protection.MergeVerifyOutput{}, nil, fmt.Errorf("failed to load list of reviwers: %w", err)
+	}
+
+	protectionRules, isRepoOwner, err := c.fetchRules(ctx, session, targetRepo)
+	if err != nil {
+		return protection.MergeVerifyOutput{}, nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	checkResults, err := c.checkStore.ListResults(ctx, targetRepo.ID, pr.SourceSHA)
+	if err != nil {
+		return protection.MergeVerifyOutput{}, nil, fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, targetRepo, pr, reviewers)
+	// check for error and ignore if it is codeowners file not found else throw error
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return protection.MergeVerifyOutput{}, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	return protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		AllowBypass:        allowBypass,
+		IsRepoOwner:        isRepoOwner,
+		TargetRepo:         targetRepo,
+		SourceRepo:         sourceRepo,
+		PullReq:            pr,
+		Reviewers:          reviewers,
+		Method:             method,
+		CheckResults:       checkResults,
+		CodeOwners:         codeOwnerWithApproval,
+		FindUnverifiedCommits: func(ctx context.Context) ([]string, error) {
+			return c.signatureVerifier.FindUnverifiedCommits(ctx,
+				git.CreateReadParams(targetRepo), pr.SourceSHA, pr.MergeBaseSHA)
+		},
+	})
+}
+
+// auditMergeBypass writes the audit log entry for a pull request merge that bypassed the protection rules.
+func (c *Controller) auditMergeBypass(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.Repository,
+	pr *types.PullReq,
+	violations []types.RuleViolations,
+) {
+	err := c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(
+			audit.ResourceTypeRepository,
+			targetRepo.Identifier,
+			audit.RepoPath,
+			targetRepo.Path,
+			audit.BypassedResourceType,
+			audit.BypassedResourceTypePullRequest,
+			audit.BypassedResourceName,
+			strconv.FormatInt(pr.Number, 10),
+			audit.ResourceName,
+			fmt.Sprintf(
+				audit.BypassPullReqLabelFormat,
+				targetRepo.Identifier,
+				strconv.FormatInt(pr.Number, 10),
+			),
+			audit.BypassAction,
+			audit.BypassActionMerged,
+		),
+		audit.ActionBypassed,
+		paths.Parent(targetRepo.Path),
+		audit.WithNewObject(audit.PullRequestObject{
+			PullReq:        *pr,
+			RepoPath:       targetRepo.Path,
+			RuleViolations: violations,
+		}),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for merge pull request operation: %s", err)
+	}
 }
@@ -0,0 +1,382 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+// MergeQueueRef returns the git reference used for the speculative merge commit of a queued pull request.
+func MergeQueueRef(pullreqNum int64) string {
+	return fmt.Sprintf("refs/pullreq/%d/merge-queue", pullreqNum)
+}
+
+// enqueue adds the pull request to the merge queue of its target branch instead of merging it right away.
+func (c *Controller) enqueue(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.Repository,
+	sourceRepo *types.Repository,
+	pr *types.PullReq,
+	in *MergeInput,
+	deleteSourceBranch bool,
+	violations []types.RuleViolations,
+) (*types.MergeResponse, *types.MergeViolations, error) {
+	if in.Method == enum.MergeMethodFastForward {
+		return nil, nil, usererror.BadRequest(
+			"The fast-forward merge method can't be used for branches that require the merge queue.")
+	}
+
+	_, err := c.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if err == nil {
+		return nil, nil, usererror.BadRequest("Pull request is already in the merge queue")
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, nil, fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	title := in.Title
+	if title == "" {
+		title = mergeTitle(in.Method, sourceRepo, pr)
+	}
+
+	now := time.Now().UnixMilli()
+	entry := &types.MergeQueueEntry{
+		RepoID:             pr.TargetRepoID,
+		PullReqID:          pr.ID,
+		PullReqNumber:      pr.Number,
+		TargetBranch:       pr.TargetBranch,
+		State:              enum.MergeQueueEntryStateQueued,
+		Method:             in.Method,
+		Title:              title,
+		Message:            in.Message,
+		RulesBypassed:      protection.IsBypassed(violations),
+		DeleteSourceBranch: deleteSourceBranch,
+		SourceSHA:          pr.SourceSHA,
+		CreatedBy:          session.Principal.ID,
+		Created:            now,
+		Updated:            now,
+	}
+
+	err = c.mergeQueueStore.Create(ctx, entry)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to add pull request to the merge queue: %w", err)
+	}
+
+	if protection.IsBypassed(violations) {
+		c.auditMergeBypass(ctx, session, targetRepo, pr, violations)
+	}
+
+	log.Ctx(ctx).Info().Msgf("pull request added to the merge queue of branch %q", pr.TargetBranch)
+
+	return &types.MergeResponse{
+		MergeQueued:    true,
+		RuleViolations: violations,
+	}, nil, nil
+}
+
+// MergeQueueList returns the entries of the merge queues of a repository.
+func (c *Controller) MergeQueueList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.MergeQueueFilter,
+) ([]*types.MergeQueueEntry, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	entries, err := c.mergeQueueStore.List(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// MergeQueueRemove removes a pull request from the merge queue.
+func (c *Controller) MergeQueueRemove(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	// the merge queue is processed under the repo level pull request lock.
+	unlock, err := c.locker.LockPR(ctx, repo.ID, 0, 30*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to lock repository for merge queue update: %w", err)
+	}
+	defer unlock()
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	entry, err := c.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return usererror.BadRequest("Pull request is not in the merge queue")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	err = c.mergeQueueStore.Delete(ctx, entry.ID)
+	if err != nil {
+		return fmt.Errorf("failed to remove pull request from the merge queue: %w", err)
+	}
+
+	c.MergeQueueDeleteRefNoAuth(ctx, repo, pr.Number)
+
+	return nil
+}
+
+// MergeQueueBuildNoAuth creates the speculative merge commit of a merge queue entry on top of the provided commit.
+// The commit is stored under the MergeQueueRef of the pull request and the pipelines are triggered for it.
+// The caller must hold the repo level pull request lock.
+func (c *Controller) MergeQueueBuildNoAuth(
+	ctx context.Context,
+	targetRepo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	baseSHA sha.SHA,
+) (git.MergeOutput, error) {
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		var err error
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return git.MergeOutput{}, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	merger, err := c.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return git.MergeOutput{}, fmt.Errorf("failed to find principal that added the entry: %w", err)
+	}
+
+	session := &auth.Session{Principal: *merger}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
+	if err != nil {
+		return git.MergeOutput{}, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	author, committer := mergeIdentities(entry.Method, merger.ToPrincipalInfo(), pr)
+	ref := MergeQueueRef(pr.Number)
+
+	now := time.Now()
+	mergeOutput, err := c.git.Merge(ctx, &git.MergeParams{
+		WriteParams:     writeParams,
+		BaseSHA:         baseSHA,
+		BaseBranch:      pr.TargetBranch,
+		HeadRepoUID:     sourceRepo.GitUID,
+		HeadBranch:      pr.SourceBranch,
+		Message:         git.CommitMessage(entry.Title, entry.Message),
+		Committer:       committer,
+		CommitterDate:   &now,
+		Author:          author,
+		AuthorDate:      &now,
+		RefType:         gitenum.RefTypeRaw,
+		RefName:         ref,
+		HeadExpectedSHA: sha.Must(entry.SourceSHA),
+		Method:          gitenum.MergeMethod(entry.Method),
+	})
+	if err != nil {
+		return git.MergeOutput{}, fmt.Errorf("speculative merge failed: %w", err)
+	}
+
+	if mergeOutput.MergeSHA.IsEmpty() || len(mergeOutput.ConflictFiles) > 0 {
+		return mergeOutput, nil
+	}
+
+	c.eventReporter.MergeQueueCheck(ctx, &pullreqevents.MergeQueueCheckPayload{
+		Base:         eventBase(pr, merger),
+		TargetBranch: pr.TargetBranch,
+		Ref:          ref,
+		BaseSHA:      mergeOutput.BaseSHA.String(),
+		MergeSHA:     mergeOutput.MergeSHA.String(),
+	})
+
+	return mergeOutput, nil
+}
+
+// MergeQueueMergeNoAuth merges the pull request of a merge queue entry
+// whose speculative merge commit has passed all required status checks.
+// The target branch is fast-forwarded to the speculative merge commit and the entry is removed from the queue.
+// The permissions of the user who added the entry and the protection rules are verified again before merging,
+// a PreconditionFailed error is returned if the pull request can no longer be merged.
+// The caller must hold the repo level pull request lock.
+func (c *Controller) MergeQueueMergeNoAuth(
+	ctx context.Context,
+	targetRepo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+) (*types.PullReq, error) {
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		var err error
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	merger, err := c.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal that added the entry: %w", err)
+	}
+
+	session := &auth.Session{Principal: *merger}
+
+	if err = c.mergeQueueVerifyNoAuth(ctx, session, targetRepo, sourceRepo, pr, entry); err != nil {
+		return nil, err
+	}
+
+	targetWriteParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	sourceWriteParams := targetWriteParams
+	if sourceRepo.ID != targetRepo.ID {
+		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+		}
+	}
+
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: targetWriteParams,
+		Type:        gitenum.RefTypeBranch,
+		Name:        pr.TargetBranch,
+		OldValue:    sha.Must(entry.BaseSHA),
+		NewValue:    sha.Must(entry.MergeSHA),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fast-forward target branch to the merge queue commit: %w", err)
+	}
+
+	if err := c.mergeQueueStore.Delete(ctx, entry.ID); err != nil {
+		return nil, fmt.Errorf("failed to remove merged entry from the merge queue: %w", err)
+	}
+
+	c.MergeQueueDeleteRefNoAuth(ctx, targetRepo, pr.Number)
+
+	mergeOutput := git.MergeOutput{
+		BaseSHA:          sha.Must(entry.BaseSHA),
+		HeadSHA:          sha.Must(entry.SourceSHA),
+		MergeBaseSHA:     sha.Must(entry.MergeBaseSHA),
+		MergeSHA:         sha.Must(entry.MergeSHA),
+		CommitCount:      int(ptr.ToInt64(pr.Stats.Commits)),
+		ChangedFileCount: int(ptr.ToInt64(pr.Stats.FilesChanged)),
+		Additions:        int(ptr.ToInt64(pr.Stats.Additions)),
+		Deletions:        int(ptr.ToInt64(pr.Stats.Deletions)),
+	}
+
+	pr, _, err = c.completeMerge(ctx, merger, targetRepo, sourceRepo, sourceWriteParams,
+		pr, entry.Method, mergeOutput, time.Now(), entry.DeleteSourceBranch, entry.RulesBypassed)
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// mergeQueueVerifyNoAuth verifies that the pull request of a merge queue entry can still be merged
+// by the user who added it to the queue: the approvals, code owners or the permissions of the user
+// might have changed while the pull request was waiting in the queue.
+func (c *Controller) mergeQueueVerifyNoAuth(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.Repository,
+	sourceRepo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+) error {
+	const errMsgNotPermitted = "The user who added the pull request to the merge queue is no longer permitted to merge it."
+
+	if session.Principal.Blocked {
+		return errors.PreconditionFailed(errMsgNotPermitted)
+	}
+
+	err := apiauth.CheckRepo(ctx, c.authorizer, session, targetRepo, enum.PermissionRepoPush)
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return errors.PreconditionFailed(errMsgNotPermitted)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check permissions of the user who added the entry: %w", err)
+	}
+
+	_, violations, err := c.verifyMergeRules(ctx, session, targetRepo, sourceRepo, pr,
+		entry.Method, entry.RulesBypassed)
+	if err != nil {
+		return fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if protection.IsCritical(violations) {
+		return errors.PreconditionFailed("The pull request no longer satisfies the merge requirements: %s",
+			protection.GenerateErrorMessageForBlockingViolations(violations))
+	}
+
+	return nil
+}
+
+// MergeQueueDeleteRefNoAuth deletes the git reference of the speculative merge commit of a pull request.
+func (c *Controller) MergeQueueDeleteRefNoAuth(ctx context.Context, repo *types.Repository, pullreqNum int64) {
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider,
+		bootstrap.NewSystemServiceSession(), repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to create RPC write params to delete merge queue ref")
+		return
+	}
+
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Type:        gitenum.RefTypeRaw,
+		Name:        MergeQueueRef(pullreqNum),
+		NewValue:    sha.None,
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete merge queue ref")
+	}
+}
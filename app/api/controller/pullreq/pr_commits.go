@@ -67,5 +67,10 @@ func (c *Controller) Commits(
 		commits[i] = *commit
 	}
 
+	err = c.signatureVerifier.SetVerifications(ctx, git.CreateReadParams(repo), commits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify commit signatures: %w", err)
+	}
+
 	return commits, nil
 }
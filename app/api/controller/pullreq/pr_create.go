@@ -65,6 +65,20 @@ func (in *CreateInput) Sanitize() error {
 	return nil
 }
 
+// validateSourceRepo checks that the source branch can be merged into the target branch:
+// A pull request is either opened within a repository or from a fork to its upstream repository.
+func validateSourceRepo(sourceRepo, targetRepo *types.Repository, sourceBranch, targetBranch string) error {
+	if sourceRepo.ID != targetRepo.ID && sourceRepo.ForkID != targetRepo.ID {
+		return usererror.BadRequest("The source repository must be a fork of the target repository.")
+	}
+
+	if sourceRepo.ID == targetRepo.ID && targetBranch == sourceBranch {
+		return usererror.BadRequest("target and source branch can't be the same")
+	}
+
+	return nil
+}
+
 // Create creates a new pull request.
 func (c *Controller) Create(
 	ctx context.Context,
@@ -76,7 +90,13 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
-	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	// Pull requests from forks can be opened by anyone who can push to the fork and view the upstream repository.
+	targetPermission := enum.PermissionRepoPush
+	if in.SourceRepoRef != "" {
+		targetPermission = enum.PermissionRepoView
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, targetPermission)
 	if err != nil {
 		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
 	}
@@ -89,8 +109,14 @@ func (c *Controller) Create(
 		}
 	}
 
-	if sourceRepo.ID == targetRepo.ID && in.TargetBranch == in.SourceBranch {
-		return nil, usererror.BadRequest("target and source branch can't be the same")
+	if sourceRepo.ID == targetRepo.ID && targetPermission != enum.PermissionRepoPush {
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, targetRepo, enum.PermissionRepoPush); err != nil {
+			return nil, fmt.Errorf("access check failed: %w", err)
+		}
+	}
+
+	if err = validateSourceRepo(sourceRepo, targetRepo, in.SourceBranch, in.TargetBranch); err != nil {
+		return nil, err
 	}
 
 	var sourceSHA sha.SHA
@@ -107,9 +133,17 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
+	if sourceRepo.ID != targetRepo.ID {
+		// Commits of the fork are copied to the target repository,
+		// so that the diff and the merge base can be computed in the target repository.
+		if err = c.fetchSourceObjects(ctx, session, sourceRepo, targetRepo, sourceSHA); err != nil {
+			return nil, err
+		}
+	}
+
 	mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-		ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-		Ref1:       in.SourceBranch,
+		ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+		Ref1:       sourceSHA.String(),
 		Ref2:       in.TargetBranch,
 	})
 	if err != nil {
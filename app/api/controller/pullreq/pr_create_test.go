@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestValidateSourceRepo(t *testing.T) {
+	upstream := &types.Repository{ID: 1}
+	fork := &types.Repository{ID: 2, ForkID: 1}
+	forkOfFork := &types.Repository{ID: 3, ForkID: 2}
+	unrelated := &types.Repository{ID: 4}
+
+	tests := []struct {
+		name         string
+		sourceRepo   *types.Repository
+		targetRepo   *types.Repository
+		sourceBranch string
+		targetBranch string
+		wantErr      bool
+	}{
+		{
+			name:         "same-repo",
+			sourceRepo:   upstream,
+			targetRepo:   upstream,
+			sourceBranch: "feature",
+			targetBranch: "main",
+		},
+		{
+			name:         "same-repo-same-branch",
+			sourceRepo:   upstream,
+			targetRepo:   upstream,
+			sourceBranch: "main",
+			targetBranch: "main",
+			wantErr:      true,
+		},
+		{
+			name:         "fork-to-upstream",
+			sourceRepo:   fork,
+			targetRepo:   upstream,
+			sourceBranch: "feature",
+			targetBranch: "main",
+		},
+		{
+			name:         "fork-to-upstream-same-branch",
+			sourceRepo:   fork,
+			targetRepo:   upstream,
+			sourceBranch: "main",
+			targetBranch: "main",
+		},
+		{
+			name:         "upstream-to-fork",
+			sourceRepo:   upstream,
+			targetRepo:   fork,
+			sourceBranch: "main",
+			targetBranch: "main",
+			wantErr:      true,
+		},
+		{
+			name:         "fork-of-fork-to-upstream",
+			sourceRepo:   forkOfFork,
+			targetRepo:   upstream,
+			sourceBranch: "main",
+			targetBranch: "main",
+			wantErr:      true,
+		},
+		{
+			name:         "unrelated-repo",
+			sourceRepo:   unrelated,
+			targetRepo:   upstream,
+			sourceBranch: "feature",
+			targetBranch: "main",
+			wantErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateSourceRepo(test.sourceRepo, test.targetRepo, test.sourceBranch, test.targetBranch)
+			if test.wantErr != (err != nil) {
+				t.Errorf("expected error: %t, got: %v", test.wantErr, err)
+			}
+		})
+	}
+}
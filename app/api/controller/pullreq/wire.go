@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -67,6 +68,9 @@ func ProvideController(
 	labelSvc *label.Service,
 	instrumentation instrument.Service,
 	userGroupService usergroup.SearchService,
+	signatureVerifier *publickey.SignatureVerifier,
+	mergeQueueStore store.MergeQueueStore,
+	autoMergeStore store.AutoMergeStore,
 ) *Controller {
 	return NewController(tx,
 		urlProvider,
@@ -99,5 +103,8 @@ func ProvideController(
 		labelSvc,
 		instrumentation,
 		userGroupService,
+		signatureVerifier,
+		mergeQueueStore,
+		autoMergeStore,
 	)
 }
@@ -0,0 +1,212 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxCherryPickCommits is the maximum number of commits that can be cherry-picked in a single request.
+const maxCherryPickCommits = 100
+
+type CherryPickInput struct {
+	// CommitSHAs is the list of commits that should be applied, in the provided order.
+	CommitSHAs []sha.SHA `json:"commit_shas"`
+	// Mainline is the 1-based number of the parent of merge commits relative to which the commits are applied.
+	Mainline int `json:"mainline"`
+
+	// Branch is the branch on top of which the commits are applied (default: the default branch).
+	Branch string `json:"branch"`
+	// NewBranch is the name of a new branch for the cherry-picked commits (optional).
+	NewBranch string `json:"new_branch"`
+
+	// CreatePullReq opens a pull request from the new branch to the branch.
+	CreatePullReq bool `json:"create_pullreq"`
+
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *CherryPickInput) sanitize() error {
+	if len(in.CommitSHAs) == 0 {
+		return usererror.BadRequest("At least one commit SHA must be provided")
+	}
+
+	if len(in.CommitSHAs) > maxCherryPickCommits {
+		return usererror.BadRequestf("At most %d commits can be cherry-picked at once", maxCherryPickCommits)
+	}
+
+	for _, commitSHA := range in.CommitSHAs {
+		if commitSHA.IsEmpty() {
+			return usererror.BadRequest("Commit SHA can't be empty")
+		}
+	}
+
+	if in.Mainline < 0 {
+		return usererror.BadRequest("Mainline parent number can't be negative")
+	}
+
+	in.Branch = strings.TrimSpace(in.Branch)
+	in.NewBranch = strings.TrimSpace(in.NewBranch)
+
+	return nil
+}
+
+// CherryPick applies the changes of one or more commits on top of a branch.
+// The commits are either added to the branch or written to a new branch, optionally with a pull request opened.
+func (c *Controller) CherryPick(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CherryPickInput,
+) (*types.CherryPickResponse, *types.MergeViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if in.Branch == "" {
+		in.Branch = repo.DefaultBranch
+	}
+
+	if err := validatePickBranches(in.Branch, in.NewBranch, in.CreatePullReq); err != nil {
+		return nil, nil, err
+	}
+
+	violations, err := c.pickVerifyRules(ctx, session, repo, in.Branch, in.NewBranch, in.BypassRules)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if in.DryRunRules {
+		// DryRunRules is true: Just return rule violations and don't attempt to cherry-pick.
+		return &types.CherryPickResponse{
+			RuleViolations: violations,
+			DryRunRules:    true,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{
+			RuleViolations: violations,
+			Message:        protection.GenerateErrorMessageForBlockingViolations(violations),
+		}, nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	now := time.Now()
+	cherryPickOutput, err := c.git.CherryPick(ctx, &git.CherryPickParams{
+		WriteParams:   writeParams,
+		CommitSHAs:    in.CommitSHAs,
+		Mainline:      in.Mainline,
+		Branch:        in.Branch,
+		NewBranch:     in.NewBranch,
+		Committer:     identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal),
+		CommitterDate: &now,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("cherry-pick execution failed: %w", err)
+	}
+
+	if len(cherryPickOutput.ConflictFiles) > 0 {
+		return nil, &types.MergeViolations{
+			ConflictFiles:  cherryPickOutput.ConflictFiles,
+			RuleViolations: violations,
+			Message: fmt.Sprintf("Cherry-pick of commit %s blocked by conflicting files: %v",
+				cherryPickOutput.ConflictCommitSHA, cherryPickOutput.ConflictFiles),
+		}, nil
+	}
+
+	if protection.IsBypassed(violations) {
+		c.auditPickBypass(ctx, session, repo, cherryPickOutput.CommitSHA, violations)
+	}
+
+	branch := in.Branch
+	if in.NewBranch != "" {
+		branch = in.NewBranch
+	}
+
+	var pr *types.PullReq
+	if in.CreatePullReq {
+		title, description, err := c.cherryPickPullReqText(ctx, repo, in)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pr, err = c.pickCreatePullReq(ctx, session, repo, in.NewBranch, in.Branch, title, description)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return &types.CherryPickResponse{
+		CommitSHA:      cherryPickOutput.CommitSHA,
+		CommitCount:    cherryPickOutput.CommitCount,
+		Branch:         branch,
+		PullReq:        pr,
+		RuleViolations: violations,
+	}, nil, nil
+}
+
+// cherryPickPullReqText returns the title and the description of the pull request opened for cherry-picked commits.
+// A single commit's title and message are used as is, otherwise the description lists the cherry-picked commits.
+func (c *Controller) cherryPickPullReqText(
+	ctx context.Context,
+	repo *types.Repository,
+	in *CherryPickInput,
+) (string, string, error) {
+	lines := make([]string, len(in.CommitSHAs))
+	for i, commitSHA := range in.CommitSHAs {
+		commit, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+			ReadParams: git.CreateReadParams(repo),
+			Revision:   commitSHA.String(),
+		})
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get commit %s: %w", commitSHA, err)
+		}
+
+		if len(in.CommitSHAs) == 1 {
+			return commit.Commit.Title, commit.Commit.Message, nil
+		}
+
+		lines[i] = fmt.Sprintf("- %s %s", commitSHA, commit.Commit.Title)
+	}
+
+	title := fmt.Sprintf("Cherry-pick %d commits to %s", len(in.CommitSHAs), in.Branch)
+	description := "Cherry-picked commits:\n" + strings.Join(lines, "\n")
+
+	return title, description, nil
+}
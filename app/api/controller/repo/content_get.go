@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/parser"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -68,6 +69,9 @@ type FileContent struct {
 	Data     string                   `json:"data"`
 	Size     int64                    `json:"size"`
 	DataSize int64                    `json:"data_size"`
+
+	// LFSObjectID is set in case the file is a git LFS pointer; the data is the content of the LFS object.
+	LFSObjectID string `json:"lfs_object_id,omitempty"`
 }
 
 func (c *FileContent) isContent() {}
@@ -135,7 +139,7 @@ func (c *Controller) GetContent(ctx context.Context,
 	case ContentTypeDir:
 		content, err = c.getDirContent(ctx, readParams, gitRef, repoPath, flattenDirectories)
 	case ContentTypeFile:
-		content, err = c.getFileContent(ctx, readParams, info.SHA)
+		content, err = c.getFileContent(ctx, readParams, repo.ID, info.SHA)
 	case ContentTypeSymlink:
 		content, err = c.getSymlinkContent(ctx, readParams, info.SHA)
 	case ContentTypeSubmodule:
@@ -178,6 +182,7 @@ func (c *Controller) getSubmoduleContent(ctx context.Context,
 
 func (c *Controller) getFileContent(ctx context.Context,
 	readParams git.ReadParams,
+	repoID int64,
 	blobSHA string,
 ) (*FileContent, error) {
 	output, err := c.git.GetBlob(ctx, &git.GetBlobParams{
@@ -200,6 +205,17 @@ func (c *Controller) getFileContent(ctx context.Context,
 		return nil, fmt.Errorf("failed to read blob content: %w", err)
 	}
 
+	if output.Size <= parser.LFSPointerMaxSize {
+		lfsObj, err := c.findLFSObject(ctx, repoID, content)
+		if err != nil {
+			return nil, err
+		}
+
+		if lfsObj != nil {
+			return c.getLFSFileContent(ctx, lfsObj)
+		}
+	}
+
 	return &FileContent{
 		Size:     output.Size,
 		DataSize: output.ContentSize,
@@ -208,6 +224,34 @@ func (c *Controller) getFileContent(ctx context.Context,
 	}, nil
 }
 
+func (c *Controller) getLFSFileContent(ctx context.Context,
+	lfsObj *types.LFSObject,
+) (*FileContent, error) {
+	file, err := c.downloadLFSObject(ctx, lfsObj)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close lfs object reader.")
+		}
+	}()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxGetContentFileSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lfs object content: %w", err)
+	}
+
+	return &FileContent{
+		Size:        lfsObj.Size,
+		DataSize:    int64(len(content)),
+		Encoding:    enum.ContentEncodingTypeBase64,
+		Data:        base64.StdEncoding.EncodeToString(content),
+		LFSObjectID: lfsObj.OID,
+	}, nil
+}
+
 func (c *Controller) getSymlinkContent(ctx context.Context,
 	readParams git.ReadParams,
 	blobSHA string,
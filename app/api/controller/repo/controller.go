@@ -23,6 +23,7 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
@@ -35,6 +36,9 @@ import (
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/pullmirror"
+	"github.com/harness/gitness/app/services/pushmirror"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/app/services/settings"
@@ -43,6 +47,7 @@ import (
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -58,6 +63,9 @@ type RepositoryOutput struct {
 	IsPublic  bool `json:"is_public" yaml:"is_public"`
 	Importing bool `json:"importing" yaml:"-"`
 	Archived  bool `json:"archived" yaml:"-"`
+
+	// Mirror is the pull mirror configuration and sync status, if the repository is a pull mirror.
+	Mirror *types.PullMirror `json:"mirror,omitempty" yaml:"-"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -110,6 +118,15 @@ type Controller struct {
 	instrumentation    instrument.Service
 	rulesSvc           *rules.Service
 	sseStreamer        sse.Streamer
+	lfsStore           store.LFSObjectStore
+	blobStore          blob.Store
+	signatureVerifier  *publickey.SignatureVerifier
+	pullMirrorStore    store.PullMirrorStore
+	secretStore        store.SecretStore
+	pullMirrorSvc      *pullmirror.Service
+	pushMirrorStore    store.PushMirrorStore
+	pushMirrorSvc      *pushmirror.Service
+	pullreqCtrl        *pullreq.Controller
 }
 
 func NewController(
@@ -148,6 +165,15 @@ func NewController(
 	userGroupService usergroup.SearchService,
 	rulesSvc *rules.Service,
 	sseStreamer sse.Streamer,
+	lfsStore store.LFSObjectStore,
+	blobStore blob.Store,
+	signatureVerifier *publickey.SignatureVerifier,
+	pullMirrorStore store.PullMirrorStore,
+	secretStore store.SecretStore,
+	pullMirrorSvc *pullmirror.Service,
+	pushMirrorStore store.PushMirrorStore,
+	pushMirrorSvc *pushmirror.Service,
+	pullreqCtrl *pullreq.Controller,
 ) *Controller {
 	return &Controller{
 		defaultBranch:      config.Git.DefaultBranch,
@@ -185,6 +211,15 @@ func NewController(
 		userGroupService:   userGroupService,
 		rulesSvc:           rulesSvc,
 		sseStreamer:        sseStreamer,
+		lfsStore:           lfsStore,
+		blobStore:          blobStore,
+		signatureVerifier:  signatureVerifier,
+		pullMirrorStore:    pullMirrorStore,
+		secretStore:        secretStore,
+		pullMirrorSvc:      pullMirrorSvc,
+		pushMirrorStore:    pushMirrorStore,
+		pushMirrorSvc:      pushMirrorSvc,
+		pullreqCtrl:        pullreqCtrl,
 	}
 }
 
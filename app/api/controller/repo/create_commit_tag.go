@@ -73,6 +73,19 @@ func (c *Controller) CreateCommitTag(ctx context.Context,
 		RefAction:   protection.RefActionCreate,
 		RefType:     protection.RefTypeTag,
 		RefNames:    []string{in.Name},
+		IsTagOnBranch: func(ctx context.Context, _ string, branchFilter func(string) bool) (bool, error) {
+			readParams := git.CreateReadParams(repo)
+
+			commitOut, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+				ReadParams: readParams,
+				Revision:   in.Target,
+			})
+			if err != nil {
+				return false, fmt.Errorf("failed to get target commit: %w", err)
+			}
+
+			return controller.IsCommitOnBranch(ctx, c.git, readParams, commitOut.Commit.SHA, branchFilter)
+		},
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -38,5 +38,15 @@ func (c *Controller) Find(ctx context.Context, session *auth.Session, repoRef st
 	repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
 	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
 
-	return GetRepoOutput(ctx, c.publicAccess, repo)
+	repoOut, err := GetRepoOutput(ctx, c.publicAccess, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	repoOut.Mirror, err = c.findPullMirror(ctx, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return repoOut, nil
 }
@@ -0,0 +1,311 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/instrument"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ForkInput struct {
+	ParentRef   string `json:"parent_ref"`
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+func (c *Controller) sanitizeForkInput(in *ForkInput, upstream *types.Repository) error {
+	if err := ValidateParentRef(in.ParentRef); err != nil {
+		return err
+	}
+
+	if in.Identifier == "" {
+		in.Identifier = upstream.Identifier
+	}
+
+	if err := c.identifierCheck(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if in.Description == "" {
+		in.Description = upstream.Description
+	}
+
+	return check.Description(in.Description)
+}
+
+// Fork creates a new repository in the provided space with all branches and tags of the repository.
+// The new repository keeps a reference to the repository it was forked from,
+// which allows pull requests from the fork to its upstream repository.
+//
+//nolint:funlen
+func (c *Controller) Fork(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ForkInput,
+) (*RepositoryOutput, error) {
+	upstream, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.sanitizeForkInput(in, upstream); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	parentSpace, err := c.getSpaceCheckAuthRepoCreation(ctx, session, in.ParentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	isPublicAccessSupported, err := c.publicAccess.IsPublicAccessSupported(ctx, parentSpace.Path)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to check if public access is supported for parent space %q: %w",
+			parentSpace.Path,
+			err,
+		)
+	}
+	if in.IsPublic && !isPublicAccessSupported {
+		return nil, errPublicRepoCreationDisabled
+	}
+
+	if in.IsPublic {
+		if err = c.checkForkPublicAccess(ctx, upstream); err != nil {
+			return nil, err
+		}
+	}
+
+	err = c.repoCheck.Create(ctx, session, &CreateInput{
+		ParentRef:     in.ParentRef,
+		Identifier:    in.Identifier,
+		DefaultBranch: upstream.DefaultBranch,
+		Description:   in.Description,
+		IsPublic:      in.IsPublic,
+		ForkID:        upstream.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	gitUID, err := c.forkGitRepository(ctx, session, upstream)
+	if err != nil {
+		return nil, fmt.Errorf("error forking repository on git: %w", err)
+	}
+
+	var repo *types.Repository
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.resourceLimiter.RepoCount(ctx, parentSpace.ID, 1); err != nil {
+			return fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxNumReposReached)
+		}
+
+		// lock the space for update during repo creation to prevent racing conditions with space soft delete.
+		parentSpace, err = c.spaceStore.FindForUpdate(ctx, parentSpace.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find the parent space: %w", err)
+		}
+
+		now := time.Now().UnixMilli()
+		repo = &types.Repository{
+			Version:       0,
+			ParentID:      parentSpace.ID,
+			Identifier:    in.Identifier,
+			GitUID:        gitUID,
+			Description:   in.Description,
+			CreatedBy:     session.Principal.ID,
+			Created:       now,
+			Updated:       now,
+			ForkID:        upstream.ID,
+			DefaultBranch: upstream.DefaultBranch,
+			IsEmpty:       upstream.IsEmpty,
+		}
+
+		if err := c.repoStore.Create(ctx, repo); err != nil {
+			return err
+		}
+
+		return c.updateNumForks(ctx, upstream.ID, 1)
+	}, sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		// best effort cleanup
+		if dErr := c.DeleteGitRepository(ctx, session, gitUID); dErr != nil {
+			log.Ctx(ctx).Warn().Err(dErr).Msg("failed to delete repo for cleanup")
+		}
+		return nil, err
+	}
+
+	err = c.publicAccess.Set(ctx, enum.PublicResourceTypeRepo, repo.Path, in.IsPublic)
+	if err != nil {
+		if dErr := c.publicAccess.Delete(ctx, enum.PublicResourceTypeRepo, repo.Path); dErr != nil {
+			return nil, fmt.Errorf("failed to set repo public access (and public access cleanup: %w): %w", dErr, err)
+		}
+
+		// only cleanup repo itself if cleanup of public access succeeded (to avoid leaking public access)
+		if dErr := c.PurgeNoAuth(ctx, session, repo); dErr != nil {
+			return nil, fmt.Errorf("failed to set repo public access (and repo purge: %w): %w", dErr, err)
+		}
+
+		return nil, fmt.Errorf("failed to set repo public access (successful cleanup): %w", err)
+	}
+
+	// backfil GitURL
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
+	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
+
+	repoOutput := GetRepoOutputWithAccess(ctx, in.IsPublic, repo)
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepository, repo.Identifier),
+		audit.ActionCreated,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(audit.RepositoryObject{
+			Repository: repoOutput.Repository,
+			IsPublic:   repoOutput.IsPublic,
+		}),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for fork repository operation: %s", err)
+	}
+
+	err = c.instrumentation.Track(ctx, instrument.Event{
+		Type:      instrument.EventTypeRepositoryCreate,
+		Principal: session.Principal.ToPrincipalInfo(),
+		Path:      repo.Path,
+		Properties: map[instrument.Property]any{
+			instrument.PropertyRepositoryID:           repo.ID,
+			instrument.PropertyRepositoryName:         repo.Identifier,
+			instrument.PropertyRepositoryCreationType: instrument.CreationTypeFork,
+		},
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert instrumentation record for fork repository operation: %s", err)
+	}
+
+	if !repo.IsEmpty {
+		err = c.indexer.Index(ctx, repo)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to index repo")
+		}
+	}
+
+	return repoOutput, nil
+}
+
+func (c *Controller) forkGitRepository(
+	ctx context.Context,
+	session *auth.Session,
+	upstream *types.Repository,
+) (string, error) {
+	gitUID, err := git.NewRepositoryUID()
+	if err != nil {
+		return "", fmt.Errorf("failed to create new uid: %w", err)
+	}
+
+	// generate envars (add everything githook CLI needs for execution)
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		c.urlProvider.GetInternalAPIURL(ctx),
+		0,
+		session.Principal.ID,
+		true,
+		true,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	_, err = c.git.ForkRepository(ctx, &git.ForkRepositoryParams{
+		WriteParams: git.WriteParams{
+			RepoUID: gitUID,
+			Actor:   *identityFromPrincipal(session.Principal),
+			EnvVars: envVars,
+		},
+		SourceRepoUID: upstream.GitUID,
+		DefaultBranch: upstream.DefaultBranch,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to fork repo: %w", err)
+	}
+
+	return gitUID, nil
+}
+
+// updateNumForks updates the number of forks of the upstream repository of a fork.
+func (c *Controller) updateNumForks(ctx context.Context, upstreamID int64, delta int) error {
+	upstream, err := c.repoStore.Find(ctx, upstreamID)
+	if err != nil {
+		return fmt.Errorf("failed to find upstream repository: %w", err)
+	}
+
+	_, err = c.repoStore.UpdateOptLock(ctx, upstream, func(repo *types.Repository) error {
+		repo.NumForks = max(repo.NumForks+delta, 0)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update number of forks of upstream repository: %w", err)
+	}
+
+	return nil
+}
+
+// getUpstreamRepo returns the repository the provided repository was forked from.
+func (c *Controller) getUpstreamRepo(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	reqPermission enum.Permission,
+) (*types.Repository, error) {
+	if repo.ForkID == 0 {
+		return nil, usererror.BadRequest("The repository isn't a fork.")
+	}
+
+	return c.getRepoCheckAccess(ctx, session, strconv.FormatInt(repo.ForkID, 10), reqPermission)
+}
+
+// checkForkPublicAccess returns an error if the upstream repository of a fork is private.
+// A fork contains the full history of its upstream, so it can't be more visible than the upstream.
+func (c *Controller) checkForkPublicAccess(ctx context.Context, upstream *types.Repository) error {
+	isUpstreamPublic, err := c.publicAccess.Get(ctx, enum.PublicResourceTypeRepo, upstream.Path)
+	if err != nil {
+		return fmt.Errorf("failed to check if upstream repository is public: %w", err)
+	}
+
+	if !isUpstreamPublic {
+		return usererror.BadRequest("A fork of a private repository can't be public.")
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type SyncForkInput struct {
+	// BranchName is the branch of the fork that should be synced with the same branch of the upstream repository.
+	// If not provided, the default branch of the fork is used.
+	BranchName string `json:"branch_name"`
+
+	BypassRules bool `json:"bypass_rules"`
+}
+
+type SyncForkOutput struct {
+	BranchName      string `json:"branch_name"`
+	OldSHA          string `json:"old_sha,omitempty"`
+	NewSHA          string `json:"new_sha"`
+	AlreadyUpToDate bool   `json:"already_up_to_date"`
+}
+
+// SyncFork fast-forwards a branch of a fork to the same branch of its upstream repository.
+// Diverged branches are rejected, they need to be merged through a pull request.
+func (c *Controller) SyncFork(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *SyncForkInput,
+) (*SyncForkOutput, []types.RuleViolations, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	upstream, err := c.getUpstreamRepo(ctx, session, repo, enum.PermissionRepoView)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	branchName := in.BranchName
+	if branchName == "" {
+		branchName = repo.DefaultBranch
+	}
+
+	upstreamBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(upstream),
+		BranchName: branchName,
+	})
+	if errors.IsNotFound(err) {
+		return nil, nil, usererror.NotFoundf("Branch %q doesn't exist in the upstream repository.", branchName)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get upstream branch: %w", err)
+	}
+
+	newSHA := upstreamBranch.Branch.SHA
+	oldSHA := sha.Nil
+	refAction := protection.RefActionCreate
+
+	forkBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: branchName,
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, nil, fmt.Errorf("failed to get fork branch: %w", err)
+	}
+	if err == nil {
+		oldSHA = forkBranch.Branch.SHA
+		refAction = protection.RefActionUpdate
+	}
+
+	out := &SyncForkOutput{
+		BranchName: branchName,
+		NewSHA:     newSHA.String(),
+	}
+	if !oldSHA.IsNil() {
+		out.OldSHA = oldSHA.String()
+	}
+
+	if oldSHA.Equal(newSHA) {
+		out.AlreadyUpToDate = true
+		return out, nil, nil
+	}
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		RefAction:   refAction,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{branchName},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+	if protection.IsCritical(violations) {
+		return nil, violations, nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: upstream.GitUID,
+		ObjectSHAs:    []sha.SHA{newSHA},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch upstream commit: %w", err)
+	}
+
+	if !oldSHA.IsNil() {
+		ancestor, err := c.git.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          git.CreateReadParams(repo),
+			AncestorCommitSHA:   oldSHA,
+			DescendantCommitSHA: newSHA,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check if the fork branch is behind the upstream: %w", err)
+		}
+
+		if !ancestor.Ancestor {
+			return nil, nil, usererror.Conflict(fmt.Sprintf(
+				"Branch %q of the fork has diverged from the upstream repository.", branchName))
+		}
+	}
+
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Type:        gitenum.RefTypeBranch,
+		Name:        branchName,
+		OldValue:    oldSHA,
+		NewValue:    newSHA,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update fork branch: %w", err)
+	}
+
+	return out, nil, nil
+}
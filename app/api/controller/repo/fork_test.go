@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type publicAccessStub struct {
+	publicaccess.Service
+	public map[string]bool
+}
+
+func (s publicAccessStub) Get(_ context.Context, _ enum.PublicResourceType, path string) (bool, error) {
+	return s.public[path], nil
+}
+
+func TestCheckForkPublicAccess(t *testing.T) {
+	c := &Controller{
+		publicAccess: publicAccessStub{public: map[string]bool{"space/public": true}},
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		expErr bool
+	}{
+		{name: "public upstream", path: "space/public"},
+		{name: "private upstream", path: "space/private", expErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := c.checkForkPublicAccess(context.Background(), &types.Repository{Path: test.path})
+			if (err != nil) != test.expErr {
+				t.Errorf("expected error %t, got %v", test.expErr, err)
+			}
+		})
+	}
+}
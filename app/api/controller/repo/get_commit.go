@@ -50,5 +50,12 @@ func (c *Controller) GetCommit(ctx context.Context,
 		return nil, fmt.Errorf("failed to map commit: %w", err)
 	}
 
+	verification, err := c.signatureVerifier.VerifyCommit(ctx, git.CreateReadParams(repo), commit.SHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify commit signature: %w", err)
+	}
+
+	commit.Verification = &verification
+
 	return commit, nil
 }
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/api/controller/lfs"
+	"github.com/harness/gitness/git/parser"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// findLFSObject checks whether the provided blob content is a git LFS pointer to an object
+// that is stored for the repository. If it isn't, nil is returned.
+func (c *Controller) findLFSObject(
+	ctx context.Context,
+	repoID int64,
+	content []byte,
+) (*types.LFSObject, error) {
+	pointer, ok := parser.ParseLFSPointer(content)
+	if !ok {
+		return nil, nil
+	}
+
+	obj, err := c.lfsStore.Find(ctx, repoID, pointer.OID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		// the pointer was committed without uploading the object - show the pointer as is.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find lfs object: %w", err)
+	}
+
+	return obj, nil
+}
+
+// downloadLFSObject returns a reader for the content of the LFS object.
+func (c *Controller) downloadLFSObject(
+	ctx context.Context,
+	obj *types.LFSObject,
+) (io.ReadCloser, error) {
+	file, err := c.blobStore.Download(ctx, lfs.GetObjectBucketPath(obj.RepoID, obj.OID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download lfs object from blobstore: %w", err)
+	}
+
+	return file, nil
+}
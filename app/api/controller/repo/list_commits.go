@@ -84,6 +84,11 @@ func (c *Controller) ListCommits(ctx context.Context,
 		commits[i] = *commit
 	}
 
+	err = c.signatureVerifier.SetVerifications(ctx, git.CreateReadParams(repo), commits)
+	if err != nil {
+		return types.ListCommitResponse{}, fmt.Errorf("failed to verify commit signatures: %w", err)
+	}
+
 	renameDetailList := make([]types.RenameDetails, len(rpcOut.RenameDetails))
 	for i := range rpcOut.RenameDetails {
 		renameDetails := controller.MapRenameDetails(rpcOut.RenameDetails[i])
@@ -0,0 +1,145 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// validatePickBranches validates the branch names of the revert and cherry-pick operations.
+// If a pull request should be opened, the new commits must be written to a new branch.
+func validatePickBranches(branch, newBranch string, createPullReq bool) error {
+	if newBranch != "" && newBranch == branch {
+		return usererror.BadRequest("The new branch must differ from the branch")
+	}
+
+	if createPullReq && newBranch == "" {
+		return usererror.BadRequest("A new branch must be provided to open a pull request")
+	}
+
+	return nil
+}
+
+// pickVerifyRules verifies the protection rules of the branch that receives the commits created
+// by the revert and cherry-pick operations: either the existing branch is updated or a new branch is created.
+func (c *Controller) pickVerifyRules(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	branch, newBranch string,
+	bypassRules bool,
+) ([]types.RuleViolations, error) {
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	refAction := protection.RefActionUpdate
+	refName := branch
+	if newBranch != "" {
+		refAction = protection.RefActionCreate
+		refName = newBranch
+	}
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		AllowBypass:        bypassRules,
+		IsRepoOwner:        isRepoOwner,
+		Repo:               repo,
+		RefAction:          refAction,
+		RefType:            protection.RefTypeBranch,
+		RefNames:           []string{refName},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	return violations, nil
+}
+
+// pickCreatePullReq opens a pull request from the branch created by the revert and cherry-pick operations.
+func (c *Controller) pickCreatePullReq(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	sourceBranch, targetBranch string,
+	title, description string,
+) (*types.PullReq, error) {
+	pr, err := c.pullreqCtrl.Create(ctx, session, repo.Path, &pullreq.CreateInput{
+		Title:        title,
+		Description:  description,
+		SourceBranch: sourceBranch,
+		TargetBranch: targetBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	return pr, nil
+}
+
+// auditPickBypass writes the audit log entry for a revert or cherry-pick commit that bypassed the protection rules.
+func (c *Controller) auditPickBypass(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	commitSHA sha.SHA,
+	violations []types.RuleViolations,
+) {
+	err := c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(
+			audit.ResourceTypeRepository,
+			repo.Identifier,
+			audit.RepoPath,
+			repo.Path,
+			audit.BypassAction,
+			audit.BypassActionCommitted,
+			audit.BypassedResourceType,
+			audit.BypassedResourceTypeCommit,
+			audit.BypassedResourceName,
+			commitSHA.String(),
+			audit.ResourceName,
+			fmt.Sprintf(
+				audit.BypassSHALabelFormat,
+				repo.Identifier,
+				commitSHA.String()[0:6],
+			),
+		),
+		audit.ActionBypassed,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(audit.CommitObject{
+			CommitSHA:      commitSHA.String(),
+			RepoPath:       repo.Path,
+			RuleViolations: violations,
+		}),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for commit operation: %s", err)
+	}
+}
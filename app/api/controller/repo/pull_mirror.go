@@ -0,0 +1,269 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/pullmirror"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type SavePullMirrorInput struct {
+	// URL is the clone URL of the remote repository, only http and https URLs are supported.
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	// Secret is the identifier of the secret in the parent space of the repository that holds
+	// the password or the access token used to authenticate with the remote repository.
+	Secret string `json:"secret"`
+	// Interval is the time between two periodic syncs, in minutes.
+	Interval int64 `json:"interval"`
+	Enabled  *bool `json:"enabled"`
+}
+
+func (in *SavePullMirrorInput) sanitize() error {
+	in.URL = strings.TrimSpace(in.URL)
+	in.Username = strings.TrimSpace(in.Username)
+	in.Secret = strings.TrimSpace(in.Secret)
+
+	if err := sanitizeMirrorURL(in.URL); err != nil {
+		return err
+	}
+
+	if in.Interval == 0 {
+		in.Interval = pullmirror.DefaultInterval
+	}
+
+	if in.Interval < pullmirror.MinInterval || in.Interval > pullmirror.MaxInterval {
+		return usererror.BadRequestf("The mirror sync interval must be between %d and %d minutes.",
+			pullmirror.MinInterval, pullmirror.MaxInterval)
+	}
+
+	return nil
+}
+
+// sanitizeMirrorURL checks that the URL of a pull or a push mirror is an http or https URL without credentials.
+// Other protocols could give access to the repositories stored on the server.
+func sanitizeMirrorURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return usererror.BadRequest("The mirror URL is invalid.")
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return usererror.BadRequest("Only http and https mirror URLs are supported.")
+	}
+
+	if u.User != nil {
+		return usererror.BadRequest("The mirror URL must not contain credentials, use the username and secret instead.")
+	}
+
+	return nil
+}
+
+// SavePullMirror configures the repository as a pull mirror of a remote repository, or updates its configuration.
+// Once the repository is a pull mirror its branches and tags can't be pushed to anymore.
+// A sync with the remote repository is started immediately, it removes all branches and tags
+// that don't exist in the remote repository.
+func (c *Controller) SavePullMirror(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *SavePullMirrorInput,
+) (*types.PullMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	var secretID *int64
+	if in.Secret != "" {
+		secret, err := c.getMirrorSecret(ctx, session, repo, in.Secret)
+		if err != nil {
+			return nil, err
+		}
+		secretID = &secret.ID
+	}
+
+	enabled := true
+	if in.Enabled != nil {
+		enabled = *in.Enabled
+	}
+
+	now := time.Now()
+
+	mirror, err := c.pullMirrorStore.Find(ctx, repo.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		mirror = &types.PullMirror{
+			RepoID:    repo.ID,
+			URL:       in.URL,
+			Username:  in.Username,
+			SecretID:  secretID,
+			Secret:    in.Secret,
+			Interval:  in.Interval,
+			Enabled:   enabled,
+			Status:    enum.PullMirrorStatusPending,
+			NextSync:  now.Add(time.Duration(in.Interval) * time.Minute).UnixMilli(),
+			CreatedBy: session.Principal.ID,
+			Created:   now.UnixMilli(),
+			Updated:   now.UnixMilli(),
+		}
+
+		err = c.pullMirrorStore.Create(ctx, mirror)
+		if errors.Is(err, gitness_store.ErrDuplicate) {
+			return nil, usererror.Conflict("The pull mirror of the repository is being configured concurrently.")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pull mirror: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find pull mirror: %w", err)
+	} else {
+		mirror, err = c.pullMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PullMirror) error {
+			if mirror.Interval != in.Interval {
+				mirror.NextSync = now.Add(time.Duration(in.Interval) * time.Minute).UnixMilli()
+			}
+			mirror.URL = in.URL
+			mirror.Username = in.Username
+			mirror.SecretID = secretID
+			mirror.Secret = in.Secret
+			mirror.Interval = in.Interval
+			mirror.Enabled = enabled
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update pull mirror: %w", err)
+		}
+	}
+
+	if !mirror.Enabled {
+		return mirror, nil
+	}
+
+	mirror, err = c.pullMirrorSvc.Sync(ctx, mirror)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start pull mirror sync: %w", err)
+	}
+
+	return mirror, nil
+}
+
+// DeletePullMirror stops mirroring the remote repository. The repository keeps its content
+// and its branches and tags can be pushed to again.
+func (c *Controller) DeletePullMirror(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return err
+	}
+
+	err = c.pullMirrorStore.Delete(ctx, repo.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return usererror.NotFound("The repository is not a pull mirror.")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete pull mirror: %w", err)
+	}
+
+	return nil
+}
+
+// SyncPullMirror starts a sync of the pull mirror with its remote repository.
+func (c *Controller) SyncPullMirror(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.PullMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror, err := c.findPullMirror(ctx, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mirror == nil {
+		return nil, usererror.NotFound("The repository is not a pull mirror.")
+	}
+
+	if mirror.Status == enum.PullMirrorStatusPending || mirror.Status == enum.PullMirrorStatusRunning {
+		return nil, usererror.Conflict("A sync of the pull mirror is already in progress.")
+	}
+
+	mirror, err = c.pullMirrorSvc.Sync(ctx, mirror)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start pull mirror sync: %w", err)
+	}
+
+	return mirror, nil
+}
+
+// findPullMirror returns the pull mirror configuration of the repository, or nil if the repository isn't a mirror.
+func (c *Controller) findPullMirror(ctx context.Context, repoID int64) (*types.PullMirror, error) {
+	mirror, err := c.pullMirrorStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull mirror: %w", err)
+	}
+
+	return mirror, nil
+}
+
+// getMirrorSecret returns the secret from the parent space of the repository
+// if the user is allowed to access it.
+func (c *Controller) getMirrorSecret(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	identifier string,
+) (*types.Secret, error) {
+	parentPath := paths.Parent(repo.Path)
+
+	err := apiauth.CheckSecret(ctx, c.authorizer, session, parentPath, identifier, enum.PermissionSecretAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := c.secretStore.FindByIdentifier(ctx, repo.ParentID, identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.NotFoundf("Secret %q doesn't exist in space %q.", identifier, parentPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find secret: %w", err)
+	}
+
+	return secret, nil
+}
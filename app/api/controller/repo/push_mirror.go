@@ -0,0 +1,398 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/pushmirror"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreatePushMirrorInput struct {
+	Identifier string `json:"identifier"`
+	// URL is the clone URL of the remote repository, only http and https URLs are supported.
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	// Secret is the identifier of the secret in the parent space of the repository that holds
+	// the password or the access token used to authenticate with the remote repository.
+	Secret string `json:"secret"`
+	// RefFilter is a regular expression that selects the pushed branches and tags by their full reference name.
+	RefFilter string                 `json:"ref_filter"`
+	Trigger   enum.PushMirrorTrigger `json:"trigger"`
+	// Interval is the time between two periodic pushes, in minutes. Used only with the interval trigger.
+	Interval int64 `json:"interval"`
+	Enabled  *bool `json:"enabled"`
+}
+
+func (in *CreatePushMirrorInput) sanitize() error {
+	in.Identifier = strings.TrimSpace(in.Identifier)
+	in.URL = strings.TrimSpace(in.URL)
+	in.Username = strings.TrimSpace(in.Username)
+	in.Secret = strings.TrimSpace(in.Secret)
+
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	if err := sanitizeMirrorURL(in.URL); err != nil {
+		return err
+	}
+
+	if err := sanitizeMirrorRefFilter(in.RefFilter); err != nil {
+		return err
+	}
+
+	trigger, ok := in.Trigger.Sanitize()
+	if !ok {
+		return usererror.BadRequest("The push mirror trigger is invalid.")
+	}
+	in.Trigger = trigger
+
+	if in.Interval == 0 {
+		in.Interval = pushmirror.DefaultInterval
+	}
+
+	return sanitizeMirrorInterval(in.Interval)
+}
+
+type UpdatePushMirrorInput struct {
+	Identifier *string                 `json:"identifier"`
+	URL        *string                 `json:"url"`
+	Username   *string                 `json:"username"`
+	Secret     *string                 `json:"secret"`
+	RefFilter  *string                 `json:"ref_filter"`
+	Trigger    *enum.PushMirrorTrigger `json:"trigger"`
+	Interval   *int64                  `json:"interval"`
+	Enabled    *bool                   `json:"enabled"`
+}
+
+func (in *UpdatePushMirrorInput) sanitize() error {
+	if in.Identifier != nil {
+		*in.Identifier = strings.TrimSpace(*in.Identifier)
+		if err := check.Identifier(*in.Identifier); err != nil {
+			return err
+		}
+	}
+
+	if in.URL != nil {
+		*in.URL = strings.TrimSpace(*in.URL)
+		if err := sanitizeMirrorURL(*in.URL); err != nil {
+			return err
+		}
+	}
+
+	if in.Username != nil {
+		*in.Username = strings.TrimSpace(*in.Username)
+	}
+
+	if in.Secret != nil {
+		*in.Secret = strings.TrimSpace(*in.Secret)
+	}
+
+	if in.RefFilter != nil {
+		if err := sanitizeMirrorRefFilter(*in.RefFilter); err != nil {
+			return err
+		}
+	}
+
+	if in.Trigger != nil {
+		trigger, ok := in.Trigger.Sanitize()
+		if !ok {
+			return usererror.BadRequest("The push mirror trigger is invalid.")
+		}
+		in.Trigger = &trigger
+	}
+
+	if in.Interval != nil {
+		if err := sanitizeMirrorInterval(*in.Interval); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sanitizeMirrorRefFilter(refFilter string) error {
+	if _, err := regexp.Compile(refFilter); err != nil {
+		return usererror.BadRequestf("The reference filter isn't a valid regular expression: %s", err)
+	}
+
+	return nil
+}
+
+func sanitizeMirrorInterval(interval int64) error {
+	if interval < pushmirror.MinInterval || interval > pushmirror.MaxInterval {
+		return usererror.BadRequestf("The push mirror interval must be between %d and %d minutes.",
+			pushmirror.MinInterval, pushmirror.MaxInterval)
+	}
+
+	return nil
+}
+
+// PushMirrorCreate adds a push mirror to the repository. If the push mirror is enabled,
+// all matching branches and tags are pushed to the remote repository immediately.
+func (c *Controller) PushMirrorCreate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreatePushMirrorInput,
+) (*types.PushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	var secretID *int64
+	if in.Secret != "" {
+		secret, err := c.getMirrorSecret(ctx, session, repo, in.Secret)
+		if err != nil {
+			return nil, err
+		}
+		secretID = &secret.ID
+	}
+
+	enabled := true
+	if in.Enabled != nil {
+		enabled = *in.Enabled
+	}
+
+	now := time.Now()
+	mirror := &types.PushMirror{
+		RepoID:     repo.ID,
+		Identifier: in.Identifier,
+		URL:        in.URL,
+		Username:   in.Username,
+		SecretID:   secretID,
+		Secret:     in.Secret,
+		RefFilter:  in.RefFilter,
+		Trigger:    in.Trigger,
+		Interval:   in.Interval,
+		Enabled:    enabled,
+		Status:     enum.PushMirrorStatusPending,
+		NextSync:   now.Add(time.Duration(in.Interval) * time.Minute).UnixMilli(),
+		CreatedBy:  session.Principal.ID,
+		Created:    now.UnixMilli(),
+		Updated:    now.UnixMilli(),
+	}
+
+	err = c.pushMirrorStore.Create(ctx, mirror)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("Push mirror %q already exists.", in.Identifier))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create push mirror: %w", err)
+	}
+
+	if !mirror.Enabled {
+		return mirror, nil
+	}
+
+	mirror, err = c.pushMirrorSvc.Push(ctx, mirror)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start push to push mirror: %w", err)
+	}
+
+	return mirror, nil
+}
+
+// PushMirrorList returns all push mirrors of the repository.
+func (c *Controller) PushMirrorList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]*types.PushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	mirrors, err := c.pushMirrorStore.List(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list push mirrors: %w", err)
+	}
+
+	return mirrors, nil
+}
+
+// PushMirrorFind returns a push mirror of the repository, including the status of the last push.
+func (c *Controller) PushMirrorFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+) (*types.PushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getPushMirror(ctx, repo.ID, identifier)
+}
+
+// PushMirrorUpdate updates a push mirror of the repository.
+func (c *Controller) PushMirrorUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+	in *UpdatePushMirrorInput,
+) (*types.PushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	mirror, err := c.getPushMirror(ctx, repo.ID, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	var secretID *int64
+	if in.Secret != nil && *in.Secret != "" {
+		secret, err := c.getMirrorSecret(ctx, session, repo, *in.Secret)
+		if err != nil {
+			return nil, err
+		}
+		secretID = &secret.ID
+	}
+
+	now := time.Now()
+
+	mirror, err = c.pushMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PushMirror) error {
+		if in.Identifier != nil {
+			mirror.Identifier = *in.Identifier
+		}
+		if in.URL != nil {
+			mirror.URL = *in.URL
+		}
+		if in.Username != nil {
+			mirror.Username = *in.Username
+		}
+		if in.Secret != nil {
+			mirror.SecretID = secretID
+			mirror.Secret = *in.Secret
+		}
+		if in.RefFilter != nil {
+			mirror.RefFilter = *in.RefFilter
+		}
+		if in.Trigger != nil {
+			mirror.Trigger = *in.Trigger
+		}
+		if in.Interval != nil && *in.Interval != mirror.Interval {
+			mirror.Interval = *in.Interval
+			mirror.NextSync = now.Add(time.Duration(mirror.Interval) * time.Minute).UnixMilli()
+		}
+		if in.Enabled != nil {
+			mirror.Enabled = *in.Enabled
+		}
+		return nil
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("Push mirror %q already exists.", *in.Identifier))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update push mirror: %w", err)
+	}
+
+	return mirror, nil
+}
+
+// PushMirrorDelete removes a push mirror from the repository. The remote repository is left intact.
+func (c *Controller) PushMirrorDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return err
+	}
+
+	mirror, err := c.getPushMirror(ctx, repo.ID, identifier)
+	if err != nil {
+		return err
+	}
+
+	if err = c.pushMirrorStore.Delete(ctx, mirror.ID); err != nil {
+		return fmt.Errorf("failed to delete push mirror: %w", err)
+	}
+
+	return nil
+}
+
+// PushMirrorSync starts a push of all matching branches and tags to the push mirror.
+func (c *Controller) PushMirrorSync(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+) (*types.PushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror, err := c.getPushMirror(ctx, repo.ID, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if !mirror.Enabled {
+		return nil, usererror.BadRequest("The push mirror is disabled.")
+	}
+
+	if mirror.Status == enum.PushMirrorStatusPending || mirror.Status == enum.PushMirrorStatusRunning {
+		return nil, usererror.Conflict("A push to the push mirror is already in progress.")
+	}
+
+	mirror, err = c.pushMirrorSvc.Push(ctx, mirror)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start push to push mirror: %w", err)
+	}
+
+	return mirror, nil
+}
+
+func (c *Controller) getPushMirror(ctx context.Context, repoID int64, identifier string) (*types.PushMirror, error) {
+	mirror, err := c.pushMirrorStore.FindByIdentifier(ctx, repoID, identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.NotFoundf("Push mirror %q doesn't exist.", identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find push mirror: %w", err)
+	}
+
+	return mirror, nil
+}
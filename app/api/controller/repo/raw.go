@@ -15,6 +15,7 @@
 package repo
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -22,8 +23,11 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/parser"
 	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // Raw finds the file of the repo at the given path and returns its raw content.
@@ -72,5 +76,35 @@ func (c *Controller) Raw(ctx context.Context,
 		return nil, 0, sha.Nil, fmt.Errorf("failed to read blob: %w", err)
 	}
 
-	return blobReader.Content, blobReader.ContentSize, blobReader.SHA, nil
+	// git LFS pointers are small - in that case check whether the actual content is stored in LFS.
+	if blobReader.Size > parser.LFSPointerMaxSize {
+		return blobReader.Content, blobReader.ContentSize, blobReader.SHA, nil
+	}
+
+	defer func() {
+		if err := blobReader.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
+		}
+	}()
+
+	content, err := io.ReadAll(blobReader.Content)
+	if err != nil {
+		return nil, 0, sha.Nil, fmt.Errorf("failed to read blob content: %w", err)
+	}
+
+	lfsObj, err := c.findLFSObject(ctx, repo.ID, content)
+	if err != nil {
+		return nil, 0, sha.Nil, err
+	}
+
+	if lfsObj == nil {
+		return io.NopCloser(bytes.NewReader(content)), int64(len(content)), blobReader.SHA, nil
+	}
+
+	lfsReader, err := c.downloadLFSObject(ctx, lfsObj)
+	if err != nil {
+		return nil, 0, sha.Nil, err
+	}
+
+	return lfsReader, lfsObj.Size, blobReader.SHA, nil
 }
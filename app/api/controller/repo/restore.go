@@ -27,6 +27,8 @@ import (
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type RestoreInput struct {
@@ -93,6 +95,13 @@ func (c *Controller) RestoreNoAuth(
 		return nil, fmt.Errorf("failed to restore the repo: %w", err)
 	}
 
+	if repo.ForkID != 0 {
+		if err := c.updateNumForks(ctx, repo.ForkID, 1); err != nil {
+			// non-critical error
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to increment number of forks of the upstream repository")
+		}
+	}
+
 	// Repos restored as private since public access data has been deleted upon deletion.
 	return GetRepoOutputWithAccess(ctx, false, repo), nil
 }
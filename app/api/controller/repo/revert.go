@@ -0,0 +1,213 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type RevertInput struct {
+	// CommitSHA is the commit that should be reverted.
+	CommitSHA sha.SHA `json:"commit_sha"`
+	// Mainline is the 1-based number of the parent of a merge commit relative to which the commit is reverted.
+	Mainline int `json:"mainline"`
+
+	// PullReqNumber is the number of a merged pull request whose changes should be reverted.
+	// It can be provided instead of the commit SHA.
+	PullReqNumber int64 `json:"pullreq_number"`
+
+	// Branch is the branch on top of which the revert commit is created (default: the default branch,
+	// or the target branch of the reverted pull request).
+	Branch string `json:"branch"`
+	// NewBranch is the name of a new branch for the revert commit (optional).
+	NewBranch string `json:"new_branch"`
+
+	Message string `json:"message"`
+
+	// CreatePullReq opens a pull request from the new branch to the branch.
+	CreatePullReq bool `json:"create_pullreq"`
+
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *RevertInput) sanitize() error {
+	if in.CommitSHA.IsEmpty() == (in.PullReqNumber == 0) {
+		return usererror.BadRequest("Either commit SHA or pull request number must be provided")
+	}
+
+	if in.Mainline < 0 {
+		return usererror.BadRequest("Mainline parent number can't be negative")
+	}
+
+	// cleanup message (NOTE: git doesn't support white space only)
+	in.Message = strings.TrimSpace(in.Message)
+
+	in.Branch = strings.TrimSpace(in.Branch)
+	in.NewBranch = strings.TrimSpace(in.NewBranch)
+
+	return nil
+}
+
+// Revert creates a commit that reverts the changes of a commit or of a merged pull request.
+// The commit is either added to the branch or written to a new branch, optionally with a pull request opened.
+func (c *Controller) Revert(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *RevertInput,
+) (*types.RevertResponse, *types.MergeViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	commitSHA := in.CommitSHA
+	var baseCommitSHA sha.SHA
+
+	if in.PullReqNumber != 0 {
+		pr, err := c.pullReqStore.FindByNumber(ctx, repo.ID, in.PullReqNumber)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		if pr.State != enum.PullReqStateMerged || pr.MergeSHA == nil || pr.MergeTargetSHA == nil {
+			return nil, nil, usererror.BadRequest("Only merged pull requests can be reverted")
+		}
+
+		// Reverting all changes between the target branch commit and the merge commit
+		// works the same way for all merge methods, including rebase and fast-forward.
+		commitSHA = sha.Must(*pr.MergeSHA)
+		baseCommitSHA = sha.Must(*pr.MergeTargetSHA)
+
+		if in.Branch == "" {
+			in.Branch = pr.TargetBranch
+		}
+
+		if in.Message == "" {
+			in.Message = fmt.Sprintf("Revert \"%s\" (#%d)\n\nThis reverts pull request #%d, merged as commit %s.",
+				pr.Title, pr.Number, pr.Number, commitSHA)
+		}
+	}
+
+	if in.Branch == "" {
+		in.Branch = repo.DefaultBranch
+	}
+
+	if err := validatePickBranches(in.Branch, in.NewBranch, in.CreatePullReq); err != nil {
+		return nil, nil, err
+	}
+
+	violations, err := c.pickVerifyRules(ctx, session, repo, in.Branch, in.NewBranch, in.BypassRules)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if in.DryRunRules {
+		// DryRunRules is true: Just return rule violations and don't attempt to revert.
+		return &types.RevertResponse{
+			RuleViolations: violations,
+			DryRunRules:    true,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{
+			RuleViolations: violations,
+			Message:        protection.GenerateErrorMessageForBlockingViolations(violations),
+		}, nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	now := time.Now()
+	revertOutput, err := c.git.Revert(ctx, &git.RevertParams{
+		WriteParams:   writeParams,
+		CommitSHA:     commitSHA,
+		Mainline:      in.Mainline,
+		BaseCommitSHA: baseCommitSHA,
+		Branch:        in.Branch,
+		NewBranch:     in.NewBranch,
+		Message:       in.Message,
+		Committer:     identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal),
+		CommitterDate: &now,
+		Author:        identityFromPrincipal(session.Principal),
+		AuthorDate:    &now,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("revert execution failed: %w", err)
+	}
+
+	if len(revertOutput.ConflictFiles) > 0 {
+		return nil, &types.MergeViolations{
+			ConflictFiles:  revertOutput.ConflictFiles,
+			RuleViolations: violations,
+			Message:        fmt.Sprintf("Revert blocked by conflicting files: %v", revertOutput.ConflictFiles),
+		}, nil
+	}
+
+	if protection.IsBypassed(violations) {
+		c.auditPickBypass(ctx, session, repo, revertOutput.CommitSHA, violations)
+	}
+
+	branch := in.Branch
+	if in.NewBranch != "" {
+		branch = in.NewBranch
+	}
+
+	var pr *types.PullReq
+	if in.CreatePullReq {
+		commit, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+			ReadParams: git.CreateReadParams(repo),
+			Revision:   revertOutput.CommitSHA.String(),
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get revert commit: %w", err)
+		}
+
+		pr, err = c.pickCreatePullReq(ctx, session, repo, in.NewBranch, in.Branch,
+			commit.Commit.Title, commit.Commit.Message)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return &types.RevertResponse{
+		CommitSHA:      revertOutput.CommitSHA,
+		Branch:         branch,
+		PullReq:        pr,
+		RuleViolations: violations,
+	}, nil, nil
+}
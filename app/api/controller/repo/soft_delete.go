@@ -105,5 +105,12 @@ func (c *Controller) SoftDeleteNoAuth(
 		return fmt.Errorf("failed to soft delete repo from db: %w", err)
 	}
 
+	if repo.ForkID != 0 {
+		if err := c.updateNumForks(ctx, repo.ForkID, -1); err != nil {
+			// non-critical error
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to decrement number of forks of the upstream repository")
+		}
+	}
+
 	return nil
 }
@@ -16,11 +16,13 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
@@ -56,6 +58,18 @@ func (c *Controller) UpdatePublicAccess(ctx context.Context,
 		return nil, errPublicRepoCreationDisabled
 	}
 
+	if in.IsPublic && repo.ForkID != 0 {
+		upstream, err := c.repoStore.Find(ctx, repo.ForkID)
+		if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to find upstream repository: %w", err)
+		}
+		if upstream != nil {
+			if err = c.checkForkPublicAccess(ctx, upstream); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	isPublic, err := c.publicAccess.Get(ctx, enum.PublicResourceTypeRepo, repo.Path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check current public access status: %w", err)
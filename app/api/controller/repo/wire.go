@@ -16,6 +16,7 @@ package repo
 
 import (
 	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/codeowners"
@@ -26,6 +27,9 @@ import (
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/pullmirror"
+	"github.com/harness/gitness/app/services/pushmirror"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/app/services/settings"
@@ -34,6 +38,7 @@ import (
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -84,6 +89,15 @@ func ProvideController(
 	userGroupService usergroup.SearchService,
 	rulesSvc *rules.Service,
 	sseStreamer sse.Streamer,
+	lfsStore store.LFSObjectStore,
+	blobStore blob.Store,
+	signatureVerifier *publickey.SignatureVerifier,
+	pullMirrorStore store.PullMirrorStore,
+	secretStore store.SecretStore,
+	pullMirrorSvc *pullmirror.Service,
+	pushMirrorStore store.PushMirrorStore,
+	pushMirrorSvc *pushmirror.Service,
+	pullreqCtrl *pullreq.Controller,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		authorizer,
@@ -92,7 +106,9 @@ func ProvideController(
 		principalInfoCache, protectionManager, rpcClient, spaceCache, repoFinder, importer,
 		codeOwners, repoReporter, indexer, limiter, locker, auditService, mtxManager, identifierCheck,
 		repoChecks, publicAccess, labelSvc, instrumentation, userGroupStore, userGroupService,
-		rulesSvc, sseStreamer,
+		rulesSvc, sseStreamer, lfsStore, blobStore, signatureVerifier,
+		pullMirrorStore, secretStore, pullMirrorSvc, pushMirrorStore, pushMirrorSvc,
+		pullreqCtrl,
 	)
 }
 
@@ -23,24 +23,43 @@ import (
 // SecuritySettings represents the security related part of repository settings as exposed externally.
 type SecuritySettings struct {
 	SecretScanningEnabled *bool `json:"secret_scanning_enabled" yaml:"secret_scanning_enabled"`
+
+	ForkPullReqPipelinesEnabled *bool `json:"fork_pullreq_pipelines_enabled" yaml:"fork_pullreq_pipelines_enabled"`
+	ForkPullReqSecretsEnabled   *bool `json:"fork_pullreq_secrets_enabled" yaml:"fork_pullreq_secrets_enabled"`
 }
 
 func GetDefaultSecuritySettings() *SecuritySettings {
 	return &SecuritySettings{
-		SecretScanningEnabled: ptr.Bool(settings.DefaultSecretScanningEnabled),
+		SecretScanningEnabled:       ptr.Bool(settings.DefaultSecretScanningEnabled),
+		ForkPullReqPipelinesEnabled: ptr.Bool(settings.DefaultForkPullReqPipelinesEnabled),
+		ForkPullReqSecretsEnabled:   ptr.Bool(settings.DefaultForkPullReqSecretsEnabled),
 	}
 }
 
 func GetSecuritySettingsMappings(s *SecuritySettings) []settings.SettingHandler {
 	return []settings.SettingHandler{
 		settings.Mapping(settings.KeySecretScanningEnabled, s.SecretScanningEnabled),
+		settings.Mapping(settings.KeyForkPullReqPipelinesEnabled, s.ForkPullReqPipelinesEnabled),
+		settings.Mapping(settings.KeyForkPullReqSecretsEnabled, s.ForkPullReqSecretsEnabled),
 	}
 }
 
 func GetSecuritySettingsAsKeyValues(s *SecuritySettings) []settings.KeyValue {
-	kvs := make([]settings.KeyValue, 0, 1)
+	kvs := make([]settings.KeyValue, 0, 3)
 	if s.SecretScanningEnabled != nil {
 		kvs = append(kvs, settings.KeyValue{Key: settings.KeySecretScanningEnabled, Value: *s.SecretScanningEnabled})
 	}
+	if s.ForkPullReqPipelinesEnabled != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyForkPullReqPipelinesEnabled,
+			Value: *s.ForkPullReqPipelinesEnabled,
+		})
+	}
+	if s.ForkPullReqSecretsEnabled != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyForkPullReqSecretsEnabled,
+			Value: *s.ForkPullReqSecretsEnabled,
+		})
+	}
 	return kvs
 }
@@ -17,6 +17,7 @@ package secret
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/encrypt"
 )
 
@@ -25,6 +26,7 @@ type Controller struct {
 	secretStore store.SecretStore
 	authorizer  authz.Authorizer
 	spaceStore  store.SpaceStore
+	auditSvc    audit.Service
 }
 
 func NewController(
@@ -32,11 +34,13 @@ func NewController(
 	encrypter encrypt.Encrypter,
 	secretStore store.SecretStore,
 	spaceStore store.SpaceStore,
+	auditSvc audit.Service,
 ) *Controller {
 	return &Controller{
 		encrypter:   encrypter,
 		secretStore: secretStore,
 		authorizer:  authorizer,
 		spaceStore:  spaceStore,
+		auditSvc:    auditSvc,
 	}
 }
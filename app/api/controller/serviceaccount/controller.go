@@ -16,11 +16,18 @@ package serviceaccount
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type Controller struct {
@@ -30,11 +37,12 @@ type Controller struct {
 	spaceStore        store.SpaceStore
 	repoStore         store.RepoStore
 	tokenStore        store.TokenStore
+	auditService      audit.Service
 }
 
 func NewController(principalUIDCheck check.PrincipalUID, authorizer authz.Authorizer,
 	principalStore store.PrincipalStore, spaceStore store.SpaceStore, repoStore store.RepoStore,
-	tokenStore store.TokenStore) *Controller {
+	tokenStore store.TokenStore, auditService audit.Service) *Controller {
 	return &Controller{
 		principalUIDCheck: principalUIDCheck,
 		authorizer:        authorizer,
@@ -42,6 +50,7 @@ func NewController(principalUIDCheck check.PrincipalUID, authorizer authz.Author
 		spaceStore:        spaceStore,
 		repoStore:         repoStore,
 		tokenStore:        tokenStore,
+		auditService:      auditService,
 	}
 }
 
@@ -49,3 +58,60 @@ func findServiceAccountFromUID(ctx context.Context,
 	principalStore store.PrincipalStore, saUID string) (*types.ServiceAccount, error) {
 	return principalStore.FindServiceAccountByUID(ctx, saUID)
 }
+
+// parentSpacePath returns the path of the space the service account belongs to.
+// For service accounts of a repository, that's the space of the repository.
+func (c *Controller) parentSpacePath(ctx context.Context, sa *types.ServiceAccount) (string, error) {
+	switch sa.ParentType {
+	case enum.ParentResourceTypeSpace:
+		space, err := c.spaceStore.Find(ctx, sa.ParentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find parent space: %w", err)
+		}
+		return space.Path, nil
+	case enum.ParentResourceTypeRepo:
+		repo, err := c.repoStore.Find(ctx, sa.ParentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find parent repository: %w", err)
+		}
+		return paths.Parent(repo.Path), nil
+	default:
+		return "", fmt.Errorf("unknown parent type %q of the service account", sa.ParentType)
+	}
+}
+
+func (c *Controller) auditToken(
+	ctx context.Context,
+	session *auth.Session,
+	sa *types.ServiceAccount,
+	token *types.Token,
+	action audit.Action,
+) {
+	spacePath, err := c.parentSpacePath(ctx, sa)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to get space path of the service account for the audit log")
+	}
+
+	object := audit.TokenObject{
+		Identifier:   token.Identifier,
+		Type:         token.Type,
+		PrincipalUID: sa.UID,
+		ExpiresAt:    token.ExpiresAt,
+	}
+
+	objectOption := audit.WithNewObject(object)
+	if action == audit.ActionDeleted {
+		objectOption = audit.WithOldObject(object)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeToken, token.Identifier),
+		action,
+		spacePath,
+		objectOption,
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for %s service account token operation: %s", action, err)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -68,6 +69,8 @@ func (c *Controller) CreateToken(
 		return nil, err
 	}
 
+	c.auditToken(ctx, session, sa, token, audit.ActionCreated)
+
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
@@ -20,6 +20,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
@@ -56,5 +57,11 @@ func (c *Controller) DeleteToken(
 		return usererror.ErrNotFound
 	}
 
-	return c.tokenStore.Delete(ctx, token.ID)
+	if err = c.tokenStore.Delete(ctx, token.ID); err != nil {
+		return err
+	}
+
+	c.auditToken(ctx, session, sa, token, audit.ActionDeleted)
+
+	return nil
 }
@@ -40,6 +40,7 @@ import (
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -102,6 +103,11 @@ type Controller struct {
 	executionStore   store.ExecutionStore
 	rulesSvc         *rules.Service
 	usageMetricStore store.UsageMetricStore
+
+	notificationChannelStore   store.NotificationChannelStore
+	encrypter                  encrypt.Encrypter
+	channelAllowLoopback       bool
+	channelAllowPrivateNetwork bool
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -116,6 +122,7 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	gitspaceSvc *gitspace.Service, labelSvc *label.Service,
 	instrumentation instrument.Service, executionStore store.ExecutionStore,
 	rulesSvc *rules.Service, usageMetricStore store.UsageMetricStore,
+	notificationChannelStore store.NotificationChannelStore, encrypter encrypt.Encrypter,
 ) *Controller {
 	return &Controller{
 		nestedSpacesEnabled: config.NestedSpacesEnabled,
@@ -147,6 +154,11 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		executionStore:      executionStore,
 		rulesSvc:            rulesSvc,
 		usageMetricStore:    usageMetricStore,
+
+		notificationChannelStore:   notificationChannelStore,
+		encrypter:                  encrypter,
+		channelAllowLoopback:       config.Notification.ChannelAllowLoopback,
+		channelAllowPrivateNetwork: config.Notification.ChannelAllowPrivateNetwork,
 	}
 }
 
@@ -21,11 +21,13 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
 )
 
 type MembershipAddInput struct {
@@ -95,6 +97,20 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 		return nil, fmt.Errorf("failed to create new membership: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeMembership, user.UID),
+		audit.ActionCreated,
+		space.Path,
+		audit.WithNewObject(audit.MembershipObject{
+			PrincipalUID: user.UID,
+			Role:         membership.Role,
+		}),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for add membership operation: %s", err)
+	}
+
 	result := &types.MembershipUser{
 		Membership: membership,
 		Principal:  *user.ToPrincipalInfo(),
@@ -19,8 +19,11 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // MembershipDelete removes an existing membership from a space.
@@ -39,13 +42,34 @@ func (c *Controller) MembershipDelete(ctx context.Context,
 		return fmt.Errorf("failed to find user by uid: %w", err)
 	}
 
-	err = c.membershipStore.Delete(ctx, types.MembershipKey{
+	key := types.MembershipKey{
 		SpaceID:     space.ID,
 		PrincipalID: user.ID,
-	})
+	}
+
+	membership, err := c.membershipStore.FindUser(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to find membership for delete: %w", err)
+	}
+
+	err = c.membershipStore.Delete(ctx, key)
 	if err != nil {
 		return fmt.Errorf("failed to delete user membership: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeMembership, user.UID),
+		audit.ActionDeleted,
+		space.Path,
+		audit.WithOldObject(audit.MembershipObject{
+			PrincipalUID: user.UID,
+			Role:         membership.Role,
+		}),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete membership operation: %s", err)
+	}
+
 	return nil
 }
@@ -20,8 +20,11 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type MembershipUpdateInput struct {
@@ -79,6 +82,7 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return membership, nil
 	}
 
+	oldRole := membership.Role
 	membership.Role = in.Role
 
 	err = c.membershipStore.Update(ctx, &membership.Membership)
@@ -86,5 +90,23 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return nil, fmt.Errorf("failed to update membership")
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeMembership, user.UID),
+		audit.ActionUpdated,
+		space.Path,
+		audit.WithOldObject(audit.MembershipObject{
+			PrincipalUID: user.UID,
+			Role:         oldRole,
+		}),
+		audit.WithNewObject(audit.MembershipObject{
+			PrincipalUID: user.UID,
+			Role:         membership.Role,
+		}),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update membership operation: %s", err)
+	}
+
 	return membership, nil
 }
@@ -0,0 +1,146 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (c *Controller) sanitizeNotificationChannelCreateInput(in *types.NotificationChannelCreateInput) error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	channelType, ok := in.Type.Sanitize()
+	if !ok {
+		return check.NewValidationErrorf("Notification channel type must be one of %v.",
+			enum.NotificationChannelType("").Enum())
+	}
+	in.Type = channelType
+
+	if err := c.checkNotificationChannelURL(in.URL); err != nil {
+		return err
+	}
+
+	triggers, err := sanitizeNotificationChannelTriggers(in.Triggers)
+	if err != nil {
+		return err
+	}
+	in.Triggers = triggers
+
+	return nil
+}
+
+func (c *Controller) sanitizeNotificationChannelUpdateInput(in *types.NotificationChannelUpdateInput) error {
+	if in.Identifier != nil {
+		if err := check.Identifier(*in.Identifier); err != nil {
+			return err
+		}
+	}
+	if in.Description != nil {
+		if err := check.Description(*in.Description); err != nil {
+			return err
+		}
+	}
+	if in.URL != nil {
+		if err := c.checkNotificationChannelURL(*in.URL); err != nil {
+			return err
+		}
+	}
+	if in.Triggers != nil {
+		triggers, err := sanitizeNotificationChannelTriggers(in.Triggers)
+		if err != nil {
+			return err
+		}
+		in.Triggers = triggers
+	}
+
+	return nil
+}
+
+func (c *Controller) checkNotificationChannelURL(url string) error {
+	return webhook.CheckURL(url, c.channelAllowLoopback, c.channelAllowPrivateNetwork, false)
+}
+
+func sanitizeNotificationChannelTriggers(
+	triggers []enum.NotificationChannelTrigger,
+) ([]enum.NotificationChannelTrigger, error) {
+	if len(triggers) == 0 {
+		return nil, check.NewValidationError("At least one notification channel trigger must be provided.")
+	}
+
+	seen := make(map[enum.NotificationChannelTrigger]struct{}, len(triggers))
+	result := make([]enum.NotificationChannelTrigger, 0, len(triggers))
+	for _, trigger := range triggers {
+		sanitized, ok := trigger.Sanitize()
+		if !ok {
+			return nil, check.NewValidationErrorf("Notification channel trigger '%s' is not supported.", trigger)
+		}
+		if _, ok := seen[sanitized]; ok {
+			continue
+		}
+		seen[sanitized] = struct{}{}
+		result = append(result, sanitized)
+	}
+
+	return result, nil
+}
+
+// auditNotificationChannel writes the audit log entry for a notification channel operation.
+func (c *Controller) auditNotificationChannel(
+	ctx context.Context,
+	session *auth.Session,
+	space *types.Space,
+	channel *types.NotificationChannel,
+	action audit.Action,
+	options ...audit.Option,
+) {
+	err := c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeNotificationChannel, channel.Identifier),
+		action,
+		space.Path,
+		options...,
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for %s notification channel operation: %s", action, err)
+	}
+}
+
+func (c *Controller) findNotificationChannel(
+	ctx context.Context,
+	space *types.Space,
+	identifier string,
+) (*types.NotificationChannel, error) {
+	channel, err := c.notificationChannelStore.FindByIdentifier(ctx, space.ID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification channel: %w", err)
+	}
+
+	return channel, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// NotificationChannelCreate creates a new notification channel for a space.
+func (c *Controller) NotificationChannelCreate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *types.NotificationChannelCreateInput,
+) (*types.NotificationChannel, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	if err := c.sanitizeNotificationChannelCreateInput(in); err != nil {
+		return nil, err
+	}
+
+	encryptedURL, err := c.encrypter.Encrypt(in.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt notification channel url: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	channel := &types.NotificationChannel{
+		SpaceID:     space.ID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Type:        in.Type,
+		URL:         string(encryptedURL),
+		Enabled:     in.Enabled,
+		Triggers:    in.Triggers,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err = c.notificationChannelStore.Create(ctx, channel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification channel: %w", err)
+	}
+
+	c.auditNotificationChannel(ctx, session, space, channel, audit.ActionCreated,
+		audit.WithNewObject(audit.NewNotificationChannelObject(channel)))
+
+	return channel, nil
+}
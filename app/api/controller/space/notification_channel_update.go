@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// NotificationChannelUpdate updates a notification channel of a space.
+func (c *Controller) NotificationChannelUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	in *types.NotificationChannelUpdateInput,
+) (*types.NotificationChannel, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	if err := c.sanitizeNotificationChannelUpdateInput(in); err != nil {
+		return nil, err
+	}
+
+	channel, err := c.findNotificationChannel(ctx, space, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	oldChannel := audit.NewNotificationChannelObject(channel)
+
+	if in.Identifier != nil {
+		channel.Identifier = *in.Identifier
+	}
+	if in.Description != nil {
+		channel.Description = *in.Description
+	}
+	if in.URL != nil {
+		encryptedURL, err := c.encrypter.Encrypt(*in.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt notification channel url: %w", err)
+		}
+		channel.URL = string(encryptedURL)
+	}
+	if in.Enabled != nil {
+		channel.Enabled = *in.Enabled
+	}
+	if in.Triggers != nil {
+		channel.Triggers = in.Triggers
+	}
+
+	err = c.notificationChannelStore.Update(ctx, channel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update notification channel: %w", err)
+	}
+
+	newChannel := audit.NewNotificationChannelObject(channel)
+	newChannel.URLUpdated = in.URL != nil
+
+	c.auditNotificationChannel(ctx, session, space, channel, audit.ActionUpdated,
+		audit.WithOldObject(oldChannel),
+		audit.WithNewObject(newChannel))
+
+	return channel, nil
+}
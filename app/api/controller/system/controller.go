@@ -16,7 +16,9 @@ package system
 
 import (
 	"context"
+	"fmt"
 
+	systemsvc "github.com/harness/gitness/app/services/system"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 )
@@ -24,12 +26,18 @@ import (
 type Controller struct {
 	principalStore store.PrincipalStore
 	config         *types.Config
+	systemService  *systemsvc.Service
 }
 
-func NewController(principalStore store.PrincipalStore, config *types.Config) *Controller {
+func NewController(
+	principalStore store.PrincipalStore,
+	config *types.Config,
+	systemService *systemsvc.Service,
+) *Controller {
 	return &Controller{
 		principalStore: principalStore,
 		config:         config,
+		systemService:  systemService,
 	}
 }
 
@@ -41,3 +49,13 @@ func (c *Controller) IsUserSignupAllowed(ctx context.Context) (bool, error) {
 
 	return usrCount == 0 || c.config.UserSignupEnabled, nil
 }
+
+// IsLocalLoginAllowed returns true if users can log in with local passwords.
+func (c *Controller) IsLocalLoginAllowed(ctx context.Context) (bool, error) {
+	settings, err := c.systemService.Find(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to find system settings: %w", err)
+	}
+
+	return settings.LocalLoginDisabled == nil || !*settings.LocalLoginDisabled, nil
+}
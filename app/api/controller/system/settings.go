@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	systemsvc "github.com/harness/gitness/app/services/system"
+)
+
+type SettingsUpdateInput struct {
+	LocalLoginDisabled *bool `json:"local_login_disabled"`
+}
+
+// FindSettings returns the system settings. Only admins can access them.
+func (c *Controller) FindSettings(ctx context.Context, session *auth.Session) (*systemsvc.Settings, error) {
+	if !session.IsSystemAdmin() {
+		return nil, usererror.ErrForbidden
+	}
+
+	settings, err := c.systemService.Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find system settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// UpdateSettings updates the system settings. Only admins can update them.
+func (c *Controller) UpdateSettings(
+	ctx context.Context,
+	session *auth.Session,
+	in *SettingsUpdateInput,
+) (*systemsvc.Settings, error) {
+	if !session.IsSystemAdmin() {
+		return nil, usererror.ErrForbidden
+	}
+
+	if in.LocalLoginDisabled != nil && *in.LocalLoginDisabled && !c.config.OIDC.Enabled && !c.config.LDAP.Enabled {
+		return nil, usererror.BadRequest(
+			"Local login can't be disabled without an enabled single sign-on or directory authentication.")
+	}
+
+	settings, err := c.systemService.Update(ctx, &systemsvc.Settings{
+		LocalLoginDisabled: in.LocalLoginDisabled,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update system settings: %w", err)
+	}
+
+	return settings, nil
+}
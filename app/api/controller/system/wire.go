@@ -15,6 +15,7 @@
 package system
 
 import (
+	systemsvc "github.com/harness/gitness/app/services/system"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 
@@ -26,6 +27,10 @@ var WireSet = wire.NewSet(
 	NewController,
 )
 
-func ProvideController(principalStore store.PrincipalStore, config *types.Config) *Controller {
-	return NewController(principalStore, config)
+func ProvideController(
+	principalStore store.PrincipalStore,
+	config *types.Config,
+	systemService *systemsvc.Service,
+) *Controller {
+	return NewController(principalStore, config, systemService)
 }
@@ -15,8 +15,13 @@
 package trigger
 
 import (
+	"regexp"
+
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/bmatcuk/doublestar/v4"
 )
 
 const (
@@ -24,6 +29,15 @@ const (
 	// TODO: Check whether this is sufficient for other SCM providers once we
 	// add support. For now it's good to have a limit and increase if needed.
 	triggerMaxSecretLength = 4096
+
+	// triggerMaxCommentPatternLength defines the max allowed length of a trigger comment pattern.
+	triggerMaxCommentPatternLength = 1024
+
+	// triggerMaxPathPatterns defines the max allowed number of path patterns (include and exclude) of a trigger.
+	triggerMaxPathPatterns = 100
+
+	// triggerMaxPathPatternLength defines the max allowed length of a single trigger path pattern.
+	triggerMaxPathPatternLength = 1024
 )
 
 // checkSecret validates the secret of a trigger.
@@ -36,6 +50,46 @@ func checkSecret(secret string) error {
 	return nil
 }
 
+// checkCommentPattern validates the regular expression used to filter pull request comments.
+func checkCommentPattern(pattern string) error {
+	if len(pattern) > triggerMaxCommentPatternLength {
+		return check.NewValidationErrorf("The comment pattern of a trigger can be at most %d characters long.",
+			triggerMaxCommentPatternLength)
+	}
+
+	if _, err := regexp.Compile(pattern); err != nil {
+		return check.NewValidationErrorf("The comment pattern of a trigger is not a valid regular expression: %s",
+			err)
+	}
+
+	return nil
+}
+
+// checkPaths validates the glob patterns used to filter the changed files.
+func checkPaths(paths types.TriggerPathFilter) error {
+	if len(paths.Include)+len(paths.Exclude) > triggerMaxPathPatterns {
+		return check.NewValidationErrorf("A trigger can have at most %d path patterns.", triggerMaxPathPatterns)
+	}
+
+	for _, patterns := range [][]string{paths.Include, paths.Exclude} {
+		for _, pattern := range patterns {
+			if pattern == "" {
+				return check.NewValidationError("The path pattern of a trigger can't be empty.")
+			}
+			if len(pattern) > triggerMaxPathPatternLength {
+				return check.NewValidationErrorf("The path pattern of a trigger can be at most %d characters long.",
+					triggerMaxPathPatternLength)
+			}
+			if !doublestar.ValidatePattern(pattern) {
+				return check.NewValidationErrorf("The path pattern '%s' of a trigger is not a valid glob pattern.",
+					pattern)
+			}
+		}
+	}
+
+	return nil
+}
+
 // checkActions validates the trigger actions.
 func checkActions(actions []enum.TriggerAction) error {
 	// ignore duplicates here, should be deduplicated later
@@ -34,6 +34,10 @@ type CreateInput struct {
 	Secret     string               `json:"secret"`
 	Disabled   bool                 `json:"disabled"`
 	Actions    []enum.TriggerAction `json:"actions"`
+	// CommentPattern optionally filters the pull request comments that fire the trigger.
+	CommentPattern string `json:"comment_pattern"`
+	// Paths optionally restricts the trigger to changes of matching files.
+	Paths types.TriggerPathFilter `json:"paths"`
 }
 
 func (c *Controller) Create(
@@ -59,17 +63,19 @@ func (c *Controller) Create(
 
 	now := time.Now().UnixMilli()
 	trigger := &types.Trigger{
-		Description: in.Description,
-		Disabled:    in.Disabled,
-		Secret:      in.Secret,
-		CreatedBy:   session.Principal.ID,
-		RepoID:      repo.ID,
-		Actions:     deduplicateActions(in.Actions),
-		Identifier:  in.Identifier,
-		PipelineID:  pipeline.ID,
-		Created:     now,
-		Updated:     now,
-		Version:     0,
+		Description:    in.Description,
+		Disabled:       in.Disabled,
+		Secret:         in.Secret,
+		CreatedBy:      session.Principal.ID,
+		RepoID:         repo.ID,
+		Actions:        deduplicateActions(in.Actions),
+		CommentPattern: in.CommentPattern,
+		Paths:          in.Paths,
+		Identifier:     in.Identifier,
+		PipelineID:     pipeline.ID,
+		Created:        now,
+		Updated:        now,
+		Version:        0,
 	}
 	err = c.triggerStore.Create(ctx, trigger)
 	if err != nil {
@@ -94,6 +100,12 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 	if err := checkActions(in.Actions); err != nil {
 		return err
 	}
+	if err := checkCommentPattern(in.CommentPattern); err != nil {
+		return err
+	}
+	if err := checkPaths(in.Paths); err != nil {
+		return err
+	}
 	if err := check.Identifier(in.Identifier); err != nil { //nolint:revive
 		return err
 	}
@@ -34,6 +34,10 @@ type UpdateInput struct {
 	Actions    []enum.TriggerAction `json:"actions"`
 	Secret     *string              `json:"secret"`
 	Disabled   *bool                `json:"disabled"` // can be nil, so keeping it a pointer
+	// CommentPattern optionally filters the pull request comments that fire the trigger.
+	CommentPattern *string `json:"comment_pattern"`
+	// Paths optionally restricts the trigger to changes of matching files.
+	Paths *types.TriggerPathFilter `json:"paths"`
 }
 
 func (c *Controller) Update(
@@ -80,6 +84,12 @@ func (c *Controller) Update(
 			if in.Disabled != nil {
 				original.Disabled = *in.Disabled
 			}
+			if in.CommentPattern != nil {
+				original.CommentPattern = *in.CommentPattern
+			}
+			if in.Paths != nil {
+				original.Paths = *in.Paths
+			}
 
 			return nil
 		})
@@ -116,5 +126,17 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	if in.CommentPattern != nil {
+		if err := checkCommentPattern(*in.CommentPattern); err != nil {
+			return err
+		}
+	}
+
+	if in.Paths != nil {
+		if err := checkPaths(*in.Paths); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
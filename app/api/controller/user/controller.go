@@ -17,8 +17,12 @@ package user
 import (
 	"context"
 
+	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -35,6 +39,14 @@ type Controller struct {
 	tokenStore        store.TokenStore
 	membershipStore   store.MembershipStore
 	publicKeyStore    store.PublicKeyStore
+
+	oidcProvider         *oidc.Provider
+	ldapDirectory        *authn.LDAPDirectory
+	spaceStore           store.SpaceStore
+	repoFinder           refcache.RepoFinder
+	userGroupStore       store.UserGroupStore
+	userGroupMemberStore store.UserGroupMemberStore
+	auditService         audit.Service
 }
 
 func NewController(
@@ -45,15 +57,29 @@ func NewController(
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
 	publicKeyStore store.PublicKeyStore,
+	oidcProvider *oidc.Provider,
+	ldapDirectory *authn.LDAPDirectory,
+	spaceStore store.SpaceStore,
+	repoFinder refcache.RepoFinder,
+	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+	auditService audit.Service,
 ) *Controller {
 	return &Controller{
-		tx:                tx,
-		principalUIDCheck: principalUIDCheck,
-		authorizer:        authorizer,
-		principalStore:    principalStore,
-		tokenStore:        tokenStore,
-		membershipStore:   membershipStore,
-		publicKeyStore:    publicKeyStore,
+		tx:                   tx,
+		principalUIDCheck:    principalUIDCheck,
+		authorizer:           authorizer,
+		principalStore:       principalStore,
+		tokenStore:           tokenStore,
+		membershipStore:      membershipStore,
+		publicKeyStore:       publicKeyStore,
+		oidcProvider:         oidcProvider,
+		ldapDirectory:        ldapDirectory,
+		spaceStore:           spaceStore,
+		repoFinder:           repoFinder,
+		userGroupStore:       userGroupStore,
+		userGroupMemberStore: userGroupMemberStore,
+		auditService:         auditService,
 	}
 }
 
@@ -22,9 +22,12 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type CreateTokenInput struct {
@@ -32,6 +35,8 @@ type CreateTokenInput struct {
 	UID        string         `json:"uid" deprecated:"true"`
 	Identifier string         `json:"identifier"`
 	Lifetime   *time.Duration `json:"lifetime"`
+	// Scopes optionally restrict the token to specific spaces or repositories and a subset of permissions.
+	Scopes []TokenScopeInput `json:"scopes"`
 }
 
 /*
@@ -57,6 +62,11 @@ func (c *Controller) CreateAccessToken(
 		return nil, err
 	}
 
+	scopes, err := c.resolveTokenScopes(ctx, session, in.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
 	token, jwtToken, err := token.CreatePAT(
 		ctx,
 		c.tokenStore,
@@ -64,11 +74,28 @@ func (c *Controller) CreateAccessToken(
 		user,
 		in.Identifier,
 		in.Lifetime,
+		scopes,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeToken, token.Identifier),
+		audit.ActionCreated,
+		"",
+		audit.WithNewObject(audit.TokenObject{
+			Identifier:   token.Identifier,
+			Type:         token.Type,
+			PrincipalUID: user.UID,
+			ExpiresAt:    token.ExpiresAt,
+		}),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create token operation: %s", err)
+	}
+
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
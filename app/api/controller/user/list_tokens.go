@@ -16,6 +16,7 @@ package user
 
 import (
 	"context"
+	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
@@ -43,5 +44,14 @@ func (c *Controller) ListTokens(ctx context.Context, session *auth.Session,
 		return nil, usererror.ErrBadRequest
 	}
 
-	return c.tokenStore.List(ctx, user.ID, tokenType)
+	tokens, err := c.tokenStore.List(ctx, user.ID, tokenType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tokens: %w", err)
+	}
+
+	if err = c.refreshTokenScopePaths(ctx, tokens); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
 }
@@ -22,10 +22,13 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/harness/gitness/app/api/controller/system"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/bcrypt"
@@ -41,10 +44,30 @@ type LoginInput struct {
  */
 func (c *Controller) Login(
 	ctx context.Context,
+	sysCtrl *system.Controller,
 	in *LoginInput,
 ) (*types.TokenResponse, error) {
 	// no auth check required, password is used for it.
 
+	if c.ldapDirectory != nil {
+		user, found, err := c.ldapLogin(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return c.createLoginSession(ctx, user, audit.LoginMethodLDAP)
+		}
+	}
+
+	localLoginAllowed, err := sysCtrl.IsLocalLoginAllowed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !localLoginAllowed {
+		return nil, usererror.Forbidden("Login with a password is disabled, please use single sign-on")
+	}
+
 	user, err := findUserFromUID(ctx, c.principalStore, in.LoginIdentifier)
 	if errors.Is(err, store.ErrResourceNotFound) {
 		user, err = findUserFromEmail(ctx, c.principalStore, in.LoginIdentifier)
@@ -54,6 +77,7 @@ func (c *Controller) Login(
 	if err != nil {
 		log.Ctx(ctx).Debug().Err(err).
 			Msgf("failed to retrieve user %q during login (returning ErrNotFound).", in.LoginIdentifier)
+		c.auditLoginFailed(ctx, in.LoginIdentifier, audit.LoginMethodPassword)
 		return nil, usererror.ErrNotFound
 	}
 
@@ -65,10 +89,20 @@ func (c *Controller) Login(
 		log.Debug().Err(err).
 			Str("user_uid", user.UID).
 			Msg("invalid password")
+		c.auditLoginFailed(ctx, user.UID, audit.LoginMethodPassword)
 
 		return nil, usererror.ErrNotFound
 	}
 
+	return c.createLoginSession(ctx, user, audit.LoginMethodPassword)
+}
+
+// createLoginSession creates a new session token for the user that has just logged in.
+func (c *Controller) createLoginSession(
+	ctx context.Context,
+	user *types.User,
+	loginMethod string,
+) (*types.TokenResponse, error) {
 	tokenIdentifier, err := GenerateSessionTokenIdentifier()
 	if err != nil {
 		return nil, err
@@ -78,9 +112,43 @@ func (c *Controller) Login(
 		return nil, err
 	}
 
+	c.auditLogin(ctx, *user.ToPrincipal(), audit.ActionLoggedIn, loginMethod)
+
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
+// auditLoginFailed records a failed login attempt in the audit trail.
+// The user might not exist, so only the provided login identifier is recorded.
+func (c *Controller) auditLoginFailed(ctx context.Context, loginIdentifier string, loginMethod string) {
+	if loginIdentifier == "" {
+		return
+	}
+
+	principal := types.Principal{
+		UID:  loginIdentifier,
+		Type: enum.PrincipalTypeUser,
+	}
+
+	c.auditLogin(ctx, principal, audit.ActionLoginFailed, loginMethod)
+}
+
+func (c *Controller) auditLogin(
+	ctx context.Context,
+	principal types.Principal,
+	action audit.Action,
+	loginMethod string,
+) {
+	err := c.auditService.Log(ctx,
+		principal,
+		audit.NewResource(audit.ResourceTypeUser, principal.UID, audit.LoginMethod, loginMethod),
+		action,
+		"",
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for login operation: %s", err)
+	}
+}
+
 func GenerateSessionTokenIdentifier() (string, error) {
 	r, err := rand.Int(rand.Reader, big.NewInt(10000))
 	if err != nil {
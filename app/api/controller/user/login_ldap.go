@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ldapLogin authenticates the user against the LDAP directory. Users are provisioned on their first login.
+// It returns false if the user should be authenticated locally instead, either because the user isn't
+// in the directory or because the directory isn't available.
+func (c *Controller) ldapLogin(ctx context.Context, in *LoginInput) (*types.User, bool, error) {
+	dirUser, err := c.ldapDirectory.Authenticate(in.LoginIdentifier, in.Password)
+	switch {
+	case errors.Is(err, authn.ErrLDAPUserNotFound):
+		return nil, false, nil
+	case errors.Is(err, authn.ErrLDAPInvalidCredentials), errors.Is(err, authn.ErrLDAPUserDisabled):
+		log.Ctx(ctx).Debug().Err(err).
+			Msgf("failed to authenticate directory user %q (returning ErrNotFound).", in.LoginIdentifier)
+		c.auditLoginFailed(ctx, in.LoginIdentifier, audit.LoginMethodLDAP)
+
+		// always return not found for security reasons.
+		return nil, false, usererror.ErrNotFound
+	case err != nil:
+		// the directory being unavailable shouldn't lock out the local users, e.g. the administrator.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to authenticate with the directory, falling back to local login")
+		return nil, false, nil
+	}
+
+	if dirUser.Email == "" {
+		return nil, false, usererror.Forbidden("The directory user doesn't have an email address")
+	}
+
+	user, err := c.findOrProvisionUser(ctx, dirUser.Email, dirUser.UID, dirUser.DisplayName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if user.Blocked {
+		return nil, false, usererror.Forbidden("The user is blocked")
+	}
+
+	return user, true, nil
+}
@@ -0,0 +1,234 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// OIDCLoginCallbackInput holds the parameters the identity provider redirected the user back with,
+// together with the data of the auth request that was kept by the client.
+type OIDCLoginCallbackInput struct {
+	Code  string
+	State string
+	Error string
+
+	ExpectedState string
+	Nonce         string
+	Verifier      string
+}
+
+// OIDCLoginStart starts the OpenID Connect login.
+// The returned auth request contains the URL of the identity provider the user has to be redirected to.
+func (c *Controller) OIDCLoginStart(ctx context.Context) (*oidc.AuthRequest, error) {
+	if c.oidcProvider == nil {
+		return nil, usererror.NotFound("Single sign-on is not enabled")
+	}
+
+	authRequest, err := c.oidcProvider.NewAuthRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start single sign-on: %w", err)
+	}
+
+	return authRequest, nil
+}
+
+// OIDCLoginCallback completes the OpenID Connect login - returns the session token if successful.
+// Users are provisioned on their first login and their groups are synchronized on every login.
+func (c *Controller) OIDCLoginCallback(
+	ctx context.Context,
+	in *OIDCLoginCallbackInput,
+) (*types.TokenResponse, error) {
+	if c.oidcProvider == nil {
+		return nil, usererror.NotFound("Single sign-on is not enabled")
+	}
+
+	if in.Error != "" {
+		return nil, usererror.BadRequestf("Single sign-on failed: %s", in.Error)
+	}
+
+	if in.State == "" || in.State != in.ExpectedState {
+		return nil, usererror.BadRequest("Single sign-on state is invalid or expired, please try again")
+	}
+
+	claims, err := c.oidcProvider.Exchange(ctx, in.Code, in.Verifier, in.Nonce)
+	if errors.Is(err, oidc.ErrInvalidToken) {
+		log.Ctx(ctx).Warn().Err(err).Msg("identity provider returned an invalid ID token")
+		return nil, usererror.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := c.oidcFindOrCreateUser(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Blocked {
+		return nil, usererror.Forbidden("The user is blocked")
+	}
+
+	if err := c.oidcSyncGroups(ctx, user, claims.Groups); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Str("user_uid", user.UID).
+			Msg("failed to synchronize groups of the identity provider")
+	}
+
+	return c.createLoginSession(ctx, user, audit.LoginMethodOIDC)
+}
+
+// oidcFindOrCreateUser finds the user by the verified email address of the ID token.
+// If there's no such user, a new one is provisioned.
+func (c *Controller) oidcFindOrCreateUser(ctx context.Context, claims *oidc.Claims) (*types.User, error) {
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, usererror.Forbidden("The identity provider didn't provide a verified email address")
+	}
+
+	return c.findOrProvisionUser(ctx, claims.Email, claims.PreferredUsername, claims.Name)
+}
+
+// oidcSyncGroups applies the group mappings of the identity provider to the user.
+// Membership of mapped user groups is fully synchronized with the groups of the user.
+// Space memberships are only created or upgraded to the mapped role, but never removed,
+// because they could have been granted manually.
+func (c *Controller) oidcSyncGroups(ctx context.Context, user *types.User, groups []string) error {
+	groupSet := make(map[string]struct{}, len(groups))
+	for _, group := range groups {
+		groupSet[group] = struct{}{}
+	}
+
+	// a user group can be mapped from multiple groups: the user is a member if it has any of them.
+	userGroupMember := make(map[int64]bool)
+	for _, m := range c.oidcProvider.UserGroupMappings() {
+		space, err := c.spaceStore.FindByRef(ctx, m.SpacePath)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			log.Ctx(ctx).Warn().Msgf("space %q of the user group mapping doesn't exist", m.SpacePath)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find space %q: %w", m.SpacePath, err)
+		}
+
+		userGroup, err := c.userGroupStore.FindByIdentifier(ctx, space.ID, m.Value)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			log.Ctx(ctx).Warn().Msgf("user group %q of the user group mapping doesn't exist", m.Value)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find user group %q: %w", m.Value, err)
+		}
+
+		_, isMember := groupSet[m.Group]
+		userGroupMember[userGroup.ID] = userGroupMember[userGroup.ID] || isMember
+	}
+
+	for userGroupID, isMember := range userGroupMember {
+		var err error
+		if isMember {
+			err = c.userGroupMemberStore.Add(ctx, userGroupID, user.ID)
+		} else {
+			err = c.userGroupMemberStore.Remove(ctx, userGroupID, user.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update user group membership: %w", err)
+		}
+	}
+
+	// if multiple groups are mapped onto the same space, the role with the most permissions wins.
+	spaceRoles := make(map[string]enum.MembershipRole)
+	for _, m := range c.oidcProvider.MembershipMappings() {
+		if _, ok := groupSet[m.Group]; !ok {
+			continue
+		}
+
+		role := enum.MembershipRole(m.Value)
+		if len(role.Permissions()) > len(spaceRoles[m.SpacePath].Permissions()) {
+			spaceRoles[m.SpacePath] = role
+		}
+	}
+
+	for spacePath, role := range spaceRoles {
+		if err := c.oidcGrantMembership(ctx, user, spacePath, role); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) oidcGrantMembership(
+	ctx context.Context,
+	user *types.User,
+	spacePath string,
+	role enum.MembershipRole,
+) error {
+	space, err := c.spaceStore.FindByRef(ctx, spacePath)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		log.Ctx(ctx).Warn().Msgf("space %q of the membership mapping doesn't exist", spacePath)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find space %q: %w", spacePath, err)
+	}
+
+	key := types.MembershipKey{SpaceID: space.ID, PrincipalID: user.ID}
+	now := time.Now().UnixMilli()
+
+	membership, err := c.membershipStore.Find(ctx, key)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		// the membership is granted on the user's own login, so it's recorded as added by the user.
+		err = c.membershipStore.Create(ctx, &types.Membership{
+			MembershipKey: key,
+			CreatedBy:     user.ID,
+			Created:       now,
+			Updated:       now,
+			Role:          role,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create membership of space %q: %w", spacePath, err)
+		}
+
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find membership of space %q: %w", spacePath, err)
+	}
+
+	if len(membership.Role.Permissions()) >= len(role.Permissions()) {
+		return nil
+	}
+
+	membership.Role = role
+	membership.Updated = now
+
+	if err := c.membershipStore.Update(ctx, membership); err != nil {
+		return fmt.Errorf("failed to update membership of space %q: %w", spacePath, err)
+	}
+
+	return nil
+}
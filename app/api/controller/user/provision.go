@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/dchest/uniuri"
+	"github.com/rs/zerolog/log"
+)
+
+var illegalUIDChars = regexp.MustCompile(`[^a-zA-Z0-9-_.]+`)
+
+// findOrProvisionUser finds the user of an external identity (single sign-on or directory) by the email address.
+// If there's no such user, a new one is created. Created users can't log in with a local password,
+// because their password is random.
+func (c *Controller) findOrProvisionUser(
+	ctx context.Context,
+	email string,
+	preferredName string,
+	displayName string,
+) (*types.User, error) {
+	user, err := c.principalStore.FindUserByEmail(ctx, email)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find user by email: %w", err)
+	}
+
+	uid := provisionedUserUID(preferredName, email)
+
+	displayName = strings.TrimSpace(displayName)
+	if displayName == "" {
+		displayName = uid
+	}
+
+	in := &CreateInput{
+		UID:         uid,
+		Email:       email,
+		DisplayName: displayName,
+		Password:    uniuri.NewLen(64),
+	}
+
+	user, err = c.CreateNoAuth(ctx, in, false)
+	if errors.Is(err, store.ErrDuplicate) {
+		// the UID is already taken by another user, fall back to a UID with a random suffix.
+		in.UID = fmt.Sprintf("%s-%s", uid, strings.ToLower(uniuri.NewLen(6)))
+		user, err = c.CreateNoAuth(ctx, in, false)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to provision user: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Str("user_uid", user.UID).Msg("provisioned user of an external identity")
+
+	return user, nil
+}
+
+// provisionedUserUID derives the UID of a provisioned user from the preferred name or the email address.
+func provisionedUserUID(preferredName string, email string) string {
+	name := preferredName
+	if name == "" || strings.Contains(name, "@") {
+		name, _, _ = strings.Cut(email, "@")
+	}
+
+	uid := strings.Trim(illegalUIDChars.ReplaceAllString(name, "-"), "-")
+	if len(uid) > check.MaxIdentifierLength-7 {
+		uid = uid[:check.MaxIdentifierLength-7]
+	}
+
+	if uid == "" || strings.EqualFold(uid, types.AnonymousPrincipalUID) {
+		uid = "user-" + strings.ToLower(uniuri.NewLen(8))
+	}
+
+	return uid
+}
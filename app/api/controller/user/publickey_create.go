@@ -71,6 +71,11 @@ func (c *Controller) CreatePublicKey(
 		Content:     in.Content,
 		Comment:     comment,
 		Type:        key.Type(),
+		Scheme:      key.Scheme(),
+	}
+
+	if k.Scheme == enum.PublicKeySchemePGP && k.Usage != enum.PublicKeyUsageSign {
+		return nil, errors.InvalidArgument("PGP keys can only be used for signing")
 	}
 
 	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
@@ -80,7 +85,7 @@ func (c *Controller) CreatePublicKey(
 		}
 
 		for _, existingKey := range existingKeys {
-			if key.Matches(existingKey.Content) {
+			if key.Matches(existingKey.Content) && existingKey.Usage == k.Usage {
 				return errors.InvalidArgument("Key is already in use")
 			}
 		}
@@ -44,6 +44,15 @@ func (c *Controller) Register(ctx context.Context, sysCtrl *system.Controller,
 		return nil, usererror.Forbidden("User sign-up is disabled")
 	}
 
+	localLoginAllowed, err := sysCtrl.IsLocalLoginAllowed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !localLoginAllowed {
+		return nil, usererror.Forbidden("User sign-up with a password is disabled, please use single sign-on")
+	}
+
 	user, err := c.CreateNoAuth(ctx, &CreateInput{
 		UID:         in.UID,
 		Email:       in.Email,
@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+const maxTokenScopes = 20
+
+// TokenScopeInput restricts a token to either a space or a repository and a subset of permissions.
+type TokenScopeInput struct {
+	SpaceRef    string            `json:"space_ref"`
+	RepoRef     string            `json:"repo_ref"`
+	Permissions []enum.Permission `json:"permissions"`
+}
+
+// resolveTokenScopes validates the provided scopes and resolves the referenced spaces and repositories.
+// The caller is required to have view access to all of them.
+func (c *Controller) resolveTokenScopes(
+	ctx context.Context,
+	session *auth.Session,
+	in []TokenScopeInput,
+) ([]types.TokenScope, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	if len(in) > maxTokenScopes {
+		return nil, usererror.BadRequestf("A token can't have more than %d scopes.", maxTokenScopes)
+	}
+
+	scopes := make([]types.TokenScope, len(in))
+	for i, scopeIn := range in {
+		permissions, err := sanitizeTokenScopePermissions(scopeIn.Permissions)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case scopeIn.SpaceRef != "" && scopeIn.RepoRef != "":
+			return nil, usererror.BadRequest("A token scope can't reference both a space and a repository.")
+		case scopeIn.SpaceRef != "":
+			space, err := c.spaceStore.FindByRef(ctx, scopeIn.SpaceRef)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find space for token scope: %w", err)
+			}
+
+			if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView); err != nil {
+				return nil, err
+			}
+
+			scopes[i] = types.TokenScope{SpaceID: space.ID, Path: space.Path, Permissions: permissions}
+		case scopeIn.RepoRef != "":
+			repo, err := c.repoFinder.FindByRef(ctx, scopeIn.RepoRef)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find repository for token scope: %w", err)
+			}
+
+			if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView); err != nil {
+				return nil, err
+			}
+
+			scopes[i] = types.TokenScope{RepoID: repo.ID, Path: repo.Path, Permissions: permissions}
+		default:
+			return nil, usererror.BadRequest("A token scope has to reference either a space or a repository.")
+		}
+	}
+
+	return scopes, nil
+}
+
+// sanitizeTokenScopePermissions verifies the permissions of a token scope and returns them sorted and deduplicated.
+func sanitizeTokenScopePermissions(in []enum.Permission) ([]enum.Permission, error) {
+	if len(in) == 0 {
+		return nil, usererror.BadRequest("A token scope requires at least one permission.")
+	}
+
+	// the space owner role has all permissions that can be granted within a space.
+	allowed := enum.MembershipRoleSpaceOwner.Permissions()
+
+	permissions := slices.Clone(in)
+	for _, permission := range permissions {
+		if _, ok := slices.BinarySearch(allowed, permission); !ok {
+			return nil, usererror.BadRequestf("Permission '%s' isn't supported by token scopes.", permission)
+		}
+	}
+
+	slices.Sort(permissions)
+
+	return slices.Compact(permissions), nil
+}
+
+// refreshTokenScopePaths updates the paths of the token scopes to the current paths
+// of the referenced spaces and repositories (they might have been moved or renamed).
+func (c *Controller) refreshTokenScopePaths(ctx context.Context, tokens []*types.Token) error {
+	for _, token := range tokens {
+		for i := range token.Scopes {
+			scope := &token.Scopes[i]
+			scope.Path = ""
+
+			switch {
+			case scope.SpaceID != 0:
+				space, err := c.spaceStore.Find(ctx, scope.SpaceID)
+				if errors.Is(err, gitness_store.ErrResourceNotFound) {
+					continue
+				}
+				if err != nil {
+					return fmt.Errorf("failed to find space of token scope: %w", err)
+				}
+
+				scope.Path = space.Path
+			case scope.RepoID != 0:
+				repo, err := c.repoFinder.FindByRef(ctx, strconv.FormatInt(scope.RepoID, 10))
+				if errors.Is(err, gitness_store.ErrResourceNotFound) {
+					continue
+				}
+				if err != nil {
+					return fmt.Errorf("failed to find repository of token scope: %w", err)
+				}
+
+				scope.Path = repo.Path
+			}
+		}
+	}
+
+	return nil
+}
@@ -22,8 +22,11 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type UpdateAdminInput struct {
@@ -55,6 +58,8 @@ func (c *Controller) UpdateAdmin(ctx context.Context, session *auth.Session,
 		}
 	}
 
+	oldUser := audit.NewUserObject(user)
+
 	user.Admin = request.Admin
 	user.Updated = time.Now().UnixMilli()
 
@@ -63,5 +68,17 @@ func (c *Controller) UpdateAdmin(ctx context.Context, session *auth.Session,
 		return nil, err
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeUser, user.UID),
+		audit.ActionUpdated,
+		"",
+		audit.WithOldObject(oldUser),
+		audit.WithNewObject(audit.NewUserObject(user)),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update user admin operation: %s", err)
+	}
+
 	return user, nil
 }
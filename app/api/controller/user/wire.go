@@ -15,8 +15,12 @@
 package user
 
 import (
+	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types/check"
 
@@ -36,6 +40,13 @@ func ProvideController(
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
 	publicKeyStore store.PublicKeyStore,
+	oidcProvider *oidc.Provider,
+	ldapDirectory *authn.LDAPDirectory,
+	spaceStore store.SpaceStore,
+	repoFinder refcache.RepoFinder,
+	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+	auditService audit.Service,
 ) *Controller {
 	return NewController(
 		tx,
@@ -44,5 +55,12 @@ func ProvideController(
 		principalStore,
 		tokenStore,
 		membershipStore,
-		publicKeyStore)
+		publicKeyStore,
+		oidcProvider,
+		ldapDirectory,
+		spaceStore,
+		repoFinder,
+		userGroupStore,
+		userGroupMemberStore,
+		auditService)
 }
@@ -24,10 +24,13 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type Controller struct {
@@ -37,6 +40,7 @@ type Controller struct {
 	webhookService *webhook.Service
 	encrypter      encrypt.Encrypter
 	preprocessor   Preprocessor
+	auditService   audit.Service
 }
 
 func NewController(
@@ -46,6 +50,7 @@ func NewController(
 	webhookService *webhook.Service,
 	encrypter encrypt.Encrypter,
 	preprocessor Preprocessor,
+	auditService audit.Service,
 ) *Controller {
 	return &Controller{
 		authorizer:     authorizer,
@@ -54,6 +59,7 @@ func NewController(
 		webhookService: webhookService,
 		encrypter:      encrypter,
 		preprocessor:   preprocessor,
+		auditService:   auditService,
 	}
 }
 
@@ -93,3 +99,24 @@ func (c *Controller) getSpaceCheckAccess(
 ) (*types.Space, error) {
 	return space.GetSpaceCheckAuth(ctx, c.spaceCache, c.authorizer, session, spaceRef, permission)
 }
+
+// auditWebhook writes the audit log entry for a webhook operation.
+func (c *Controller) auditWebhook(
+	ctx context.Context,
+	session *auth.Session,
+	hook *types.Webhook,
+	action audit.Action,
+	spacePath string,
+	options ...audit.Option,
+) {
+	err := c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeWebhook, hook.Identifier),
+		action,
+		spacePath,
+		options...,
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for %s webhook operation: %s", action, err)
+	}
+}
@@ -19,6 +19,8 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -48,5 +50,8 @@ func (c *Controller) CreateRepo(
 		return nil, fmt.Errorf("failed create webhook: %w", err)
 	}
 
+	c.auditWebhook(ctx, session, hook, audit.ActionCreated, paths.Parent(repo.Path),
+		audit.WithNewObject(audit.NewWebhookObject(hook)))
+
 	return hook, nil
 }
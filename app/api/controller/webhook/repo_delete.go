@@ -19,6 +19,8 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -34,8 +36,21 @@ func (c *Controller) DeleteRepo(
 		return fmt.Errorf("failed to acquire access to the repo: %w", err)
 	}
 
-	return c.webhookService.Delete(
+	hook, err := c.webhookService.Find(ctx, repo.ID, enum.WebhookParentRepo, webhookIdentifier)
+	if err != nil {
+		return err
+	}
+
+	err = c.webhookService.Delete(
 		ctx, repo.ID, enum.WebhookParentRepo, webhookIdentifier,
 		c.preprocessor.IsInternalCall(session.Principal.Type),
 	)
+	if err != nil {
+		return err
+	}
+
+	c.auditWebhook(ctx, session, hook, audit.ActionDeleted, paths.Parent(repo.Path),
+		audit.WithOldObject(audit.NewWebhookObject(hook)))
+
+	return nil
 }
@@ -19,6 +19,8 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -42,5 +44,22 @@ func (c *Controller) UpdateRepo(
 		return nil, fmt.Errorf("failed to preprocess update input: %w", err)
 	}
 
-	return c.webhookService.Update(ctx, repo.ID, enum.WebhookParentRepo, webhookIdentifier, typ, in)
+	oldHook, err := c.webhookService.Find(ctx, repo.ID, enum.WebhookParentRepo, webhookIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	hook, err := c.webhookService.Update(ctx, repo.ID, enum.WebhookParentRepo, webhookIdentifier, typ, in)
+	if err != nil {
+		return nil, err
+	}
+
+	newHook := audit.NewWebhookObject(hook)
+	newHook.URLUpdated = hook.URL != oldHook.URL
+
+	c.auditWebhook(ctx, session, hook, audit.ActionUpdated, paths.Parent(repo.Path),
+		audit.WithOldObject(audit.NewWebhookObject(oldHook)),
+		audit.WithNewObject(newHook))
+
+	return hook, nil
 }
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -46,5 +47,8 @@ func (c *Controller) CreateSpace(
 		return nil, fmt.Errorf("failed to create webhook: %w", err)
 	}
 
+	c.auditWebhook(ctx, session, hook, audit.ActionCreated, space.Path,
+		audit.WithNewObject(audit.NewWebhookObject(hook)))
+
 	return hook, nil
 }
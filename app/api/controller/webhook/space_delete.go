@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -34,8 +35,21 @@ func (c *Controller) DeleteSpace(
 		return fmt.Errorf("failed to acquire access to space: %w", err)
 	}
 
-	return c.webhookService.Delete(
+	hook, err := c.webhookService.Find(ctx, space.ID, enum.WebhookParentSpace, webhookIdentifier)
+	if err != nil {
+		return err
+	}
+
+	err = c.webhookService.Delete(
 		ctx, space.ID, enum.WebhookParentSpace, webhookIdentifier,
 		c.preprocessor.IsInternalCall(session.Principal.Type),
 	)
+	if err != nil {
+		return err
+	}
+
+	c.auditWebhook(ctx, session, hook, audit.ActionDeleted, space.Path,
+		audit.WithOldObject(audit.NewWebhookObject(hook)))
+
+	return nil
 }
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListFailedExecutionsSpace returns the failed executions of all webhooks in the space, its sub-spaces
+// and their repositories, that won't be redelivered automatically.
+func (c *Controller) ListFailedExecutionsSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	filter *types.WebhookFailedExecutionFilter,
+) ([]*types.WebhookFailedExecution, int64, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	if filter.Trigger != "" {
+		if _, ok := filter.Trigger.Sanitize(); !ok {
+			return nil, 0, check.NewValidationErrorf("The provided webhook trigger '%s' is invalid.", filter.Trigger)
+		}
+	}
+
+	return c.webhookService.ListFailedExecutions(ctx, space.ID, filter)
+}
+
+// RetriggerFailedExecutionsSpace retriggers failed executions of webhooks in the space, its sub-spaces
+// and their repositories.
+func (c *Controller) RetriggerFailedExecutionsSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *types.WebhookRetriggerExecutionsInput,
+) ([]*types.WebhookExecution, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	return c.webhookService.RetriggerFailedExecutions(ctx, space.ID, in.ExecutionIDs)
+}
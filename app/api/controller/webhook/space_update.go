@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -42,7 +43,22 @@ func (c *Controller) UpdateSpace(
 		return nil, fmt.Errorf("failed to preprocess update input: %w", err)
 	}
 
-	return c.webhookService.Update(
-		ctx, space.ID, enum.WebhookParentSpace, webhookIdentifier, typ, in,
-	)
+	oldHook, err := c.webhookService.Find(ctx, space.ID, enum.WebhookParentSpace, webhookIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	hook, err := c.webhookService.Update(ctx, space.ID, enum.WebhookParentSpace, webhookIdentifier, typ, in)
+	if err != nil {
+		return nil, err
+	}
+
+	newHook := audit.NewWebhookObject(hook)
+	newHook.URLUpdated = hook.URL != oldHook.URL
+
+	c.auditWebhook(ctx, session, hook, audit.ActionUpdated, space.Path,
+		audit.WithOldObject(audit.NewWebhookObject(oldHook)),
+		audit.WithNewObject(newHook))
+
+	return hook, nil
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/encrypt"
 
 	"github.com/google/wire"
@@ -31,10 +32,10 @@ var WireSet = wire.NewSet(
 func ProvideController(authorizer authz.Authorizer,
 	spaceCache refcache.SpaceCache, repoFinder refcache.RepoFinder,
 	webhookService *webhook.Service, encrypter encrypt.Encrypter,
-	preprocessor Preprocessor,
+	preprocessor Preprocessor, auditService audit.Service,
 ) *Controller {
 	return NewController(
-		authorizer, spaceCache, repoFinder, webhookService, encrypter, preprocessor)
+		authorizer, spaceCache, repoFinder, webhookService, encrypter, preprocessor, auditService)
 }
 
 func ProvidePreprocessor() Preprocessor {
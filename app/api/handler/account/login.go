@@ -18,13 +18,14 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/system"
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/app/api/render"
 )
 
 // HandleLogin returns an http.HandlerFunc that authenticates
 // the user and returns an authentication token on success.
-func HandleLogin(userCtrl *user.Controller, cookieName string) http.HandlerFunc {
+func HandleLogin(userCtrl *user.Controller, sysCtrl *system.Controller, cookieName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
@@ -35,7 +36,7 @@ func HandleLogin(userCtrl *user.Controller, cookieName string) http.HandlerFunc
 			return
 		}
 
-		tokenResponse, err := userCtrl.Login(ctx, in)
+		tokenResponse, err := userCtrl.Login(ctx, sysCtrl, in)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -0,0 +1,125 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	oidcLoginCookieName   = "oidc_login"
+	oidcLoginCookieMaxAge = 10 * time.Minute
+)
+
+// oidcLoginState is the data of a started single sign-on, kept in a cookie until the identity provider
+// redirects the user back.
+type oidcLoginState struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+// HandleLoginOIDC returns an http.HandlerFunc that starts the single sign-on
+// by redirecting the user to the identity provider.
+func HandleLoginOIDC(userCtrl *user.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		authRequest, err := userCtrl.OIDCLoginStart(ctx)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		raw, err := json.Marshal(oidcLoginState{
+			State:    authRequest.State,
+			Nonce:    authRequest.Nonce,
+			Verifier: authRequest.Verifier,
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		cookie := newOIDCLoginCookie(r)
+		cookie.Value = base64.RawURLEncoding.EncodeToString(raw)
+		cookie.MaxAge = int(oidcLoginCookieMaxAge.Seconds())
+		http.SetCookie(w, cookie)
+
+		http.Redirect(w, r, authRequest.URL, http.StatusFound)
+	}
+}
+
+// HandleLoginOIDCCallback returns an http.HandlerFunc that completes the single sign-on
+// and redirects the user to the UI on success.
+func HandleLoginOIDCCallback(userCtrl *user.Controller, config *types.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		in := &user.OIDCLoginCallbackInput{
+			Code:  r.URL.Query().Get("code"),
+			State: r.URL.Query().Get("state"),
+			Error: r.URL.Query().Get("error"),
+		}
+
+		// a missing or malformed cookie leaves the expected state empty, which fails the login.
+		if cookie, err := r.Cookie(oidcLoginCookieName); err == nil {
+			state := oidcLoginState{}
+			if raw, err := base64.RawURLEncoding.DecodeString(cookie.Value); err == nil {
+				_ = json.Unmarshal(raw, &state)
+			}
+
+			in.ExpectedState = state.State
+			in.Nonce = state.Nonce
+			in.Verifier = state.Verifier
+		}
+
+		// the login state can be used only once.
+		cookie := newOIDCLoginCookie(r)
+		cookie.MaxAge = -1
+		http.SetCookie(w, cookie)
+
+		tokenResponse, err := userCtrl.OIDCLoginCallback(ctx, in)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if config.Token.CookieName != "" {
+			includeTokenCookie(r, w, tokenResponse, config.Token.CookieName)
+		}
+
+		http.Redirect(w, r, config.URL.UI, http.StatusFound)
+	}
+}
+
+func newOIDCLoginCookie(r *http.Request) *http.Cookie {
+	return &http.Cookie{
+		Name: oidcLoginCookieName,
+		// the cookie has to be sent on the redirect from the identity provider, which is a cross-site navigation.
+		SameSite: http.SameSiteLaxMode,
+		HttpOnly: true,
+		Path:     "/",
+		Domain:   r.URL.Hostname(),
+		Secure:   r.URL.Scheme == "https",
+	}
+}
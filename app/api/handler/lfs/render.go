@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/url"
+
+	"github.com/rs/zerolog/log"
+)
+
+// mediaType is the content type git LFS clients expect for API responses.
+const mediaType = "application/vnd.git-lfs+json"
+
+func renderLFS(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", mediaType)
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Err(err).Msgf("Failed to write git LFS json encoding to response body.")
+	}
+}
+
+// renderBasicAuth renders a response that indicates that the client (git LFS) requires basic authentication.
+func renderBasicAuth(ctx context.Context, w http.ResponseWriter, urlProvider url.Provider) {
+	w.Header().Add("LFS-Authenticate", fmt.Sprintf(`Basic realm="%s"`, urlProvider.GetAPIHostname(ctx)))
+	w.Header().Add("WWW-Authenticate", fmt.Sprintf(`Basic realm="%s"`, urlProvider.GetAPIHostname(ctx)))
+	w.WriteHeader(http.StatusUnauthorized)
+}
@@ -32,6 +32,9 @@ type ConfigOutput struct {
 	SSHEnabled                    bool `json:"ssh_enabled"`
 	GitspaceEnabled               bool `json:"gitspace_enabled"`
 	ArtifactRegistryEnabled       bool `json:"artifact_registry_enabled"`
+	LocalLoginAllowed             bool `json:"local_login_allowed"`
+	OIDCLoginEnabled              bool `json:"oidc_login_enabled"`
+	LDAPLoginEnabled              bool `json:"ldap_login_enabled"`
 	UI                            UI   `json:"ui"`
 }
 
@@ -47,12 +50,21 @@ func HandleGetConfig(config *types.Config, sysCtrl *system.Controller) http.Hand
 			return
 		}
 
+		localLoginAllowed, err := sysCtrl.IsLocalLoginAllowed(ctx)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
 		render.JSON(w, http.StatusOK, ConfigOutput{
 			SSHEnabled:                    config.SSH.Enable,
 			UserSignupAllowed:             userSignupAllowed,
 			PublicResourceCreationEnabled: config.PublicResourceCreationEnabled,
 			GitspaceEnabled:               config.Gitspace.Enable,
 			ArtifactRegistryEnabled:       config.Registry.Enable,
+			LocalLoginAllowed:             localLoginAllowed,
+			OIDCLoginEnabled:              config.OIDC.Enabled,
+			LDAPLoginEnabled:              config.LDAP.Enabled,
 			UI:                            UI{ShowPlugin: config.UI.ShowPlugin},
 		})
 	}
@@ -64,16 +64,16 @@ func RestrictTo(pType enum.PrincipalType) func(http.Handler) http.Handler {
 /*
  * RestrictToAdmin returns an http.HandlerFunc middleware that ensures the principal
  * is an admin. In case there is no authenticated principal,
- * or the principal isn't an admin, an error is rendered.
+ * the principal isn't an admin or the session is restricted (e.g. by token scopes), an error is rendered.
  */
 func RestrictToAdmin() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
 
-			p, ok := request.PrincipalFrom(ctx)
-			if !ok || !p.Admin {
-				log.Ctx(ctx).Debug().Msg("No principal found or the principal is no admin")
+			session, ok := request.AuthSessionFrom(ctx)
+			if !ok || !session.IsSystemAdmin() {
+				log.Ctx(ctx).Debug().Msg("No session found or the session has no admin access")
 
 				render.Forbidden(ctx, w)
 				return
@@ -51,6 +51,13 @@ type registerRequest struct {
 	user.RegisterInput
 }
 
+// request to complete the OpenID Connect login.
+type loginOIDCCallbackRequest struct {
+	Code  string `query:"code"`
+	State string `query:"state"`
+	Error string `query:"error"`
+}
+
 // helper function that constructs the openapi specification
 // for the account registration and login endpoints.
 func buildAccount(reflector *openapi3.Reflector) {
@@ -84,4 +91,23 @@ func buildAccount(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/register", onRegister)
+
+	opLoginOIDC := openapi3.Operation{}
+	opLoginOIDC.WithTags("account")
+	opLoginOIDC.WithMapOfAnything(map[string]interface{}{"operationId": "opLoginOIDC"})
+	_ = reflector.SetRequest(&opLoginOIDC, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opLoginOIDC, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&opLoginOIDC, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opLoginOIDC, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/login/oidc", opLoginOIDC)
+
+	opLoginOIDCCallback := openapi3.Operation{}
+	opLoginOIDCCallback.WithTags("account")
+	opLoginOIDCCallback.WithMapOfAnything(map[string]interface{}{"operationId": "opLoginOIDCCallback"})
+	_ = reflector.SetRequest(&opLoginOIDCCallback, new(loginOIDCCallbackRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opLoginOIDCCallback, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&opLoginOIDCCallback, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opLoginOIDCCallback, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLoginOIDCCallback, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/login/oidc/callback", opLoginOIDCCallback)
 }
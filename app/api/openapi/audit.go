@@ -0,0 +1,175 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+var queryParameterAuditAction = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAuditAction,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("List of actions used to filter the audit events."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterAuditResourceType = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAuditResourceType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("List of resource types used to filter the audit events."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterAuditPrincipalUID = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAuditPrincipalUID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The UID of the principal that performed the audited actions."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterAuditFrom = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAuditFrom,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The result should contain only events that happened since this timestamp (unix millis)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Minimum: ptr.Float64(0),
+			},
+		},
+	},
+}
+
+var queryParameterAuditTo = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAuditTo,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The result should contain only events that happened before this timestamp (unix millis)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Minimum: ptr.Float64(0),
+			},
+		},
+	},
+}
+
+//nolint:funlen
+func auditOperations(reflector *openapi3.Reflector) {
+	opListSpace := openapi3.Operation{}
+	opListSpace.WithTags("space")
+	opListSpace.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceAuditEvents"})
+	opListSpace.WithParameters(
+		QueryParameterRecursive, queryParameterAuditAction, queryParameterAuditResourceType,
+		queryParameterAuditPrincipalUID, queryParameterAuditFrom, queryParameterAuditTo,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListSpace, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListSpace, []types.AuditEvent{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListSpace, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListSpace, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListSpace, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListSpace, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListSpace, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/audit-events", opListSpace)
+
+	opExportSpace := openapi3.Operation{}
+	opExportSpace.WithTags("space")
+	opExportSpace.WithMapOfAnything(map[string]interface{}{"operationId": "exportSpaceAuditEvents"})
+	opExportSpace.WithParameters(
+		QueryParameterRecursive, queryParameterAuditAction, queryParameterAuditResourceType,
+		queryParameterAuditPrincipalUID, queryParameterAuditFrom, queryParameterAuditTo)
+	_ = reflector.SetRequest(&opExportSpace, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opExportSpace, http.StatusOK, "application/x-ndjson")
+	_ = reflector.SetJSONResponse(&opExportSpace, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opExportSpace, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opExportSpace, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opExportSpace, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opExportSpace, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/audit-events/export", opExportSpace)
+
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListAuditEvents"})
+	opList.WithParameters(
+		queryParameterAuditAction, queryParameterAuditResourceType,
+		queryParameterAuditPrincipalUID, queryParameterAuditFrom, queryParameterAuditTo,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, []types.AuditEvent{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/audit-events", opList)
+
+	opExport := openapi3.Operation{}
+	opExport.WithTags("admin")
+	opExport.WithMapOfAnything(map[string]interface{}{"operationId": "adminExportAuditEvents"})
+	opExport.WithParameters(
+		queryParameterAuditAction, queryParameterAuditResourceType,
+		queryParameterAuditPrincipalUID, queryParameterAuditFrom, queryParameterAuditTo)
+	_ = reflector.SetRequest(&opExport, nil, http.MethodGet)
+	_ = reflector.SetStringResponse(&opExport, http.StatusOK, "application/x-ndjson")
+	_ = reflector.SetJSONResponse(&opExport, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opExport, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opExport, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opExport, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/audit-events/export", opExport)
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+//nolint:funlen
+func notificationPreferenceOperations(reflector *openapi3.Reflector) {
+	opFind := openapi3.Operation{}
+	opFind.WithTags("user")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "getNotificationPreferences"})
+	_ = reflector.SetJSONResponse(&opFind, new(types.NotificationPreferences), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notifications", opFind)
+
+	opUpdateSettings := openapi3.Operation{}
+	opUpdateSettings.WithTags("user")
+	opUpdateSettings.WithMapOfAnything(map[string]interface{}{"operationId": "updateNotificationSettings"})
+	_ = reflector.SetRequest(&opUpdateSettings, new(types.NotificationSettingsInput), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateSettings, new(types.NotificationSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateSettings, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateSettings, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateSettings, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user/notifications/settings", opUpdateSettings)
+
+	opSetSpace := openapi3.Operation{}
+	opSetSpace.WithTags("space")
+	opSetSpace.WithMapOfAnything(map[string]interface{}{"operationId": "setSpaceNotificationSubscription"})
+	_ = reflector.SetRequest(&opSetSpace, struct {
+		spaceRequest
+		types.NotificationSubscriptionInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opSetSpace, new(types.NotificationSubscription), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSetSpace, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSetSpace, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSetSpace, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSetSpace, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSetSpace, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/notification-subscription", opSetSpace)
+
+	opDeleteSpace := openapi3.Operation{}
+	opDeleteSpace.WithTags("space")
+	opDeleteSpace.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpaceNotificationSubscription"})
+	_ = reflector.SetRequest(&opDeleteSpace, new(spaceRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteSpace, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteSpace, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteSpace, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteSpace, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteSpace, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/notification-subscription", opDeleteSpace)
+
+	opSetRepo := openapi3.Operation{}
+	opSetRepo.WithTags("repository")
+	opSetRepo.WithMapOfAnything(map[string]interface{}{"operationId": "setRepoNotificationSubscription"})
+	_ = reflector.SetRequest(&opSetRepo, struct {
+		repoRequest
+		types.NotificationSubscriptionInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opSetRepo, new(types.NotificationSubscription), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSetRepo, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSetRepo, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSetRepo, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSetRepo, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSetRepo, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/notification-subscription", opSetRepo)
+
+	opDeleteRepo := openapi3.Operation{}
+	opDeleteRepo.WithTags("repository")
+	opDeleteRepo.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepoNotificationSubscription"})
+	_ = reflector.SetRequest(&opDeleteRepo, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteRepo, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteRepo, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteRepo, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteRepo, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteRepo, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/notification-subscription", opDeleteRepo)
+
+	opSetPullReq := openapi3.Operation{}
+	opSetPullReq.WithTags("pullreq")
+	opSetPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "setPullReqNotificationSubscription"})
+	_ = reflector.SetRequest(&opSetPullReq, struct {
+		pullReqRequest
+		types.NotificationSubscriptionInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opSetPullReq, new(types.NotificationSubscription), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSetPullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSetPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSetPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSetPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSetPullReq, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/notification-subscription", opSetPullReq)
+
+	opDeletePullReq := openapi3.Operation{}
+	opDeletePullReq.WithTags("pullreq")
+	opDeletePullReq.WithMapOfAnything(map[string]interface{}{"operationId": "deletePullReqNotificationSubscription"})
+	_ = reflector.SetRequest(&opDeletePullReq, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeletePullReq, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeletePullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeletePullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeletePullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeletePullReq, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/notification-subscription", opDeletePullReq)
+}
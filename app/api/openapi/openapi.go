@@ -73,6 +73,9 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	uploadOperations(&reflector)
 	gitspaceOperations(&reflector)
 	infraProviderOperations(&reflector)
+	auditOperations(&reflector)
+	notificationChannelOperations(&reflector)
+	notificationPreferenceOperations(&reflector)
 
 	//
 	// define security scheme
@@ -49,6 +49,22 @@ type logRequest struct {
 	StepNum  string `path:"step_number"`
 }
 
+type artifactRequest struct {
+	executionRequest
+	Name string `path:"artifact_name"`
+}
+
+type uploadArtifactRequest struct {
+	artifactRequest
+	Stage         int64 `query:"stage" description:"The number of the stage that published the artifact."`
+	RetentionDays int64 `query:"retention_days" description:"The number of days the artifact is kept."`
+}
+
+type cacheRequest struct {
+	pipelineRequest
+	Key string `path:"cache_key"`
+}
+
 type createExecutionRequest struct {
 	pipelineRequest
 }
@@ -343,4 +359,118 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/logs/{stage_number}/{step_number}",
 		logView,
 	)
+
+	artifactList := openapi3.Operation{}
+	artifactList.WithTags("pipeline")
+	artifactList.WithMapOfAnything(map[string]interface{}{"operationId": "listArtifacts"})
+	_ = reflector.SetRequest(&artifactList, new(executionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&artifactList, []types.Artifact{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts", artifactList)
+
+	artifactUpload := openapi3.Operation{}
+	artifactUpload.WithTags("pipeline")
+	artifactUpload.WithMapOfAnything(map[string]interface{}{"operationId": "uploadArtifact"})
+	artifactUpload.WithRequestBody(openapi3.RequestBodyOrRef{
+		RequestBody: &openapi3.RequestBody{
+			Description: ptr.String("Content of the artifact"),
+			Content: map[string]openapi3.MediaType{
+				"application/octet-stream": {Schema: &openapi3.SchemaOrRef{}},
+			},
+			Required: ptr.Bool(true),
+		},
+	})
+	_ = reflector.SetRequest(&artifactUpload, new(uploadArtifactRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(types.Artifact), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusRequestEntityTooLarge)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts/{artifact_name}",
+		artifactUpload)
+
+	artifactDownload := openapi3.Operation{}
+	artifactDownload.WithTags("pipeline")
+	artifactDownload.WithMapOfAnything(map[string]interface{}{"operationId": "downloadArtifact"})
+	_ = reflector.SetRequest(&artifactDownload, new(artifactRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&artifactDownload, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts/{artifact_name}",
+		artifactDownload)
+
+	artifactDelete := openapi3.Operation{}
+	artifactDelete.WithTags("pipeline")
+	artifactDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteArtifact"})
+	_ = reflector.SetRequest(&artifactDelete, new(artifactRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&artifactDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&artifactDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&artifactDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts/{artifact_name}",
+		artifactDelete)
+
+	cacheUpload := openapi3.Operation{}
+	cacheUpload.WithTags("pipeline")
+	cacheUpload.WithMapOfAnything(map[string]interface{}{"operationId": "uploadPipelineCache"})
+	cacheUpload.WithRequestBody(openapi3.RequestBodyOrRef{
+		RequestBody: &openapi3.RequestBody{
+			Description: ptr.String("Content of the build cache"),
+			Content: map[string]openapi3.MediaType{
+				"application/octet-stream": {Schema: &openapi3.SchemaOrRef{}},
+			},
+			Required: ptr.Bool(true),
+		},
+	})
+	_ = reflector.SetRequest(&cacheUpload, new(cacheRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&cacheUpload, new(types.PipelineCache), http.StatusOK)
+	_ = reflector.SetJSONResponse(&cacheUpload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&cacheUpload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cacheUpload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cacheUpload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cacheUpload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&cacheUpload, new(usererror.Error), http.StatusRequestEntityTooLarge)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/caches/{cache_key}", cacheUpload)
+
+	cacheDownload := openapi3.Operation{}
+	cacheDownload.WithTags("pipeline")
+	cacheDownload.WithMapOfAnything(map[string]interface{}{"operationId": "downloadPipelineCache"})
+	_ = reflector.SetRequest(&cacheDownload, new(cacheRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&cacheDownload, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&cacheDownload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&cacheDownload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cacheDownload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cacheDownload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cacheDownload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/caches/{cache_key}", cacheDownload)
+
+	cacheDelete := openapi3.Operation{}
+	cacheDelete.WithTags("pipeline")
+	cacheDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deletePipelineCache"})
+	_ = reflector.SetRequest(&cacheDelete, new(cacheRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&cacheDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/caches/{cache_key}", cacheDelete)
 }
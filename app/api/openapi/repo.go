@@ -54,6 +54,36 @@ type updateDefaultBranchRequest struct {
 	repo.UpdateDefaultBranchInput
 }
 
+type forkRepoRequest struct {
+	repoRequest
+	repo.ForkInput
+}
+
+type syncForkRequest struct {
+	repoRequest
+	repo.SyncForkInput
+}
+
+type savePullMirrorRequest struct {
+	repoRequest
+	repo.SavePullMirrorInput
+}
+
+type pushMirrorRequest struct {
+	repoRequest
+	Identifier string `path:"push_mirror_identifier"`
+}
+
+type createPushMirrorRequest struct {
+	repoRequest
+	repo.CreatePushMirrorInput
+}
+
+type updatePushMirrorRequest struct {
+	pushMirrorRequest
+	repo.UpdatePushMirrorInput
+}
+
 type moveRepoRequest struct {
 	repoRequest
 	repo.MoveInput
@@ -794,6 +824,155 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opUpdateDefaultBranch, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/default-branch", opUpdateDefaultBranch)
 
+	opFork := openapi3.Operation{}
+	opFork.WithTags("repository")
+	opFork.WithMapOfAnything(map[string]interface{}{"operationId": "forkRepository"})
+	_ = reflector.SetRequest(&opFork, new(forkRepoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opFork, new(repo.RepositoryOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/fork", opFork)
+
+	opSyncFork := openapi3.Operation{}
+	opSyncFork.WithTags("repository")
+	opSyncFork.WithMapOfAnything(map[string]interface{}{"operationId": "syncFork"})
+	_ = reflector.SetRequest(&opSyncFork, new(syncForkRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(repo.SyncForkOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(types.RulesViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/fork/sync", opSyncFork)
+
+	opListForks := openapi3.Operation{}
+	opListForks.WithTags("repository")
+	opListForks.WithMapOfAnything(map[string]interface{}{"operationId": "listForks"})
+	opListForks.WithParameters(queryParameterQueryRepo, queryParameterSortRepo, queryParameterOrder,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListForks, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListForks, []repo.RepositoryOutput{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/forks", opListForks)
+
+	opSavePullMirror := openapi3.Operation{}
+	opSavePullMirror.WithTags("repository")
+	opSavePullMirror.WithMapOfAnything(map[string]interface{}{"operationId": "savePullMirror"})
+	_ = reflector.SetRequest(&opSavePullMirror, new(savePullMirrorRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opSavePullMirror, new(types.PullMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSavePullMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSavePullMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSavePullMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSavePullMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSavePullMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/mirror", opSavePullMirror)
+
+	opDeletePullMirror := openapi3.Operation{}
+	opDeletePullMirror.WithTags("repository")
+	opDeletePullMirror.WithMapOfAnything(map[string]interface{}{"operationId": "deletePullMirror"})
+	_ = reflector.SetRequest(&opDeletePullMirror, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeletePullMirror, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeletePullMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeletePullMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeletePullMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeletePullMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/mirror", opDeletePullMirror)
+
+	opSyncPullMirror := openapi3.Operation{}
+	opSyncPullMirror.WithTags("repository")
+	opSyncPullMirror.WithMapOfAnything(map[string]interface{}{"operationId": "syncPullMirror"})
+	_ = reflector.SetRequest(&opSyncPullMirror, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSyncPullMirror, new(types.PullMirror), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opSyncPullMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSyncPullMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSyncPullMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSyncPullMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSyncPullMirror, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/mirror/sync", opSyncPullMirror)
+
+	opCreatePushMirror := openapi3.Operation{}
+	opCreatePushMirror.WithTags("repository")
+	opCreatePushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "createPushMirror"})
+	_ = reflector.SetRequest(&opCreatePushMirror, new(createPushMirrorRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(types.PushMirror), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/push-mirrors", opCreatePushMirror)
+
+	opListPushMirrors := openapi3.Operation{}
+	opListPushMirrors.WithTags("repository")
+	opListPushMirrors.WithMapOfAnything(map[string]interface{}{"operationId": "listPushMirrors"})
+	_ = reflector.SetRequest(&opListPushMirrors, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, []types.PushMirror{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/push-mirrors", opListPushMirrors)
+
+	opFindPushMirror := openapi3.Operation{}
+	opFindPushMirror.WithTags("repository")
+	opFindPushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "findPushMirror"})
+	_ = reflector.SetRequest(&opFindPushMirror, new(pushMirrorRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(types.PushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_identifier}", opFindPushMirror)
+
+	opUpdatePushMirror := openapi3.Operation{}
+	opUpdatePushMirror.WithTags("repository")
+	opUpdatePushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "updatePushMirror"})
+	_ = reflector.SetRequest(&opUpdatePushMirror, new(updatePushMirrorRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(types.PushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_identifier}", opUpdatePushMirror)
+
+	opDeletePushMirror := openapi3.Operation{}
+	opDeletePushMirror.WithTags("repository")
+	opDeletePushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "deletePushMirror"})
+	_ = reflector.SetRequest(&opDeletePushMirror, new(pushMirrorRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_identifier}", opDeletePushMirror)
+
+	opSyncPushMirror := openapi3.Operation{}
+	opSyncPushMirror.WithTags("repository")
+	opSyncPushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "syncPushMirror"})
+	_ = reflector.SetRequest(&opSyncPushMirror, new(pushMirrorRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSyncPushMirror, new(types.PushMirror), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opSyncPushMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSyncPushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSyncPushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSyncPushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSyncPushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSyncPushMirror, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_identifier}/sync", opSyncPushMirror)
+
 	opDelete := openapi3.Operation{}
 	opDelete.WithTags("repository")
 	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepository"})
@@ -1425,4 +1604,42 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opSquashBranch, new(types.MergeViolations), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/squash", opSquashBranch)
+
+	opRevert := openapi3.Operation{}
+	opRevert.WithTags("repository")
+	opRevert.WithMapOfAnything(
+		map[string]interface{}{"operationId": "revert"})
+	_ = reflector.SetRequest(&opRevert, &struct {
+		repoRequest
+		repo.RevertInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRevert, new(types.RevertResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opRevert, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/revert", opRevert)
+
+	opCherryPick := openapi3.Operation{}
+	opCherryPick.WithTags("repository")
+	opCherryPick.WithMapOfAnything(
+		map[string]interface{}{"operationId": "cherryPick"})
+	_ = reflector.SetRequest(&opCherryPick, &struct {
+		repoRequest
+		repo.CherryPickInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(types.CherryPickResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/cherry-pick", opCherryPick)
 }
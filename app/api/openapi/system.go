@@ -17,8 +17,10 @@ package openapi
 import (
 	"net/http"
 
+	systemctrl "github.com/harness/gitness/app/api/controller/system"
 	"github.com/harness/gitness/app/api/handler/system"
 	"github.com/harness/gitness/app/api/usererror"
+	systemsvc "github.com/harness/gitness/app/services/system"
 
 	"github.com/swaggest/openapi-go/openapi3"
 )
@@ -34,4 +36,25 @@ func buildSystem(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetConfig, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opGetConfig, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/system/config", opGetConfig)
+
+	opFindSettings := openapi3.Operation{}
+	opFindSettings.WithTags("admin")
+	opFindSettings.WithMapOfAnything(map[string]interface{}{"operationId": "adminFindSystemSettings"})
+	_ = reflector.SetRequest(&opFindSettings, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindSettings, new(systemsvc.Settings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindSettings, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindSettings, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/settings", opFindSettings)
+
+	opUpdateSettings := openapi3.Operation{}
+	opUpdateSettings.WithTags("admin")
+	opUpdateSettings.WithMapOfAnything(map[string]interface{}{"operationId": "adminUpdateSystemSettings"})
+	_ = reflector.SetRequest(&opUpdateSettings, new(systemctrl.SettingsUpdateInput), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateSettings, new(systemsvc.Settings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateSettings, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateSettings, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateSettings, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/admin/settings", opUpdateSettings)
 }
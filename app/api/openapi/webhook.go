@@ -60,6 +60,16 @@ type repoWebhookRequest struct {
 	ID int64 `path:"webhook_identifier"`
 }
 
+type testSpaceWebhookRequest struct {
+	spaceWebhookRequest
+	types.WebhookTestInput
+}
+
+type testRepoWebhookRequest struct {
+	repoWebhookRequest
+	types.WebhookTestInput
+}
+
 type getSpaceWebhookRequest struct {
 	spaceWebhookRequest
 }
@@ -112,6 +122,11 @@ type getRepoWebhookExecutionRequest struct {
 	repoWebhookExecutionRequest
 }
 
+type retriggerSpaceFailedWebhookExecutionsRequest struct {
+	spaceRequest
+	types.WebhookRetriggerExecutionsInput
+}
+
 var queryParameterSortWebhook = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -150,6 +165,20 @@ var queryParameterQueryWebhook = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterTriggerWebhookExecution = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamWebhookTrigger,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The trigger by which the webhook executions are filtered."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 //nolint:funlen
 func webhookOperations(reflector *openapi3.Reflector) {
 	// space
@@ -259,6 +288,56 @@ func webhookOperations(reflector *openapi3.Reflector) {
 		retriggerSpaceWebhookExecution,
 	)
 
+	testSpaceWebhook := openapi3.Operation{}
+	testSpaceWebhook.WithTags("webhook")
+	testSpaceWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "testSpaceWebhook"})
+	_ = reflector.SetRequest(&testSpaceWebhook, new(testSpaceWebhookRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&testSpaceWebhook, new(types.WebhookExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&testSpaceWebhook, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&testSpaceWebhook, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&testSpaceWebhook, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&testSpaceWebhook, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&testSpaceWebhook, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/webhooks/{webhook_identifier}/test", testSpaceWebhook)
+
+	listSpaceFailedWebhookExecutions := openapi3.Operation{}
+	listSpaceFailedWebhookExecutions.WithTags("webhook")
+	listSpaceFailedWebhookExecutions.WithMapOfAnything(
+		map[string]interface{}{"operationId": "listSpaceFailedWebhookExecutions"},
+	)
+	listSpaceFailedWebhookExecutions.WithParameters(QueryParameterPage, QueryParameterLimit,
+		queryParameterTriggerWebhookExecution)
+	_ = reflector.SetRequest(&listSpaceFailedWebhookExecutions, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listSpaceFailedWebhookExecutions,
+		new([]types.WebhookFailedExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listSpaceFailedWebhookExecutions, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listSpaceFailedWebhookExecutions, new(usererror.Error),
+		http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listSpaceFailedWebhookExecutions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listSpaceFailedWebhookExecutions, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/webhook-executions/failed", listSpaceFailedWebhookExecutions)
+
+	retriggerSpaceFailedWebhookExecutions := openapi3.Operation{}
+	retriggerSpaceFailedWebhookExecutions.WithTags("webhook")
+	retriggerSpaceFailedWebhookExecutions.WithMapOfAnything(
+		map[string]interface{}{"operationId": "retriggerSpaceFailedWebhookExecutions"},
+	)
+	_ = reflector.SetRequest(&retriggerSpaceFailedWebhookExecutions,
+		new(retriggerSpaceFailedWebhookExecutionsRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&retriggerSpaceFailedWebhookExecutions,
+		new([]types.WebhookExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&retriggerSpaceFailedWebhookExecutions, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&retriggerSpaceFailedWebhookExecutions, new(usererror.Error),
+		http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&retriggerSpaceFailedWebhookExecutions, new(usererror.Error),
+		http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&retriggerSpaceFailedWebhookExecutions, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&retriggerSpaceFailedWebhookExecutions, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/webhook-executions/failed/retrigger", retriggerSpaceFailedWebhookExecutions)
+
 	// repo
 
 	createRepoWebhook := openapi3.Operation{}
@@ -358,4 +437,17 @@ func webhookOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/webhooks/{webhook_identifier}/executions/{webhook_execution_id}/retrigger",
 		retriggerRepoWebhookExecution)
+
+	testRepoWebhook := openapi3.Operation{}
+	testRepoWebhook.WithTags("webhook")
+	testRepoWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "testRepoWebhook"})
+	_ = reflector.SetRequest(&testRepoWebhook, new(testRepoWebhookRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&testRepoWebhook, new(types.WebhookExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&testRepoWebhook, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&testRepoWebhook, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&testRepoWebhook, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&testRepoWebhook, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&testRepoWebhook, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/webhooks/{webhook_identifier}/test", testRepoWebhook)
 }
@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	QueryParamAuditAction       = "action"
+	QueryParamAuditResourceType = "resource_type"
+	QueryParamAuditPrincipalUID = "principal_uid"
+	QueryParamAuditFrom         = "from"
+	QueryParamAuditTo           = "to"
+)
+
+// ParseAuditEventFilter extracts the audit event filter from the url.
+func ParseAuditEventFilter(r *http.Request) (*types.AuditEventFilter, error) {
+	recursive, err := ParseRecursiveFromQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditFrom, 0)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing from: %w", err)
+	}
+
+	to, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditTo, 0)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing to: %w", err)
+	}
+
+	actions, _ := QueryParamList(r, QueryParamAuditAction)
+	resourceTypes, _ := QueryParamList(r, QueryParamAuditResourceType)
+
+	return &types.AuditEventFilter{
+		Page:          ParsePage(r),
+		Size:          ParseLimit(r),
+		Recursive:     recursive,
+		Actions:       actions,
+		ResourceTypes: resourceTypes,
+		PrincipalUID:  QueryParamOrDefault(r, QueryParamAuditPrincipalUID, ""),
+		From:          from,
+		To:            to,
+	}, nil
+}
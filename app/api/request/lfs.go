@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+const (
+	PathParamLFSObjectID   = "lfs_object_id"
+	PathParamLFSObjectSize = "lfs_object_size"
+)
+
+func GetLFSObjectIDFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamLFSObjectID)
+}
+
+// GetLFSObjectSizeFromPath extracts the size of an LFS object from the request path.
+// Unlike most other integer path parameters, zero is a valid value.
+func GetLFSObjectSizeFromPath(r *http.Request) (int64, error) {
+	rawValue, err := PathParamOrError(r, PathParamLFSObjectSize)
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := strconv.ParseInt(rawValue, 10, 64)
+	if err != nil || size < 0 {
+		return 0, usererror.BadRequestf("Parameter '%s' must be a non-negative integer.", PathParamLFSObjectSize)
+	}
+
+	return size, nil
+}
@@ -29,6 +29,11 @@ const (
 	QueryParamLatest            = "latest"
 	QueryParamLastExecutions    = "last_executions"
 	QueryParamBranch            = "branch"
+
+	PathParamArtifactName   = "artifact_name"
+	PathParamCacheKey       = "cache_key"
+	QueryParamStage         = "stage"
+	QueryParamRetentionDays = "retention_days"
 )
 
 func GetPipelineIdentifierFromPath(r *http.Request) (string, error) {
@@ -56,6 +61,24 @@ func GetLatestFromPath(r *http.Request) bool {
 	return l
 }
 
+func GetArtifactNameFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamArtifactName)
+}
+
+func GetCacheKeyFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamCacheKey)
+}
+
+// GetArtifactStageFromQuery returns the stage number provided for an artifact upload, zero if not provided.
+func GetArtifactStageFromQuery(r *http.Request) (int64, error) {
+	return QueryParamAsPositiveInt64OrDefault(r, QueryParamStage, 0)
+}
+
+// GetArtifactRetentionDaysFromQuery returns the artifact retention in days, zero if not provided.
+func GetArtifactRetentionDaysFromQuery(r *http.Request) (int64, error) {
+	return QueryParamAsPositiveInt64OrDefault(r, QueryParamRetentionDays, 0)
+}
+
 func GetTriggerIdentifierFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamTriggerIdentifier)
 }
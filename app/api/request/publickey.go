@@ -24,6 +24,9 @@ import (
 
 const (
 	PathParamPublicKeyIdentifier = "public_key_identifier"
+
+	QueryParamPublicKeyUsage  = "usage"
+	QueryParamPublicKeyScheme = "scheme"
 )
 
 func GetPublicKeyIdentifierFromPath(r *http.Request) (string, error) {
@@ -42,5 +45,51 @@ func ParseListPublicKeyQueryFilterFromRequest(r *http.Request) (types.PublicKeyF
 		ListQueryFilter: ParseListQueryFilterFromRequest(r),
 		Sort:            sort,
 		Order:           ParseOrder(r),
+		Usages:          parsePublicKeyUsages(r),
+		Schemes:         parsePublicKeySchemes(r),
 	}, nil
 }
+
+// parsePublicKeyUsages extracts the public key usages from the url.
+func parsePublicKeyUsages(r *http.Request) []enum.PublicKeyUsage {
+	strUsages := r.URL.Query()[QueryParamPublicKeyUsage]
+	m := make(map[enum.PublicKeyUsage]struct{}) // use map to eliminate duplicates
+	for _, s := range strUsages {
+		if usage, ok := enum.PublicKeyUsage(s).Sanitize(); ok {
+			m[usage] = struct{}{}
+		}
+	}
+
+	if len(m) == 0 {
+		return nil
+	}
+
+	usages := make([]enum.PublicKeyUsage, 0, len(m))
+	for u := range m {
+		usages = append(usages, u)
+	}
+
+	return usages
+}
+
+// parsePublicKeySchemes extracts the public key schemes from the url.
+func parsePublicKeySchemes(r *http.Request) []enum.PublicKeyScheme {
+	strSchemes := r.URL.Query()[QueryParamPublicKeyScheme]
+	m := make(map[enum.PublicKeyScheme]struct{}) // use map to eliminate duplicates
+	for _, s := range strSchemes {
+		if scheme, ok := enum.PublicKeyScheme(s).Sanitize(); ok {
+			m[scheme] = struct{}{}
+		}
+	}
+
+	if len(m) == 0 {
+		return nil
+	}
+
+	schemes := make([]enum.PublicKeyScheme, 0, len(m))
+	for s := range m {
+		schemes = append(schemes, s)
+	}
+
+	return schemes
+}
@@ -230,6 +230,13 @@ func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 	}, nil
 }
 
+// ParseMergeQueueFilter extracts the merge queue query parameters from the url.
+func ParseMergeQueueFilter(r *http.Request) *types.MergeQueueFilter {
+	return &types.MergeQueueFilter{
+		TargetBranch: r.URL.Query().Get(QueryParamTargetBranch),
+	}
+}
+
 // ParsePullReqActivityFilter extracts the pull request activity query parameter from the url.
 func ParsePullReqActivityFilter(r *http.Request) (*types.PullReqActivityFilter, error) {
 	// after is optional, skipped if set to 0
@@ -24,6 +24,8 @@ import (
 const (
 	PathParamWebhookIdentifier  = "webhook_identifier"
 	PathParamWebhookExecutionID = "webhook_execution_id"
+
+	QueryParamWebhookTrigger = "trigger"
 )
 
 func GetWebhookIdentifierFromPath(r *http.Request) (string, error) {
@@ -53,6 +55,15 @@ func ParseWebhookExecutionFilter(r *http.Request) *types.WebhookExecutionFilter
 	}
 }
 
+// ParseWebhookFailedExecutionFilter extracts the failed WebhookExecution query parameters for listing from the url.
+func ParseWebhookFailedExecutionFilter(r *http.Request) *types.WebhookFailedExecutionFilter {
+	return &types.WebhookFailedExecutionFilter{
+		Page:    ParsePage(r),
+		Size:    ParseLimit(r),
+		Trigger: enum.WebhookTrigger(r.URL.Query().Get(QueryParamWebhookTrigger)),
+	}
+}
+
 // ParseSortWebhook extracts the webhook sort parameter from the url.
 func ParseSortWebhook(r *http.Request) enum.WebhookAttr {
 	return enum.ParseWebhookAttr(
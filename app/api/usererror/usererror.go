@@ -70,6 +70,10 @@ var (
 	// ErrPullReqRefsCantBeModified is returned if a user tries to tinker with a pull request git ref.
 	ErrPullReqRefsCantBeModified = New(http.StatusBadRequest, "The pull request git refs can't be modified")
 
+	// ErrPullMirrorRefsCantBeModified is returned if a user tries to modify a branch or a tag of a pull mirror.
+	ErrPullMirrorRefsCantBeModified = New(http.StatusBadRequest,
+		"The branches and tags of a pull mirror can't be modified, they are synced from the remote repository")
+
 	// ErrRequestTooLarge is returned if the request it too large.
 	ErrRequestTooLarge = New(http.StatusRequestEntityTooLarge, "The request is too large")
 
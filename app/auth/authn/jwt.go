@@ -126,6 +126,7 @@ func (a *JWTAuthenticator) metadataFromTokenClaims(
 	return &auth.TokenMetadata{
 		TokenType: tkn.Type,
 		TokenID:   tkn.ID,
+		Scopes:    tkn.Scopes,
 	}, nil
 }
 
@@ -135,6 +136,7 @@ func (a *JWTAuthenticator) metadataFromMembershipClaims(
 	// We could check if space exists - but also okay to fail later (saves db call)
 	return &auth.MembershipMetadata{
 		SpaceID: mbsClaims.SpaceID,
+		RepoID:  mbsClaims.RepoID,
 		Role:    mbsClaims.Role,
 	}
 }
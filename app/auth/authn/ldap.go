@@ -0,0 +1,285 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+var (
+	// ErrLDAPUserNotFound is returned if the user doesn't exist in the directory.
+	ErrLDAPUserNotFound = errors.New("user not found in the directory")
+
+	// ErrLDAPInvalidCredentials is returned if the password of the user is wrong.
+	ErrLDAPInvalidCredentials = errors.New("invalid directory credentials")
+
+	// ErrLDAPUserDisabled is returned if the user is disabled in the directory.
+	ErrLDAPUserDisabled = errors.New("user is disabled in the directory")
+)
+
+// LDAPUser is a user entry of the directory.
+type LDAPUser struct {
+	DN          string
+	UID         string
+	Email       string
+	DisplayName string
+	Disabled    bool
+}
+
+// LDAPGroup is a group entry of the directory.
+type LDAPGroup struct {
+	DN        string
+	Name      string
+	MemberDNs []string
+}
+
+// LDAPDirectory authenticates users against an LDAP directory (e.g. OpenLDAP or Active Directory)
+// and lists its users and groups. Every operation opens a new connection that is bound with
+// the configured service account, so the directory is never accessed anonymously.
+type LDAPDirectory struct {
+	config *types.Config
+}
+
+func NewLDAPDirectory(config *types.Config) (*LDAPDirectory, error) {
+	c := config.LDAP
+	if c.URL == "" {
+		return nil, errors.New("ldap url is required")
+	}
+	if c.UserBaseDN == "" {
+		return nil, errors.New("ldap user base dn is required")
+	}
+	if strings.Count(c.UserFilter, "%s") != 1 {
+		return nil, errors.New("ldap user filter must contain exactly one %s placeholder")
+	}
+	if c.GroupSpace != "" && c.GroupBaseDN == "" {
+		return nil, errors.New("ldap group base dn is required for the group synchronization")
+	}
+
+	return &LDAPDirectory{
+		config: config,
+	}, nil
+}
+
+// GroupSyncEnabled returns true if the directory groups should be synchronized into user groups.
+func (d *LDAPDirectory) GroupSyncEnabled() bool {
+	return d.config.LDAP.GroupSpace != ""
+}
+
+// GroupSpace returns the path of the space the directory groups are synchronized into.
+func (d *LDAPDirectory) GroupSpace() string {
+	return d.config.LDAP.GroupSpace
+}
+
+// Authenticate finds the user with the provided login identifier and verifies the password
+// by binding to the directory as that user.
+func (d *LDAPDirectory) Authenticate(loginIdentifier, password string) (*LDAPUser, error) {
+	// an empty password would result in an unauthenticated bind, which many servers accept.
+	if loginIdentifier == "" || password == "" {
+		return nil, ErrLDAPInvalidCredentials
+	}
+
+	conn, err := d.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	users, err := d.searchUsers(conn, d.userFilter(ldap.EscapeFilter(loginIdentifier)))
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(users) {
+	case 0:
+		return nil, ErrLDAPUserNotFound
+	case 1:
+	default:
+		return nil, fmt.Errorf("login identifier %q matches %d directory users", loginIdentifier, len(users))
+	}
+
+	user := users[0]
+	if user.Disabled {
+		return nil, ErrLDAPUserDisabled
+	}
+
+	err = conn.Bind(user.DN, password)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		return nil, ErrLDAPInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind as directory user: %w", err)
+	}
+
+	return user, nil
+}
+
+// ListUsers returns all users of the directory.
+func (d *LDAPDirectory) ListUsers() ([]*LDAPUser, error) {
+	conn, err := d.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// the placeholder of the user filter is replaced with a wildcard, turning it into a presence filter.
+	return d.searchUsers(conn, d.userFilter("*"))
+}
+
+// ListGroups returns all groups of the directory.
+func (d *LDAPDirectory) ListGroups() ([]*LDAPGroup, error) {
+	conn, err := d.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	c := d.config.LDAP
+
+	entries, err := d.search(conn, c.GroupBaseDN, c.GroupFilter,
+		[]string{c.GroupAttributeName, c.GroupAttributeMember})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search directory groups: %w", err)
+	}
+
+	groups := make([]*LDAPGroup, 0, len(entries))
+	for _, entry := range entries {
+		groups = append(groups, &LDAPGroup{
+			DN:        entry.DN,
+			Name:      entry.GetAttributeValue(c.GroupAttributeName),
+			MemberDNs: entry.GetAttributeValues(c.GroupAttributeMember),
+		})
+	}
+
+	return groups, nil
+}
+
+// NormalizeLDAPDN returns the canonical form of a distinguished name, suitable for comparison.
+func NormalizeLDAPDN(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(dn))
+	}
+
+	return strings.ToLower(parsed.String())
+}
+
+func (d *LDAPDirectory) userFilter(value string) string {
+	return strings.Replace(d.config.LDAP.UserFilter, "%s", value, 1)
+}
+
+func (d *LDAPDirectory) searchUsers(conn *ldap.Conn, filter string) ([]*LDAPUser, error) {
+	c := d.config.LDAP
+
+	entries, err := d.search(conn, c.UserBaseDN, filter,
+		[]string{c.UserAttributeUID, c.UserAttributeEmail, c.UserAttributeDisplayName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search directory users: %w", err)
+	}
+
+	disabled := make(map[string]struct{})
+	if c.UserDisabledFilter != "" && len(entries) > 0 {
+		// attribute "1.1" requests no attributes, only the DNs of the disabled users are needed.
+		disabledFilter := "(&" + filter + c.UserDisabledFilter + ")"
+		disabledEntries, errSearch := d.search(conn, c.UserBaseDN, disabledFilter, []string{"1.1"})
+		if errSearch != nil {
+			return nil, fmt.Errorf("failed to search disabled directory users: %w", errSearch)
+		}
+
+		for _, entry := range disabledEntries {
+			disabled[NormalizeLDAPDN(entry.DN)] = struct{}{}
+		}
+	}
+
+	users := make([]*LDAPUser, 0, len(entries))
+	for _, entry := range entries {
+		_, isDisabled := disabled[NormalizeLDAPDN(entry.DN)]
+		users = append(users, &LDAPUser{
+			DN:          entry.DN,
+			UID:         entry.GetAttributeValue(c.UserAttributeUID),
+			Email:       entry.GetAttributeValue(c.UserAttributeEmail),
+			DisplayName: entry.GetAttributeValue(c.UserAttributeDisplayName),
+			Disabled:    isDisabled,
+		})
+	}
+
+	return users, nil
+}
+
+func (d *LDAPDirectory) search(
+	conn *ldap.Conn,
+	baseDN string,
+	filter string,
+	attributes []string,
+) ([]*ldap.Entry, error) {
+	const pageSize = 500
+
+	req := ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0, // no size limit
+		int(d.config.LDAP.Timeout/time.Second),
+		false,
+		filter,
+		attributes,
+		nil,
+	)
+
+	result, err := conn.SearchWithPaging(req, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Entries, nil
+}
+
+func (d *LDAPDirectory) connect() (*ldap.Conn, error) {
+	c := d.config.LDAP
+
+	//nolint:gosec // skipping the verification is an explicit choice of the administrator.
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	conn, err := ldap.DialURL(c.URL, ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the directory: %w", err)
+	}
+
+	conn.SetTimeout(c.Timeout)
+
+	if c.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("failed to start tls with the directory: %w", err)
+		}
+	}
+
+	if err := conn.Bind(c.BindDN, c.BindPassword); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to bind to the directory with the service account: %w", err)
+	}
+
+	return conn, nil
+}
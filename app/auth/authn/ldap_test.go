@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestNewLDAPDirectory(t *testing.T) {
+	newConfig := func(f func(config *types.Config)) *types.Config {
+		config := &types.Config{}
+		config.LDAP.URL = "ldap://ldap.example.com"
+		config.LDAP.UserBaseDN = "ou=people,dc=example,dc=com"
+		config.LDAP.UserFilter = "(uid=%s)"
+		f(config)
+		return config
+	}
+
+	tests := []struct {
+		name   string
+		config *types.Config
+		expErr bool
+	}{
+		{
+			name:   "valid",
+			config: newConfig(func(*types.Config) {}),
+		},
+		{
+			name:   "missing-url",
+			config: newConfig(func(c *types.Config) { c.LDAP.URL = "" }),
+			expErr: true,
+		},
+		{
+			name:   "filter-without-placeholder",
+			config: newConfig(func(c *types.Config) { c.LDAP.UserFilter = "(objectClass=person)" }),
+			expErr: true,
+		},
+		{
+			name:   "group-sync-without-base-dn",
+			config: newConfig(func(c *types.Config) { c.LDAP.GroupSpace = "acme" }),
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewLDAPDirectory(test.config)
+			if test.expErr != (err != nil) {
+				t.Errorf("expected error=%t, got %v", test.expErr, err)
+			}
+		})
+	}
+}
+
+func TestNormalizeLDAPDN(t *testing.T) {
+	a := NormalizeLDAPDN("CN=John Doe, OU=People,DC=example,DC=com")
+	b := NormalizeLDAPDN("cn=john doe,ou=people,dc=example,dc=com")
+	if a != b {
+		t.Errorf("expected equal DNs, got %q and %q", a, b)
+	}
+}
+
+func TestLDAPAuthenticateEmptyPassword(t *testing.T) {
+	config := &types.Config{}
+	config.LDAP.URL = "ldap://ldap.example.com"
+	config.LDAP.UserBaseDN = "ou=people,dc=example,dc=com"
+	config.LDAP.UserFilter = "(uid=%s)"
+
+	d, err := NewLDAPDirectory(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// no connection must be attempted, an empty password would result in an unauthenticated bind.
+	if _, err := d.Authenticate("john", ""); !errors.Is(err, ErrLDAPInvalidCredentials) {
+		t.Errorf("expected invalid credentials, got %v", err)
+	}
+}
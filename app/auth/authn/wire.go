@@ -24,6 +24,7 @@ import (
 // WireSet provides a wire set for this package.
 var WireSet = wire.NewSet(
 	ProvideAuthenticator,
+	ProvideLDAPDirectory,
 )
 
 func ProvideAuthenticator(
@@ -33,3 +34,12 @@ func ProvideAuthenticator(
 ) Authenticator {
 	return NewTokenAuthenticator(principalStore, tokenStore, config.Token.CookieName)
 }
+
+// ProvideLDAPDirectory provides the LDAP directory. It returns nil if the LDAP authentication is disabled.
+func ProvideLDAPDirectory(config *types.Config) (*LDAPDirectory, error) {
+	if !config.LDAP.Enabled {
+		return nil, nil //nolint:nilnil // the directory is optional
+	}
+
+	return NewLDAPDirectory(config)
+}
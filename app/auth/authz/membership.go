@@ -17,6 +17,7 @@ package authz
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
@@ -34,17 +35,20 @@ var _ Authorizer = (*MembershipAuthorizer)(nil)
 type MembershipAuthorizer struct {
 	permissionCache PermissionCache
 	spaceStore      store.SpaceStore
+	repoStore       store.RepoStore
 	publicAccess    publicaccess.Service
 }
 
 func NewMembershipAuthorizer(
 	permissionCache PermissionCache,
 	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
 	publicAccess publicaccess.Service,
 ) *MembershipAuthorizer {
 	return &MembershipAuthorizer{
 		permissionCache: permissionCache,
 		spaceStore:      spaceStore,
+		repoStore:       repoStore,
 		publicAccess:    publicAccess,
 	}
 }
@@ -76,6 +80,18 @@ func (a *MembershipAuthorizer) Check(
 		session.Metadata,
 	)
 
+	// scoped tokens are restricted to their scopes, even for system admins
+	if tokenMetadata, ok := session.Metadata.(*auth.TokenMetadata); ok && len(tokenMetadata.Scopes) > 0 {
+		inScope, err := a.checkWithTokenScopes(ctx, tokenMetadata.Scopes, scope, resource, permission)
+		if err != nil {
+			return false, fmt.Errorf("failed to check token scopes: %w", err)
+		}
+
+		if !inScope {
+			return false, nil
+		}
+	}
+
 	if session.Principal.Admin {
 		return true, nil // system admin can call any API
 	}
@@ -139,6 +155,17 @@ func (a *MembershipAuthorizer) Check(
 
 	// ephemeral membership overrides any other space memberships of the principal
 	if membershipMetadata, ok := session.Metadata.(*auth.MembershipMetadata); ok {
+		if membershipMetadata.RepoID != 0 {
+			inRepo, err := a.checkMembershipRepo(ctx, membershipMetadata.RepoID, scope, resource)
+			if err != nil {
+				return false, fmt.Errorf("failed to check repository of ephemeral membership: %w", err)
+			}
+
+			if !inRepo {
+				return false, nil
+			}
+		}
+
 		return a.checkWithMembershipMetadata(ctx, membershipMetadata, spacePath, permission)
 	}
 
@@ -148,7 +175,9 @@ func (a *MembershipAuthorizer) Check(
 	}
 
 	// ensure we aren't bypassing unknown metadata with impact on authorization
-	if session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
+	// (token scopes have been checked above and only restrict the permissions of the principal)
+	_, isTokenMetadata := session.Metadata.(*auth.TokenMetadata)
+	if session.Metadata != nil && !isTokenMetadata && session.Metadata.ImpactsAuthorization() {
 		return false, fmt.Errorf("session contains unknown metadata that impacts authorization: %T", session.Metadata)
 	}
 
@@ -207,6 +236,26 @@ func (a *MembershipAuthorizer) checkWithMembershipMetadata(
 	return true, nil
 }
 
+// checkMembershipRepo checks if the resource belongs to the repository an ephemeral membership is restricted to.
+func (a *MembershipAuthorizer) checkMembershipRepo(
+	ctx context.Context,
+	repoID int64,
+	scope *types.Scope,
+	resource *types.Resource,
+) (bool, error) {
+	resourcePath := tokenScopeResourcePath(scope, resource)
+	if resourcePath == "" {
+		return false, nil
+	}
+
+	repo, err := a.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	return strings.EqualFold(repo.Path, resourcePath), nil
+}
+
 // checkWithAccessPermissionMetadata checks access using the ephemeral membership provided in the metadata.
 func (a *MembershipAuthorizer) checkWithAccessPermissionMetadata(
 	ctx context.Context,
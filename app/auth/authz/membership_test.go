@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCheckMembershipRepo(t *testing.T) {
+	a := &MembershipAuthorizer{
+		repoStore: repoStoreStub{repos: map[int64]*types.Repository{
+			1: {ID: 1, Path: "space/repo"},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		scope    *types.Scope
+		resource *types.Resource
+		exp      bool
+	}{
+		{
+			name:     "repo",
+			scope:    &types.Scope{SpacePath: "space"},
+			resource: &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "repo"},
+			exp:      true,
+		},
+		{
+			name:     "pipeline in repo",
+			scope:    &types.Scope{SpacePath: "space", Repo: "repo"},
+			resource: &types.Resource{Type: enum.ResourceTypePipeline, Identifier: "build"},
+			exp:      true,
+		},
+		{
+			name:     "other repo in space",
+			scope:    &types.Scope{SpacePath: "space"},
+			resource: &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "other"},
+			exp:      false,
+		},
+		{
+			name:     "space",
+			scope:    &types.Scope{},
+			resource: &types.Resource{Type: enum.ResourceTypeSpace, Identifier: "space"},
+			exp:      false,
+		},
+		{
+			name:     "secret in space",
+			scope:    &types.Scope{SpacePath: "space"},
+			resource: &types.Resource{Type: enum.ResourceTypeSecret, Identifier: "secret"},
+			exp:      false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := a.checkMembershipRepo(context.Background(), 1, test.scope, test.resource)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != test.exp {
+				t.Errorf("expected %t, got %t", test.exp, got)
+			}
+		})
+	}
+}
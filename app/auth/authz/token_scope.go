@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/paths"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+// checkWithTokenScopes checks if the requested permission on the resource is within the scopes of the token.
+// Scopes only restrict a token, so the principal is still required to have the permission.
+// Resources that aren't part of a space (like users) are outside of any token scope.
+func (a *MembershipAuthorizer) checkWithTokenScopes(
+	ctx context.Context,
+	tokenScopes []types.TokenScope,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	resourcePath := tokenScopeResourcePath(scope, resource)
+	if resourcePath == "" {
+		return false, nil
+	}
+
+	for _, tokenScope := range tokenScopes {
+		if !slices.Contains(tokenScope.Permissions, permission) {
+			continue
+		}
+
+		switch {
+		case tokenScope.SpaceID != 0:
+			space, err := a.spaceStore.Find(ctx, tokenScope.SpaceID)
+			if errors.Is(err, gitness_store.ErrResourceNotFound) {
+				continue
+			}
+			if err != nil {
+				return false, fmt.Errorf("failed to find space of token scope: %w", err)
+			}
+
+			if isPathWithin(space.Path, resourcePath) {
+				return true, nil
+			}
+
+		case tokenScope.RepoID != 0:
+			repo, err := a.repoStore.Find(ctx, tokenScope.RepoID)
+			if errors.Is(err, gitness_store.ErrResourceNotFound) {
+				continue
+			}
+			if err != nil {
+				return false, fmt.Errorf("failed to find repository of token scope: %w", err)
+			}
+
+			if strings.EqualFold(repo.Path, resourcePath) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// tokenScopeResourcePath returns the path of the space or repository the resource belongs to,
+// or an empty string if the resource doesn't belong to any.
+func tokenScopeResourcePath(scope *types.Scope, resource *types.Resource) string {
+	//nolint:exhaustive // resources outside of spaces are never within a token scope
+	switch resource.Type {
+	case enum.ResourceTypeSpace, enum.ResourceTypeRepo:
+		return paths.Concatenate(scope.SpacePath, resource.Identifier)
+	case enum.ResourceTypeUser, enum.ResourceTypeService:
+		return ""
+	default:
+		if scope.Repo != "" {
+			return paths.Concatenate(scope.SpacePath, scope.Repo)
+		}
+		return scope.SpacePath
+	}
+}
+
+// isPathWithin returns true if the path is equal to or a descendant of the parent path.
+func isPathWithin(parent, path string) bool {
+	parent = strings.ToLower(strings.Trim(parent, types.PathSeparatorAsString))
+	path = strings.ToLower(strings.Trim(path, types.PathSeparatorAsString))
+
+	return path == parent || strings.HasPrefix(path, parent+types.PathSeparatorAsString)
+}
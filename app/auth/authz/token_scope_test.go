@@ -0,0 +1,218 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type spaceStoreStub struct {
+	store.SpaceStore
+	spaces map[int64]*types.Space
+}
+
+func (s spaceStoreStub) Find(_ context.Context, id int64) (*types.Space, error) {
+	if space, ok := s.spaces[id]; ok {
+		return space, nil
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type repoStoreStub struct {
+	store.RepoStore
+	repos map[int64]*types.Repository
+}
+
+func (s repoStoreStub) Find(_ context.Context, id int64) (*types.Repository, error) {
+	if repo, ok := s.repos[id]; ok {
+		return repo, nil
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func TestIsPathWithin(t *testing.T) {
+	tests := []struct {
+		name   string
+		parent string
+		path   string
+		exp    bool
+	}{
+		{name: "equal", parent: "space/sub", path: "space/sub", exp: true},
+		{name: "equal case insensitive", parent: "Space/Sub", path: "space/SUB", exp: true},
+		{name: "descendant", parent: "space", path: "space/sub/repo", exp: true},
+		{name: "surrounding separators", parent: "/space/", path: "space/repo/", exp: true},
+		{name: "common prefix", parent: "space", path: "spaceship/repo", exp: false},
+		{name: "ancestor", parent: "space/sub", path: "space", exp: false},
+		{name: "sibling", parent: "space/a", path: "space/b", exp: false},
+		{name: "empty path", parent: "space", path: "", exp: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isPathWithin(test.parent, test.path); got != test.exp {
+				t.Errorf("expected %t, got %t", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestTokenScopeResourcePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		scope    *types.Scope
+		resource *types.Resource
+		exp      string
+	}{
+		{
+			name:     "space",
+			scope:    &types.Scope{SpacePath: "space"},
+			resource: &types.Resource{Type: enum.ResourceTypeSpace, Identifier: "sub"},
+			exp:      "space/sub",
+		},
+		{
+			name:     "root space",
+			scope:    &types.Scope{},
+			resource: &types.Resource{Type: enum.ResourceTypeSpace, Identifier: "space"},
+			exp:      "space",
+		},
+		{
+			name:     "repo",
+			scope:    &types.Scope{SpacePath: "space"},
+			resource: &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "repo"},
+			exp:      "space/repo",
+		},
+		{
+			name:     "pipeline in repo",
+			scope:    &types.Scope{SpacePath: "space", Repo: "repo"},
+			resource: &types.Resource{Type: enum.ResourceTypePipeline, Identifier: "build"},
+			exp:      "space/repo",
+		},
+		{
+			name:     "secret in space",
+			scope:    &types.Scope{SpacePath: "space"},
+			resource: &types.Resource{Type: enum.ResourceTypeSecret, Identifier: "secret"},
+			exp:      "space",
+		},
+		{
+			name:     "user",
+			scope:    &types.Scope{},
+			resource: &types.Resource{Type: enum.ResourceTypeUser, Identifier: "user"},
+			exp:      "",
+		},
+		{
+			name:     "service",
+			scope:    &types.Scope{},
+			resource: &types.Resource{Type: enum.ResourceTypeService, Identifier: "service"},
+			exp:      "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := tokenScopeResourcePath(test.scope, test.resource); got != test.exp {
+				t.Errorf("expected %q, got %q", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestCheckWithTokenScopes(t *testing.T) {
+	a := &MembershipAuthorizer{
+		spaceStore: spaceStoreStub{spaces: map[int64]*types.Space{
+			1: {ID: 1, Path: "space/sub"},
+		}},
+		repoStore: repoStoreStub{repos: map[int64]*types.Repository{
+			2: {ID: 2, Path: "other/repo"},
+		}},
+	}
+
+	tokenScopes := []types.TokenScope{
+		{SpaceID: 1, Permissions: []enum.Permission{enum.PermissionSpaceView, enum.PermissionRepoView}},
+		{RepoID: 2, Permissions: []enum.Permission{enum.PermissionRepoView, enum.PermissionRepoPush}},
+		// scopes of deleted spaces and repositories are ignored.
+		{SpaceID: 10, Permissions: []enum.Permission{enum.PermissionRepoPush}},
+		{RepoID: 20, Permissions: []enum.Permission{enum.PermissionRepoPush}},
+	}
+
+	repo := func(spacePath, identifier string) (*types.Scope, *types.Resource) {
+		return &types.Scope{SpacePath: spacePath},
+			&types.Resource{Type: enum.ResourceTypeRepo, Identifier: identifier}
+	}
+	space := func(spacePath, identifier string) (*types.Scope, *types.Resource) {
+		return &types.Scope{SpacePath: spacePath},
+			&types.Resource{Type: enum.ResourceTypeSpace, Identifier: identifier}
+	}
+
+	tests := []struct {
+		name       string
+		target     func(string, string) (*types.Scope, *types.Resource)
+		spacePath  string
+		identifier string
+		permission enum.Permission
+		exp        bool
+	}{
+		{name: "scoped space", target: space, spacePath: "space", identifier: "sub",
+			permission: enum.PermissionSpaceView, exp: true},
+		{name: "parent of scoped space", target: space, spacePath: "", identifier: "space",
+			permission: enum.PermissionSpaceView, exp: false},
+		{name: "repo in scoped space", target: repo, spacePath: "space/sub", identifier: "repo",
+			permission: enum.PermissionRepoView, exp: true},
+		{name: "repo in subspace of scoped space", target: repo, spacePath: "space/sub/nested", identifier: "repo",
+			permission: enum.PermissionRepoView, exp: true},
+		{name: "permission not in space scope", target: repo, spacePath: "space/sub", identifier: "repo",
+			permission: enum.PermissionRepoPush, exp: false},
+		{name: "scoped repo", target: repo, spacePath: "other", identifier: "repo",
+			permission: enum.PermissionRepoPush, exp: true},
+		{name: "scoped repo case insensitive", target: repo, spacePath: "Other", identifier: "REPO",
+			permission: enum.PermissionRepoPush, exp: true},
+		{name: "permission not in repo scope", target: repo, spacePath: "other", identifier: "repo",
+			permission: enum.PermissionRepoDelete, exp: false},
+		{name: "other repo", target: repo, spacePath: "other", identifier: "repo2",
+			permission: enum.PermissionRepoView, exp: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scope, resource := test.target(test.spacePath, test.identifier)
+
+			got, err := a.checkWithTokenScopes(context.Background(), tokenScopes, scope, resource, test.permission)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != test.exp {
+				t.Errorf("expected %t, got %t", test.exp, got)
+			}
+		})
+	}
+
+	t.Run("user", func(t *testing.T) {
+		got, err := a.checkWithTokenScopes(context.Background(), tokenScopes, &types.Scope{},
+			&types.Resource{Type: enum.ResourceTypeUser, Identifier: "user"}, enum.PermissionUserView)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got {
+			t.Error("expected users to be outside of any token scope")
+		}
+	})
+}
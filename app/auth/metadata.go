@@ -16,6 +16,7 @@ package auth
 
 import (
 	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -34,16 +35,20 @@ func (m *EmptyMetadata) ImpactsAuthorization() bool {
 type TokenMetadata struct {
 	TokenType enum.TokenType
 	TokenID   int64
+	// Scopes restrict the permissions granted to the token (if any).
+	Scopes []types.TokenScope
 }
 
 func (m *TokenMetadata) ImpactsAuthorization() bool {
-	return false
+	return len(m.Scopes) > 0
 }
 
 // MembershipMetadata contains information about an ephemeral membership grant.
 type MembershipMetadata struct {
 	SpaceID int64
-	Role    enum.MembershipRole
+	// RepoID optionally restricts the membership to a single repository of the space.
+	RepoID int64
+	Role   enum.MembershipRole
 }
 
 func (m *MembershipMetadata) ImpactsAuthorization() bool {
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// GroupMapping maps a group of the identity provider onto a resource of a space.
+// The value is either a user group identifier or a membership role, depending on the mapping type.
+type GroupMapping struct {
+	Group     string
+	SpacePath string
+	Value     string
+}
+
+// ParseGroupMappings parses group mappings in the format <group>=<space path>:<value>.
+func ParseGroupMappings(mappings []string) ([]GroupMapping, error) {
+	result := make([]GroupMapping, 0, len(mappings))
+
+	for _, mapping := range mappings {
+		mapping = strings.TrimSpace(mapping)
+		if mapping == "" {
+			continue
+		}
+
+		group, target, ok := strings.Cut(mapping, "=")
+		if !ok {
+			return nil, fmt.Errorf("mapping %q must have the format <group>=<space path>:<value>", mapping)
+		}
+
+		idx := strings.LastIndex(target, ":")
+		if idx < 0 {
+			return nil, fmt.Errorf("mapping %q must have the format <group>=<space path>:<value>", mapping)
+		}
+
+		m := GroupMapping{
+			Group:     strings.TrimSpace(group),
+			SpacePath: strings.Trim(strings.TrimSpace(target[:idx]), "/"),
+			Value:     strings.TrimSpace(target[idx+1:]),
+		}
+
+		if m.Group == "" || m.SpacePath == "" || m.Value == "" {
+			return nil, fmt.Errorf("mapping %q has an empty group, space path or value", mapping)
+		}
+
+		result = append(result, m)
+	}
+
+	return result, nil
+}
+
+// ParseMembershipMappings parses group mappings whose values are space membership roles.
+func ParseMembershipMappings(mappings []string) ([]GroupMapping, error) {
+	result, err := ParseGroupMappings(mappings)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range result {
+		role, ok := enum.MembershipRole(result[i].Value).Sanitize()
+		if !ok {
+			return nil, fmt.Errorf("mapping of group %q has an invalid membership role %q",
+				result[i].Group, result[i].Value)
+		}
+
+		result[i].Value = string(role)
+	}
+
+	return result, nil
+}
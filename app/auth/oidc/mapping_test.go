@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGroupMappings(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings []string
+		exp      []GroupMapping
+		expErr   bool
+	}{
+		{
+			name:     "empty",
+			mappings: nil,
+			exp:      []GroupMapping{},
+		},
+		{
+			name:     "nested-space",
+			mappings: []string{"eng=acme/platform:reviewers", " ops = acme : admins "},
+			exp: []GroupMapping{
+				{Group: "eng", SpacePath: "acme/platform", Value: "reviewers"},
+				{Group: "ops", SpacePath: "acme", Value: "admins"},
+			},
+		},
+		{
+			name:     "missing-group",
+			mappings: []string{"acme:reviewers"},
+			expErr:   true,
+		},
+		{
+			name:     "missing-value",
+			mappings: []string{"eng=acme:"},
+			expErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseGroupMappings(test.mappings)
+			if test.expErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(test.exp, got) {
+				t.Errorf("want=%+v got=%+v", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestParseMembershipMappings(t *testing.T) {
+	got, err := ParseMembershipMappings([]string{"eng=acme:space_owner"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(got) != 1 || got[0].Value != "space_owner" {
+		t.Errorf("unexpected mappings: %+v", got)
+	}
+
+	if _, err = ParseMembershipMappings([]string{"eng=acme:superuser"}); err == nil {
+		t.Error("expected an error for an invalid role")
+	}
+}
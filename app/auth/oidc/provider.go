@@ -0,0 +1,385 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	gojwt "github.com/golang-jwt/jwt"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrNotEnabled   = errors.New("OpenID Connect login is not enabled")
+	ErrInvalidToken = errors.New("invalid ID token")
+)
+
+const (
+	// keysRefreshInterval is the minimum time between two fetches of the signing keys of the identity provider.
+	keysRefreshInterval = time.Minute
+
+	httpTimeout = 30 * time.Second
+)
+
+// Provider implements the OpenID Connect authorization code flow with PKCE
+// against a single identity provider.
+type Provider struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+	groupsClaim  string
+
+	userGroupMappings  []GroupMapping
+	membershipMappings []GroupMapping
+
+	client *http.Client
+
+	mx          sync.Mutex
+	discovery   *discoveryDocument
+	keys        map[string]any
+	keysFetched time.Time
+}
+
+// AuthRequest holds the data of a started authentication.
+// The State, Nonce and Verifier must be kept by the caller until the identity provider redirects back.
+type AuthRequest struct {
+	URL      string
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+// Claims are the claims of a verified ID token that are relevant for the login.
+type Claims struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+	Groups            []string
+}
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// NewProvider creates a new Provider from the OIDC config.
+// The identity provider is discovered lazily, on the first login.
+func NewProvider(config *types.Config) (*Provider, error) {
+	cfg := config.OIDC
+
+	if cfg.Issuer == "" || cfg.ClientID == "" {
+		return nil, errors.New("OpenID Connect issuer and client ID are required")
+	}
+
+	redirectURL := cfg.RedirectURL
+	if redirectURL == "" {
+		var err error
+		redirectURL, err = url.JoinPath(config.URL.API, "v1", "login", "oidc", "callback")
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive OpenID Connect redirect URL: %w", err)
+		}
+	}
+
+	userGroupMappings, err := ParseGroupMappings(cfg.UserGroupMappings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OpenID Connect user group mappings: %w", err)
+	}
+
+	membershipMappings, err := ParseMembershipMappings(cfg.MembershipMappings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OpenID Connect membership mappings: %w", err)
+	}
+
+	return &Provider{
+		issuer:             strings.TrimSuffix(cfg.Issuer, "/"),
+		clientID:           cfg.ClientID,
+		clientSecret:       cfg.ClientSecret,
+		redirectURL:        redirectURL,
+		scopes:             cfg.Scopes,
+		groupsClaim:        cfg.GroupsClaim,
+		userGroupMappings:  userGroupMappings,
+		membershipMappings: membershipMappings,
+		client:             &http.Client{Timeout: httpTimeout},
+	}, nil
+}
+
+// UserGroupMappings returns the mappings of identity provider groups onto user groups.
+func (p *Provider) UserGroupMappings() []GroupMapping {
+	return p.userGroupMappings
+}
+
+// MembershipMappings returns the mappings of identity provider groups onto space memberships.
+func (p *Provider) MembershipMappings() []GroupMapping {
+	return p.membershipMappings
+}
+
+// NewAuthRequest starts a new authentication and returns the URL of the identity provider
+// the user should be redirected to.
+func (p *Provider) NewAuthRequest(ctx context.Context) (*AuthRequest, error) {
+	cfg, err := p.oauth2Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+
+	verifier := oauth2.GenerateVerifier()
+
+	authURL := cfg.AuthCodeURL(state,
+		oauth2.S256ChallengeOption(verifier),
+		oauth2.SetAuthURLParam("nonce", nonce))
+
+	return &AuthRequest{
+		URL:      authURL,
+		State:    state,
+		Nonce:    nonce,
+		Verifier: verifier,
+	}, nil
+}
+
+// Exchange exchanges the authorization code for tokens and returns the claims of the verified ID token.
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (*Claims, error) {
+	cfg, err := p.oauth2Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.client)
+
+	token, err := cfg.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("%w: token response doesn't contain an ID token", ErrInvalidToken)
+	}
+
+	return p.verify(ctx, rawIDToken, nonce)
+}
+
+// verify verifies the signature and the claims of the ID token.
+func (p *Provider) verify(ctx context.Context, rawIDToken, nonce string) (*Claims, error) {
+	mapClaims := gojwt.MapClaims{}
+
+	_, err := gojwt.ParseWithClaims(rawIDToken, mapClaims, func(token *gojwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *gojwt.SigningMethodRSA, *gojwt.SigningMethodECDSA:
+		default:
+			return nil, fmt.Errorf("unsupported signing method %q", token.Header["alg"])
+		}
+
+		kid, _ := token.Header["kid"].(string)
+
+		return p.signingKey(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	if !mapClaims.VerifyIssuer(p.issuer, true) {
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	}
+
+	if !mapClaims.VerifyAudience(p.clientID, true) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+
+	if tokenNonce, _ := mapClaims["nonce"].(string); tokenNonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+	}
+
+	claims := &Claims{}
+	claims.Subject, _ = mapClaims["sub"].(string)
+	claims.Email, _ = mapClaims["email"].(string)
+	claims.Name, _ = mapClaims["name"].(string)
+	claims.PreferredUsername, _ = mapClaims["preferred_username"].(string)
+
+	// Some identity providers return the email_verified claim as a string.
+	switch v := mapClaims["email_verified"].(type) {
+	case bool:
+		claims.EmailVerified = v
+	case string:
+		claims.EmailVerified = v == "true"
+	}
+
+	switch v := mapClaims[p.groupsClaim].(type) {
+	case string:
+		claims.Groups = []string{v}
+	case []any:
+		for _, group := range v {
+			if s, ok := group.(string); ok {
+				claims.Groups = append(claims.Groups, s)
+			}
+		}
+	}
+
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	return claims, nil
+}
+
+func (p *Provider) oauth2Config(ctx context.Context) (*oauth2.Config, error) {
+	doc, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Config{
+		ClientID:     p.clientID,
+		ClientSecret: p.clientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  doc.AuthorizationEndpoint,
+			TokenURL: doc.TokenEndpoint,
+		},
+		RedirectURL: p.redirectURL,
+		Scopes:      p.scopes,
+	}, nil
+}
+
+// discover returns the discovery document of the identity provider. The document is cached once fetched.
+func (p *Provider) discover(ctx context.Context) (*discoveryDocument, error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	doc := &discoveryDocument{}
+	if err := p.getJSON(ctx, p.issuer+"/.well-known/openid-configuration", doc); err != nil {
+		return nil, fmt.Errorf("failed to discover OpenID Connect provider: %w", err)
+	}
+
+	if strings.TrimSuffix(doc.Issuer, "/") != p.issuer {
+		return nil, fmt.Errorf("discovered issuer %q doesn't match the configured issuer %q", doc.Issuer, p.issuer)
+	}
+
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, errors.New("discovery document of the OpenID Connect provider is incomplete")
+	}
+
+	p.discovery = doc
+
+	return doc, nil
+}
+
+// signingKey returns the public key with the provided key ID.
+// The keys of the identity provider are refetched if the key is unknown, to support key rotation.
+func (p *Provider) signingKey(ctx context.Context, kid string) (any, error) {
+	doc, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if key, ok := p.findKey(kid); ok {
+		return key, nil
+	}
+
+	if time.Since(p.keysFetched) < keysRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	keySet := jsonWebKeySet{}
+	if err := p.getJSON(ctx, doc.JWKSURI, &keySet); err != nil {
+		return nil, fmt.Errorf("failed to fetch signing keys: %w", err)
+	}
+
+	p.keys = keySet.publicKeys()
+	p.keysFetched = time.Now()
+
+	if key, ok := p.findKey(kid); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// findKey returns the key with the provided ID. Tokens without a key ID are accepted only if there's a single key.
+func (p *Provider) findKey(kid string) (any, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+
+	key, ok := p.keys[kid]
+
+	return key, ok
+}
+
+func (p *Provider) getJSON(ctx context.Context, rawURL string, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random string: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,235 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	gojwt "github.com/golang-jwt/jwt"
+	"golang.org/x/oauth2"
+)
+
+// mockIdP is a minimal OpenID Connect identity provider.
+// It issues ID tokens for a single authorization code and verifies the PKCE code verifier.
+type mockIdP struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	clientID  string
+	challenge string
+	nonce     string
+	claims    gojwt.MapClaims
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	idp := &mockIdP{key: key, clientID: "gitness"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(discoveryDocument{
+			Issuer:                idp.server.URL,
+			AuthorizationEndpoint: idp.server.URL + "/authorize",
+			TokenEndpoint:         idp.server.URL + "/token",
+			JWKSURI:               idp.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(jsonWebKeySet{Keys: []jsonWebKey{{
+			KeyID:   "key1",
+			KeyType: "RSA",
+			Use:     "sig",
+			N:       base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != "code1" ||
+			oauth2.S256ChallengeFromVerifier(r.FormValue("code_verifier")) != idp.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+
+		claims := gojwt.MapClaims{
+			"iss":   idp.server.URL,
+			"aud":   idp.clientID,
+			"sub":   "user1",
+			"nonce": idp.nonce,
+			"iat":   time.Now().Unix(),
+			"exp":   time.Now().Add(time.Minute).Unix(),
+		}
+		for k, v := range idp.claims {
+			claims[k] = v
+		}
+
+		token := gojwt.NewWithClaims(gojwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "key1"
+
+		idToken, err := token.SignedString(key)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access1",
+			"token_type":   "Bearer",
+			"id_token":     idToken,
+		})
+	})
+
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+// authorize simulates the user's authentication at the identity provider.
+func (idp *mockIdP) authorize(t *testing.T, authURL string) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("failed to parse auth URL: %s", err)
+	}
+
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" {
+		t.Fatalf("expected S256 code challenge, got %q", q.Get("code_challenge_method"))
+	}
+
+	idp.challenge = q.Get("code_challenge")
+	idp.nonce = q.Get("nonce")
+}
+
+func newTestProvider(t *testing.T, idp *mockIdP) *Provider {
+	config := &types.Config{}
+	config.URL.API = "http://localhost:3000/api"
+	config.OIDC.Issuer = idp.server.URL
+	config.OIDC.ClientID = idp.clientID
+	config.OIDC.Scopes = []string{"openid", "email"}
+	config.OIDC.GroupsClaim = "groups"
+
+	p, err := NewProvider(config)
+	if err != nil {
+		t.Fatalf("failed to create provider: %s", err)
+	}
+
+	return p
+}
+
+func TestProvider_Login(t *testing.T) {
+	idp := newMockIdP(t)
+	idp.claims = gojwt.MapClaims{
+		"email":          "jane@example.com",
+		"email_verified": true,
+		"groups":         []string{"eng", "release"},
+	}
+
+	p := newTestProvider(t, idp)
+	ctx := context.Background()
+
+	req, err := p.NewAuthRequest(ctx)
+	if err != nil {
+		t.Fatalf("failed to create auth request: %s", err)
+	}
+
+	idp.authorize(t, req.URL)
+
+	claims, err := p.Exchange(ctx, "code1", req.Verifier, req.Nonce)
+	if err != nil {
+		t.Fatalf("failed to exchange code: %s", err)
+	}
+
+	if claims.Subject != "user1" || claims.Email != "jane@example.com" || !claims.EmailVerified {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+
+	if len(claims.Groups) != 2 || claims.Groups[0] != "eng" || claims.Groups[1] != "release" {
+		t.Errorf("unexpected groups: %v", claims.Groups)
+	}
+}
+
+func TestProvider_Login_WrongVerifier(t *testing.T) {
+	idp := newMockIdP(t)
+	p := newTestProvider(t, idp)
+	ctx := context.Background()
+
+	req, err := p.NewAuthRequest(ctx)
+	if err != nil {
+		t.Fatalf("failed to create auth request: %s", err)
+	}
+
+	idp.authorize(t, req.URL)
+
+	if _, err = p.Exchange(ctx, "code1", oauth2.GenerateVerifier(), req.Nonce); err == nil {
+		t.Error("expected the exchange to fail")
+	}
+}
+
+func TestProvider_Login_WrongNonce(t *testing.T) {
+	idp := newMockIdP(t)
+	p := newTestProvider(t, idp)
+	ctx := context.Background()
+
+	req, err := p.NewAuthRequest(ctx)
+	if err != nil {
+		t.Fatalf("failed to create auth request: %s", err)
+	}
+
+	idp.authorize(t, req.URL)
+
+	_, err = p.Exchange(ctx, "code1", req.Verifier, "other")
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected invalid token error, got %v", err)
+	}
+}
+
+func TestProvider_Login_WrongAudience(t *testing.T) {
+	idp := newMockIdP(t)
+	idp.claims = gojwt.MapClaims{"aud": "other"}
+
+	p := newTestProvider(t, idp)
+	ctx := context.Background()
+
+	req, err := p.NewAuthRequest(ctx)
+	if err != nil {
+		t.Fatalf("failed to create auth request: %s", err)
+	}
+
+	idp.authorize(t, req.URL)
+
+	_, err = p.Exchange(ctx, "code1", req.Verifier, req.Nonce)
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected invalid token error, got %v", err)
+	}
+}
@@ -26,3 +26,10 @@ type Session struct {
 	// Metadata contains auth related information (access grants, tokenId, sshKeyId, ...)
 	Metadata Metadata
 }
+
+// IsSystemAdmin returns true if the session has system admin access.
+// Sessions with metadata that impacts authorization (e.g. scoped tokens) are restricted by it,
+// so they never have system admin access.
+func (s *Session) IsSystemAdmin() bool {
+	return s.Principal.Admin && (s.Metadata == nil || !s.Metadata.ImpactsAuthorization())
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestSession_IsSystemAdmin(t *testing.T) {
+	scopes := []types.TokenScope{{RepoID: 1, Permissions: []enum.Permission{enum.PermissionRepoView}}}
+
+	tests := []struct {
+		name     string
+		admin    bool
+		metadata Metadata
+		exp      bool
+	}{
+		{name: "admin without metadata", admin: true, exp: true},
+		{name: "admin with empty metadata", admin: true, metadata: &EmptyMetadata{}, exp: true},
+		{name: "admin with token", admin: true, metadata: &TokenMetadata{TokenType: enum.TokenTypePAT}, exp: true},
+		{name: "admin with scoped token", admin: true, metadata: &TokenMetadata{Scopes: scopes}, exp: false},
+		{name: "user", admin: false, exp: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			session := &Session{Principal: types.Principal{Admin: test.admin}, Metadata: test.metadata}
+			if got := session.IsSystemAdmin(); got != test.exp {
+				t.Errorf("expected %t, got %t", test.exp, got)
+			}
+		})
+	}
+}
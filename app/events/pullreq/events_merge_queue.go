@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const MergeQueueCheckEvent events.EventType = "merge-queue-check"
+
+// MergeQueueCheckPayload is sent when a speculative merge commit for a queued pull request is created
+// and the required status checks should be run on it.
+type MergeQueueCheckPayload struct {
+	Base
+	TargetBranch string `json:"target_branch"`
+	Ref          string `json:"ref"`
+	BaseSHA      string `json:"base_sha"`
+	MergeSHA     string `json:"merge_sha"`
+}
+
+func (r *Reporter) MergeQueueCheck(ctx context.Context, payload *MergeQueueCheckPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, MergeQueueCheckEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request merge queue check event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request merge queue check event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterMergeQueueCheck(
+	fn events.HandlerFunc[*MergeQueueCheckPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, MergeQueueCheckEvent, fn, opts...)
+}
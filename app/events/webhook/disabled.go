@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const DisabledEvent events.EventType = "disabled"
+
+// DisabledPayload describes a webhook that got disabled automatically after too many consecutive failed deliveries.
+type DisabledPayload struct {
+	WebhookID           int64              `json:"webhook_id"`
+	WebhookIdentifier   string             `json:"webhook_identifier"`
+	ParentType          enum.WebhookParent `json:"parent_type"`
+	ParentID            int64              `json:"parent_id"`
+	CreatedBy           int64              `json:"created_by"`
+	ConsecutiveFailures int                `json:"consecutive_failures"`
+	LastError           string             `json:"last_error"`
+}
+
+func (r *Reporter) Disabled(ctx context.Context, payload *DisabledPayload) {
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, DisabledEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send webhook disabled event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported webhook disabled event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterDisabled(fn events.HandlerFunc[*DisabledPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, DisabledEvent, fn, opts...)
+}
@@ -35,6 +35,7 @@ type Config struct {
 type InfraEventOpts struct {
 	RequiredGitspacePorts []types.GitspacePort
 	CanDeleteUserData     bool
+	HostRequirements      *types.HostRequirements
 }
 
 type InfraProvisioner struct {
@@ -103,9 +104,10 @@ func (i InfraProvisioner) TriggerInfraEventWithOpts(
 	case enum.InfraEventProvision:
 		if infraProvider.ProvisioningType() == enum.InfraProvisioningTypeNew {
 			return i.provisionNewInfrastructure(ctx, infraProvider, infraProviderEntity.Type,
-				gitspaceConfig, opts.RequiredGitspacePorts)
+				gitspaceConfig, opts.RequiredGitspacePorts, opts.HostRequirements)
 		}
-		return i.provisionExistingInfrastructure(ctx, infraProvider, gitspaceConfig, opts.RequiredGitspacePorts)
+		return i.provisionExistingInfrastructure(ctx, infraProvider, gitspaceConfig, opts.RequiredGitspacePorts,
+			opts.HostRequirements)
 
 	case enum.InfraEventDeprovision:
 		if infraProvider.ProvisioningType() == enum.InfraProvisioningTypeNew {
@@ -130,6 +132,7 @@ func (i InfraProvisioner) provisionNewInfrastructure(
 	infraProviderType enum.InfraProviderType,
 	gitspaceConfig types.GitspaceConfig,
 	requiredGitspacePorts []types.GitspacePort,
+	hostRequirements *types.HostRequirements,
 ) error {
 	// Logic for new provisioning...
 	infraProvisionedLatest, _ := i.infraProvisionedStore.FindLatestByGitspaceInstanceID(
@@ -190,6 +193,7 @@ func (i InfraProvisioner) provisionNewInfrastructure(
 		agentPort,
 		requiredGitspacePorts,
 		allParams,
+		hostRequirements,
 	)
 	if err != nil {
 		infraProvisioned.InfraStatus = enum.InfraStatusUnknown
@@ -214,6 +218,7 @@ func (i InfraProvisioner) provisionExistingInfrastructure(
 	infraProvider infraprovider.InfraProvider,
 	gitspaceConfig types.GitspaceConfig,
 	requiredGitspacePorts []types.GitspacePort,
+	hostRequirements *types.HostRequirements,
 ) error {
 	allParams, err := i.getAllParamsFromDB(ctx, gitspaceConfig.InfraProviderResource, infraProvider)
 	if err != nil {
@@ -234,6 +239,7 @@ func (i InfraProvisioner) provisionExistingInfrastructure(
 		0, // NOTE: Agent port is not required for provisioning type Existing.
 		requiredGitspacePorts,
 		allParams,
+		hostRequirements,
 	)
 	if err != nil {
 		return fmt.Errorf(
@@ -0,0 +1,458 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/harness/gitness/app/gitspace/scm"
+	gitspaceTypes "github.com/harness/gitness/app/gitspace/types"
+	"github.com/harness/gitness/types"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	composeProjectLabel = "gitspace.compose.project"
+	composeServiceLabel = "gitspace.compose.service"
+	composeOrderLabel   = "gitspace.compose.order"
+)
+
+func getComposeNetworkName(containerName string) string {
+	return containerName + "-network"
+}
+
+func getComposeServiceContainerName(containerName string, service string) string {
+	return containerName + "-" + service
+}
+
+func getComposeVolumeName(containerName string, volumeName string) string {
+	return containerName + "-" + volumeName
+}
+
+// setupComposeServices prepares a Docker Compose based gitspace: it creates the project network,
+// starts the services the primary service needs and prepares the image of the primary service.
+// It returns the image of the primary service and its environment variables.
+// The gitspace container, which runs the IDE, takes the place of the primary service.
+func (e *EmbeddedDockerOrchestrator) setupComposeServices(
+	ctx context.Context,
+	dockerClient *client.Client,
+	gitspaceConfig types.GitspaceConfig,
+	resolvedRepoDetails scm.ResolvedDetails,
+	runArgsMap map[types.RunArg]*types.RunArgValue,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+	imageAuthMap map[string]gitspaceTypes.DockerRegistryAuth,
+) (string, []string, error) {
+	containerName := GetGitspaceContainerName(gitspaceConfig)
+	devcontainerConfig := resolvedRepoDetails.DevcontainerConfig
+
+	project, projectDir, err := e.loadComposeProject(ctx, gitspaceConfig, resolvedRepoDetails, gitspaceLogger)
+	if err != nil {
+		return "", nil, logStreamWrapError(gitspaceLogger, "Error loading compose files", err)
+	}
+
+	primary, ok := project.Services[devcontainerConfig.Service]
+	if !ok {
+		return "", nil, logStreamWrapError(gitspaceLogger, "Error loading compose files",
+			fmt.Errorf("service %q is not defined in the compose files", devcontainerConfig.Service))
+	}
+
+	services, err := composeServicesToStart(project, devcontainerConfig.Service, devcontainerConfig.RunServices)
+	if err != nil {
+		return "", nil, logStreamWrapError(gitspaceLogger, "Error resolving compose services", err)
+	}
+
+	networkName := getComposeNetworkName(containerName)
+	if err = ensureComposeNetwork(ctx, dockerClient, containerName, networkName, gitspaceLogger); err != nil {
+		return "", nil, err
+	}
+
+	for i, name := range services {
+		imageName, err := e.prepareComposeServiceImage(ctx, dockerClient, gitspaceConfig, resolvedRepoDetails,
+			projectDir, name, project.Services[name], runArgsMap, gitspaceLogger, imageAuthMap)
+		if err != nil {
+			return "", nil, err
+		}
+
+		err = createAndStartComposeService(ctx, dockerClient, containerName, networkName, name, i,
+			project.Services[name], imageName, gitspaceLogger)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	imageName, err := e.prepareComposeServiceImage(ctx, dockerClient, gitspaceConfig, resolvedRepoDetails,
+		projectDir, devcontainerConfig.Service, primary, runArgsMap, gitspaceLogger, imageAuthMap)
+	if err != nil {
+		return "", nil, err
+	}
+
+	runArgsMap[types.RunArgNetwork] = &types.RunArgValue{
+		Name:   types.RunArgNetwork,
+		Values: []string{networkName},
+	}
+
+	return imageName, primary.Environment.List(), nil
+}
+
+// loadComposeProject fetches the compose files from the repository and merges them into a single project.
+// It returns the project and the project directory, which the relative paths of the services are resolved against.
+func (e *EmbeddedDockerOrchestrator) loadComposeProject(
+	ctx context.Context,
+	gitspaceConfig types.GitspaceConfig,
+	resolvedRepoDetails scm.ResolvedDetails,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+) (*composeProject, string, error) {
+	filePaths, projectDir, err := composeFilePaths(resolvedRepoDetails.DevcontainerConfig.DockerComposeFile)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var project *composeProject
+	for _, filePath := range filePaths {
+		gitspaceLogger.Info(fmt.Sprintf("Reading compose file %s", filePath))
+
+		content, err := e.scm.GetFileContent(ctx, gitspaceConfig, &resolvedRepoDetails.ResolvedCredentials, filePath)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read compose file %s: %w", filePath, err)
+		}
+		if len(content) == 0 {
+			return nil, "", fmt.Errorf("compose file %s not found", filePath)
+		}
+
+		fileProject, err := parseComposeFile(content)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to parse compose file %s: %w", filePath, err)
+		}
+
+		project = mergeComposeProjects(project, fileProject)
+	}
+
+	return project, projectDir, nil
+}
+
+// prepareComposeServiceImage builds or pulls the image of a compose service.
+func (e *EmbeddedDockerOrchestrator) prepareComposeServiceImage(
+	ctx context.Context,
+	dockerClient *client.Client,
+	gitspaceConfig types.GitspaceConfig,
+	resolvedRepoDetails scm.ResolvedDetails,
+	projectDir string,
+	name string,
+	service *composeService,
+	runArgsMap map[types.RunArg]*types.RunArgValue,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+	imageAuthMap map[string]gitspaceTypes.DockerRegistryAuth,
+) (string, error) {
+	if service.Build != nil {
+		spec, err := composeBuildSpec(projectDir, service.Build)
+		if err != nil {
+			return "", logStreamWrapError(gitspaceLogger,
+				fmt.Sprintf("Error resolving the build of service %s", name), err)
+		}
+
+		imageName := service.Image
+		if imageName == "" {
+			imageName = getBuildImageName(gitspaceConfig, name)
+		}
+
+		err = e.BuildImage(ctx, dockerClient, gitspaceConfig, resolvedRepoDetails, spec, imageName, gitspaceLogger)
+		if err != nil {
+			return "", err
+		}
+
+		return imageName, nil
+	}
+
+	if service.Image == "" {
+		return "", logStreamWrapError(gitspaceLogger, "Error preparing compose service",
+			fmt.Errorf("service %s has neither an image nor a build", name))
+	}
+
+	if err := PullImage(ctx, service.Image, dockerClient, runArgsMap, gitspaceLogger, imageAuthMap); err != nil {
+		return "", err
+	}
+
+	return service.Image, nil
+}
+
+// ensureComposeNetwork creates the network of a compose based gitspace, unless it already exists.
+func ensureComposeNetwork(
+	ctx context.Context,
+	dockerClient *client.Client,
+	containerName string,
+	networkName string,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+) error {
+	_, err := dockerClient.NetworkInspect(ctx, networkName, network.InspectOptions{})
+	if err == nil {
+		return nil
+	}
+	if !errdefs.IsNotFound(err) {
+		return logStreamWrapError(gitspaceLogger, "Error while inspecting network", err)
+	}
+
+	_, err = dockerClient.NetworkCreate(ctx, networkName, network.CreateOptions{
+		Labels: map[string]string{composeProjectLabel: containerName},
+	})
+	if err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error while creating network", err)
+	}
+
+	gitspaceLogger.Info(fmt.Sprintf("Created network %s", networkName))
+
+	return nil
+}
+
+// createAndStartComposeService (re)creates and starts the container of a compose service.
+func createAndStartComposeService(
+	ctx context.Context,
+	dockerClient *client.Client,
+	containerName string,
+	networkName string,
+	name string,
+	order int,
+	service *composeService,
+	imageName string,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+) error {
+	serviceContainerName := getComposeServiceContainerName(containerName, name)
+	labels := map[string]string{composeProjectLabel: containerName}
+
+	// a container might be left behind by a previous attempt to start the gitspace.
+	err := dockerClient.ContainerRemove(ctx, serviceContainerName, container.RemoveOptions{Force: true})
+	if err != nil && !errdefs.IsNotFound(err) {
+		return logStreamWrapError(gitspaceLogger, "Error while removing container of service "+name, err)
+	}
+
+	mounts := make([]mount.Mount, 0, len(service.Volumes))
+	for _, v := range service.Volumes {
+		if v.Type != composeVolumeTypeVolume {
+			gitspaceLogger.Warn(fmt.Sprintf("Skipping %s mount %s of service %s, only volumes are supported",
+				v.Type, v.Target, name))
+			continue
+		}
+
+		source := v.Source
+		if source != "" {
+			source = getComposeVolumeName(containerName, v.Source)
+			_, err = dockerClient.VolumeCreate(ctx, volume.CreateOptions{Name: source, Labels: labels})
+			if err != nil {
+				return logStreamWrapError(gitspaceLogger, "Error while creating volume "+source, err)
+			}
+		}
+
+		mounts = append(mounts, mount.Mount{
+			Type:     mount.TypeVolume,
+			Source:   source,
+			Target:   v.Target,
+			ReadOnly: v.ReadOnly,
+		})
+	}
+
+	gitspaceLogger.Info(fmt.Sprintf("Creating container %s of service %s with image %s",
+		serviceContainerName, name, imageName))
+
+	_, err = dockerClient.ContainerCreate(ctx,
+		&container.Config{
+			Image:      imageName,
+			Env:        service.Environment.List(),
+			Cmd:        []string(service.Command),
+			Entrypoint: []string(service.Entrypoint),
+			User:       service.User,
+			WorkingDir: service.WorkingDir,
+			Labels: map[string]string{
+				composeProjectLabel: containerName,
+				composeServiceLabel: name,
+				composeOrderLabel:   strconv.Itoa(order),
+			},
+		},
+		&container.HostConfig{
+			NetworkMode: container.NetworkMode(networkName),
+			Mounts:      mounts,
+		},
+		&network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				networkName: {Aliases: []string{name}},
+			},
+		},
+		nil,
+		serviceContainerName,
+	)
+	if err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error while creating container of service "+name, err)
+	}
+
+	if err = dockerClient.ContainerStart(ctx, serviceContainerName, container.StartOptions{}); err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error while starting container of service "+name, err)
+	}
+
+	gitspaceLogger.Info(fmt.Sprintf("Started service %s", name))
+
+	return nil
+}
+
+// connectComposePrimaryService reconnects the gitspace container to the compose network
+// with the primary service name as an alias, so the other services can reach it by the service name.
+// The container must not be running yet.
+func connectComposePrimaryService(
+	ctx context.Context,
+	dockerClient *client.Client,
+	containerName string,
+	service string,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+) error {
+	networkName := getComposeNetworkName(containerName)
+
+	if err := dockerClient.NetworkDisconnect(ctx, networkName, containerName, true); err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error while disconnecting container from network", err)
+	}
+
+	err := dockerClient.NetworkConnect(ctx, networkName, containerName, &network.EndpointSettings{
+		Aliases: []string{service},
+	})
+	if err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error while connecting container to network", err)
+	}
+
+	return nil
+}
+
+// listComposeServiceContainers returns the names of the compose service containers of a gitspace, in start order.
+func listComposeServiceContainers(
+	ctx context.Context,
+	dockerClient *client.Client,
+	containerName string,
+) ([]string, error) {
+	args := filters.NewArgs()
+	args.Add("label", composeProjectLabel+"="+containerName)
+
+	containers, err := dockerClient.ContainerList(ctx, container.ListOptions{All: true, Filters: args})
+	if err != nil {
+		return nil, fmt.Errorf("could not list compose service containers of %s: %w", containerName, err)
+	}
+
+	sort.Slice(containers, func(i, j int) bool {
+		orderI, _ := strconv.Atoi(containers[i].Labels[composeOrderLabel])
+		orderJ, _ := strconv.Atoi(containers[j].Labels[composeOrderLabel])
+		return orderI < orderJ
+	})
+
+	names := make([]string, len(containers))
+	for i, c := range containers {
+		names[i] = getComposeServiceContainerName(containerName, c.Labels[composeServiceLabel])
+	}
+
+	return names, nil
+}
+
+// startComposeServices starts the compose service containers of a stopped gitspace.
+// It's a no-op for gitspaces that don't use Docker Compose.
+func startComposeServices(
+	ctx context.Context,
+	dockerClient *client.Client,
+	containerName string,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+) error {
+	names, err := listComposeServiceContainers(ctx, dockerClient, containerName)
+	if err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error while listing compose services", err)
+	}
+
+	for _, name := range names {
+		if err = ManageContainer(ctx, ContainerActionStart, name, dockerClient, gitspaceLogger); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// stopComposeServices stops the compose service containers of a gitspace, in reverse start order.
+// It's a no-op for gitspaces that don't use Docker Compose.
+func stopComposeServices(
+	ctx context.Context,
+	dockerClient *client.Client,
+	containerName string,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+) error {
+	names, err := listComposeServiceContainers(ctx, dockerClient, containerName)
+	if err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error while listing compose services", err)
+	}
+
+	for i := len(names) - 1; i >= 0; i-- {
+		if err = ManageContainer(ctx, ContainerActionStop, names[i], dockerClient, gitspaceLogger); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeComposeResources removes the compose service containers, the network and the volumes of a gitspace.
+// It's a no-op for gitspaces that don't use Docker Compose.
+func removeComposeResources(
+	ctx context.Context,
+	dockerClient *client.Client,
+	containerName string,
+) error {
+	names, err := listComposeServiceContainers(ctx, dockerClient, containerName)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		err = dockerClient.ContainerRemove(ctx, name, container.RemoveOptions{Force: true})
+		if err != nil && !errdefs.IsNotFound(err) {
+			return fmt.Errorf("failed to remove compose service container %s: %w", name, err)
+		}
+	}
+
+	networkName := getComposeNetworkName(containerName)
+	if err = dockerClient.NetworkRemove(ctx, networkName); err != nil && !errdefs.IsNotFound(err) {
+		return fmt.Errorf("failed to remove network %s: %w", networkName, err)
+	}
+
+	args := filters.NewArgs()
+	args.Add("label", composeProjectLabel+"="+containerName)
+
+	volumes, err := dockerClient.VolumeList(ctx, volume.ListOptions{Filters: args})
+	if err != nil {
+		return fmt.Errorf("failed to list compose volumes of %s: %w", containerName, err)
+	}
+
+	for _, v := range volumes.Volumes {
+		if err = dockerClient.VolumeRemove(ctx, v.Name, true); err != nil && !errdefs.IsNotFound(err) {
+			return fmt.Errorf("failed to remove volume %s: %w", v.Name, err)
+		}
+	}
+
+	if len(names) > 0 {
+		log.Ctx(ctx).Debug().Msgf("removed %d compose services of %s", len(names), containerName)
+	}
+
+	return nil
+}
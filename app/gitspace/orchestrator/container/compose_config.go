@@ -0,0 +1,418 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/app/gitspace/scm"
+
+	"github.com/anmitsu/go-shlex"
+	"gopkg.in/yaml.v3"
+)
+
+// composeProject is the subset of the Docker Compose file format used to run a dev container with its services.
+type composeProject struct {
+	Services map[string]*composeService `yaml:"services"`
+}
+
+type composeService struct {
+	Image       string             `yaml:"image"`
+	Build       *composeBuild      `yaml:"build"`
+	Environment composeEnvironment `yaml:"environment"`
+	Command     composeCommand     `yaml:"command"`
+	Entrypoint  composeCommand     `yaml:"entrypoint"`
+	User        string             `yaml:"user"`
+	WorkingDir  string             `yaml:"working_dir"`
+	DependsOn   composeDependsOn   `yaml:"depends_on"`
+	Volumes     []composeVolume    `yaml:"volumes"`
+}
+
+type composeBuild struct {
+	Context    string             `yaml:"context"`
+	Dockerfile string             `yaml:"dockerfile"`
+	Args       composeEnvironment `yaml:"args"`
+	Target     string             `yaml:"target"`
+	CacheFrom  []string           `yaml:"cache_from"`
+}
+
+// UnmarshalYAML supports both the short (context path only) and the long build syntax.
+func (b *composeBuild) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		b.Context = value.Value
+		return nil
+	}
+
+	type plain composeBuild
+	return value.Decode((*plain)(b))
+}
+
+// composeEnvironment holds environment variables or build arguments,
+// provided either as a mapping or as a list of KEY=VALUE entries.
+type composeEnvironment map[string]string
+
+func (env *composeEnvironment) UnmarshalYAML(value *yaml.Node) error {
+	result := composeEnvironment{}
+
+	switch value.Kind { //nolint:exhaustive
+	case yaml.MappingNode:
+		var m map[string]*string
+		if err := value.Decode(&m); err != nil {
+			return err
+		}
+		for key, val := range m {
+			if val == nil {
+				// the value would be taken from the host environment, which isn't available.
+				continue
+			}
+			result[key] = *val
+		}
+	case yaml.SequenceNode:
+		var list []string
+		if err := value.Decode(&list); err != nil {
+			return err
+		}
+		for _, entry := range list {
+			key, val, ok := strings.Cut(entry, "=")
+			if !ok {
+				continue
+			}
+			result[key] = val
+		}
+	default:
+		return fmt.Errorf("line %d: environment must be a mapping or a list", value.Line)
+	}
+
+	*env = result
+	return nil
+}
+
+// List returns the variables as a sorted list of KEY=VALUE entries.
+func (env composeEnvironment) List() []string {
+	list := make([]string, 0, len(env))
+	for key, val := range env {
+		list = append(list, key+"="+val)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// composeCommand is a command provided either as a list or as a string split with the shell quoting rules.
+type composeCommand []string
+
+func (c *composeCommand) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind { //nolint:exhaustive
+	case yaml.ScalarNode:
+		args, err := shlex.Split(value.Value, true)
+		if err != nil {
+			return fmt.Errorf("line %d: invalid command: %w", value.Line, err)
+		}
+		*c = args
+		return nil
+	case yaml.SequenceNode:
+		var args []string
+		if err := value.Decode(&args); err != nil {
+			return err
+		}
+		*c = args
+		return nil
+	default:
+		return fmt.Errorf("line %d: command must be a string or a list", value.Line)
+	}
+}
+
+// composeDependsOn holds the names of the services a service depends on,
+// provided either as a list or as a mapping of service names to conditions.
+type composeDependsOn []string
+
+func (d *composeDependsOn) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind { //nolint:exhaustive
+	case yaml.SequenceNode:
+		var names []string
+		if err := value.Decode(&names); err != nil {
+			return err
+		}
+		*d = names
+		return nil
+	case yaml.MappingNode:
+		var m map[string]yaml.Node
+		if err := value.Decode(&m); err != nil {
+			return err
+		}
+		names := make([]string, 0, len(m))
+		for name := range m {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		*d = names
+		return nil
+	default:
+		return fmt.Errorf("line %d: depends_on must be a list or a mapping", value.Line)
+	}
+}
+
+const (
+	composeVolumeTypeVolume = "volume"
+	composeVolumeTypeBind   = "bind"
+)
+
+type composeVolume struct {
+	Type     string `yaml:"type"`
+	Source   string `yaml:"source"`
+	Target   string `yaml:"target"`
+	ReadOnly bool   `yaml:"read_only"`
+}
+
+// UnmarshalYAML supports both the short (SOURCE:TARGET:MODE) and the long volume syntax.
+func (v *composeVolume) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		type plain composeVolume
+		if err := value.Decode((*plain)(v)); err != nil {
+			return err
+		}
+		if v.Type == "" {
+			v.Type = composeVolumeTypeVolume
+		}
+		return nil
+	}
+
+	parts := strings.Split(value.Value, ":")
+	switch len(parts) {
+	case 1:
+		v.Target = parts[0]
+	case 2, 3:
+		v.Source = parts[0]
+		v.Target = parts[1]
+		if len(parts) == 3 {
+			v.ReadOnly = strings.Contains(parts[2], "ro")
+		}
+	default:
+		return fmt.Errorf("line %d: invalid volume %q", value.Line, value.Value)
+	}
+
+	v.Type = composeVolumeTypeVolume
+	if strings.HasPrefix(v.Source, "/") || strings.HasPrefix(v.Source, ".") || strings.HasPrefix(v.Source, "~") {
+		v.Type = composeVolumeTypeBind
+	}
+
+	return nil
+}
+
+func parseComposeFile(content []byte) (*composeProject, error) {
+	project := &composeProject{}
+	if err := yaml.Unmarshal(content, project); err != nil {
+		return nil, err
+	}
+
+	for name, service := range project.Services {
+		if service == nil {
+			project.Services[name] = &composeService{}
+		}
+	}
+
+	return project, nil
+}
+
+// mergeComposeProjects applies the override file on top of the base project,
+// following the rules Docker Compose uses when multiple files are provided.
+func mergeComposeProjects(base *composeProject, override *composeProject) *composeProject {
+	if base == nil {
+		return override
+	}
+
+	if base.Services == nil {
+		base.Services = map[string]*composeService{}
+	}
+
+	for name, overrideService := range override.Services {
+		service, ok := base.Services[name]
+		if !ok {
+			base.Services[name] = overrideService
+			continue
+		}
+
+		if overrideService.Image != "" {
+			service.Image = overrideService.Image
+		}
+		if overrideService.Build != nil {
+			service.Build = overrideService.Build
+		}
+		if overrideService.Command != nil {
+			service.Command = overrideService.Command
+		}
+		if overrideService.Entrypoint != nil {
+			service.Entrypoint = overrideService.Entrypoint
+		}
+		if overrideService.User != "" {
+			service.User = overrideService.User
+		}
+		if overrideService.WorkingDir != "" {
+			service.WorkingDir = overrideService.WorkingDir
+		}
+
+		if len(overrideService.Environment) > 0 && service.Environment == nil {
+			service.Environment = composeEnvironment{}
+		}
+		for key, val := range overrideService.Environment {
+			service.Environment[key] = val
+		}
+
+		for _, dependency := range overrideService.DependsOn {
+			if !slices.Contains(service.DependsOn, dependency) {
+				service.DependsOn = append(service.DependsOn, dependency)
+			}
+		}
+
+		// volumes are merged by their mount path
+		for _, overrideVolume := range overrideService.Volumes {
+			replaced := false
+			for i := range service.Volumes {
+				if service.Volumes[i].Target == overrideVolume.Target {
+					service.Volumes[i] = overrideVolume
+					replaced = true
+					break
+				}
+			}
+			if !replaced {
+				service.Volumes = append(service.Volumes, overrideVolume)
+			}
+		}
+	}
+
+	return base
+}
+
+// composeServicesToStart returns the services that need to run next to the primary service, in start order.
+// These are the requested services (all services if none are requested)
+// and all services that any of them, or the primary service, depend on.
+func composeServicesToStart(project *composeProject, primary string, runServices []string) ([]string, error) {
+	requested := runServices
+	if len(requested) == 0 {
+		requested = make([]string, 0, len(project.Services))
+		for name := range project.Services {
+			requested = append(requested, name)
+		}
+	}
+
+	requested = append([]string{primary}, requested...)
+	sort.Strings(requested[1:])
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(project.Services))
+	order := make([]string, 0, len(project.Services))
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		service, ok := project.Services[name]
+		if !ok {
+			return fmt.Errorf("service %q is not defined in the compose files", name)
+		}
+
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("circular dependency involving service %q", name)
+		}
+
+		state[name] = visiting
+		for _, dependency := range service.DependsOn {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+
+		if name != primary {
+			order = append(order, name)
+		}
+
+		return nil
+	}
+
+	for _, name := range requested {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// composeBuildSpec resolves the build properties of a compose service.
+// The context is relative to the project directory and the Dockerfile is relative to the context.
+func composeBuildSpec(projectDir string, build *composeBuild) (imageBuildSpec, error) {
+	contextPath := build.Context
+	if contextPath == "" {
+		contextPath = "."
+	}
+
+	contextDir, err := resolveRepoPath(projectDir, contextPath)
+	if err != nil {
+		return imageBuildSpec{}, fmt.Errorf("invalid build context: %w", err)
+	}
+
+	dockerfile := build.Dockerfile
+	if dockerfile == "" {
+		dockerfile = defaultDockerfile
+	}
+
+	dockerfilePath, err := resolveRepoPath(contextDir, dockerfile)
+	if err != nil {
+		return imageBuildSpec{}, fmt.Errorf("invalid dockerfile: %w", err)
+	}
+
+	dockerfile, err = relativeToDir(contextDir, dockerfilePath)
+	if err != nil {
+		return imageBuildSpec{}, fmt.Errorf("invalid dockerfile: %w", err)
+	}
+
+	return imageBuildSpec{
+		ContextDir: contextDir,
+		Dockerfile: dockerfile,
+		Args:       build.Args,
+		Target:     build.Target,
+		CacheFrom:  build.CacheFrom,
+	}, nil
+}
+
+// composeFilePaths resolves the paths of the compose files, which are relative to the devcontainer.json file.
+// The project directory is the directory of the first compose file.
+func composeFilePaths(files []string) ([]string, string, error) {
+	if len(files) == 0 {
+		return nil, "", errors.New("no compose files provided")
+	}
+
+	paths := make([]string, len(files))
+	for i, file := range files {
+		filePath, err := resolveRepoPath(scm.DevcontainerDir, file)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid dockerComposeFile: %w", err)
+		}
+		paths[i] = filePath
+	}
+
+	return paths, path.Dir(paths[0]), nil
+}
@@ -0,0 +1,185 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseComposeFile(t *testing.T) {
+	content := []byte(`
+services:
+  app:
+    build:
+      context: ..
+      dockerfile: .devcontainer/Dockerfile
+      args:
+        - VARIANT=3.12
+    environment:
+      DB_HOST: db
+      DB_PORT: 5432
+      FROM_HOST:
+    depends_on:
+      db:
+        condition: service_healthy
+  db:
+    image: postgres:16
+    command: postgres -c "log_statement=all"
+    volumes:
+      - db-data:/var/lib/postgresql/data
+      - ./init:/docker-entrypoint-initdb.d:ro
+      - /tmp
+  cache:
+    build: ./cache
+`)
+
+	project, err := parseComposeFile(content)
+	if err != nil {
+		t.Fatalf("failed to parse compose file: %v", err)
+	}
+
+	app := project.Services["app"]
+	if app.Build == nil || app.Build.Context != ".." || app.Build.Args["VARIANT"] != "3.12" {
+		t.Errorf("unexpected build of app: %+v", app.Build)
+	}
+	if want := []string{"DB_HOST=db", "DB_PORT=5432"}; !reflect.DeepEqual(app.Environment.List(), want) {
+		t.Errorf("environment: got %v, want %v", app.Environment.List(), want)
+	}
+	if want := []string{"db"}; !reflect.DeepEqual([]string(app.DependsOn), want) {
+		t.Errorf("depends_on: got %v, want %v", app.DependsOn, want)
+	}
+
+	db := project.Services["db"]
+	if want := []string{"postgres", "-c", "log_statement=all"}; !reflect.DeepEqual([]string(db.Command), want) {
+		t.Errorf("command: got %v, want %v", db.Command, want)
+	}
+	wantVolumes := []composeVolume{
+		{Type: composeVolumeTypeVolume, Source: "db-data", Target: "/var/lib/postgresql/data"},
+		{Type: composeVolumeTypeBind, Source: "./init", Target: "/docker-entrypoint-initdb.d", ReadOnly: true},
+		{Type: composeVolumeTypeVolume, Target: "/tmp"},
+	}
+	if !reflect.DeepEqual(db.Volumes, wantVolumes) {
+		t.Errorf("volumes: got %+v, want %+v", db.Volumes, wantVolumes)
+	}
+
+	if cache := project.Services["cache"]; cache.Build == nil || cache.Build.Context != "./cache" {
+		t.Errorf("unexpected build of cache: %+v", cache.Build)
+	}
+}
+
+func TestComposeServicesToStart(t *testing.T) {
+	project := &composeProject{Services: map[string]*composeService{
+		"app":    {DependsOn: composeDependsOn{"db"}},
+		"db":     {},
+		"cache":  {},
+		"worker": {DependsOn: composeDependsOn{"cache", "db"}},
+	}}
+
+	tests := []struct {
+		name        string
+		runServices []string
+		want        []string
+		wantErr     bool
+	}{
+		{name: "all services", want: []string{"db", "cache", "worker"}},
+		{name: "dependencies of the primary service", runServices: []string{"app"}, want: []string{"db"}},
+		{name: "dependencies first", runServices: []string{"worker"}, want: []string{"db", "cache", "worker"}},
+		{name: "unknown service", runServices: []string{"web"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := composeServicesToStart(project, "app", test.runServices)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestComposeServicesToStartCycle(t *testing.T) {
+	project := &composeProject{Services: map[string]*composeService{
+		"app": {},
+		"a":   {DependsOn: composeDependsOn{"b"}},
+		"b":   {DependsOn: composeDependsOn{"a"}},
+	}}
+
+	if _, err := composeServicesToStart(project, "app", nil); err == nil {
+		t.Error("expected an error for circular dependencies")
+	}
+}
+
+func TestBuildSpec(t *testing.T) {
+	tests := []struct {
+		name       string
+		projectDir string
+		build      *composeBuild
+		want       imageBuildSpec
+		wantErr    bool
+	}{
+		{
+			name:       "repository root context",
+			projectDir: ".devcontainer",
+			build:      &composeBuild{Context: "..", Dockerfile: ".devcontainer/Dockerfile"},
+			want:       imageBuildSpec{ContextDir: ".", Dockerfile: ".devcontainer/Dockerfile"},
+		},
+		{
+			name:       "default dockerfile",
+			projectDir: ".",
+			build:      &composeBuild{Context: "./cache"},
+			want:       imageBuildSpec{ContextDir: "cache", Dockerfile: "Dockerfile"},
+		},
+		{
+			name:       "context outside of the repository",
+			projectDir: ".devcontainer",
+			build:      &composeBuild{Context: "../.."},
+			wantErr:    true,
+		},
+		{
+			name:       "dockerfile outside of the context",
+			projectDir: ".",
+			build:      &composeBuild{Context: "app", Dockerfile: "../Dockerfile"},
+			wantErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := composeBuildSpec(test.projectDir, test.build)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/app/gitspace/scm"
+	gitspaceTypes "github.com/harness/gitness/app/gitspace/types"
+	"github.com/harness/gitness/types"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	gitspaceBuildHashLabel = "gitspace.build.hash"
+	defaultDockerfile      = "Dockerfile"
+)
+
+// imageBuildSpec describes an image built from the repository of a gitspace.
+// ContextDir is relative to the repository root and Dockerfile is relative to ContextDir.
+type imageBuildSpec struct {
+	ContextDir string
+	Dockerfile string
+	Args       map[string]string
+	Target     string
+	CacheFrom  []string
+}
+
+// devcontainerBuildSpec resolves the build properties of a devcontainer.json file.
+// Both the context and the Dockerfile are relative to the directory of the devcontainer.json file.
+func devcontainerBuildSpec(build *types.DevcontainerBuild) (imageBuildSpec, error) {
+	if build.Dockerfile == "" {
+		return imageBuildSpec{}, errors.New("build.dockerfile is required")
+	}
+
+	contextPath := build.Context
+	if contextPath == "" {
+		contextPath = "."
+	}
+
+	contextDir, err := resolveRepoPath(scm.DevcontainerDir, contextPath)
+	if err != nil {
+		return imageBuildSpec{}, fmt.Errorf("invalid build.context: %w", err)
+	}
+
+	dockerfilePath, err := resolveRepoPath(scm.DevcontainerDir, build.Dockerfile)
+	if err != nil {
+		return imageBuildSpec{}, fmt.Errorf("invalid build.dockerfile: %w", err)
+	}
+
+	dockerfile, err := relativeToDir(contextDir, dockerfilePath)
+	if err != nil {
+		return imageBuildSpec{}, fmt.Errorf("invalid build.dockerfile: %w", err)
+	}
+
+	return imageBuildSpec{
+		ContextDir: contextDir,
+		Dockerfile: dockerfile,
+		Args:       build.Args,
+		Target:     build.Target,
+		CacheFrom:  build.CacheFrom,
+	}, nil
+}
+
+// resolveRepoPath resolves a relative path against a directory of the repository.
+// It fails if the resulting path is outside the repository.
+func resolveRepoPath(baseDir string, p string) (string, error) {
+	if path.IsAbs(p) {
+		return "", fmt.Errorf("path %q must be relative", p)
+	}
+
+	resolved := path.Clean(path.Join(baseDir, p))
+	if resolved == ".." || strings.HasPrefix(resolved, "../") {
+		return "", fmt.Errorf("path %q points outside of the repository", p)
+	}
+
+	return resolved, nil
+}
+
+// relativeToDir returns the path of a file of the repository relative to a directory of the repository.
+// It fails if the file isn't inside the directory.
+func relativeToDir(dir string, filePath string) (string, error) {
+	if dir == "." {
+		return filePath, nil
+	}
+
+	rel, ok := strings.CutPrefix(filePath, dir+"/")
+	if !ok {
+		return "", fmt.Errorf("file %q must be inside the build context %q", filePath, dir)
+	}
+
+	return rel, nil
+}
+
+// getBuildImageName returns the name of the image built for a gitspace config.
+// The name is stable across gitspace instances, so the image and its layers are reused as the build cache.
+func getBuildImageName(gitspaceConfig types.GitspaceConfig, suffix string) string {
+	tag := strings.ToLower(GetGitspaceContainerName(gitspaceConfig))
+	if suffix != "" {
+		tag += "-" + strings.ToLower(suffix)
+	}
+	return "gitspace-build:" + tag
+}
+
+// BuildImage builds an image from a directory of the gitspace repository.
+// The build is skipped if the image was already built from identical sources.
+func (e *EmbeddedDockerOrchestrator) BuildImage(
+	ctx context.Context,
+	dockerClient *client.Client,
+	gitspaceConfig types.GitspaceConfig,
+	resolvedRepoDetails scm.ResolvedDetails,
+	spec imageBuildSpec,
+	imageName string,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+) error {
+	gitspaceLogger.Info(fmt.Sprintf("Fetching build context %s", spec.ContextDir))
+
+	buildContext, err := os.CreateTemp("", "gitspace-build-context-*.tar")
+	if err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error creating build context file", err)
+	}
+	defer func() {
+		_ = buildContext.Close()
+		if err := os.Remove(buildContext.Name()); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to remove build context file %s", buildContext.Name())
+		}
+	}()
+
+	hasher := sha256.New()
+
+	err = e.scm.GetBuildContext(ctx, gitspaceConfig, &resolvedRepoDetails.ResolvedCredentials, spec.ContextDir,
+		io.MultiWriter(buildContext, hasher))
+	if err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error fetching build context", err)
+	}
+
+	buildHash, err := spec.hash(hasher)
+	if err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error computing build hash", err)
+	}
+
+	imageInspect, _, err := dockerClient.ImageInspectWithRaw(ctx, imageName)
+	if err == nil && imageInspect.Config != nil && imageInspect.Config.Labels[gitspaceBuildHashLabel] == buildHash {
+		gitspaceLogger.Info(fmt.Sprintf("Sources are unchanged, using the previously built image %s", imageName))
+		return nil
+	}
+
+	if _, err = buildContext.Seek(0, io.SeekStart); err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error reading build context file", err)
+	}
+
+	buildArgs := make(map[string]*string, len(spec.Args)+1)
+	for key, value := range spec.Args {
+		buildArgs[key] = &value
+	}
+	// embed the cache metadata in the image, so it can be used as a cache source of the next build.
+	inlineCache := "1"
+	buildArgs["BUILDKIT_INLINE_CACHE"] = &inlineCache
+
+	gitspaceLogger.Info(fmt.Sprintf("Building image %s from %s", imageName, path.Join(spec.ContextDir, spec.Dockerfile)))
+
+	buildRes, err := dockerClient.ImageBuild(ctx, buildContext, dockerTypes.ImageBuildOptions{
+		Tags:       []string{imageName},
+		Dockerfile: spec.Dockerfile,
+		BuildArgs:  buildArgs,
+		Target:     spec.Target,
+		CacheFrom:  append(spec.CacheFrom, imageName),
+		Labels:     map[string]string{gitspaceBuildHashLabel: buildHash},
+		Remove:     true,
+		Version:    dockerTypes.BuilderBuildKit,
+	})
+	if err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error while building image", err)
+	}
+	defer func() {
+		if closeErr := buildRes.Body.Close(); closeErr != nil {
+			log.Ctx(ctx).Warn().Err(closeErr).Msg("failed to close docker image build response body")
+		}
+	}()
+
+	if err = processImageBuildResponse(buildRes.Body, gitspaceLogger); err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error while building image", err)
+	}
+
+	gitspaceLogger.Info(fmt.Sprintf("Built image %s", imageName))
+
+	return nil
+}
+
+// hash returns the hash of the build sources, including the build options, given a hash of the build context.
+func (spec imageBuildSpec) hash(contextHasher hash.Hash) (string, error) {
+	keys := make([]string, 0, len(spec.Args))
+	for key := range spec.Args {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	args := make([]string, len(keys))
+	for i, key := range keys {
+		args[i] = key + "=" + spec.Args[key]
+	}
+
+	options, err := json.Marshal(struct {
+		Dockerfile string
+		Target     string
+		Args       []string
+	}{
+		Dockerfile: spec.Dockerfile,
+		Target:     spec.Target,
+		Args:       args,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	_, _ = contextHasher.Write(options)
+
+	return hex.EncodeToString(contextHasher.Sum(nil)), nil
+}
+
+func processImageBuildResponse(buildResponse io.Reader, gitspaceLogger gitspaceTypes.GitspaceLogger) error {
+	decoder := json.NewDecoder(buildResponse)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to decode image build response: %w", err)
+		}
+
+		if msg.Error != nil {
+			return msg.Error
+		}
+
+		if line := strings.TrimSpace(msg.Stream); line != "" {
+			gitspaceLogger.Info(line)
+		} else if msg.Status != "" {
+			gitspaceLogger.Info(msg.Status)
+		}
+	}
+}
@@ -18,7 +18,9 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/harness/gitness/app/gitspace/orchestrator/ide"
@@ -27,6 +29,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/docker/docker/api/types/mount"
 	"github.com/rs/zerolog/log"
 )
 
@@ -185,14 +188,115 @@ func ExtractForwardPorts(devcontainerConfig types.DevcontainerConfig) []int {
 }
 
 func ExtractLifecycleCommands(actionType PostAction, devcontainerConfig types.DevcontainerConfig) []string {
+	command := getLifecycleCommand(actionType, devcontainerConfig)
+	if command == nil {
+		return []string{} // Return empty string if actionType is not recognized
+	}
+	return command.ToCommandArray()
+}
+
+func getLifecycleCommand(actionType PostAction, devcontainerConfig types.DevcontainerConfig) *types.LifecycleCommand {
 	switch actionType {
+	case OnCreateAction:
+		return &devcontainerConfig.OnCreateCommand
+	case UpdateContentAction:
+		return &devcontainerConfig.UpdateContentCommand
 	case PostCreateAction:
-		return devcontainerConfig.PostCreateCommand.ToCommandArray()
+		return &devcontainerConfig.PostCreateCommand
 	case PostStartAction:
-		return devcontainerConfig.PostStartCommand.ToCommandArray()
+		return &devcontainerConfig.PostStartCommand
+	case PostAttachAction:
+		return &devcontainerConfig.PostAttachCommand
+	case InitializeAction:
+		return nil // the initializeCommand runs on the host, which isn't supported
 	default:
-		return []string{} // Return empty string if actionType is not recognized
+		return nil
+	}
+}
+
+func getFeatureLifecycleCommand(
+	actionType PostAction,
+	featureConfig *types.DevcontainerFeatureConfig,
+) *types.LifecycleCommand {
+	switch actionType {
+	case OnCreateAction:
+		return &featureConfig.OnCreateCommand
+	case UpdateContentAction:
+		return &featureConfig.UpdateContentCommand
+	case PostCreateAction:
+		return &featureConfig.PostCreateCommand
+	case PostStartAction:
+		return &featureConfig.PostStartCommand
+	case PostAttachAction:
+		return &featureConfig.PostAttachCommand
+	case InitializeAction:
+		return nil // features can't provide an initializeCommand
+	default:
+		return nil
+	}
+}
+
+// getWaitForAction returns the lifecycle action the gitspace waits for before it's reported as started.
+// It defaults to the updateContentCommand, as in the dev container specification.
+func getWaitForAction(devcontainerConfig types.DevcontainerConfig) PostAction {
+	for action, name := range lifecycleCommandNames {
+		if action != PostAttachAction && name == devcontainerConfig.WaitFor {
+			return action
+		}
+	}
+	return UpdateContentAction
+}
+
+// splitActionsByWaitFor splits the lifecycle actions into the ones that must complete before
+// the gitspace is reported as started and the ones that continue in the background.
+func splitActionsByWaitFor(actions []PostAction, waitFor PostAction) ([]PostAction, []PostAction) {
+	waitForIdx := slices.Index(createActions, waitFor)
+
+	var foreground, background []PostAction
+	for _, action := range actions {
+		if slices.Index(createActions, action) <= waitForIdx {
+			foreground = append(foreground, action)
+		} else {
+			background = append(background, action)
+		}
+	}
+
+	return foreground, background
+}
+
+// getCodeRepoDir returns the absolute path of the repository in the gitspace container.
+// It's the workspaceFolder if one is set, otherwise a directory named after the repository in the user home.
+func getCodeRepoDir(devcontainerConfig types.DevcontainerConfig, homeDir string, repoName string) string {
+	if devcontainerConfig.WorkspaceFolder != "" {
+		return path.Clean(devcontainerConfig.WorkspaceFolder)
+	}
+	return filepath.Join(homeDir, repoName)
+}
+
+// getWorkspaceMount resolves the workspaceMount of the devcontainer.json file.
+// It returns nil for bind mounts: the repository is cloned into the gitspace rather than mounted from the host,
+// so a bind mount of the local workspace folder has no counterpart.
+func getWorkspaceMount(devcontainerConfig types.DevcontainerConfig, repoName string) (*types.Mount, error) {
+	if devcontainerConfig.WorkspaceMount == "" {
+		return nil, nil //nolint:nilnil
 	}
+
+	mountStr := strings.NewReplacer(
+		"${localWorkspaceFolderBasename}", repoName,
+		"${containerWorkspaceFolderBasename}", path.Base(devcontainerConfig.WorkspaceFolder),
+		"${containerWorkspaceFolder}", devcontainerConfig.WorkspaceFolder,
+	).Replace(devcontainerConfig.WorkspaceMount)
+
+	mounts, err := types.ParseMountsFromStringSlice([]string{mountStr})
+	if err != nil {
+		return nil, fmt.Errorf("invalid workspaceMount %q: %w", devcontainerConfig.WorkspaceMount, err)
+	}
+
+	if mounts[0].Type == string(mount.TypeBind) {
+		return nil, nil //nolint:nilnil
+	}
+
+	return mounts[0], nil
 }
 
 func AddIDECustomizationsArg(
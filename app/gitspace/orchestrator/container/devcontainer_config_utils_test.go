@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestSplitActionsByWaitFor(t *testing.T) {
+	tests := []struct {
+		name           string
+		waitFor        string
+		actions        []PostAction
+		wantForeground []PostAction
+		wantBackground []PostAction
+	}{
+		{
+			name:           "default waits for updateContentCommand",
+			actions:        createActions,
+			wantForeground: []PostAction{OnCreateAction, UpdateContentAction},
+			wantBackground: []PostAction{PostCreateAction, PostStartAction, PostAttachAction},
+		},
+		{
+			name:           "wait for postStartCommand",
+			waitFor:        "postStartCommand",
+			actions:        createActions,
+			wantForeground: []PostAction{OnCreateAction, UpdateContentAction, PostCreateAction, PostStartAction},
+			wantBackground: []PostAction{PostAttachAction},
+		},
+		{
+			name:           "wait for the skipped initializeCommand",
+			waitFor:        "initializeCommand",
+			actions:        createActions,
+			wantForeground: nil,
+			wantBackground: []PostAction{OnCreateAction, UpdateContentAction, PostCreateAction, PostStartAction,
+				PostAttachAction},
+		},
+		{
+			name:           "restart waits for initializeCommand",
+			waitFor:        "initializeCommand",
+			actions:        startActions,
+			wantForeground: nil,
+			wantBackground: []PostAction{PostStartAction, PostAttachAction},
+		},
+		{
+			name:           "unknown value falls back to the default",
+			waitFor:        "postAttachCommand",
+			actions:        startActions,
+			wantForeground: nil,
+			wantBackground: []PostAction{PostStartAction, PostAttachAction},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			waitFor := getWaitForAction(types.DevcontainerConfig{WaitFor: test.waitFor})
+			foreground, background := splitActionsByWaitFor(test.actions, waitFor)
+			if !reflect.DeepEqual(foreground, test.wantForeground) {
+				t.Errorf("foreground: want %v, got %v", test.wantForeground, foreground)
+			}
+			if !reflect.DeepEqual(background, test.wantBackground) {
+				t.Errorf("background: want %v, got %v", test.wantBackground, background)
+			}
+		})
+	}
+}
+
+func TestMergeLifeCycleHooksSkipsInitializeCommand(t *testing.T) {
+	config := types.DevcontainerConfig{}
+	err := json.Unmarshal([]byte(`{
+		"initializeCommand": "touch /tmp/host",
+		"onCreateCommand": "make setup"
+	}`), &config)
+	if err != nil {
+		t.Fatalf("failed to parse devcontainer config: %s", err)
+	}
+
+	hooks := mergeLifeCycleHooks(config, nil)
+
+	if len(hooks[InitializeAction]) != 0 {
+		t.Errorf("initializeCommand must not be executed in the container, got %d hooks",
+			len(hooks[InitializeAction]))
+	}
+	if len(hooks[OnCreateAction]) != 1 {
+		t.Errorf("want 1 onCreateCommand hook, got %d", len(hooks[OnCreateAction]))
+	}
+}
+
+func TestGetWorkspaceMount(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    types.DevcontainerConfig
+		want      *types.Mount
+		wantError bool
+	}{
+		{
+			name:   "no workspace mount",
+			config: types.DevcontainerConfig{},
+		},
+		{
+			name: "bind mount is ignored",
+			config: types.DevcontainerConfig{
+				WorkspaceMount: "source=${localWorkspaceFolder},target=/workspace,type=bind,consistency=cached",
+			},
+		},
+		{
+			name: "volume mount with variables",
+			config: types.DevcontainerConfig{
+				WorkspaceFolder: "/workspaces/app",
+				WorkspaceMount: "source=${localWorkspaceFolderBasename}-data," +
+					"target=${containerWorkspaceFolder},type=volume",
+			},
+			want: &types.Mount{Source: "repo-data", Target: "/workspaces/app", Type: "volume"},
+		},
+		{
+			name:      "invalid mount",
+			config:    types.DevcontainerConfig{WorkspaceMount: "target"},
+			wantError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := getWorkspaceMount(test.config, "repo")
+			if test.wantError {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
@@ -23,7 +23,6 @@ import (
 	"fmt"
 	"io"
 	"os/exec"
-	"path/filepath"
 	goruntime "runtime"
 	"strconv"
 	"strings"
@@ -146,13 +145,15 @@ func CreateContainer(
 	features []*types.ResolvedFeature,
 	devcontainerConfig types.DevcontainerConfig,
 	metadataFromImage map[string]any,
+	codeRepoDir string,
+	workspaceMount *types.Mount,
 ) (map[PostAction][]*LifecycleHookStep, error) {
 	exposedPorts, portBindings := applyPortMappings(portMappings)
 
 	gitspaceLogger.Info(fmt.Sprintf("Creating container %s with image %s", containerName, imageName))
 
 	hostConfig, err := prepareHostConfig(bindMountSource, bindMountTarget, mountType, portBindings, runArgsMap,
-		features, devcontainerConfig, metadataFromImage)
+		features, devcontainerConfig, metadataFromImage, workspaceMount)
 	if err != nil {
 		return nil, err
 	}
@@ -172,6 +173,11 @@ func CreateContainer(
 		cmd = []string{"-c", "trap 'exit 0' 15; sleep infinity & wait $!"}
 	}
 
+	if len(devcontainerConfig.InitializeCommand.ToCommandArray()) > 0 {
+		gitspaceLogger.Warn("Skipping initializeCommand: it runs on the host machine, " +
+			"which isn't supported for gitspaces.")
+	}
+
 	lifecycleHookSteps := mergeLifeCycleHooks(devcontainerConfig, features)
 	lifecycleHookStepsStr, err := json.Marshal(lifecycleHookSteps)
 	if err != nil {
@@ -186,6 +192,9 @@ func CreateContainer(
 	// Setting the following so that it can be read later to run the postStartCommands during restarts.
 	labels[gitspaceLifeCycleHooksLabel] = string(lifecycleHookStepsStr)
 
+	// Setting the following so that it can be read later to form gitspace URL and run commands in the repository.
+	labels[gitspaceWorkspaceFolderLabel] = codeRepoDir
+
 	// Create the container
 	containerConfig := &container.Config{
 		Hostname:     getHostname(runArgsMap),
@@ -215,49 +224,36 @@ func mergeLifeCycleHooks(
 	devcontainerConfig types.DevcontainerConfig,
 	features []*types.ResolvedFeature,
 ) map[PostAction][]*LifecycleHookStep {
-	var postCreateHooks []*LifecycleHookStep
-	var postStartHooks []*LifecycleHookStep
-	for _, feature := range features {
-		if len(feature.DownloadedFeature.DevcontainerFeatureConfig.PostCreateCommand.ToCommandArray()) > 0 {
-			postCreateHooks = append(postCreateHooks, &LifecycleHookStep{
-				Source:        feature.DownloadedFeature.Source,
-				Command:       feature.DownloadedFeature.DevcontainerFeatureConfig.PostCreateCommand,
-				ActionType:    PostCreateAction,
-				StopOnFailure: true,
-			})
+	lifecycleHooks := make(map[PostAction][]*LifecycleHookStep, len(createActions))
+
+	for _, action := range createActions {
+		var hooks []*LifecycleHookStep
+
+		for _, feature := range features {
+			command := getFeatureLifecycleCommand(action, feature.DownloadedFeature.DevcontainerFeatureConfig)
+			if command != nil && len(command.ToCommandArray()) > 0 {
+				hooks = append(hooks, &LifecycleHookStep{
+					Source:        feature.DownloadedFeature.Source,
+					Command:       *command,
+					ActionType:    action,
+					StopOnFailure: true,
+				})
+			}
 		}
-		if len(feature.DownloadedFeature.DevcontainerFeatureConfig.PostStartCommand.ToCommandArray()) > 0 {
-			postStartHooks = append(postStartHooks, &LifecycleHookStep{
-				Source:        feature.DownloadedFeature.Source,
-				Command:       feature.DownloadedFeature.DevcontainerFeatureConfig.PostStartCommand,
-				ActionType:    PostStartAction,
-				StopOnFailure: true,
+
+		if command := getLifecycleCommand(action, devcontainerConfig); len(command.ToCommandArray()) > 0 {
+			hooks = append(hooks, &LifecycleHookStep{
+				Source:        "devcontainer.json",
+				Command:       *command,
+				ActionType:    action,
+				StopOnFailure: false,
 			})
 		}
-	}
-
-	if len(devcontainerConfig.PostCreateCommand.ToCommandArray()) > 0 {
-		postCreateHooks = append(postCreateHooks, &LifecycleHookStep{
-			Source:        "devcontainer.json",
-			Command:       devcontainerConfig.PostCreateCommand,
-			ActionType:    PostCreateAction,
-			StopOnFailure: false,
-		})
-	}
 
-	if len(devcontainerConfig.PostStartCommand.ToCommandArray()) > 0 {
-		postStartHooks = append(postStartHooks, &LifecycleHookStep{
-			Source:        "devcontainer.json",
-			Command:       devcontainerConfig.PostStartCommand,
-			ActionType:    PostStartAction,
-			StopOnFailure: false,
-		})
+		lifecycleHooks[action] = hooks
 	}
 
-	return map[PostAction][]*LifecycleHookStep{
-		PostCreateAction: postCreateHooks,
-		PostStartAction:  postStartHooks,
-	}
+	return lifecycleHooks
 }
 
 func mergeEntrypoints(
@@ -303,6 +299,7 @@ func prepareHostConfig(
 	features []*types.ResolvedFeature,
 	devcontainerConfig types.DevcontainerConfig,
 	metadataFromImage map[string]any,
+	workspaceMount *types.Mount,
 ) (*container.HostConfig, error) {
 	hostResources, err := getHostResources(runArgsMap)
 	if err != nil {
@@ -335,7 +332,8 @@ func prepareHostConfig(
 		Target: bindMountTarget,
 	}
 
-	mergedMounts, err := mergeMounts(devcontainerConfig, runArgsMap, features, defaultMount, metadataFromImage)
+	mergedMounts, err := mergeMounts(devcontainerConfig, runArgsMap, features, defaultMount, metadataFromImage,
+		workspaceMount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to merge mounts: %w", err)
 	}
@@ -378,6 +376,7 @@ func mergeMounts(
 	features []*types.ResolvedFeature,
 	defaultMount mount.Mount,
 	metadataFromImage map[string]any,
+	workspaceMount *types.Mount,
 ) ([]mount.Mount, error) {
 	var allMountsRaw []*types.Mount
 	for _, feature := range features {
@@ -408,6 +407,10 @@ func mergeMounts(
 		}
 	}
 
+	if workspaceMount != nil {
+		allMountsRaw = append(allMountsRaw, workspaceMount)
+	}
+
 	var allMounts []mount.Mount
 	for _, rawMount := range allMountsRaw {
 		if rawMount.Type == "" {
@@ -557,10 +560,11 @@ func GetContainerInfo(
 	containerName string,
 	dockerClient *client.Client,
 	portMappings map[int]*types.PortMapping,
-) (string, map[int]string, string, error) {
+	repoName string,
+) (string, map[int]string, string, string, error) {
 	inspectResp, err := dockerClient.ContainerInspect(ctx, containerName)
 	if err != nil {
-		return "", nil, "", fmt.Errorf("could not inspect container %s: %w", containerName, err)
+		return "", nil, "", "", fmt.Errorf("could not inspect container %s: %w", containerName, err)
 	}
 
 	usedPorts := make(map[int]string)
@@ -568,7 +572,7 @@ func GetContainerInfo(
 		portRaw := strings.Split(string(portAndProtocol), "/")[0]
 		port, conversionErr := strconv.Atoi(portRaw)
 		if conversionErr != nil {
-			return "", nil, "", fmt.Errorf("could not convert port %s to int: %w", portRaw, conversionErr)
+			return "", nil, "", "", fmt.Errorf("could not convert port %s to int: %w", portRaw, conversionErr)
 		}
 
 		if portMappings[port] != nil {
@@ -577,8 +581,9 @@ func GetContainerInfo(
 	}
 
 	remoteUser := ExtractRemoteUserFromLabels(inspectResp)
+	codeRepoDir := ExtractWorkspaceFolderFromLabels(inspectResp, remoteUser, repoName)
 
-	return inspectResp.ID, usedPorts, remoteUser, nil
+	return inspectResp.ID, usedPorts, remoteUser, codeRepoDir, nil
 }
 
 func ExtractMetadataAndUserFromImage(
@@ -794,14 +799,12 @@ func GetContainerResponse(
 	portMappings map[int]*types.PortMapping,
 	repoName string,
 ) (*StartResponse, error) {
-	id, ports, remoteUser, err := GetContainerInfo(ctx, containerName, dockerClient, portMappings)
+	id, ports, remoteUser, codeRepoDir, err := GetContainerInfo(ctx, containerName, dockerClient, portMappings,
+		repoName)
 	if err != nil {
 		return nil, err
 	}
 
-	homeDir := GetUserHomeDir(remoteUser)
-	codeRepoDir := filepath.Join(homeDir, repoName)
-
 	return &StartResponse{
 		ContainerID:      id,
 		ContainerName:    containerName,
@@ -815,18 +818,20 @@ func GetGitspaceInfoFromContainerLabels(
 	ctx context.Context,
 	containerName string,
 	dockerClient *client.Client,
-) (string, map[PostAction][]*LifecycleHookStep, error) {
+	repoName string,
+) (string, string, map[PostAction][]*LifecycleHookStep, error) {
 	inspectResp, err := dockerClient.ContainerInspect(ctx, containerName)
 	if err != nil {
-		return "", nil, fmt.Errorf("could not inspect container %s: %w", containerName, err)
+		return "", "", nil, fmt.Errorf("could not inspect container %s: %w", containerName, err)
 	}
 
 	remoteUser := ExtractRemoteUserFromLabels(inspectResp)
+	codeRepoDir := ExtractWorkspaceFolderFromLabels(inspectResp, remoteUser, repoName)
 	lifecycleHooks, err := ExtractLifecycleHooksFromLabels(inspectResp)
 	if err != nil {
-		return "", nil, fmt.Errorf("could not extract lifecycle hooks: %w", err)
+		return "", "", nil, fmt.Errorf("could not extract lifecycle hooks: %w", err)
 	}
-	return remoteUser, lifecycleHooks, nil
+	return remoteUser, codeRepoDir, lifecycleHooks, nil
 }
 
 // Helper function to encode the AuthConfig into a Base64 string.
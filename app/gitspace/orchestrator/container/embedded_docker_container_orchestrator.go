@@ -17,7 +17,6 @@ package container
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 
 	"github.com/harness/gitness/app/gitspace/logutil"
 	"github.com/harness/gitness/app/gitspace/orchestrator/devcontainer"
@@ -44,6 +43,7 @@ type EmbeddedDockerOrchestrator struct {
 	dockerClientFactory *infraprovider.DockerClientFactory
 	statefulLogger      *logutil.StatefulLogger
 	runArgProvider      runarg.Provider
+	scm                 *scm.SCM
 }
 
 // Step represents a single setup action.
@@ -85,11 +85,13 @@ func NewEmbeddedDockerOrchestrator(
 	dockerClientFactory *infraprovider.DockerClientFactory,
 	statefulLogger *logutil.StatefulLogger,
 	runArgProvider runarg.Provider,
+	scm *scm.SCM,
 ) Orchestrator {
 	return &EmbeddedDockerOrchestrator{
 		dockerClientFactory: dockerClientFactory,
 		statefulLogger:      statefulLogger,
 		runArgProvider:      runArgProvider,
+		scm:                 scm,
 	}
 }
 
@@ -139,6 +141,7 @@ func (e *EmbeddedDockerOrchestrator) CreateAndStartGitspace(
 		if err = e.startStoppedGitspace(
 			ctx,
 			gitspaceConfig,
+			infra,
 			dockerClient,
 			resolvedRepoDetails,
 			accessKey,
@@ -174,6 +177,7 @@ func (e *EmbeddedDockerOrchestrator) CreateAndStartGitspace(
 func (e *EmbeddedDockerOrchestrator) startStoppedGitspace(
 	ctx context.Context,
 	gitspaceConfig types.GitspaceConfig,
+	infra types.Infrastructure,
 	dockerClient *client.Client,
 	resolvedRepoDetails scm.ResolvedDetails,
 	accessKey string,
@@ -188,7 +192,8 @@ func (e *EmbeddedDockerOrchestrator) startStoppedGitspace(
 	}
 	defer e.flushLogStream(logStreamInstance, gitspaceConfig.ID)
 
-	remoteUser, lifecycleHooks, err := GetGitspaceInfoFromContainerLabels(ctx, containerName, dockerClient)
+	remoteUser, codeRepoDir, lifecycleHooks, err := GetGitspaceInfoFromContainerLabels(
+		ctx, containerName, dockerClient, resolvedRepoDetails.RepoName)
 	if err != nil {
 		return fmt.Errorf("error getting remote user for gitspace instance %s: %w",
 			gitspaceConfig.GitspaceInstance.Identifier, err)
@@ -196,13 +201,15 @@ func (e *EmbeddedDockerOrchestrator) startStoppedGitspace(
 
 	homeDir := GetUserHomeDir(remoteUser)
 
+	if err = startComposeServices(ctx, dockerClient, containerName, logStreamInstance); err != nil {
+		return err
+	}
+
 	startErr := ManageContainer(ctx, ContainerActionStart, containerName, dockerClient, logStreamInstance)
 	if startErr != nil {
 		return startErr
 	}
 
-	codeRepoDir := filepath.Join(homeDir, resolvedRepoDetails.RepoName)
-
 	exec := &devcontainer.Exec{
 		ContainerName:     containerName,
 		DockerClient:      dockerClient,
@@ -221,30 +228,33 @@ func (e *EmbeddedDockerOrchestrator) startStoppedGitspace(
 
 	// Run IDE setup
 	runIDEArgs := make(map[gitspaceTypes.IDEArg]interface{})
-	runIDEArgs[gitspaceTypes.IDERepoNameArg] = resolvedRepoDetails.RepoName
+	runIDEArgs[gitspaceTypes.IDERepoDirArg] = codeRepoDir
 	runIDEArgs = AddIDEDirNameArg(ideService, runIDEArgs)
 	if err = ideService.Run(ctx, exec, runIDEArgs, logStreamInstance); err != nil {
 		return err
 	}
 
-	if len(lifecycleHooks) > 0 && len(lifecycleHooks[PostStartAction]) > 0 {
-		for _, lifecycleHook := range lifecycleHooks[PostStartAction] {
-			startErr = ExecuteLifecycleCommands(ctx, *exec, codeRepoDir, logStreamInstance,
-				lifecycleHook.Command.ToCommandArray(), PostStartAction)
-			if startErr != nil {
-				log.Warn().Msgf("Error in post-start command, continuing : %s", startErr.Error())
-			}
-		}
-	} else {
-		// Execute post-start command for the containers before this label was introduced
-		devcontainerConfig := resolvedRepoDetails.DevcontainerConfig
-		command := ExtractLifecycleCommands(PostStartAction, devcontainerConfig)
-		startErr = ExecuteLifecycleCommands(ctx, *exec, codeRepoDir, logStreamInstance, command, PostStartAction)
-		if startErr != nil {
-			log.Warn().Msgf("Error in post-start command, continuing : %s", startErr.Error())
-		}
+	// Execute the lifecycle commands from the devcontainer.json file for the containers
+	// created before the lifecycle hooks label was introduced.
+	if len(lifecycleHooks) == 0 {
+		lifecycleHooks = mergeLifeCycleHooks(resolvedRepoDetails.DevcontainerConfig, nil)
+	}
+
+	foregroundActions, backgroundActions := splitActionsByWaitFor(
+		startActions, getWaitForAction(resolvedRepoDetails.DevcontainerConfig))
+
+	// The lifecycle commands of a restart never stop the gitspace from starting.
+	steps := buildLifecycleHookSteps(lifecycleHooks, foregroundActions, codeRepoDir)
+	for i := range steps {
+		steps[i].StopOnFailure = false
+	}
+	if err = e.ExecuteSteps(ctx, exec, logStreamInstance, steps); err != nil {
+		return err
 	}
 
+	e.executeStepsInBackground(ctx, infra, *exec,
+		buildLifecycleHookSteps(lifecycleHooks, backgroundActions, codeRepoDir))
+
 	return nil
 }
 
@@ -310,7 +320,12 @@ func (e *EmbeddedDockerOrchestrator) stopRunningGitspace(
 	defer e.flushLogStream(logStreamInstance, gitspaceConfig.ID)
 
 	// Step 5: Stop the container
-	return ManageContainer(ctx, ContainerActionStop, containerName, dockerClient, logStreamInstance)
+	if err = ManageContainer(ctx, ContainerActionStop, containerName, dockerClient, logStreamInstance); err != nil {
+		return err
+	}
+
+	// Step 6: Stop the other services of Docker Compose based gitspaces
+	return stopComposeServices(ctx, dockerClient, containerName, logStreamInstance)
 }
 
 // Status is NOOP for EmbeddedDockerOrchestrator as the docker host is verified by the infra provisioner.
@@ -344,7 +359,7 @@ func (e *EmbeddedDockerOrchestrator) StopAndRemoveGitspace(
 	// Step 3: Handle container states
 	if state == ContainerStateRemoved {
 		logger.Debug().Msg("gitspace is already removed")
-		return nil
+		return removeComposeResources(ctx, dockerClient, containerName)
 	}
 
 	// Step 4: Create logger stream for stopping and removing the container
@@ -371,6 +386,11 @@ func (e *EmbeddedDockerOrchestrator) StopAndRemoveGitspace(
 		return fmt.Errorf("failed to remove gitspace %s: %w", containerName, err)
 	}
 
+	// Step 7: Remove the other services of Docker Compose based gitspaces
+	if err = removeComposeResources(ctx, dockerClient, containerName); err != nil {
+		return fmt.Errorf("failed to remove compose services of gitspace %s: %w", containerName, err)
+	}
+
 	logger.Debug().Msg("removed gitspace")
 	return nil
 }
@@ -404,7 +424,6 @@ func (e *EmbeddedDockerOrchestrator) runGitspaceSetupSteps(
 	containerName := GetGitspaceContainerName(gitspaceConfig)
 
 	devcontainerConfig := resolvedRepoDetails.DevcontainerConfig
-	imageName := getImage(devcontainerConfig, defaultBaseImage)
 
 	runArgsMap, err := ExtractRunArgsWithLogging(ctx, gitspaceConfig.SpaceID, e.runArgProvider,
 		devcontainerConfig.RunArgs, gitspaceLogger)
@@ -412,8 +431,10 @@ func (e *EmbeddedDockerOrchestrator) runGitspaceSetupSteps(
 		return err
 	}
 
-	// Pull the required image
-	if err = PullImage(ctx, imageName, dockerClient, runArgsMap, gitspaceLogger, imageAuthMap); err != nil {
+	// Prepare the required image
+	imageName, serviceEnvironment, err := e.prepareImage(ctx, dockerClient, gitspaceConfig, resolvedRepoDetails,
+		defaultBaseImage, runArgsMap, gitspaceLogger, imageAuthMap)
+	if err != nil {
 		return err
 	}
 
@@ -435,7 +456,7 @@ func (e *EmbeddedDockerOrchestrator) runGitspaceSetupSteps(
 	}
 
 	storage := infrastructure.Storage
-	environment := ExtractEnv(devcontainerConfig, runArgsMap)
+	environment := append(serviceEnvironment, ExtractEnv(devcontainerConfig, runArgsMap)...)
 	if len(environment) > 0 {
 		gitspaceLogger.Info(fmt.Sprintf("Setting Environment : %v", environment))
 	}
@@ -445,6 +466,15 @@ func (e *EmbeddedDockerOrchestrator) runGitspaceSetupSteps(
 
 	containerUserHomeDir := GetUserHomeDir(containerUser)
 	remoteUserHomeDir := GetUserHomeDir(remoteUser)
+	codeRepoDir := getCodeRepoDir(devcontainerConfig, remoteUserHomeDir, resolvedRepoDetails.RepoName)
+
+	workspaceMount, err := getWorkspaceMount(devcontainerConfig, resolvedRepoDetails.RepoName)
+	if err != nil {
+		return logStreamWrapError(gitspaceLogger, "Error resolving the workspace mount", err)
+	}
+	if workspaceMount == nil && devcontainerConfig.WorkspaceMount != "" {
+		gitspaceLogger.Warn("Ignoring the bind mount of workspaceMount, the repository is cloned into the gitspace")
+	}
 
 	gitspaceLogger.Info(fmt.Sprintf("Container user: %s", containerUser))
 	gitspaceLogger.Info(fmt.Sprintf("Remote user: %s", remoteUser))
@@ -480,11 +510,20 @@ func (e *EmbeddedDockerOrchestrator) runGitspaceSetupSteps(
 		features,
 		resolvedRepoDetails.DevcontainerConfig,
 		metadataFromImage,
+		codeRepoDir,
+		workspaceMount,
 	)
 	if err != nil {
 		return err
 	}
 
+	if devcontainerConfig.IsCompose() {
+		err = connectComposePrimaryService(ctx, dockerClient, containerName, devcontainerConfig.Service, gitspaceLogger)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Start the container
 	if err = ManageContainer(ctx, ContainerActionStart, containerName, dockerClient, gitspaceLogger); err != nil {
 		return err
@@ -506,9 +545,11 @@ func (e *EmbeddedDockerOrchestrator) runGitspaceSetupSteps(
 		gitspaceLogger,
 		ideService,
 		gitspaceConfig,
+		infrastructure,
 		resolvedRepoDetails,
 		defaultBaseImage,
 		environment,
+		codeRepoDir,
 		lifecycleHookSteps,
 	); err != nil {
 		return logStreamWrapError(gitspaceLogger, "Error while setting up gitspace", err)
@@ -517,6 +558,50 @@ func (e *EmbeddedDockerOrchestrator) runGitspaceSetupSteps(
 	return nil
 }
 
+// prepareImage builds or pulls the image of the gitspace container.
+// For Docker Compose based gitspaces, it also starts the other services and returns the environment
+// variables of the primary service, which the gitspace container takes the place of.
+func (e *EmbeddedDockerOrchestrator) prepareImage(
+	ctx context.Context,
+	dockerClient *client.Client,
+	gitspaceConfig types.GitspaceConfig,
+	resolvedRepoDetails scm.ResolvedDetails,
+	defaultBaseImage string,
+	runArgsMap map[types.RunArg]*types.RunArgValue,
+	gitspaceLogger gitspaceTypes.GitspaceLogger,
+	imageAuthMap map[string]gitspaceTypes.DockerRegistryAuth,
+) (string, []string, error) {
+	devcontainerConfig := resolvedRepoDetails.DevcontainerConfig
+
+	switch {
+	case devcontainerConfig.IsCompose():
+		return e.setupComposeServices(ctx, dockerClient, gitspaceConfig, resolvedRepoDetails, runArgsMap,
+			gitspaceLogger, imageAuthMap)
+
+	case devcontainerConfig.Build != nil:
+		spec, err := devcontainerBuildSpec(devcontainerConfig.Build)
+		if err != nil {
+			return "", nil, logStreamWrapError(gitspaceLogger, "Error resolving the devcontainer build", err)
+		}
+
+		imageName := getBuildImageName(gitspaceConfig, "")
+		err = e.BuildImage(ctx, dockerClient, gitspaceConfig, resolvedRepoDetails, spec, imageName, gitspaceLogger)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return imageName, nil, nil
+
+	default:
+		imageName := getImage(devcontainerConfig, defaultBaseImage)
+		if err := PullImage(ctx, imageName, dockerClient, runArgsMap, gitspaceLogger, imageAuthMap); err != nil {
+			return "", nil, err
+		}
+
+		return imageName, nil, nil
+	}
+}
+
 func InstallFeatures(
 	ctx context.Context,
 	gitspaceInstanceIdentifier string,
@@ -574,6 +659,7 @@ func (e *EmbeddedDockerOrchestrator) buildSetupSteps(
 	environment []string,
 	codeRepoDir string,
 	lifecycleHookSteps map[PostAction][]*LifecycleHookStep,
+	lifecycleActions []PostAction,
 ) []step {
 	steps := []step{
 		{
@@ -627,6 +713,20 @@ func (e *EmbeddedDockerOrchestrator) buildSetupSteps(
 			},
 			StopOnFailure: true,
 		},
+		{
+			Name: "Prepare Workspace Folder",
+			Execute: func(
+				ctx context.Context,
+				exec *devcontainer.Exec,
+				gitspaceLogger gitspaceTypes.GitspaceLogger,
+			) error {
+				if resolvedRepoDetails.DevcontainerConfig.WorkspaceFolder != "" {
+					return utils.PrepareWorkspaceFolder(ctx, exec, codeRepoDir, gitspaceLogger)
+				}
+				return nil
+			},
+			StopOnFailure: true,
+		},
 		{
 			Name: "Clone Code",
 			Execute: func(
@@ -634,7 +734,7 @@ func (e *EmbeddedDockerOrchestrator) buildSetupSteps(
 				exec *devcontainer.Exec,
 				gitspaceLogger gitspaceTypes.GitspaceLogger,
 			) error {
-				return utils.CloneCode(ctx, exec, resolvedRepoDetails, defaultBaseImage, gitspaceLogger)
+				return utils.CloneCode(ctx, exec, resolvedRepoDetails, defaultBaseImage, codeRepoDir, gitspaceLogger)
 			},
 			StopOnFailure: true,
 		},
@@ -648,7 +748,7 @@ func (e *EmbeddedDockerOrchestrator) buildSetupSteps(
 				// Run IDE setup
 				args := make(map[gitspaceTypes.IDEArg]interface{})
 				args = AddIDECustomizationsArg(ideService, resolvedRepoDetails.DevcontainerConfig, args)
-				args[gitspaceTypes.IDERepoNameArg] = resolvedRepoDetails.RepoName
+				args[gitspaceTypes.IDERepoDirArg] = codeRepoDir
 				args = AddIDEDownloadURLArg(ideService, args)
 				args = AddIDEDirNameArg(ideService, args)
 
@@ -664,62 +764,96 @@ func (e *EmbeddedDockerOrchestrator) buildSetupSteps(
 				gitspaceLogger gitspaceTypes.GitspaceLogger,
 			) error {
 				args := make(map[gitspaceTypes.IDEArg]interface{})
-				args[gitspaceTypes.IDERepoNameArg] = resolvedRepoDetails.RepoName
+				args[gitspaceTypes.IDERepoDirArg] = codeRepoDir
 				args = AddIDEDirNameArg(ideService, args)
 				return ideService.Run(ctx, exec, args, gitspaceLogger)
 			},
 			StopOnFailure: true,
 		}}
 
-	// Add the postCreateCommand lifecycle hooks to the steps
-	for _, lifecycleHook := range lifecycleHookSteps[PostCreateAction] {
-		steps = append(steps, step{
-			Name: fmt.Sprintf("Execute postCreateCommand from %s", lifecycleHook.Source),
-			Execute: func(
-				ctx context.Context,
-				exec *devcontainer.Exec,
-				gitspaceLogger gitspaceTypes.GitspaceLogger,
-			) error {
-				return ExecuteLifecycleCommands(ctx, *exec, codeRepoDir, gitspaceLogger,
-					lifecycleHook.Command.ToCommandArray(), PostCreateAction)
-			},
-			StopOnFailure: lifecycleHook.StopOnFailure,
-		})
-	}
+	// Add the lifecycle hooks to the steps
+	steps = append(steps, buildLifecycleHookSteps(lifecycleHookSteps, lifecycleActions, codeRepoDir)...)
 
-	// Add the postStartCommand lifecycle hooks to the steps
-	for _, lifecycleHook := range lifecycleHookSteps[PostStartAction] {
-		steps = append(steps, step{
-			Name: fmt.Sprintf("Execute postStartCommand from %s", lifecycleHook.Source),
-			Execute: func(
-				ctx context.Context,
-				exec *devcontainer.Exec,
-				gitspaceLogger gitspaceTypes.GitspaceLogger,
-			) error {
-				return ExecuteLifecycleCommands(ctx, *exec, codeRepoDir, gitspaceLogger,
-					lifecycleHook.Command.ToCommandArray(), PostStartAction)
-			},
-			StopOnFailure: lifecycleHook.StopOnFailure,
-		})
+	return steps
+}
+
+// buildLifecycleHookSteps constructs the steps executing the lifecycle hooks of the provided actions, in order.
+func buildLifecycleHookSteps(
+	lifecycleHookSteps map[PostAction][]*LifecycleHookStep,
+	lifecycleActions []PostAction,
+	codeRepoDir string,
+) []step {
+	var steps []step
+	for _, action := range lifecycleActions {
+		for _, lifecycleHook := range lifecycleHookSteps[action] {
+			steps = append(steps, step{
+				Name: fmt.Sprintf("Execute %s from %s", lifecycleCommandNames[action], lifecycleHook.Source),
+				Execute: func(
+					ctx context.Context,
+					exec *devcontainer.Exec,
+					gitspaceLogger gitspaceTypes.GitspaceLogger,
+				) error {
+					return ExecuteLifecycleCommands(ctx, *exec, codeRepoDir, gitspaceLogger,
+						lifecycleHook.Command.ToCommandArray(), action)
+				},
+				StopOnFailure: lifecycleHook.StopOnFailure,
+			})
+		}
 	}
 
 	return steps
 }
 
+// executeStepsInBackground executes the steps once the gitspace is reported as started.
+// The steps get a docker client of their own, as the one of the caller is closed when it returns,
+// and log to the server logs, as the log stream of the caller is flushed when it returns.
+func (e *EmbeddedDockerOrchestrator) executeStepsInBackground(
+	ctx context.Context,
+	infra types.Infrastructure,
+	exec devcontainer.Exec,
+	steps []step,
+) {
+	if len(steps) == 0 {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		logger := log.Ctx(ctx).With().Str(loggingKey, exec.ContainerName).Logger()
+
+		dockerClient, err := e.getDockerClient(ctx, infra)
+		if err != nil {
+			logger.Warn().Err(err).Msg("failed to execute lifecycle commands in background")
+			return
+		}
+		defer e.closeDockerClient(dockerClient)
+
+		exec.DockerClient = dockerClient
+
+		if err = e.ExecuteSteps(ctx, &exec, gitspaceTypes.NewZerologAdapter(&logger), steps); err != nil {
+			logger.Warn().Err(err).Msg("failed to execute lifecycle commands in background")
+		}
+	}()
+}
+
 // setupGitspaceAndIDE initializes Gitspace and IdeType by registering and executing the setup steps.
+// The lifecycle hooks following the one the devcontainer.json file waits for are executed in the background.
 func (e *EmbeddedDockerOrchestrator) setupGitspaceAndIDE(
 	ctx context.Context,
 	exec *devcontainer.Exec,
 	gitspaceLogger gitspaceTypes.GitspaceLogger,
 	ideService ide.IDE,
 	gitspaceConfig types.GitspaceConfig,
+	infra types.Infrastructure,
 	resolvedRepoDetails scm.ResolvedDetails,
 	defaultBaseImage string,
 	environment []string,
+	codeRepoDir string,
 	lifecycleHookSteps map[PostAction][]*LifecycleHookStep,
 ) error {
-	homeDir := GetUserHomeDir(exec.RemoteUser)
-	codeRepoDir := filepath.Join(homeDir, resolvedRepoDetails.RepoName)
+	foregroundActions, backgroundActions := splitActionsByWaitFor(
+		createActions, getWaitForAction(resolvedRepoDetails.DevcontainerConfig))
 
 	steps := e.buildSetupSteps(
 		ideService,
@@ -729,12 +863,22 @@ func (e *EmbeddedDockerOrchestrator) setupGitspaceAndIDE(
 		environment,
 		codeRepoDir,
 		lifecycleHookSteps,
+		foregroundActions,
 	)
 
 	// Execute the registered steps
 	if err := e.ExecuteSteps(ctx, exec, gitspaceLogger, steps); err != nil {
 		return err
 	}
+
+	if len(backgroundActions) > 0 {
+		gitspaceLogger.Info(fmt.Sprintf("Gitspace is ready, executing the remaining lifecycle commands in background: %v",
+			backgroundActions))
+	}
+
+	e.executeStepsInBackground(ctx, infra, *exec,
+		buildLifecycleHookSteps(lifecycleHookSteps, backgroundActions, codeRepoDir))
+
 	return nil
 }
 
@@ -25,10 +25,41 @@ type StartResponse struct {
 type PostAction string
 
 const (
-	PostCreateAction PostAction = "post-create"
-	PostStartAction  PostAction = "post-start"
+	InitializeAction    PostAction = "initialize"
+	OnCreateAction      PostAction = "on-create"
+	UpdateContentAction PostAction = "update-content"
+	PostCreateAction    PostAction = "post-create"
+	PostStartAction     PostAction = "post-start"
+	PostAttachAction    PostAction = "post-attach"
 )
 
+// createActions are the lifecycle actions executed when a gitspace container is created, in order.
+// The initializeCommand isn't executed, as the specification runs it on the host machine
+// before the container is created, and gitspaces don't run commands on their host.
+var createActions = []PostAction{
+	OnCreateAction,
+	UpdateContentAction,
+	PostCreateAction,
+	PostStartAction,
+	PostAttachAction,
+}
+
+// startActions are the lifecycle actions executed when a stopped gitspace container is started again, in order.
+var startActions = []PostAction{
+	PostStartAction,
+	PostAttachAction,
+}
+
+// lifecycleCommandNames maps the lifecycle actions to the names of their devcontainer.json properties.
+var lifecycleCommandNames = map[PostAction]string{
+	InitializeAction:    "initializeCommand",
+	OnCreateAction:      "onCreateCommand",
+	UpdateContentAction: "updateContentCommand",
+	PostCreateAction:    "postCreateCommand",
+	PostStartAction:     "postStartCommand",
+	PostAttachAction:    "postAttachCommand",
+}
+
 type State string
 
 const (
@@ -33,6 +33,8 @@ const (
 	deprecatedRemoteUser        = "harness"
 	gitspaceRemoteUserLabel     = "gitspace.remote.user"
 	gitspaceLifeCycleHooksLabel = "gitspace.lifecycle.hooks"
+
+	gitspaceWorkspaceFolderLabel = "gitspace.workspace.folder"
 )
 
 func GetGitspaceContainerName(config types.GitspaceConfig) string {
@@ -87,6 +89,19 @@ func ExtractRemoteUserFromLabels(inspectResp dockerTypes.ContainerJSON) string {
 	return remoteUser
 }
 
+// ExtractWorkspaceFolderFromLabels returns the absolute path of the repository in the gitspace container.
+// Containers created before the label was introduced have the repository in the remote user home.
+func ExtractWorkspaceFolderFromLabels(
+	inspectResp dockerTypes.ContainerJSON,
+	remoteUser string,
+	repoName string,
+) string {
+	if workspaceFolder, ok := inspectResp.Config.Labels[gitspaceWorkspaceFolderLabel]; ok && workspaceFolder != "" {
+		return workspaceFolder
+	}
+	return filepath.Join(GetUserHomeDir(remoteUser), repoName)
+}
+
 func ExtractLifecycleHooksFromLabels(
 	inspectResp dockerTypes.ContainerJSON,
 ) (map[PostAction][]*LifecycleHookStep, error) {
@@ -17,6 +17,7 @@ package container
 import (
 	"github.com/harness/gitness/app/gitspace/logutil"
 	"github.com/harness/gitness/app/gitspace/orchestrator/runarg"
+	"github.com/harness/gitness/app/gitspace/scm"
 	"github.com/harness/gitness/infraprovider"
 
 	"github.com/google/wire"
@@ -30,10 +31,12 @@ func ProvideEmbeddedDockerOrchestrator(
 	dockerClientFactory *infraprovider.DockerClientFactory,
 	statefulLogger *logutil.StatefulLogger,
 	runArgProvdier runarg.Provider,
+	scm *scm.SCM,
 ) Orchestrator {
 	return NewEmbeddedDockerOrchestrator(
 		dockerClientFactory,
 		statefulLogger,
 		runArgProvdier,
+		scm,
 	)
 }
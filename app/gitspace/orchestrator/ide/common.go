@@ -51,18 +51,18 @@ func getIDEDirName(args map[gitspaceTypes.IDEArg]interface{}) (string, error) {
 	return dirNameStr, nil
 }
 
-func getRepoName(
+func getRepoDir(
 	args map[gitspaceTypes.IDEArg]interface{},
 ) (string, error) {
-	repoName, exists := args[gitspaceTypes.IDERepoNameArg]
+	repoDir, exists := args[gitspaceTypes.IDERepoDirArg]
 	if !exists {
-		return "", nil // No repo name found, nothing to do
+		return "", nil // No repo dir found, nothing to do
 	}
 
-	repoNameStr, ok := repoName.(string)
+	repoDirStr, ok := repoDir.(string)
 	if !ok {
-		return "", fmt.Errorf("repo name is not of type string")
+		return "", fmt.Errorf("repo dir is not of type string")
 	}
 
-	return repoNameStr, nil
+	return repoDirStr, nil
 }
@@ -241,12 +241,12 @@ func (jb *JetBrainsIDE) runRemoteIDE(
 	payload := gitspaceTypes.RunIntellijIDEPayload{
 		Username: exec.RemoteUser,
 	}
-	// get Repository Name
-	repoName, err := getRepoName(args)
+	// get Repository Directory
+	repoDir, err := getRepoDir(args)
 	if err != nil {
 		return err
 	}
-	payload.RepoName = repoName
+	payload.RepoDir = repoDir
 
 	// get DIR name
 	dirName, err := getIDEDirName(args)
@@ -185,16 +185,12 @@ func (v *VSCode) updateVSCodeSetupPayload(
 	if err := v.handleVSCodeCustomization(args, gitspaceLogger, payload); err != nil {
 		return err
 	}
-	// Handle Repository Name
-	repoName, exists := args[gitspaceTypes.IDERepoNameArg]
-	if !exists {
-		return nil // No repo name found, nothing to do
-	}
-	repoNameStr, ok := repoName.(string)
-	if !ok {
-		return fmt.Errorf("repo name is not of type string")
+	// Handle Repository Directory
+	repoDir, err := getRepoDir(args)
+	if err != nil {
+		return err
 	}
-	payload.RepoName = repoNameStr
+	payload.RepoDir = repoDir
 	return nil
 }
 
@@ -124,7 +124,10 @@ func (o Orchestrator) TriggerStartGitspace(
 
 	o.emitGitspaceEvent(ctx, gitspaceConfig, enum.GitspaceEventTypeInfraProvisioningStart)
 
-	opts := infrastructure.InfraEventOpts{RequiredGitspacePorts: requiredGitspacePorts}
+	opts := infrastructure.InfraEventOpts{
+		RequiredGitspacePorts: requiredGitspacePorts,
+		HostRequirements:      devcontainerConfig.HostRequirements,
+	}
 	err = o.infraProvisioner.TriggerInfraEventWithOpts(ctx, enum.InfraEventProvision, gitspaceConfig, nil, opts)
 	if err != nil {
 		o.emitGitspaceEvent(ctx, gitspaceConfig, enum.GitspaceEventTypeInfraProvisioningFailed)
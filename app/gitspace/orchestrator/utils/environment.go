@@ -34,6 +34,7 @@ const (
 	templateSetupGitCredentials        = "setup_git_credentials.sh" // nolint:gosec
 	templateCloneCode                  = "clone_code.sh"
 	templateManagerUser                = "manage_user.sh"
+	templatePrepareWorkspaceFolder     = "prepare_workspace_folder.sh"
 )
 
 //go:embed script/os_info.sh
@@ -77,6 +77,7 @@ func CloneCode(
 	exec *devcontainer.Exec,
 	resolvedRepoDetails scm.ResolvedDetails,
 	defaultBaseImage string,
+	codeRepoDir string,
 	gitspaceLogger types.GitspaceLogger,
 ) error {
 	cloneURL, err := url.Parse(resolvedRepoDetails.CloneURL.Value())
@@ -86,10 +87,10 @@ func CloneCode(
 	}
 	cloneURL.User = nil
 	data := &types.CloneCodePayload{
-		RepoURL:  cloneURL.String(),
-		Image:    defaultBaseImage,
-		Branch:   resolvedRepoDetails.Branch,
-		RepoName: resolvedRepoDetails.RepoName,
+		RepoURL: cloneURL.String(),
+		Image:   defaultBaseImage,
+		Branch:  resolvedRepoDetails.Branch,
+		RepoDir: codeRepoDir,
 	}
 	if resolvedRepoDetails.ResolvedCredentials.Credentials != nil {
 		data.Email = resolvedRepoDetails.Credentials.Email
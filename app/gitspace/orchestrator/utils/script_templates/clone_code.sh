@@ -3,7 +3,7 @@
 repo_url="{{ .RepoURL }}"
 image="{{ .Image }}"
 branch="{{ .Branch }}"
-repo_name="{{ .RepoName }}"
+repo_dir="{{ .RepoDir }}"
 name="{{ .Name }}"
 email="{{ .Email }}"
 
@@ -35,9 +35,9 @@ print_top_commits() {
 print_latest_commit
 
 # Clone the repository inside the working directory if it doesn't exist
-if [ ! -d "$HOME/$repo_name/.git" ]; then
+if [ ! -d "$repo_dir/.git" ]; then
     echo "Cloning the repository..."
-    if ! git clone "$repo_url" --branch "$branch" "$HOME/$repo_name" 2>&1; then
+    if ! git clone "$repo_url" --branch "$branch" "$repo_dir" 2>&1; then
       echo "Failed to clone the repository. Exiting..." >&2
       exit 1
     fi
@@ -46,19 +46,19 @@ else
 fi
 
 # Navigate to the repository directory after cloning
-cd "$HOME/$repo_name" || exit 0
+cd "$repo_dir" || exit 0
 
 # Print top 10 commits from the cloned repository
 print_top_commits
 
 # Configure Git directory
-git config --global --add safe.directory "$HOME/$repo_name"
+git config --global --add safe.directory "$repo_dir"
 
 # Check if .devcontainer/devcontainer.json exists
-if [ ! -f "$HOME/$repo_name/.devcontainer/devcontainer.json" ]; then
+if [ ! -f "$repo_dir/.devcontainer/devcontainer.json" ]; then
     echo "Creating .devcontainer directory and devcontainer.json..."
-    mkdir -p "$HOME/$repo_name/.devcontainer"
-    cat <<EOL > "$HOME/$repo_name/.devcontainer/devcontainer.json"
+    mkdir -p "$repo_dir/.devcontainer"
+    cat <<EOL > "$repo_dir/.devcontainer/devcontainer.json"
 {
     "image": "$image"
 }
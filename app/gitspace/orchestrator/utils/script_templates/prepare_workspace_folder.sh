@@ -0,0 +1,25 @@
+#!/bin/sh
+
+username="{{ .Username }}"
+workspaceFolder="{{ .WorkspaceFolder }}"
+
+# Create the workspace folder if it doesn't exist
+if [ ! -d "$workspaceFolder" ]; then
+  echo "Directory $workspaceFolder does not exist. Creating it..."
+  mkdir -p "$workspaceFolder"
+  if [ $? -ne 0 ]; then
+    echo "Failed to create directory $workspaceFolder."
+    exit 1
+  fi
+fi
+
+# Ensure the user owns the workspace folder, so the repository can be cloned into it
+currentOwner=$(stat -c '%U' "$workspaceFolder")
+if [ "$currentOwner" != "$username" ]; then
+  echo "Updating ownership of $workspaceFolder to $username..."
+  chown "$username:$username" "$workspaceFolder"
+  if [ $? -ne 0 ]; then
+    echo "Failed to update ownership of $workspaceFolder."
+    exit 1
+  fi
+fi
@@ -1,7 +1,7 @@
 #!/bin/sh
 
 username={{ .Username }}
-repoName="{{ .RepoName }}"
+repoDir="{{ .RepoDir }}"
 ideDirName="{{ .IdeDirName}}"
 
 # Create .cache/JetBrains for the current user
@@ -17,7 +17,7 @@ fi
 echo "registering remote Intellij IDE..."
 INTELLIJ_LOG_FILE="$INTELLIJ_PATH/jetbrains.log"
 echo "storing ide logs in $INTELLIJ_LOG_FILE..."
-nohup "$INTELLIJ_PATH/bin/remote-dev-server.sh" run "$USER_HOME/$repoName" --ssh-link-user "$username" > "$INTELLIJ_LOG_FILE" 2>&1 &
+nohup "$INTELLIJ_PATH/bin/remote-dev-server.sh" run "$repoDir" --ssh-link-user "$username" > "$INTELLIJ_LOG_FILE" 2>&1 &
 
 is_ide_running(){
   retries=5
@@ -1,11 +1,10 @@
 #!/bin/sh
 
 username={{ .Username }}
-repoName="{{ .RepoName }}"
+repoDir="{{ .RepoDir }}"
 
 # Create .vscode/extensions.json for the current user
-USER_HOME=$(eval echo ~$username)
-VSCODE_REMOTE_DIR="$USER_HOME/$repoName/.vscode"
+VSCODE_REMOTE_DIR="$repoDir/.vscode"
 EXTENSIONS_FILE="$VSCODE_REMOTE_DIR/extensions.json"
 
 # Create .vscode directory with correct ownership
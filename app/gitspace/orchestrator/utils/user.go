@@ -49,3 +49,31 @@ func ManageUser(
 
 	return nil
 }
+
+// PrepareWorkspaceFolder creates the workspace folder of the gitspace, owned by the remote user,
+// as it might be outside the user home, where the user lacks the permissions to create it.
+func PrepareWorkspaceFolder(
+	ctx context.Context,
+	exec *devcontainer.Exec,
+	workspaceFolder string,
+	gitspaceLogger types.GitspaceLogger,
+) error {
+	script, err := GenerateScriptFromTemplate(
+		templatePrepareWorkspaceFolder, &types.PrepareWorkspaceFolderPayload{
+			Username:        exec.RemoteUser,
+			WorkspaceFolder: workspaceFolder,
+		})
+	if err != nil {
+		return fmt.Errorf(
+			"failed to generate scipt to prepare workspace folder from template %s: %w",
+			templatePrepareWorkspaceFolder, err)
+	}
+
+	gitspaceLogger.Info("Preparing workspace folder " + workspaceFolder)
+	err = exec.ExecuteCommandInHomeDirAndLog(ctx, script, true, gitspaceLogger, true)
+	if err != nil {
+		return fmt.Errorf("failed to prepare workspace folder: %w", err)
+	}
+
+	return nil
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/harness/gitness/app/token"
 	urlprovider "github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -190,7 +191,8 @@ func (s *GitnessSCM) ResolveCredentials(
 			&gitspacePrincipal,
 			user,
 			defaultGitspacePATIdentifier,
-			&gitspaceJWTLifetime)
+			&gitspaceJWTLifetime,
+			nil)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create JWT: %w", err)
@@ -255,6 +257,33 @@ func (s *GitnessSCM) GetFileContent(
 	return catFileOutput, nil
 }
 
+// GetBuildContext writes a tar archive of a directory of the repository at the gitspace branch.
+func (s *GitnessSCM) GetBuildContext(
+	ctx context.Context,
+	gitspaceConfig types.GitspaceConfig,
+	dirPath string,
+	_ *ResolvedCredentials,
+	w io.Writer,
+) error {
+	repo, err := s.repoFinder.FindByRef(ctx, *gitspaceConfig.CodeRepo.Ref)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	err = s.git.Archive(ctx, git.ArchiveParams{
+		ReadParams: git.CreateReadParams(repo),
+		ArchiveParams: api.ArchiveParams{
+			Format:  api.ArchiveFormatTar,
+			Treeish: buildContextTreeish(gitspaceConfig.CodeRepo.Branch, dirPath),
+		},
+	}, w)
+	if err != nil {
+		return fmt.Errorf("failed to archive build context %q: %w", dirPath, err)
+	}
+
+	return nil
+}
+
 func findUserFromUID(
 	ctx context.Context,
 	principalStore store.PrincipalStore, userUID string,
@@ -62,31 +62,11 @@ func (s *GenericSCM) GetFileContent(
 	filePath string,
 	_ *ResolvedCredentials,
 ) ([]byte, error) {
-	gitWorkingDirectory := "/tmp/git/"
-	cloneDir := gitWorkingDirectory + uuid.New().String()
-	err := os.MkdirAll(cloneDir, os.ModePerm)
+	cloneDir, err := s.shallowClone(ctx, gitspaceConfig)
 	if err != nil {
-		return nil, fmt.Errorf("error creating directory %s: %w", cloneDir, err)
-	}
-
-	defer func() {
-		err = os.RemoveAll(cloneDir)
-		if err != nil {
-			log.Ctx(ctx).Warn().Err(err).Msg("Unable to remove working directory")
-		}
-	}()
-
-	log.Info().Msg("Cloning the repository...")
-	cmd := command.New("clone",
-		command.WithFlag("--branch", gitspaceConfig.CodeRepo.Branch),
-		command.WithFlag("--no-checkout"),
-		command.WithFlag("--depth", "1"),
-		command.WithArg(gitspaceConfig.CodeRepo.URL),
-		command.WithArg(cloneDir),
-	)
-	if err := cmd.Run(ctx, command.WithDir(cloneDir)); err != nil {
-		return nil, fmt.Errorf("failed to clone repository %s: %w", gitspaceConfig.CodeRepo.URL, err)
+		return nil, err
 	}
+	defer s.removeClone(ctx, cloneDir)
 
 	var lsTreeOutput bytes.Buffer
 	lsTreeCmd := command.New("ls-tree",
@@ -120,6 +100,63 @@ func (s *GenericSCM) GetFileContent(
 	return catFileOutput.Bytes(), nil
 }
 
+// GetBuildContext writes a tar archive of a directory of the repository at the gitspace branch.
+func (s *GenericSCM) GetBuildContext(
+	ctx context.Context,
+	gitspaceConfig types.GitspaceConfig,
+	dirPath string,
+	_ *ResolvedCredentials,
+	w io.Writer,
+) error {
+	cloneDir, err := s.shallowClone(ctx, gitspaceConfig)
+	if err != nil {
+		return err
+	}
+	defer s.removeClone(ctx, cloneDir)
+
+	archiveCmd := command.New("archive",
+		command.WithFlag("--format", "tar"),
+		command.WithArg(buildContextTreeish("HEAD", dirPath)),
+	)
+	if err := archiveCmd.Run(ctx, command.WithDir(cloneDir), command.WithStdout(w)); err != nil {
+		return fmt.Errorf("failed to archive build context %q: %w", dirPath, err)
+	}
+
+	return nil
+}
+
+// shallowClone clones the gitspace branch of the repository, without checking out the files,
+// to a new temporary directory and returns its path.
+func (s *GenericSCM) shallowClone(ctx context.Context, gitspaceConfig types.GitspaceConfig) (string, error) {
+	gitWorkingDirectory := "/tmp/git/"
+	cloneDir := gitWorkingDirectory + uuid.New().String()
+	err := os.MkdirAll(cloneDir, os.ModePerm)
+	if err != nil {
+		return "", fmt.Errorf("error creating directory %s: %w", cloneDir, err)
+	}
+
+	log.Info().Msg("Cloning the repository...")
+	cmd := command.New("clone",
+		command.WithFlag("--branch", gitspaceConfig.CodeRepo.Branch),
+		command.WithFlag("--no-checkout"),
+		command.WithFlag("--depth", "1"),
+		command.WithArg(gitspaceConfig.CodeRepo.URL),
+		command.WithArg(cloneDir),
+	)
+	if err := cmd.Run(ctx, command.WithDir(cloneDir)); err != nil {
+		s.removeClone(ctx, cloneDir)
+		return "", fmt.Errorf("failed to clone repository %s: %w", gitspaceConfig.CodeRepo.URL, err)
+	}
+
+	return cloneDir, nil
+}
+
+func (s *GenericSCM) removeClone(ctx context.Context, cloneDir string) {
+	if err := os.RemoveAll(cloneDir); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Unable to remove working directory")
+	}
+}
+
 func (s *GenericSCM) ResolveCredentials(
 	_ context.Context,
 	gitspaceConfig types.GitspaceConfig,
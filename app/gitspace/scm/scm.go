@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"regexp"
 	"strings"
 
@@ -34,7 +35,12 @@ var (
 	ErrNoDefaultBranch = errors.New("no default branch")
 )
 
-const devcontainerDefaultPath = ".devcontainer/devcontainer.json"
+const (
+	// DevcontainerDir is the directory of the devcontainer.json file,
+	// relative paths in the devcontainer config are resolved against it.
+	DevcontainerDir         = ".devcontainer"
+	devcontainerDefaultPath = DevcontainerDir + "/devcontainer.json"
+)
 
 type SCM struct {
 	scmProviderFactory Factory
@@ -104,6 +110,47 @@ func (s *SCM) GetSCMRepoDetails(
 	return resolvedDetails, nil
 }
 
+// GetFileContent reads a file of the gitspace repository.
+// It returns empty content if the file doesn't exist.
+func (s *SCM) GetFileContent(
+	ctx context.Context,
+	gitspaceConfig types.GitspaceConfig,
+	resolvedCredentials *ResolvedCredentials,
+	filePath string,
+) ([]byte, error) {
+	scmProvider, err := s.getSCMProvider(gitspaceConfig.CodeRepo.Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve SCM provider: %w", err)
+	}
+
+	return scmProvider.GetFileContent(ctx, gitspaceConfig, filePath, resolvedCredentials)
+}
+
+// GetBuildContext writes a tar archive of a directory of the gitspace repository to w.
+// An empty dirPath archives the whole repository.
+func (s *SCM) GetBuildContext(
+	ctx context.Context,
+	gitspaceConfig types.GitspaceConfig,
+	resolvedCredentials *ResolvedCredentials,
+	dirPath string,
+	w io.Writer,
+) error {
+	scmProvider, err := s.getSCMProvider(gitspaceConfig.CodeRepo.Type)
+	if err != nil {
+		return fmt.Errorf("failed to resolve SCM provider: %w", err)
+	}
+
+	return scmProvider.GetBuildContext(ctx, gitspaceConfig, dirPath, resolvedCredentials, w)
+}
+
+// buildContextTreeish returns the git tree-ish of a directory at the provided revision.
+func buildContextTreeish(rev string, dirPath string) string {
+	if dirPath == "" || dirPath == "." {
+		return rev
+	}
+	return rev + ":" + dirPath
+}
+
 func detectDefaultGitBranch(ctx context.Context, gitRepoDir string) (string, error) {
 	cmd := command.New("ls-remote",
 		command.WithFlag("--symref"),
@@ -16,6 +16,7 @@ package scm
 
 import (
 	"context"
+	"io"
 
 	"github.com/harness/gitness/types"
 )
@@ -43,4 +44,14 @@ type Provider interface {
 	) ([]Branch, error)
 
 	GetBranchURL(spacePath string, repoURL string, branch string) (string, error)
+
+	// GetBuildContext writes a tar archive of the directory at dirPath of the repository to w.
+	// An empty dirPath archives the whole repository.
+	GetBuildContext(
+		ctx context.Context,
+		gitspaceConfig types.GitspaceConfig,
+		dirPath string,
+		credentials *ResolvedCredentials,
+		w io.Writer,
+	) error
 }
@@ -17,12 +17,17 @@ package types
 import "github.com/harness/gitness/types/enum"
 
 type CloneCodePayload struct {
-	RepoURL  string
-	Image    string
-	Branch   string
-	RepoName string
-	Name     string
-	Email    string
+	RepoURL string
+	Image   string
+	Branch  string
+	RepoDir string
+	Name    string
+	Email   string
+}
+
+type PrepareWorkspaceFolderPayload struct {
+	Username        string
+	WorkspaceFolder string
 }
 
 type SetupGitInstallPayload struct {
@@ -59,7 +64,7 @@ type SetupSSHServerPayload struct {
 type SetupVSCodeExtensionsPayload struct {
 	Username   string
 	Extensions string
-	RepoName   string
+	RepoDir    string
 }
 
 type RunSSHServerPayload struct {
@@ -87,7 +92,7 @@ type SetupIntellijIDEPayload struct {
 
 type RunIntellijIDEPayload struct {
 	Username       string
-	RepoName       string
+	RepoDir        string
 	IdeDownloadURL string
 	IdeDirName     string
 }
@@ -26,7 +26,7 @@ const (
 	VSCodeCustomizationArg    IDEArg = "VSCODE_CUSTOMIZATION"
 	JetBrainsCustomizationArg IDEArg = "JETBRAINS_CUSTOMIZATION"
 	VSCodeProxyURIArg         IDEArg = "VSCODE_PROXY_URI"
-	IDERepoNameArg            IDEArg = "IDE_REPO_NAME"
+	IDERepoDirArg             IDEArg = "IDE_REPO_DIR"
 	IDEDownloadURLArg         IDEArg = "IDE_DOWNLOAD_URL"
 	IDEDIRNameArg             IDEArg = "IDE_DIR_NAME"
 )
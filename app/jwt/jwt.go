@@ -51,12 +51,17 @@ type Claims struct {
 type SubClaimsToken struct {
 	Type enum.TokenType `json:"typ,omitempty"`
 	ID   int64          `json:"id,omitempty"`
+	// Scopes are the restrictions of the token at the time it was issued.
+	// NOTE: For authorization the scopes stored with the token are used.
+	Scopes []types.TokenScope `json:"scp,omitempty"`
 }
 
 // SubClaimsMembership contains the ephemeral membership the JWT was created with.
 type SubClaimsMembership struct {
 	Role    enum.MembershipRole `json:"role,omitempty"`
 	SpaceID int64               `json:"sid,omitempty"`
+	// RepoID optionally restricts the membership to a single repository of the space.
+	RepoID int64 `json:"rid,omitempty"`
 }
 
 // SubClaimsAccessPermissions stores allowed actions on a resource.
@@ -87,8 +92,9 @@ func GenerateForToken(token *types.Token, secret string) (string, error) {
 		},
 		PrincipalID: token.PrincipalID,
 		Token: &SubClaimsToken{
-			Type: token.Type,
-			ID:   token.ID,
+			Type:   token.Type,
+			ID:     token.ID,
+			Scopes: token.Scopes,
 		},
 	})
 
@@ -107,6 +113,35 @@ func GenerateWithMembership(
 	role enum.MembershipRole,
 	lifetime time.Duration,
 	secret string,
+) (string, error) {
+	return generateWithMembership(principalID, &SubClaimsMembership{
+		SpaceID: spaceID,
+		Role:    role,
+	}, lifetime, secret)
+}
+
+// GenerateWithRepoMembership generates a jwt with the given ephemeral membership
+// that is restricted to a single repository of the space.
+func GenerateWithRepoMembership(
+	principalID int64,
+	spaceID int64,
+	repoID int64,
+	role enum.MembershipRole,
+	lifetime time.Duration,
+	secret string,
+) (string, error) {
+	return generateWithMembership(principalID, &SubClaimsMembership{
+		SpaceID: spaceID,
+		RepoID:  repoID,
+		Role:    role,
+	}, lifetime, secret)
+}
+
+func generateWithMembership(
+	principalID int64,
+	membership *SubClaimsMembership,
+	lifetime time.Duration,
+	secret string,
 ) (string, error) {
 	issuedAt := time.Now()
 	expiresAt := issuedAt.Add(lifetime)
@@ -119,10 +154,7 @@ func GenerateWithMembership(
 			ExpiresAt: expiresAt.Unix(),
 		},
 		PrincipalID: principalID,
-		Membership: &SubClaimsMembership{
-			SpaceID: spaceID,
-			Role:    role,
-		},
+		Membership:  membership,
 	})
 
 	res, err := jwtToken.SignedString([]byte(secret))
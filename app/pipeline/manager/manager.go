@@ -29,6 +29,7 @@ import (
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	urlprovider "github.com/harness/gitness/app/url"
@@ -45,6 +46,8 @@ const (
 	pipelineJWTLifetime = 72 * time.Hour
 	// pipelineJWTRole specifies the role of an ephemeral pipeline jwt token.
 	pipelineJWTRole = enum.MembershipRoleContributor
+	// pipelineForkJWTRole specifies the role of an ephemeral pipeline jwt token of pull requests from forks.
+	pipelineForkJWTRole = enum.MembershipRoleReader
 )
 
 var noContext = context.Background()
@@ -155,6 +158,7 @@ type Manager struct {
 	publicAccess publicaccess.Service
 	// events reporter
 	reporter events.Reporter
+	settings *settings.Service
 }
 
 func New(
@@ -176,6 +180,7 @@ func New(
 	userStore store.PrincipalStore,
 	publicAccess publicaccess.Service,
 	reporter events.Reporter,
+	settings *settings.Service,
 ) *Manager {
 	return &Manager{
 		Config:           config,
@@ -196,6 +201,7 @@ func New(
 		Users:            userStore,
 		publicAccess:     publicAccess,
 		reporter:         reporter,
+		settings:         settings,
 	}
 }
 
@@ -328,7 +334,7 @@ func (m *Manager) Details(ctx context.Context, stageID int64) (*ExecutionContext
 
 	// TODO: Currently we fetch all the secrets from the same space.
 	// This logic can be updated when needed.
-	secrets, err := m.Secrets.ListAll(noContext, repo.ParentID)
+	secrets, err := m.listSecrets(noContext, repo, execution)
 	if err != nil {
 		log.Warn().Err(err).Msg("manager: cannot list secrets")
 		return nil, err
@@ -362,7 +368,7 @@ func (m *Manager) Details(ctx context.Context, stageID int64) (*ExecutionContext
 		return nil, err
 	}
 
-	netrc, err := m.createNetrc(repo)
+	netrc, err := m.createNetrc(repo, execution)
 	if err != nil {
 		log.Warn().Err(err).Msg("manager: failed to create netrc")
 		return nil, err
@@ -379,15 +385,32 @@ func (m *Manager) Details(ctx context.Context, stageID int64) (*ExecutionContext
 	}, nil
 }
 
-func (m *Manager) createNetrc(repo *types.Repository) (*Netrc, error) {
+// createNetrc returns the credentials the execution uses to access the repository.
+// Executions of pull requests from forks run code controlled by the author of the fork,
+// so their credentials only allow to read the repository itself.
+func (m *Manager) createNetrc(repo *types.Repository, execution *types.Execution) (*Netrc, error) {
 	pipelinePrincipal := bootstrap.NewPipelineServiceSession().Principal
-	jwt, err := jwt.GenerateWithMembership(
-		pipelinePrincipal.ID,
-		repo.ParentID,
-		pipelineJWTRole,
-		pipelineJWTLifetime,
-		pipelinePrincipal.Salt,
-	)
+
+	var jwtToken string
+	var err error
+	if execution.Fork != "" {
+		jwtToken, err = jwt.GenerateWithRepoMembership(
+			pipelinePrincipal.ID,
+			repo.ParentID,
+			repo.ID,
+			pipelineForkJWTRole,
+			pipelineJWTLifetime,
+			pipelinePrincipal.Salt,
+		)
+	} else {
+		jwtToken, err = jwt.GenerateWithMembership(
+			pipelinePrincipal.ID,
+			repo.ParentID,
+			pipelineJWTRole,
+			pipelineJWTLifetime,
+			pipelinePrincipal.Salt,
+		)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create jwt: %w", err)
 	}
@@ -400,7 +423,7 @@ func (m *Manager) createNetrc(repo *types.Repository) (*Netrc, error) {
 	return &Netrc{
 		Machine:  cloneURL.Hostname(),
 		Login:    pipelinePrincipal.UID,
-		Password: jwt,
+		Password: jwtToken,
 	}, nil
 }
 
@@ -523,3 +546,30 @@ func (m *Manager) Watch(ctx context.Context, executionID int64) (bool, error) {
 	}
 	return execution.Status.IsDone(), nil
 }
+
+// listSecrets returns the secrets available to the execution.
+// Executions of pull requests from forks run code controlled by the author of the fork,
+// so they get no secrets unless that's explicitly allowed in the repository settings.
+func (m *Manager) listSecrets(
+	ctx context.Context,
+	repo *types.Repository,
+	execution *types.Execution,
+) ([]*types.Secret, error) {
+	if execution.Fork != "" {
+		enabled, err := settings.RepoGet(
+			ctx,
+			m.settings,
+			repo.ID,
+			settings.KeyForkPullReqSecretsEnabled,
+			settings.DefaultForkPullReqSecretsEnabled,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check settings whether secrets are enabled for forks: %w", err)
+		}
+		if !enabled {
+			return nil, nil
+		}
+	}
+
+	return m.Secrets.ListAll(ctx, repo.ParentID)
+}
@@ -373,5 +373,6 @@ func (t *teardown) reportExecutionCompleted(ctx context.Context, execution *type
 		RepoID:       execution.RepoID,
 		ExecutionNum: execution.Number,
 		Status:       execution.Status,
+		CommitSHA:    execution.After,
 	})
 }
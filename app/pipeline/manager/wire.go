@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -56,10 +57,11 @@ func ProvideExecutionManager(
 	userStore store.PrincipalStore,
 	publicAccess publicaccess.Service,
 	reporter *events.Reporter,
+	settings *settings.Service,
 ) ExecutionManager {
 	return New(config, executionStore, pipelineStore, urlProvider, sseStreamer, fileService, converterService,
 		logStore, logStream, checkStore, repoStore, scheduler, secretStore,
-		stageStore, stepStore, userStore, publicAccess, *reporter)
+		stageStore, stepStore, userStore, publicAccess, *reporter, settings)
 }
 
 // ProvideExecutionClient provides a client implementation to interact with the execution manager.
@@ -157,6 +157,13 @@ func (q *queue) signal(ctx context.Context) error {
 			continue
 		}
 
+		// if the execution belongs to a concurrency group
+		// we need to make sure the executions ahead of it
+		// in the group are completed before proceeding.
+		if !withinConcurrencyGroup(item, items) {
+			continue
+		}
+
 	loop:
 		for w := range q.workers {
 			// the worker must match the resource kind and type
@@ -301,6 +308,26 @@ func shouldThrottle(stage *types.Stage, siblings []*types.Stage, limit int) bool
 	return count >= limit
 }
 
+// withinConcurrencyGroup returns false if an older execution of the
+// same concurrency group in the repository is still in progress.
+func withinConcurrencyGroup(stage *types.Stage, siblings []*types.Stage) bool {
+	if stage.ConcurrencyGroup == "" {
+		return true
+	}
+	for _, sibling := range siblings {
+		if sibling.RepoID != stage.RepoID {
+			continue
+		}
+		if sibling.ConcurrencyGroup != stage.ConcurrencyGroup {
+			continue
+		}
+		if sibling.ExecutionID < stage.ExecutionID {
+			return false
+		}
+	}
+	return true
+}
+
 // matchResource is a helper function that returns.
 func matchResource(kinda, typea, kindb, typeb string) bool {
 	if kinda == "" {
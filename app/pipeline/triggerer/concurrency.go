@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/checks"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// concurrencyGroup resolves the concurrency group key of an execution from the template of the pipeline.
+// The template can reference the execution parameters and the branch, ref, commit, event and pull request
+// of the execution using the drone environment variable names, e.g. "pr-${DRONE_PULL_REQUEST}".
+func concurrencyGroup(pipeline *types.Pipeline, execution *types.Execution) string {
+	if pipeline.ConcurrencyGroup == "" {
+		return ""
+	}
+
+	vars := combine(execution.Params, map[string]string{
+		"DRONE_BRANCH":        execution.Target,
+		"DRONE_SOURCE_BRANCH": execution.Source,
+		"DRONE_TARGET_BRANCH": execution.Target,
+		"DRONE_COMMIT_REF":    execution.Ref,
+		"DRONE_COMMIT_SHA":    execution.After,
+		"DRONE_BUILD_EVENT":   string(execution.Event),
+		"DRONE_PULL_REQUEST":  pullReqNumber(execution.Ref),
+	})
+
+	return os.Expand(pipeline.ConcurrencyGroup, func(name string) string {
+		return vars[name]
+	})
+}
+
+// pullReqNumber extracts the pull request number from a pull request reference, e.g. "refs/pullreq/12/head".
+func pullReqNumber(ref string) string {
+	const prefix = "refs/pullreq/"
+	if !strings.HasPrefix(ref, prefix) {
+		return ""
+	}
+	number, _, _ := strings.Cut(strings.TrimPrefix(ref, prefix), "/")
+	return number
+}
+
+// cancelSuperseded cancels the executions in progress that belong to the same concurrency group
+// as the provided execution and were created before it. The canceled executions are annotated
+// with the number of the superseding execution.
+func (t *triggerer) cancelSuperseded(
+	ctx context.Context,
+	repo *types.Repository,
+	pipeline *types.Pipeline,
+	execution *types.Execution,
+) error {
+	const lockExpiry = 30 * time.Second
+
+	unlock, err := t.locker.LockConcurrencyGroup(ctx, repo.ID, execution.ConcurrencyGroup, lockExpiry)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	executions, err := t.executionStore.ListIncompleteInConcurrencyGroup(ctx, repo.ID, execution.ConcurrencyGroup)
+	if err != nil {
+		return fmt.Errorf("failed to list executions in concurrency group: %w", err)
+	}
+
+	for _, superseded := range executions {
+		if superseded.ID >= execution.ID {
+			continue
+		}
+
+		supersededPipeline := pipeline
+		if superseded.PipelineID != pipeline.ID {
+			supersededPipeline, err = t.pipelineStore.Find(ctx, superseded.PipelineID)
+			if err != nil {
+				return fmt.Errorf("failed to find pipeline of superseded execution: %w", err)
+			}
+		}
+
+		superseded.SupersededBy = execution.Number
+		superseded.Error = fmt.Sprintf("Canceled in favor of execution #%d of pipeline %q in concurrency group %q.",
+			execution.Number, pipeline.Identifier, execution.ConcurrencyGroup)
+
+		err = t.canceler.Cancel(ctx, repo, superseded)
+		if err != nil {
+			// the execution might have completed in the meantime
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("execution.id", superseded.ID).
+				Msg("failed to cancel superseded execution")
+			continue
+		}
+
+		// try to write to check store, log on failure
+		if superseded.Status == enum.CIStatusKilled {
+			err = checks.Write(ctx, t.checkStore, superseded, supersededPipeline)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to update check of superseded execution")
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestConcurrencyGroup(t *testing.T) {
+	execution := &types.Execution{
+		Event:  enum.TriggerEventPullRequest,
+		Ref:    "refs/pullreq/12/head",
+		Source: "feature",
+		Target: "main",
+		Params: map[string]string{"ENVIRONMENT": "prod"},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{name: "none", template: "", want: ""},
+		{name: "static", template: "deploy", want: "deploy"},
+		{name: "branch", template: "deploy-${DRONE_BRANCH}", want: "deploy-main"},
+		{name: "pull request", template: "pr-${DRONE_PULL_REQUEST}", want: "pr-12"},
+		{name: "param", template: "deploy-$ENVIRONMENT", want: "deploy-prod"},
+		{name: "unknown", template: "deploy-${UNKNOWN}", want: "deploy-"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := concurrencyGroup(&types.Pipeline{ConcurrencyGroup: test.template}, execution)
+			if got != test.want {
+				t.Errorf("expected %q, got %q", test.want, got)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"runtime/debug"
 	"time"
 
+	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
@@ -28,6 +29,7 @@ import (
 	"github.com/harness/gitness/app/pipeline/resolver"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/pipeline/triggerer/dag"
+	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -78,6 +80,9 @@ type Hook struct {
 // returned.
 type Triggerer interface {
 	Trigger(ctx context.Context, pipeline *types.Pipeline, hook *Hook) (*types.Execution, error)
+
+	// Skip records a skipped execution of the pipeline for the hook along with the reason it was skipped.
+	Skip(ctx context.Context, pipeline *types.Pipeline, hook *Hook, reason string) (*types.Execution, error)
 }
 
 type triggerer struct {
@@ -94,6 +99,8 @@ type triggerer struct {
 	templateStore    store.TemplateStore
 	pluginStore      store.PluginStore
 	publicAccess     publicaccess.Service
+	canceler         canceler.Canceler
+	locker           *locker.Locker
 }
 
 func New(
@@ -110,6 +117,8 @@ func New(
 	templateStore store.TemplateStore,
 	pluginStore store.PluginStore,
 	publicAccess publicaccess.Service,
+	canceler canceler.Canceler,
+	locker *locker.Locker,
 ) Triggerer {
 	return &triggerer{
 		executionStore:   executionStore,
@@ -125,6 +134,8 @@ func New(
 		templateStore:    templateStore,
 		pluginStore:      pluginStore,
 		publicAccess:     publicAccess,
+		canceler:         canceler,
+		locker:           locker,
 	}
 }
 
@@ -357,6 +368,10 @@ func (t *triggerer) Trigger(
 	// would lead to an incremented pipeline sequence number.
 	execution.Number = pipeline.Seq
 	execution.Params = combine(execution.Params, Envs(ctx, repo, pipeline, t.urlProvider))
+	execution.ConcurrencyGroup = concurrencyGroup(pipeline, execution)
+	for _, stage := range stages {
+		stage.ConcurrencyGroup = execution.ConcurrencyGroup
+	}
 
 	err = t.createExecutionWithStages(ctx, execution, stages)
 	if err != nil {
@@ -370,6 +385,13 @@ func (t *triggerer) Trigger(
 		log.Error().Err(err).Msg("trigger: could not write to check store")
 	}
 
+	if execution.ConcurrencyGroup != "" && pipeline.ConcurrencyPolicy == enum.ConcurrencyPolicyCancelInProgress {
+		err = t.cancelSuperseded(ctx, repo, pipeline, execution)
+		if err != nil {
+			log.Error().Err(err).Msg("trigger: could not cancel superseded executions")
+		}
+	}
+
 	for _, stage := range stages {
 		if stage.Status != enum.CIStatusPending {
 			continue
@@ -530,12 +552,33 @@ func (t *triggerer) createExecutionWithStages(
 	})
 }
 
+func (t *triggerer) Skip(
+	ctx context.Context,
+	pipeline *types.Pipeline,
+	base *Hook,
+	reason string,
+) (*types.Execution, error) {
+	return t.createCompletedExecution(ctx, pipeline, base, enum.CIStatusSkipped, reason)
+}
+
 // createExecutionWithError creates an execution with an error message.
 func (t *triggerer) createExecutionWithError(
 	ctx context.Context,
 	pipeline *types.Pipeline,
 	base *Hook,
 	message string,
+) (*types.Execution, error) {
+	return t.createCompletedExecution(ctx, pipeline, base, enum.CIStatusError, message)
+}
+
+// createCompletedExecution creates an execution without stages that is already completed with the provided
+// status. The message explains the status and is stored as the execution error.
+func (t *triggerer) createCompletedExecution(
+	ctx context.Context,
+	pipeline *types.Pipeline,
+	base *Hook,
+	status enum.CIStatus,
+	message string,
 ) (*types.Execution, error) {
 	log := log.With().
 		Int64("pipeline.id", pipeline.ID).
@@ -555,7 +598,7 @@ func (t *triggerer) createExecutionWithError(
 		PipelineID:   pipeline.ID,
 		Number:       pipeline.Seq,
 		Parent:       base.Parent,
-		Status:       enum.CIStatusError,
+		Status:       status,
 		Error:        message,
 		Event:        base.Action.GetTriggerEvent(),
 		Action:       base.Action,
@@ -583,7 +626,7 @@ func (t *triggerer) createExecutionWithError(
 
 	err = t.executionStore.Create(ctx, execution)
 	if err != nil {
-		log.Error().Err(err).Msg("trigger: cannot create execution error")
+		log.Error().Err(err).Msgf("trigger: cannot create execution with status %s", status)
 		return nil, err
 	}
 
@@ -20,6 +20,7 @@ import (
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/aiagent"
+	"github.com/harness/gitness/app/api/controller/auditlog"
 	"github.com/harness/gitness/app/api/controller/capabilities"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
@@ -30,6 +31,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
+	"github.com/harness/gitness/app/api/controller/notificationpref"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
@@ -48,6 +50,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/handler/account"
 	handleraiagent "github.com/harness/gitness/app/api/handler/aiagent"
+	handlerauditlog "github.com/harness/gitness/app/api/handler/auditlog"
 	handlercapabilities "github.com/harness/gitness/app/api/handler/capabilities"
 	handlercheck "github.com/harness/gitness/app/api/handler/check"
 	handlerconnector "github.com/harness/gitness/app/api/handler/connector"
@@ -58,6 +61,7 @@ import (
 	handlerkeywordsearch "github.com/harness/gitness/app/api/handler/keywordsearch"
 	handlerlogs "github.com/harness/gitness/app/api/handler/logs"
 	handlermigrate "github.com/harness/gitness/app/api/handler/migrate"
+	handlernotificationpref "github.com/harness/gitness/app/api/handler/notificationpref"
 	handlerpipeline "github.com/harness/gitness/app/api/handler/pipeline"
 	handlerplugin "github.com/harness/gitness/app/api/handler/plugin"
 	handlerprincipal "github.com/harness/gitness/app/api/handler/principal"
@@ -137,6 +141,8 @@ func NewAPIHandler(
 	gitspaceCtrl *gitspace.Controller,
 	aiagentCtrl *aiagent.Controller,
 	capabilitiesCtrl *capabilities.Controller,
+	auditCtrl *auditlog.Controller,
+	notificationPrefCtrl *notificationpref.Controller,
 	usageSender usage.Sender,
 ) http.Handler {
 	// Use go-chi router for inner routing.
@@ -169,8 +175,9 @@ func NewAPIHandler(
 
 			setupRoutesV1WithAuth(r, appCtx, config, repoCtrl, repoSettingsCtrl, executionCtrl, triggerCtrl, logCtrl,
 				pipelineCtrl, connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
-				webhookCtrl, githookCtrl, git, saCtrl, userCtrl, principalCtrl, userGroupCtrl, checkCtrl, uploadCtrl,
-				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, aiagentCtrl, capabilitiesCtrl, usageSender)
+				webhookCtrl, githookCtrl, git, saCtrl, userCtrl, sysCtrl, principalCtrl, userGroupCtrl, checkCtrl, uploadCtrl,
+				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, aiagentCtrl, capabilitiesCtrl, auditCtrl,
+				notificationPrefCtrl, usageSender)
 		})
 	})
 
@@ -212,6 +219,7 @@ func setupRoutesV1WithAuth(r chi.Router,
 	git git.Interface,
 	saCtrl *serviceaccount.Controller,
 	userCtrl *user.Controller,
+	sysCtrl *system.Controller,
 	principalCtrl principal.Controller,
 	userGroupCtrl *usergroup.Controller,
 	checkCtrl *check.Controller,
@@ -222,21 +230,23 @@ func setupRoutesV1WithAuth(r chi.Router,
 	migrateCtrl *migrate.Controller,
 	aiagentCtrl *aiagent.Controller,
 	capabilitiesCtrl *capabilities.Controller,
+	auditCtrl *auditlog.Controller,
+	notificationPrefCtrl *notificationpref.Controller,
 	usageSender usage.Sender,
 ) {
 	setupAccountWithAuth(r, userCtrl, config)
-	setupSpaces(r, appCtx, spaceCtrl, userGroupCtrl, webhookCtrl, checkCtrl)
+	setupSpaces(r, appCtx, spaceCtrl, userGroupCtrl, webhookCtrl, checkCtrl, auditCtrl, notificationPrefCtrl)
 	setupRepos(r, repoCtrl, repoSettingsCtrl, pipelineCtrl, executionCtrl, triggerCtrl,
-		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl, usageSender)
+		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl, notificationPrefCtrl, usageSender)
 	setupConnectors(r, connectorCtrl)
 	setupTemplates(r, templateCtrl)
 	setupSecrets(r, secretCtrl)
 	setupAiAgent(r, aiagentCtrl, capabilitiesCtrl)
-	setupUser(r, userCtrl)
+	setupUser(r, userCtrl, notificationPrefCtrl)
 	setupServiceAccounts(r, saCtrl)
 	setupPrincipals(r, principalCtrl)
 	setupInternal(r, githookCtrl, git)
-	setupAdmin(r, userCtrl)
+	setupAdmin(r, userCtrl, sysCtrl, auditCtrl)
 	setupPlugins(r, pluginCtrl)
 	setupKeywordSearch(r, searchCtrl)
 	setupInfraProviders(r, infraProviderCtrl)
@@ -252,6 +262,8 @@ func setupSpaces(
 	userGroupCtrl *usergroup.Controller,
 	webhookCtrl *webhook.Controller,
 	checkCtrl *check.Controller,
+	auditCtrl *auditlog.Controller,
+	notificationPrefCtrl *notificationpref.Controller,
 ) {
 	r.Route("/spaces", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
@@ -297,11 +309,21 @@ func setupSpaces(
 			SetupSpaceLabels(r, spaceCtrl)
 			SetupWebhookSpace(r, webhookCtrl)
 			SetupRulesSpace(r, spaceCtrl)
+			SetupNotificationChannelsSpace(r, spaceCtrl)
+
+			r.Route("/notification-subscription", func(r chi.Router) {
+				r.Put("/", handlernotificationpref.HandleSetSpaceSubscription(notificationPrefCtrl))
+				r.Delete("/", handlernotificationpref.HandleDeleteSpaceSubscription(notificationPrefCtrl))
+			})
 
 			r.Get("/checks/recent", handlercheck.HandleCheckListRecentSpace(checkCtrl))
 			r.Route("/usage", func(r chi.Router) {
 				r.Get("/metric", handlerspace.HandleUsageMetric(spaceCtrl))
 			})
+			r.Route("/audit-events", func(r chi.Router) {
+				r.Get("/", handlerauditlog.HandleListSpace(auditCtrl))
+				r.Get("/export", handlerauditlog.HandleExportSpace(auditCtrl))
+			})
 		})
 	})
 }
@@ -337,6 +359,7 @@ func SetupWebhookSpace(r chi.Router, webhookCtrl *webhook.Controller) {
 			r.Get("/", handlerwebhook.HandleFindSpace(webhookCtrl))
 			r.Patch("/", handlerwebhook.HandleUpdateSpace(webhookCtrl))
 			r.Delete("/", handlerwebhook.HandleDeleteSpace(webhookCtrl))
+			r.Post("/test", handlerwebhook.HandleTestDeliverySpace(webhookCtrl))
 
 			r.Route("/executions", func(r chi.Router) {
 				r.Get("/", handlerwebhook.HandleListExecutionsSpace(webhookCtrl))
@@ -348,6 +371,11 @@ func SetupWebhookSpace(r chi.Router, webhookCtrl *webhook.Controller) {
 			})
 		})
 	})
+
+	r.Route("/webhook-executions/failed", func(r chi.Router) {
+		r.Get("/", handlerwebhook.HandleListFailedExecutionsSpace(webhookCtrl))
+		r.Post("/retrigger", handlerwebhook.HandleRetriggerFailedExecutionsSpace(webhookCtrl))
+	})
 }
 
 func SetupRulesSpace(r chi.Router, spaceCtrl *space.Controller) {
@@ -363,6 +391,19 @@ func SetupRulesSpace(r chi.Router, spaceCtrl *space.Controller) {
 	})
 }
 
+func SetupNotificationChannelsSpace(r chi.Router, spaceCtrl *space.Controller) {
+	r.Route("/notification-channels", func(r chi.Router) {
+		r.Post("/", handlerspace.HandleNotificationChannelCreate(spaceCtrl))
+		r.Get("/", handlerspace.HandleNotificationChannelList(spaceCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamNotificationChannelIdentifier), func(r chi.Router) {
+			r.Get("/", handlerspace.HandleNotificationChannelFind(spaceCtrl))
+			r.Patch("/", handlerspace.HandleNotificationChannelUpdate(spaceCtrl))
+			r.Delete("/", handlerspace.HandleNotificationChannelDelete(spaceCtrl))
+		})
+	})
+}
+
 func setupRepos(r chi.Router,
 	repoCtrl *repo.Controller,
 	repoSettingsCtrl *reposettings.Controller,
@@ -374,6 +415,7 @@ func setupRepos(r chi.Router,
 	webhookCtrl *webhook.Controller,
 	checkCtrl *check.Controller,
 	uploadCtrl *upload.Controller,
+	notificationPrefCtrl *notificationpref.Controller,
 	usageSender usage.Sender,
 ) {
 	r.Route("/repos", func(r chi.Router) {
@@ -405,6 +447,31 @@ func setupRepos(r chi.Router,
 
 			r.Post("/default-branch", handlerrepo.HandleUpdateDefaultBranch(repoCtrl))
 
+			r.Post("/fork", handlerrepo.HandleFork(repoCtrl))
+			r.Post("/fork/sync", handlerrepo.HandleSyncFork(repoCtrl))
+			r.Get("/forks", handlerrepo.HandleListForks(repoCtrl))
+
+			r.Put("/mirror", handlerrepo.HandleSavePullMirror(repoCtrl))
+			r.Delete("/mirror", handlerrepo.HandleDeletePullMirror(repoCtrl))
+			r.Post("/mirror/sync", handlerrepo.HandleSyncPullMirror(repoCtrl))
+
+			r.Route("/push-mirrors", func(r chi.Router) {
+				r.Post("/", handlerrepo.HandlePushMirrorCreate(repoCtrl))
+				r.Get("/", handlerrepo.HandlePushMirrorList(repoCtrl))
+
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamPushMirrorIdentifier), func(r chi.Router) {
+					r.Get("/", handlerrepo.HandlePushMirrorFind(repoCtrl))
+					r.Patch("/", handlerrepo.HandlePushMirrorUpdate(repoCtrl))
+					r.Delete("/", handlerrepo.HandlePushMirrorDelete(repoCtrl))
+					r.Post("/sync", handlerrepo.HandlePushMirrorSync(repoCtrl))
+				})
+			})
+
+			r.Route("/notification-subscription", func(r chi.Router) {
+				r.Put("/", handlernotificationpref.HandleSetRepoSubscription(notificationPrefCtrl))
+				r.Delete("/", handlernotificationpref.HandleDeleteRepoSubscription(notificationPrefCtrl))
+			})
+
 			// content operations
 			// NOTE: this allows /content and /content/ to both be valid (without any other tricks.)
 			// We don't expect there to be any other operations in that route (as that could overlap with file names)
@@ -470,6 +537,8 @@ func setupRepos(r chi.Router,
 
 			r.Post("/rebase", handlerrepo.HandleRebase(repoCtrl))
 			r.Post("/squash", handlerrepo.HandleSquash(repoCtrl))
+			r.Post("/revert", handlerrepo.HandleRevert(repoCtrl))
+			r.Post("/cherry-pick", handlerrepo.HandleCherryPick(repoCtrl))
 
 			r.Get("/codeowners/validate", handlerrepo.HandleCodeOwnersValidate(repoCtrl))
 
@@ -477,7 +546,7 @@ func setupRepos(r chi.Router,
 				usage.Middleware(usageSender, false),
 			).Get(fmt.Sprintf("/archive/%s", request.PathParamArchiveGitRef), handlerrepo.HandleArchive(repoCtrl))
 
-			SetupPullReq(r, pullreqCtrl)
+			SetupPullReq(r, pullreqCtrl, notificationPrefCtrl)
 
 			SetupWebhookRepo(r, webhookCtrl)
 
@@ -541,6 +610,7 @@ func setupPipelines(
 			r.Delete("/", handlerpipeline.HandleDelete(pipelineCtrl))
 			setupExecutions(r, executionCtrl, logCtrl)
 			setupTriggers(r, triggerCtrl)
+			setupPipelineCaches(r, pipelineCtrl)
 		})
 	})
 }
@@ -630,10 +700,29 @@ func setupExecutions(
 					request.PathParamStageNumber,
 					request.PathParamStepNumber,
 				), handlerlogs.HandleTail(logCtrl))
+			r.Route("/artifacts", func(r chi.Router) {
+				r.Get("/", handlerexecution.HandleListArtifacts(executionCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamArtifactName), func(r chi.Router) {
+					r.Get("/", handlerexecution.HandleDownloadArtifact(executionCtrl))
+					r.Put("/", handlerexecution.HandleUploadArtifact(executionCtrl))
+					r.Delete("/", handlerexecution.HandleDeleteArtifact(executionCtrl))
+				})
+			})
 		})
 	})
 }
 
+func setupPipelineCaches(
+	r chi.Router,
+	pipelineCtrl *pipeline.Controller,
+) {
+	r.Route(fmt.Sprintf("/caches/{%s}", request.PathParamCacheKey), func(r chi.Router) {
+		r.Get("/", handlerpipeline.HandleDownloadCache(pipelineCtrl))
+		r.Put("/", handlerpipeline.HandleUploadCache(pipelineCtrl))
+		r.Delete("/", handlerpipeline.HandleDeleteCache(pipelineCtrl))
+	})
+}
+
 func setupTriggers(
 	r chi.Router,
 	triggerCtrl *trigger.Controller,
@@ -663,10 +752,11 @@ func SetupGitHooks(r chi.Router, githookCtrl *controllergithook.Controller, git
 	})
 }
 
-func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
+func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller, notificationPrefCtrl *notificationpref.Controller) {
 	r.Route("/pullreq", func(r chi.Router) {
 		r.Post("/", handlerpullreq.HandleCreate(pullreqCtrl))
 		r.Get("/", handlerpullreq.HandleList(pullreqCtrl))
+		r.Get("/merge-queue", handlerpullreq.HandleMergeQueueList(pullreqCtrl))
 		r.Get(
 			fmt.Sprintf("/{%s}...{%s}", request.PathParamTargetBranch, request.PathParamSourceBranch),
 			handlerpullreq.HandleFindByBranches(pullreqCtrl),
@@ -677,6 +767,10 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			r.Patch("/", handlerpullreq.HandleUpdate(pullreqCtrl))
 			r.Post("/state", handlerpullreq.HandleState(pullreqCtrl))
 			r.Get("/activities", handlerpullreq.HandleListActivities(pullreqCtrl))
+			r.Route("/notification-subscription", func(r chi.Router) {
+				r.Put("/", handlernotificationpref.HandleSetPullReqSubscription(notificationPrefCtrl))
+				r.Delete("/", handlernotificationpref.HandleDeletePullReqSubscription(notificationPrefCtrl))
+			})
 			r.Route("/comments", func(r chi.Router) {
 				r.Post("/", handlerpullreq.HandleCommentCreate(pullreqCtrl))
 				r.Post("/apply-suggestions", handlerpullreq.HandleCommentApplySuggestions(pullreqCtrl))
@@ -706,6 +800,12 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
 			})
 			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
+			r.Delete("/merge-queue", handlerpullreq.HandleMergeQueueRemove(pullreqCtrl))
+			r.Route("/auto-merge", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleAutoMergeFind(pullreqCtrl))
+				r.Post("/", handlerpullreq.HandleAutoMergeEnable(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleAutoMergeDisable(pullreqCtrl))
+			})
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
 			r.Get("/metadata", handlerpullreq.HandleMetadata(pullreqCtrl))
 			r.Route("/branch", func(r chi.Router) {
@@ -747,6 +847,7 @@ func SetupWebhookRepo(r chi.Router, webhookCtrl *webhook.Controller) {
 			r.Get("/", handlerwebhook.HandleFindRepo(webhookCtrl))
 			r.Patch("/", handlerwebhook.HandleUpdateRepo(webhookCtrl))
 			r.Delete("/", handlerwebhook.HandleDeleteRepo(webhookCtrl))
+			r.Post("/test", handlerwebhook.HandleTestDeliveryRepo(webhookCtrl))
 
 			r.Route("/executions", func(r chi.Router) {
 				r.Get("/", handlerwebhook.HandleListExecutionsRepo(webhookCtrl))
@@ -783,7 +884,7 @@ func SetupRulesRepo(r chi.Router, repoCtrl *repo.Controller) {
 	})
 }
 
-func setupUser(r chi.Router, userCtrl *user.Controller) {
+func setupUser(r chi.Router, userCtrl *user.Controller, notificationPrefCtrl *notificationpref.Controller) {
 	r.Route("/user", func(r chi.Router) {
 		// enforce principal authenticated and it's a user
 		r.Use(middlewareprincipal.RestrictTo(enum.PrincipalTypeUser))
@@ -819,6 +920,12 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 			r.Delete(fmt.Sprintf("/{%s}", request.PathParamPublicKeyIdentifier),
 				handleruser.HandleDeletePublicKey(userCtrl))
 		})
+
+		// Notification preferences
+		r.Route("/notifications", func(r chi.Router) {
+			r.Get("/", handlernotificationpref.HandleFind(notificationPrefCtrl))
+			r.Patch("/settings", handlernotificationpref.HandleUpdateSettings(notificationPrefCtrl))
+		})
 	})
 }
 
@@ -897,9 +1004,15 @@ func setupInfraProviders(r chi.Router, infraProviderCtrl *infraprovider.Controll
 	})
 }
 
-func setupAdmin(r chi.Router, userCtrl *user.Controller) {
+func setupAdmin(r chi.Router, userCtrl *user.Controller, sysCtrl *system.Controller, auditCtrl *auditlog.Controller) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
+		r.Get("/settings", handlersystem.HandleFindSettings(sysCtrl))
+		r.Patch("/settings", handlersystem.HandleUpdateSettings(sysCtrl))
+		r.Route("/audit-events", func(r chi.Router) {
+			r.Get("/", handlerauditlog.HandleList(auditCtrl))
+			r.Get("/export", handlerauditlog.HandleExport(auditCtrl))
+		})
 		r.Route("/users", func(r chi.Router) {
 			r.Get("/", users.HandleList(userCtrl))
 			r.Post("/", users.HandleCreate(userCtrl))
@@ -921,7 +1034,9 @@ func setupAccountWithoutAuth(
 	config *types.Config,
 ) {
 	cookieName := config.Token.CookieName
-	r.Post("/login", account.HandleLogin(userCtrl, cookieName))
+	r.Post("/login", account.HandleLogin(userCtrl, sysCtrl, cookieName))
+	r.Get("/login/oidc", account.HandleLoginOIDC(userCtrl))
+	r.Get("/login/oidc/callback", account.HandleLoginOIDCCallback(userCtrl, config))
 	r.Post("/register", account.HandleRegister(userCtrl, sysCtrl, cookieName))
 }
 
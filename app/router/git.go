@@ -18,7 +18,9 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/repo"
+	handlerlfs "github.com/harness/gitness/app/api/handler/lfs"
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
 	middlewareauthz "github.com/harness/gitness/app/api/middleware/authz"
@@ -44,6 +46,7 @@ func NewGitHandler(
 	urlProvider url.Provider,
 	authenticator authn.Authenticator,
 	repoCtrl *repo.Controller,
+	lfsCtrl *lfs.Controller,
 	usageSender usage.Sender,
 ) http.Handler {
 	// maxRepoDepth depends on config
@@ -89,6 +92,16 @@ func NewGitHandler(
 				enum.GitServiceTypeReceivePack, repoCtrl, urlProvider))
 			r.Get("/info/refs", handlerrepo.HandleGitInfoRefs(repoCtrl, urlProvider))
 
+			// git lfs protocol
+			r.Route("/info/lfs/objects", func(r chi.Router) {
+				r.Post("/batch", handlerlfs.HandleLFSBatch(lfsCtrl, urlProvider))
+				r.Post("/verify", handlerlfs.HandleLFSVerify(lfsCtrl, urlProvider))
+				r.Get(fmt.Sprintf("/{%s}", request.PathParamLFSObjectID),
+					handlerlfs.HandleLFSDownload(lfsCtrl, urlProvider))
+				r.Put(fmt.Sprintf("/{%s}/{%s}", request.PathParamLFSObjectID, request.PathParamLFSObjectSize),
+					handlerlfs.HandleLFSUpload(lfsCtrl, urlProvider))
+			})
+
 			// dumb protocol
 			r.Get("/HEAD", stubGitHandler())
 			r.Get("/objects/info/alternates", stubGitHandler())
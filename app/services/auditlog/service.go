@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+)
+
+var _ audit.Service = (*Service)(nil)
+
+// Service is an audit.Service that persists the audit events, so they can be listed and exported.
+type Service struct {
+	auditEventStore store.AuditEventStore
+}
+
+func NewService(auditEventStore store.AuditEventStore) *Service {
+	return &Service{
+		auditEventStore: auditEventStore,
+	}
+}
+
+// Log records a new audit event.
+func (s *Service) Log(
+	ctx context.Context,
+	user types.Principal,
+	resource audit.Resource,
+	action audit.Action,
+	spacePath string,
+	options ...audit.Option,
+) error {
+	event, err := audit.NewEvent(ctx, user, resource, action, spacePath, options...)
+	if err != nil {
+		return fmt.Errorf("invalid audit event: %w", err)
+	}
+
+	oldObject, err := marshalObject(event.DiffObject.OldObject)
+	if err != nil {
+		return fmt.Errorf("failed to marshal old object of the audit event: %w", err)
+	}
+
+	newObject, err := marshalObject(event.DiffObject.NewObject)
+	if err != nil {
+		return fmt.Errorf("failed to marshal new object of the audit event: %w", err)
+	}
+
+	err = s.auditEventStore.Create(ctx, &types.AuditEvent{
+		Timestamp:          event.Timestamp,
+		Action:             string(event.Action),
+		Principal:          *event.User.ToPrincipalInfo(),
+		SpacePath:          event.SpacePath,
+		ResourceType:       string(event.Resource.Type),
+		ResourceIdentifier: event.Resource.Identifier,
+		ResourceData:       event.Resource.Data,
+		OldObject:          oldObject,
+		NewObject:          newObject,
+		ClientIP:           event.ClientIP,
+		RequestMethod:      event.RequestMethod,
+		Data:               event.Data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store audit event: %w", err)
+	}
+
+	return nil
+}
+
+func marshalObject(object any) (json.RawMessage, error) {
+	if object == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(object)
+}
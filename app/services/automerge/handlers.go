@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"fmt"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+// handleEventAutoMergeEnabled merges the pull request right away if the merge requirements are already satisfied.
+func (s *Service) handleEventAutoMergeEnabled(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.AutoMergeEnabledPayload],
+) error {
+	return s.pullreqCtrl.AutoMergeNoAuth(ctx, event.Payload.PullReqID)
+}
+
+// handleEventBranchUpdated re-evaluates the auto-merge after new commits were pushed to the source branch.
+func (s *Service) handleEventBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	return s.pullreqCtrl.AutoMergeOnBranchUpdateNoAuth(ctx, event.Payload.PullReqID, event.Payload.PrincipalID)
+}
+
+// handleEventReviewSubmitted re-evaluates the auto-merge after a review, approvals are a common merge requirement.
+func (s *Service) handleEventReviewSubmitted(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
+) error {
+	return s.pullreqCtrl.AutoMergeNoAuth(ctx, event.Payload.PullReqID)
+}
+
+// handleEventClosed removes the auto-merge of a closed pull request, so it doesn't get merged if reopened.
+func (s *Service) handleEventClosed(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	return s.deleteAutoMerge(ctx, event.Payload.PullReqID)
+}
+
+// handleEventMerged removes the auto-merge of a pull request that has been merged manually.
+func (s *Service) handleEventMerged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	return s.deleteAutoMerge(ctx, event.Payload.PullReqID)
+}
+
+// handleEventCheckReported re-evaluates the auto-merge of all pull requests
+// for which the reported commit is the latest source branch commit.
+func (s *Service) handleEventCheckReported(
+	ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload],
+) error {
+	if !event.Payload.Status.IsCompleted() {
+		return nil // a pending or running status check can't unblock the merge
+	}
+
+	return s.autoMergeForCommit(ctx, event.Payload.RepoID, event.Payload.CommitSHA)
+}
+
+// handleEventPipelineExecuted re-evaluates the auto-merge after a pipeline execution has updated its status check.
+func (s *Service) handleEventPipelineExecuted(
+	ctx context.Context,
+	event *events.Event[*pipelineevents.ExecutedPayload],
+) error {
+	if event.Payload.CommitSHA == "" {
+		return nil
+	}
+
+	return s.autoMergeForCommit(ctx, event.Payload.RepoID, event.Payload.CommitSHA)
+}
+
+func (s *Service) autoMergeForCommit(ctx context.Context, repoID int64, commitSHA string) error {
+	autoMerges, err := s.autoMergeStore.ListByCommitSHA(ctx, repoID, commitSHA)
+	if err != nil {
+		return fmt.Errorf("failed to list auto-merges for commit %s: %w", commitSHA, err)
+	}
+
+	for _, am := range autoMerges {
+		if err := s.pullreqCtrl.AutoMergeNoAuth(ctx, am.PullReqID); err != nil {
+			// one failing pull request shouldn't prevent merging of the others
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("pullreq_id", am.PullReqID).
+				Msg("failed to auto-merge pull request")
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) deleteAutoMerge(ctx context.Context, pullreqID int64) error {
+	err := s.autoMergeStore.Delete(ctx, pullreqID)
+	if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to delete auto-merge: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	checkevents "github.com/harness/gitness/app/events/check"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+)
+
+// Service merges pull requests with enabled auto-merge once all merge requirements are satisfied.
+// The merge requirements are re-evaluated whenever the pull request source branch is updated,
+// a review is submitted or a status check of the source branch commit is completed.
+type Service struct {
+	autoMergeStore store.AutoMergeStore
+	pullreqCtrl    *pullreq.Controller
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pipelineEvReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+	autoMergeStore store.AutoMergeStore,
+	pullreqCtrl *pullreq.Controller,
+) (*Service, error) {
+	service := &Service{
+		autoMergeStore: autoMergeStore,
+		pullreqCtrl:    pullreqCtrl,
+	}
+
+	const groupAutoMerge = "gitness:pullreq:automerge"
+	const idleTimeout = 1 * time.Minute
+
+	_, err := pullreqEvReaderFactory.Launch(ctx, groupAutoMerge, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(3),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterAutoMergeEnabled(service.handleEventAutoMergeEnabled)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+			_ = r.RegisterReviewSubmitted(service.handleEventReviewSubmitted)
+			_ = r.RegisterClosed(service.handleEventClosed)
+			_ = r.RegisterMerged(service.handleEventMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = checkEvReaderFactory.Launch(ctx, groupAutoMerge, config.InstanceID,
+		func(r *checkevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(3),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterReported(service.handleEventCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = pipelineEvReaderFactory.Launch(ctx, groupAutoMerge, config.InstanceID,
+		func(r *pipelineevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(3),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterExecuted(service.handleEventPipelineExecuted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatops
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Message is a short message that is posted to a chat-ops tool through an incoming webhook.
+type Message struct {
+	Title string
+	Text  string
+	// Quote is an optional text (like a comment) that is displayed as a quote below the text.
+	Quote string
+	// URL is the URL the title of the message links to.
+	URL string
+	// LinkName is the name of the button that opens the URL, for tools that display one.
+	LinkName string
+}
+
+// SlackText returns the text of the message in the Slack message formatting.
+func (m Message) SlackText() string {
+	text := fmt.Sprintf("*<%s|%s>*\n%s", m.URL, slackEscape(m.Title), slackEscape(m.Text))
+	if m.Quote != "" {
+		text += "\n>" + strings.ReplaceAll(slackEscape(m.Quote), "\n", "\n>")
+	}
+
+	return text
+}
+
+// TeamsCard returns the message as a Microsoft Teams card.
+// Teams incoming webhooks accept the legacy actionable message card format.
+func (m Message) TeamsCard() map[string]any {
+	text := m.Text
+	if m.Quote != "" {
+		text += "\n\n> " + strings.ReplaceAll(m.Quote, "\n", "\n> ")
+	}
+
+	return map[string]any{
+		"@type":    "MessageCard",
+		"@context": "https://schema.org/extensions",
+		"summary":  m.Title,
+		"title":    m.Title,
+		"text":     text,
+		"potentialAction": []map[string]any{{
+			"@type":   "OpenUri",
+			"name":    m.LinkName,
+			"targets": []map[string]string{{"os": "default", "uri": m.URL}},
+		}},
+	}
+}
+
+// DiscordBody returns the message as the body of a Discord webhook execution.
+func (m Message) DiscordBody() map[string]any {
+	description := m.Text
+	if m.Quote != "" {
+		description += "\n> " + strings.ReplaceAll(m.Quote, "\n", "\n> ")
+	}
+
+	return map[string]any{
+		"embeds": []map[string]any{{
+			"title":       m.Title,
+			"url":         m.URL,
+			"description": description,
+		}},
+	}
+}
+
+// slackEscape escapes the control characters of the Slack message formatting.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
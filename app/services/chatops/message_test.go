@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatops
+
+import (
+	"testing"
+)
+
+func TestMessage_SlackText(t *testing.T) {
+	msg := Message{
+		Title: "[repo] #7 <Fix> & bug",
+		Text:  "Jane commented.",
+		Quote: "first\n> second",
+		URL:   "https://example.com/pr/7",
+	}
+
+	want := "*<https://example.com/pr/7|[repo] #7 &lt;Fix&gt; &amp; bug>*\nJane commented.\n>first\n>&gt; second"
+	if got := msg.SlackText(); got != want {
+		t.Errorf("SlackText() = %q, want %q", got, want)
+	}
+}
+
+func TestMessage_TeamsCard(t *testing.T) {
+	msg := Message{
+		Title:    "title",
+		Text:     "text",
+		Quote:    "a\nb",
+		URL:      "https://example.com",
+		LinkName: "View",
+	}
+
+	card := msg.TeamsCard()
+	if got, want := card["text"], "text\n\n> a\n> b"; got != want {
+		t.Errorf("text = %q, want %q", got, want)
+	}
+
+	actions, _ := card["potentialAction"].([]map[string]any)
+	if len(actions) != 1 || actions[0]["name"] != "View" {
+		t.Errorf("unexpected potential actions: %v", card["potentialAction"])
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/execution"
+	"github.com/harness/gitness/app/api/controller/pipeline"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypePipelineArtifacts        = "gitness:cleanup:pipeline-artifacts"
+	jobCronPipelineArtifacts        = "41 */2 * * *" // At minute 41 past every 2nd hour.
+	jobMaxDurationPipelineArtifacts = 10 * time.Minute
+
+	pipelineArtifactsBatchSize = 100
+)
+
+type pipelineArtifactsCleanupJob struct {
+	cacheRetentionTime time.Duration
+
+	blobStore     blob.Store
+	artifactStore store.PipelineArtifactStore
+	cacheStore    store.PipelineCacheStore
+}
+
+func newPipelineArtifactsCleanupJob(
+	cacheRetentionTime time.Duration,
+	blobStore blob.Store,
+	artifactStore store.PipelineArtifactStore,
+	cacheStore store.PipelineCacheStore,
+) *pipelineArtifactsCleanupJob {
+	return &pipelineArtifactsCleanupJob{
+		cacheRetentionTime: cacheRetentionTime,
+
+		blobStore:     blobStore,
+		artifactStore: artifactStore,
+		cacheStore:    cacheStore,
+	}
+}
+
+// Handle purges expired pipeline artifacts and build caches that haven't been used within the retention time.
+func (j *pipelineArtifactsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	now := time.Now()
+	unusedSince := now.Add(-j.cacheRetentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging expired pipeline artifacts and build caches unused for %s (aka last used before %s)",
+		j.cacheRetentionTime,
+		unusedSince.Format(time.RFC3339Nano))
+
+	artifactCount, err := j.purgeArtifacts(ctx, now.UnixMilli())
+	if err != nil {
+		return "", err
+	}
+
+	cacheCount, err := j.purgeCaches(ctx, unusedSince.UnixMilli())
+	if err != nil {
+		return "", err
+	}
+
+	result := "no expired pipeline artifacts or unused build caches found"
+	if artifactCount > 0 || cacheCount > 0 {
+		result = fmt.Sprintf("deleted %d pipeline artifacts and %d build caches", artifactCount, cacheCount)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+func (j *pipelineArtifactsCleanupJob) purgeArtifacts(ctx context.Context, before int64) (int, error) {
+	var n int
+	for {
+		artifacts, err := j.artifactStore.ListExpired(ctx, before, pipelineArtifactsBatchSize)
+		if err != nil {
+			return n, fmt.Errorf("failed to list expired pipeline artifacts: %w", err)
+		}
+
+		for _, artifact := range artifacts {
+			err = j.deleteBlob(ctx, execution.ArtifactBlobPath(artifact.RepoID, artifact.ExecutionID, artifact.BlobUID))
+			if err != nil {
+				return n, fmt.Errorf("failed to delete content of pipeline artifact %d: %w", artifact.ID, err)
+			}
+
+			if err = j.artifactStore.Delete(ctx, artifact.ID); err != nil {
+				return n, fmt.Errorf("failed to delete pipeline artifact %d: %w", artifact.ID, err)
+			}
+
+			n++
+		}
+
+		if len(artifacts) < pipelineArtifactsBatchSize {
+			return n, nil
+		}
+	}
+}
+
+func (j *pipelineArtifactsCleanupJob) purgeCaches(ctx context.Context, before int64) (int, error) {
+	var n int
+	for {
+		caches, err := j.cacheStore.ListUnused(ctx, before, pipelineArtifactsBatchSize)
+		if err != nil {
+			return n, fmt.Errorf("failed to list unused build caches: %w", err)
+		}
+
+		for _, cache := range caches {
+			err = j.deleteBlob(ctx, pipeline.CacheBlobPath(cache.RepoID, cache.PipelineID, cache.BlobUID))
+			if err != nil {
+				return n, fmt.Errorf("failed to delete content of build cache %d: %w", cache.ID, err)
+			}
+
+			if err = j.cacheStore.Delete(ctx, cache.ID); err != nil {
+				return n, fmt.Errorf("failed to delete build cache %d: %w", cache.ID, err)
+			}
+
+			n++
+		}
+
+		if len(caches) < pipelineArtifactsBatchSize {
+			return n, nil
+		}
+	}
+}
+
+func (j *pipelineArtifactsCleanupJob) deleteBlob(ctx context.Context, blobPath string) error {
+	err := j.blobStore.Delete(ctx, blobPath)
+	if err != nil && !errors.Is(err, blob.ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
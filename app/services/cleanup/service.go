@@ -22,12 +22,15 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/job"
 )
 
 type Config struct {
 	WebhookExecutionsRetentionTime   time.Duration
 	DeletedRepositoriesRetentionTime time.Duration
+
+	PipelineCacheRetentionTime time.Duration
 }
 
 func (c *Config) Prepare() error {
@@ -41,6 +44,10 @@ func (c *Config) Prepare() error {
 	if c.DeletedRepositoriesRetentionTime <= 0 {
 		return errors.New("config.DeletedRepositoriesRetentionTime has to be provided")
 	}
+
+	if c.PipelineCacheRetentionTime <= 0 {
+		return errors.New("config.PipelineCacheRetentionTime has to be provided")
+	}
 	return nil
 }
 
@@ -53,6 +60,10 @@ type Service struct {
 	tokenStore            store.TokenStore
 	repoStore             store.RepoStore
 	repoCtrl              *repo.Controller
+
+	blobStore             blob.Store
+	pipelineArtifactStore store.PipelineArtifactStore
+	pipelineCacheStore    store.PipelineCacheStore
 }
 
 func NewService(
@@ -63,6 +74,9 @@ func NewService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	blobStore blob.Store,
+	pipelineArtifactStore store.PipelineArtifactStore,
+	pipelineCacheStore store.PipelineCacheStore,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided cleanup config is invalid: %w", err)
@@ -77,6 +91,9 @@ func NewService(
 		tokenStore:            tokenStore,
 		repoStore:             repoStore,
 		repoCtrl:              repoCtrl,
+		blobStore:             blobStore,
+		pipelineArtifactStore: pipelineArtifactStore,
+		pipelineCacheStore:    pipelineCacheStore,
 	}, nil
 }
 
@@ -126,6 +143,17 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to schedule deleted repo cleanup job: %w", err)
 	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypePipelineArtifacts,
+		jobTypePipelineArtifacts,
+		jobCronPipelineArtifacts,
+		jobMaxDurationPipelineArtifacts,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule pipeline artifacts cleanup job: %w", err)
+	}
 	return nil
 }
 
@@ -160,5 +188,17 @@ func (s *Service) registerJobHandlers() error {
 	); err != nil {
 		return fmt.Errorf("failed to register job handler for deleted repos cleanup: %w", err)
 	}
+
+	if err := s.executor.Register(
+		jobTypePipelineArtifacts,
+		newPipelineArtifactsCleanupJob(
+			s.config.PipelineCacheRetentionTime,
+			s.blobStore,
+			s.pipelineArtifactStore,
+			s.pipelineCacheStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for pipeline artifacts cleanup: %w", err)
+	}
 	return nil
 }
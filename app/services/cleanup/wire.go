@@ -17,6 +17,7 @@ package cleanup
 import (
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
@@ -34,6 +35,9 @@ func ProvideService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	blobStore blob.Store,
+	pipelineArtifactStore store.PipelineArtifactStore,
+	pipelineCacheStore store.PipelineCacheStore,
 ) (*Service, error) {
 	return NewService(
 		config,
@@ -43,5 +47,8 @@ func ProvideService(
 		tokenStore,
 		repoStore,
 		repoCtrl,
+		blobStore,
+		pipelineArtifactStore,
+		pipelineCacheStore,
 	)
 }
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitremote
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	secretCtrl "github.com/harness/gitness/app/api/controller/secret"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+)
+
+// Password returns the decrypted value of the secret used to access a remote repository.
+// If no secret is provided, the returned password is empty.
+func Password(
+	ctx context.Context,
+	secretStore store.SecretStore,
+	encrypter encrypt.Encrypter,
+	secretID *int64,
+) (string, error) {
+	if secretID == nil {
+		return "", nil
+	}
+
+	secret, err := secretStore.Find(ctx, *secretID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find mirror secret: %w", err)
+	}
+
+	secret, err = secretCtrl.Dec(encrypter, secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt mirror secret: %w", err)
+	}
+
+	return secret.Data, nil
+}
+
+// URL returns the URL of a remote repository including the credentials.
+func URL(rawURL, username, password string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse mirror URL: %w", err)
+	}
+
+	if username != "" || password != "" {
+		u.User = url.UserPassword(username, password)
+	}
+
+	return u.String(), nil
+}
+
+// Redact removes the password, plain and URL encoded, from the provided error message.
+func Redact(msg, password string) string {
+	if password == "" {
+		return msg
+	}
+
+	const mask = "******"
+
+	// the user info of an URL is formatted as "username:password" with the password escaped.
+	escaped := strings.TrimPrefix(url.UserPassword("", password).String(), ":")
+
+	msg = strings.ReplaceAll(msg, escaped, mask)
+	msg = strings.ReplaceAll(msg, password, mask)
+
+	return msg
+}
@@ -0,0 +1,217 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// indexVersion is the version of the on-disk format of the index.
+// Indexes with a different version are ignored and rebuilt from scratch.
+const indexVersion = 1
+
+const indexFileExtension = ".idx"
+
+// binaryDetectionSize is the number of leading bytes of a file inspected to detect binary content.
+const binaryDetectionSize = 8000
+
+// repoIndex is the trigram index of all files of a single branch of a repository.
+type repoIndex struct {
+	Version   int
+	RepoID    int64
+	Branch    string
+	CommitSHA string
+	Documents []document
+
+	// Postings maps every trigram to the sorted list of positions of the documents containing it.
+	// Trigrams are built from the lower-case content, so the postings are used for both,
+	// case-sensitive and case-insensitive, queries.
+	Postings map[trigram][]uint32
+}
+
+// document is a single file of the index.
+type document struct {
+	Path    string
+	BlobSHA string
+	// Skipped is set for files that are part of the tree but whose content isn't indexed (e.g. binary files).
+	// Such files are kept in the index to avoid reading their content again on the next index update.
+	Skipped bool
+	Content []byte
+}
+
+func newRepoIndex(repoID int64, branch, commitSHA string, docs []document) *repoIndex {
+	idx := &repoIndex{
+		Version:   indexVersion,
+		RepoID:    repoID,
+		Branch:    branch,
+		CommitSHA: commitSHA,
+		Documents: docs,
+		Postings:  make(map[trigram][]uint32),
+	}
+
+	seen := make(map[trigram]struct{})
+	for i := range docs {
+		if docs[i].Skipped {
+			continue
+		}
+
+		clear(seen)
+		forEachTrigram(docs[i].Content, func(t trigram) {
+			if _, ok := seen[t]; ok {
+				return
+			}
+			seen[t] = struct{}{}
+			// documents are processed in order, so the postings remain sorted.
+			idx.Postings[t] = append(idx.Postings[t], uint32(i))
+		})
+	}
+
+	return idx
+}
+
+// candidates returns positions of the documents that contain all the provided trigrams.
+// If no trigrams are provided, all indexed documents are returned.
+func (idx *repoIndex) candidates(trigrams []trigram) []uint32 {
+	if len(trigrams) == 0 {
+		all := make([]uint32, 0, len(idx.Documents))
+		for i := range idx.Documents {
+			if !idx.Documents[i].Skipped {
+				all = append(all, uint32(i))
+			}
+		}
+		return all
+	}
+
+	result := idx.Postings[trigrams[0]]
+	for _, t := range trigrams[1:] {
+		if len(result) == 0 {
+			break
+		}
+		result = intersect(result, idx.Postings[t])
+	}
+
+	return result
+}
+
+// intersect returns the intersection of two sorted lists.
+func intersect(a, b []uint32) []uint32 {
+	result := make([]uint32, 0, min(len(a), len(b)))
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// isBinary reports whether the content looks like binary data, using the same heuristic as git.
+func isBinary(content []byte) bool {
+	if len(content) > binaryDetectionSize {
+		content = content[:binaryDetectionSize]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
+
+func indexFilePath(root string, repoID int64) string {
+	return filepath.Join(root, strconv.FormatInt(repoID, 10)+indexFileExtension)
+}
+
+// readIndexFile reads the index from the disk. It returns nil if the index doesn't exist
+// or if it has been written in an old format.
+func readIndexFile(path string) (*repoIndex, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index file: %w", err)
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+	}
+	defer zr.Close()
+
+	idx := &repoIndex{}
+	if err := gob.NewDecoder(zr).Decode(idx); err != nil {
+		return nil, fmt.Errorf("failed to decode index: %w", err)
+	}
+
+	if idx.Version != indexVersion {
+		return nil, nil
+	}
+
+	return idx, nil
+}
+
+// writeIndexFile writes the index to the disk. The index is first written to a temporary file
+// which is then renamed, so the readers never observe a partially written index.
+func writeIndexFile(path string, idx *repoIndex) (err error) {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary index file: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	zw := gzip.NewWriter(f)
+
+	if err = gob.NewEncoder(zw).Encode(idx); err != nil {
+		return fmt.Errorf("failed to encode index: %w", err)
+	}
+
+	if err = zw.Close(); err != nil {
+		return fmt.Errorf("failed to flush compressed index: %w", err)
+	}
+
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary index file: %w", err)
+	}
+
+	if err = os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to rename temporary index file: %w", err)
+	}
+
+	return nil
+}
+
+func removeIndexFile(path string) error {
+	err := os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove index file: %w", err)
+	}
+	return nil
+}
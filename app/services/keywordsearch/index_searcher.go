@@ -25,6 +25,12 @@ type Indexer interface {
 }
 
 type Searcher interface {
-	Search(ctx context.Context, repoIDs []int64, query string, enableRegex bool, maxResultCount int) (
-		types.SearchResult, error)
+	Search(
+		ctx context.Context,
+		repoIDs []int64,
+		query string,
+		enableRegex bool,
+		caseSensitive bool,
+		maxResultCount int,
+	) (types.SearchResult, error)
 }
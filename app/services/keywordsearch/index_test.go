@@ -0,0 +1,156 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestRepoIndex_Search(t *testing.T) {
+	docs := []document{
+		{Path: "main.go", Content: []byte("package main\n\nfunc main() {\n\tprintln(\"Hello World\")\n}\n")},
+		{Path: "README.md", Content: []byte("# Hello\r\nThis is a hello world example.\r\n")},
+		{Path: "logo.png", Skipped: true},
+		{Path: "util.go", Content: []byte("package main\n\nfunc helper() int {\n\treturn 42\n}\n")},
+	}
+
+	idx := newRepoIndex(1, "main", "abc", docs)
+
+	tests := []struct {
+		name          string
+		query         string
+		enableRegex   bool
+		caseSensitive bool
+		expFiles      []string
+	}{
+		{
+			name:     "literal",
+			query:    "hello world",
+			expFiles: []string{"main.go", "README.md"},
+		},
+		{
+			name:          "literal-case-sensitive",
+			query:         "Hello World",
+			caseSensitive: true,
+			expFiles:      []string{"main.go"},
+		},
+		{
+			name:        "regex",
+			query:       `func (main|helper)\(\)`,
+			enableRegex: true,
+			expFiles:    []string{"main.go", "util.go"},
+		},
+		{
+			name:        "regex-with-literals",
+			query:       `return \d+`,
+			enableRegex: true,
+			expFiles:    []string{"util.go"},
+		},
+		{
+			name:     "short-query",
+			query:    "42",
+			expFiles: []string{"util.go"},
+		},
+		{
+			name:  "no-match",
+			query: "missing",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			re, err := compileQuery(test.query, test.enableRegex, test.caseSensitive)
+			if err != nil {
+				t.Fatalf("failed to compile query: %s", err)
+			}
+
+			trigrams, err := queryTrigrams(test.query, test.enableRegex)
+			if err != nil {
+				t.Fatalf("failed to get query trigrams: %s", err)
+			}
+
+			var files []string
+			for _, pos := range idx.candidates(trigrams) {
+				if len(matchContent(re, idx.Documents[pos].Content)) > 0 {
+					files = append(files, idx.Documents[pos].Path)
+				}
+			}
+
+			if !reflect.DeepEqual(test.expFiles, files) {
+				t.Errorf("want=%v got=%v", test.expFiles, files)
+			}
+		})
+	}
+}
+
+func TestMatchContent(t *testing.T) {
+	re, err := compileQuery("o", false, true)
+	if err != nil {
+		t.Fatalf("failed to compile query: %s", err)
+	}
+
+	matches := matchContent(re, []byte("first\nfoo bar\nlast"))
+
+	exp := []types.Match{
+		{
+			LineNum: 2,
+			Fragments: []types.Fragment{
+				{Pre: "f", Match: "o"},
+				{Pre: "", Match: "o", Post: " bar"},
+			},
+			Before: "first",
+			After:  "last",
+		},
+	}
+
+	if !reflect.DeepEqual(exp, matches) {
+		t.Errorf("want=%+v got=%+v", exp, matches)
+	}
+}
+
+func TestIndexFile(t *testing.T) {
+	path := indexFilePath(t.TempDir(), 1)
+
+	idx, err := readIndexFile(path)
+	if err != nil || idx != nil {
+		t.Fatalf("expected no index: idx=%v err=%v", idx, err)
+	}
+
+	want := newRepoIndex(1, "main", "abc", []document{
+		{Path: "a.txt", BlobSHA: "123", Content: []byte("some text")},
+		{Path: "b.bin", BlobSHA: "456", Skipped: true},
+	})
+
+	if err := writeIndexFile(path, want); err != nil {
+		t.Fatalf("failed to write index: %s", err)
+	}
+
+	got, err := readIndexFile(path)
+	if err != nil {
+		t.Fatalf("failed to read index: %s", err)
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want=%+v got=%+v", want, got)
+	}
+
+	if matches, _ := filepath.Glob(filepath.Join(filepath.Dir(path), "*.tmp")); len(matches) > 0 {
+		t.Errorf("temporary files left behind: %v", matches)
+	}
+}
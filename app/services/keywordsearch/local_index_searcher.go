@@ -16,27 +16,330 @@ package keywordsearch
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
 
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 )
 
+// defaultMaxResultCount is the maximum number of file matches returned if the caller didn't specify one.
+const defaultMaxResultCount = 50
+
+// LocalIndexSearcher is an embedded keyword search engine. It maintains a trigram index
+// of the default branch of every repository and stores it on the local disk, one file per repository.
+// The index is updated incrementally: content of files which didn't change since the last update is reused
+// and only the new blobs are read from git.
+// The most recently searched indexes are kept in memory, up to the configured number of repositories.
 type LocalIndexSearcher struct {
+	config Config
+	git    git.Interface
+
+	// repoLocks contains a *sync.Mutex per repository, used to serialize index updates of the same repository.
+	repoLocks sync.Map
+
+	mx      sync.Mutex
+	indexes map[int64]*cachedIndex
+}
+
+type cachedIndex struct {
+	idx      *repoIndex
+	lastUsed time.Time
 }
 
-func NewLocalIndexSearcher() *LocalIndexSearcher {
-	return &LocalIndexSearcher{}
+func NewLocalIndexSearcher(config Config, git git.Interface) (*LocalIndexSearcher, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided keyword search config is invalid: %w", err)
+	}
+
+	if err := os.MkdirAll(config.IndexPath, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create keyword search index directory: %w", err)
+	}
+
+	return &LocalIndexSearcher{
+		config:  config,
+		git:     git,
+		indexes: make(map[int64]*cachedIndex),
+	}, nil
 }
 
+// Search searches the indexes of the provided repositories.
+// The caller is responsible to provide only repositories the user has access to.
 func (s *LocalIndexSearcher) Search(
-	_ context.Context,
-	_ []int64,
-	_ string,
-	_ bool,
-	_ int,
+	ctx context.Context,
+	repoIDs []int64,
+	query string,
+	enableRegex bool,
+	caseSensitive bool,
+	maxResultCount int,
 ) (types.SearchResult, error) {
-	return types.SearchResult{}, nil
+	if maxResultCount <= 0 {
+		maxResultCount = defaultMaxResultCount
+	}
+
+	re, err := compileQuery(query, enableRegex, caseSensitive)
+	if err != nil {
+		return types.SearchResult{}, errors.InvalidArgument("Invalid regular expression: %s", err)
+	}
+
+	trigrams, err := queryTrigrams(query, enableRegex)
+	if err != nil {
+		return types.SearchResult{}, errors.InvalidArgument("Invalid regular expression: %s", err)
+	}
+
+	// sort repositories to return results in a stable order.
+	repoIDs = slices.Clone(repoIDs)
+	slices.Sort(repoIDs)
+
+	result := types.SearchResult{
+		FileMatches: []types.FileMatch{},
+	}
+
+	for _, repoID := range repoIDs {
+		idx, err := s.getIndex(repoID, true)
+		if err != nil {
+			return types.SearchResult{}, fmt.Errorf("failed to get index of repo %d: %w", repoID, err)
+		}
+
+		if idx == nil {
+			// the repository hasn't been indexed yet
+			continue
+		}
+
+		for _, pos := range idx.candidates(trigrams) {
+			if err := ctx.Err(); err != nil {
+				return types.SearchResult{}, err
+			}
+
+			doc := &idx.Documents[pos]
+
+			matches := matchContent(re, doc.Content)
+			if len(matches) == 0 {
+				continue
+			}
+
+			result.FileMatches = append(result.FileMatches, types.FileMatch{
+				FileName:   doc.Path,
+				RepoID:     repoID,
+				RepoBranch: idx.Branch,
+				Matches:    matches,
+			})
+			result.Stats.TotalFiles++
+			result.Stats.TotalMatches += len(matches)
+
+			if len(result.FileMatches) >= maxResultCount {
+				return result, nil
+			}
+		}
+	}
+
+	return result, nil
 }
 
-func (s *LocalIndexSearcher) Index(_ context.Context, _ *types.Repository) error {
+// Index updates the index of the default branch of the repository.
+func (s *LocalIndexSearcher) Index(ctx context.Context, repo *types.Repository) error {
+	lock := s.repoLock(repo.ID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if repo.DefaultBranch == "" {
+		return s.removeIndex(repo.ID)
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	branchOut, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: repo.DefaultBranch,
+	})
+	if errors.IsNotFound(err) {
+		// the default branch doesn't exist (e.g. empty repository), so there's nothing to search in.
+		return s.removeIndex(repo.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	commitSHA := branchOut.Branch.SHA.String()
+
+	oldIdx, err := s.getIndex(repo.ID, false)
+	if err != nil {
+		return fmt.Errorf("failed to get current index: %w", err)
+	}
+
+	if oldIdx != nil && oldIdx.Branch == repo.DefaultBranch && oldIdx.CommitSHA == commitSHA {
+		return nil
+	}
+
+	filesOut, err := s.git.ListFiles(ctx, &git.ListFilesParams{
+		ReadParams:   readParams,
+		GitREF:       commitSHA,
+		IncludeSizes: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list files: %w", err)
+	}
+
+	known := make(map[string]*document)
+	if oldIdx != nil {
+		for i := range oldIdx.Documents {
+			known[oldIdx.Documents[i].BlobSHA] = &oldIdx.Documents[i]
+		}
+	}
+
+	docs := make([]document, 0, len(filesOut.Files))
+	for _, file := range filesOut.Files {
+		if file.Type != git.TreeNodeTypeBlob || file.Mode == git.TreeNodeModeSymlink {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		doc := document{
+			Path:    file.Path,
+			BlobSHA: file.SHA,
+		}
+
+		switch knownDoc, ok := known[file.SHA]; {
+		case ok:
+			doc.Skipped = knownDoc.Skipped
+			doc.Content = knownDoc.Content
+		case file.Size > s.config.MaxFileSize:
+			doc.Skipped = true
+		default:
+			content, err := s.readBlob(ctx, readParams, file.SHA)
+			if err != nil {
+				return fmt.Errorf("failed to read file %q: %w", file.Path, err)
+			}
+
+			if isBinary(content) {
+				doc.Skipped = true
+			} else {
+				doc.Content = content
+			}
+		}
+
+		docs = append(docs, doc)
+	}
+
+	idx := newRepoIndex(repo.ID, repo.DefaultBranch, commitSHA, docs)
+
+	if err := writeIndexFile(indexFilePath(s.config.IndexPath, repo.ID), idx); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+
+	s.mx.Lock()
+	if cached, ok := s.indexes[repo.ID]; ok {
+		cached.idx = idx
+	}
+	s.mx.Unlock()
+
 	return nil
 }
+
+func (s *LocalIndexSearcher) readBlob(ctx context.Context, readParams git.ReadParams, blobSHA string) ([]byte, error) {
+	out, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        blobSHA,
+		SizeLimit:  s.config.MaxFileSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	defer out.Content.Close()
+
+	return io.ReadAll(out.Content)
+}
+
+// getCachedIndex returns the index of the repository if it's kept in memory.
+// The index is replaced on updates, so it's read while holding the lock.
+func (s *LocalIndexSearcher) getCachedIndex(repoID int64) (*repoIndex, bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	cached, ok := s.indexes[repoID]
+	if !ok {
+		return nil, false
+	}
+
+	cached.lastUsed = time.Now()
+
+	return cached.idx, true
+}
+
+// getIndex returns the index of the repository. It returns nil if the repository hasn't been indexed.
+// If cache is set, the index is kept in memory for subsequent calls.
+func (s *LocalIndexSearcher) getIndex(repoID int64, cache bool) (*repoIndex, error) {
+	if idx, ok := s.getCachedIndex(repoID); ok {
+		return idx, nil
+	}
+
+	idx, err := readIndexFile(indexFilePath(s.config.IndexPath, repoID))
+	if err != nil {
+		return nil, err
+	}
+
+	if idx == nil || !cache {
+		return idx, nil
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	// the index might have been loaded or updated while it was read from the disk.
+	if cached, ok := s.indexes[repoID]; ok {
+		return cached.idx, nil
+	}
+
+	if len(s.indexes) >= s.config.MaxCachedIndexes {
+		s.evictLeastRecentlyUsed()
+	}
+
+	s.indexes[repoID] = &cachedIndex{
+		idx:      idx,
+		lastUsed: time.Now(),
+	}
+
+	return idx, nil
+}
+
+// evictLeastRecentlyUsed removes the least recently used index from the memory.
+// The caller must hold the lock.
+func (s *LocalIndexSearcher) evictLeastRecentlyUsed() {
+	var (
+		evictID   int64
+		evictTime time.Time
+		found     bool
+	)
+
+	for repoID, cached := range s.indexes {
+		if !found || cached.lastUsed.Before(evictTime) {
+			evictID, evictTime, found = repoID, cached.lastUsed, true
+		}
+	}
+
+	if found {
+		delete(s.indexes, evictID)
+	}
+}
+
+func (s *LocalIndexSearcher) removeIndex(repoID int64) error {
+	s.mx.Lock()
+	delete(s.indexes, repoID)
+	s.mx.Unlock()
+
+	return removeIndexFile(indexFilePath(s.config.IndexPath, repoID))
+}
+
+func (s *LocalIndexSearcher) repoLock(repoID int64) *sync.Mutex {
+	lock, _ := s.repoLocks.LoadOrStore(repoID, &sync.Mutex{})
+	return lock.(*sync.Mutex) //nolint:errcheck // the map only contains mutexes
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/harness/gitness/types"
+)
+
+// maxMatchesPerFile is the maximum number of matched lines returned for a single file.
+const maxMatchesPerFile = 50
+
+// compileQuery returns the regular expression used to match lines of the indexed files.
+func compileQuery(query string, enableRegex bool, caseSensitive bool) (*regexp.Regexp, error) {
+	if !enableRegex {
+		query = regexp.QuoteMeta(query)
+	}
+
+	if !caseSensitive {
+		query = "(?i)" + query
+	}
+
+	return regexp.Compile(query)
+}
+
+// matchContent returns all lines of the content matched by the regular expression.
+func matchContent(re *regexp.Regexp, content []byte) []types.Match {
+	var matches []types.Match
+
+	lines := bytes.Split(content, []byte{'\n'})
+	for i := range lines {
+		lines[i] = bytes.TrimSuffix(lines[i], []byte{'\r'})
+	}
+
+	for i, line := range lines {
+		locs := re.FindAllIndex(line, -1)
+		if len(locs) == 0 {
+			continue
+		}
+
+		fragments := make([]types.Fragment, 0, len(locs))
+		var prevEnd int
+		for _, loc := range locs {
+			if loc[0] == loc[1] {
+				// skip empty matches (e.g. of the regular expression "a*")
+				continue
+			}
+
+			fragments = append(fragments, types.Fragment{
+				Pre:   string(line[prevEnd:loc[0]]),
+				Match: string(line[loc[0]:loc[1]]),
+			})
+			prevEnd = loc[1]
+		}
+
+		if len(fragments) == 0 {
+			continue
+		}
+
+		fragments[len(fragments)-1].Post = string(line[prevEnd:])
+
+		match := types.Match{
+			LineNum:   i + 1,
+			Fragments: fragments,
+		}
+		if i > 0 {
+			match.Before = string(lines[i-1])
+		}
+		if i+1 < len(lines) {
+			match.After = string(lines[i+1])
+		}
+
+		matches = append(matches, match)
+
+		if len(matches) >= maxMatchesPerFile {
+			break
+		}
+	}
+
+	return matches
+}
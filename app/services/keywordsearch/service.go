@@ -33,6 +33,13 @@ type Config struct {
 	EventReaderName string
 	Concurrency     int
 	MaxRetries      int
+
+	// IndexPath is the directory where the search index of the repositories is stored.
+	IndexPath string
+	// MaxFileSize is the maximum size of files that are indexed. Larger files are excluded from the search.
+	MaxFileSize int64
+	// MaxCachedIndexes is the maximum number of repository indexes kept in memory.
+	MaxCachedIndexes int
 }
 
 func (c *Config) Prepare() error {
@@ -48,6 +55,15 @@ func (c *Config) Prepare() error {
 	if c.MaxRetries < 0 {
 		return errors.New("config.MaxRetries can't be negative")
 	}
+	if c.IndexPath == "" {
+		return errors.New("config.IndexPath is required")
+	}
+	if c.MaxFileSize < 1 {
+		return errors.New("config.MaxFileSize has to be a positive number")
+	}
+	if c.MaxCachedIndexes < 1 {
+		return errors.New("config.MaxCachedIndexes has to be a positive number")
+	}
 	return nil
 }
 
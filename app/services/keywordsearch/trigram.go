@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"regexp/syntax"
+	"slices"
+)
+
+// trigram is a sequence of three consecutive bytes encoded as a single number.
+type trigram uint32
+
+func newTrigram(a, b, c byte) trigram {
+	return trigram(a)<<16 | trigram(b)<<8 | trigram(c)
+}
+
+// toLowerASCII converts ASCII upper case letters to lower case. Other bytes are left untouched.
+func toLowerASCII(b byte) byte {
+	if 'A' <= b && b <= 'Z' {
+		return b + ('a' - 'A')
+	}
+	return b
+}
+
+// forEachTrigram calls the provided function for each trigram of the lower-case version of the data.
+// Trigrams containing non-ASCII bytes are skipped, because case-insensitive matching of non-ASCII characters
+// can't be done on byte level. The function might be called multiple times for the same trigram.
+func forEachTrigram(data []byte, fn func(t trigram)) {
+	for i := 0; i+3 <= len(data); i++ {
+		a, b, c := data[i], data[i+1], data[i+2]
+		if a >= 0x80 || b >= 0x80 || c >= 0x80 {
+			continue
+		}
+
+		fn(newTrigram(toLowerASCII(a), toLowerASCII(b), toLowerASCII(c)))
+	}
+}
+
+// queryTrigrams returns the trigrams that have to be present in a document for it to possibly match the query.
+// An empty result means that the query can't be narrowed down and that all documents have to be checked.
+func queryTrigrams(query string, enableRegex bool) ([]trigram, error) {
+	var literals []string
+	if enableRegex {
+		re, err := syntax.Parse(query, syntax.Perl)
+		if err != nil {
+			return nil, err
+		}
+
+		literals = requiredLiterals(re.Simplify())
+	} else {
+		literals = []string{query}
+	}
+
+	set := make(map[trigram]struct{})
+	for _, literal := range literals {
+		forEachTrigram([]byte(literal), func(t trigram) {
+			set[t] = struct{}{}
+		})
+	}
+
+	trigrams := make([]trigram, 0, len(set))
+	for t := range set {
+		trigrams = append(trigrams, t)
+	}
+
+	slices.Sort(trigrams)
+
+	return trigrams, nil
+}
+
+// requiredLiterals returns the literal strings that must be present in any text matched by the regular expression.
+// It's a conservative approximation: Returning fewer literals is always safe, it only results in more documents
+// to be checked with the regular expression.
+func requiredLiterals(re *syntax.Regexp) []string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return []string{string(re.Rune)}
+
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+
+	case syntax.OpRepeat:
+		if re.Min == 0 {
+			return nil
+		}
+		return requiredLiterals(re.Sub[0])
+
+	case syntax.OpConcat:
+		var literals []string
+		var run []rune
+		for _, sub := range re.Sub {
+			if sub.Op == syntax.OpLiteral {
+				run = append(run, sub.Rune...)
+				continue
+			}
+
+			if len(run) > 0 {
+				literals = append(literals, string(run))
+				run = nil
+			}
+
+			literals = append(literals, requiredLiterals(sub)...)
+		}
+
+		if len(run) > 0 {
+			literals = append(literals, string(run))
+		}
+
+		return literals
+
+	default:
+		// alternations, optional or repeated expressions, character classes... don't require any literal.
+		return nil
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldapsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+)
+
+const (
+	jobType        = "gitness:ldap-sync"
+	jobMaxDuration = 30 * time.Minute
+)
+
+// Service periodically synchronizes the LDAP directory: the directory groups are synchronized
+// into user groups, so they can be used as code owners, reviewers and bypass groups in protection rules,
+// and the tokens of the users that are disabled in the directory are revoked.
+type Service struct {
+	cron                 string
+	scheduler            *job.Scheduler
+	executor             *job.Executor
+	directory            *authn.LDAPDirectory
+	principalStore       store.PrincipalStore
+	tokenStore           store.TokenStore
+	spaceStore           store.SpaceStore
+	userGroupStore       store.UserGroupStore
+	userGroupMemberStore store.UserGroupMemberStore
+}
+
+func NewService(
+	cron string,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	directory *authn.LDAPDirectory,
+	principalStore store.PrincipalStore,
+	tokenStore store.TokenStore,
+	spaceStore store.SpaceStore,
+	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+) *Service {
+	return &Service{
+		cron:                 cron,
+		scheduler:            scheduler,
+		executor:             executor,
+		directory:            directory,
+		principalStore:       principalStore,
+		tokenStore:           tokenStore,
+		spaceStore:           spaceStore,
+		userGroupStore:       userGroupStore,
+		userGroupMemberStore: userGroupMemberStore,
+	}
+}
+
+// Register registers the job handler and schedules the recurring synchronization job.
+// The handler is registered even if the LDAP authentication is disabled,
+// because the job could have been scheduled while it was enabled.
+func (s *Service) Register(ctx context.Context) error {
+	if err := s.executor.Register(jobType, s); err != nil {
+		return fmt.Errorf("failed to register ldap sync job handler: %w", err)
+	}
+
+	if s.directory == nil {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, jobMaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to schedule ldap sync job: %w", err)
+	}
+
+	return nil
+}
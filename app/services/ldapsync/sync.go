@@ -0,0 +1,261 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldapsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/rs/zerolog/log"
+)
+
+var illegalIdentifierChars = regexp.MustCompile(`[^a-zA-Z0-9-_.]+`)
+
+// Handle synchronizes the directory users and groups.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if s.directory == nil {
+		return "", nil
+	}
+
+	dirUsers, err := s.directory.ListUsers()
+	if err != nil {
+		return "", fmt.Errorf("failed to list directory users: %w", err)
+	}
+
+	users := newUserFinder(s.principalStore)
+
+	if err := s.revokeDisabledUsers(ctx, users, dirUsers); err != nil {
+		return "", err
+	}
+
+	if !s.directory.GroupSyncEnabled() {
+		return "", nil
+	}
+
+	if err := s.syncGroups(ctx, users, dirUsers); err != nil {
+		return "", err
+	}
+
+	return "", nil
+}
+
+// revokeDisabledUsers deletes all tokens of the users that are disabled in the directory.
+func (s *Service) revokeDisabledUsers(ctx context.Context, users *userFinder, dirUsers []*authn.LDAPUser) error {
+	for _, dirUser := range dirUsers {
+		if !dirUser.Disabled {
+			continue
+		}
+
+		user, err := users.find(ctx, dirUser.Email)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			continue
+		}
+
+		n, err := s.tokenStore.DeleteForPrincipal(ctx, user.ID)
+		if err != nil {
+			return fmt.Errorf("failed to revoke tokens of user %q: %w", user.UID, err)
+		}
+
+		if n > 0 {
+			log.Ctx(ctx).Info().Str("user_uid", user.UID).
+				Msgf("revoked %d tokens of a user disabled in the directory", n)
+		}
+	}
+
+	return nil
+}
+
+// syncGroups synchronizes the directory groups into user groups of the configured space.
+// Only the users that have already logged in are added as members, the others are added
+// on the first synchronization after their first login.
+// User groups of groups removed from the directory are kept, because they could be referenced,
+// but they aren't updated anymore.
+func (s *Service) syncGroups(ctx context.Context, users *userFinder, dirUsers []*authn.LDAPUser) error {
+	space, err := s.spaceStore.FindByRef(ctx, s.directory.GroupSpace())
+	if err != nil {
+		return fmt.Errorf("failed to find space %q for the directory groups: %w", s.directory.GroupSpace(), err)
+	}
+
+	dirUsersByDN := make(map[string]*authn.LDAPUser, len(dirUsers))
+	for _, dirUser := range dirUsers {
+		if !dirUser.Disabled {
+			dirUsersByDN[authn.NormalizeLDAPDN(dirUser.DN)] = dirUser
+		}
+	}
+
+	dirGroups, err := s.directory.ListGroups()
+	if err != nil {
+		return fmt.Errorf("failed to list directory groups: %w", err)
+	}
+
+	for _, dirGroup := range dirGroups {
+		identifier := userGroupIdentifier(dirGroup.Name)
+		if identifier == "" {
+			log.Ctx(ctx).Warn().Msgf("skipping directory group %q without a valid name", dirGroup.DN)
+			continue
+		}
+
+		memberIDs := make(map[int64]struct{})
+		for _, memberDN := range dirGroup.MemberDNs {
+			dirUser, ok := dirUsersByDN[authn.NormalizeLDAPDN(memberDN)]
+			if !ok {
+				continue
+			}
+
+			user, err := users.find(ctx, dirUser.Email)
+			if err != nil {
+				return err
+			}
+			if user != nil {
+				memberIDs[user.ID] = struct{}{}
+			}
+		}
+
+		if err := s.syncGroup(ctx, space.ID, identifier, dirGroup, memberIDs); err != nil {
+			return fmt.Errorf("failed to synchronize directory group %q: %w", dirGroup.DN, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) syncGroup(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+	dirGroup *authn.LDAPGroup,
+	memberIDs map[int64]struct{},
+) error {
+	now := time.Now().UnixMilli()
+
+	err := s.userGroupStore.CreateOrUpdate(ctx, spaceID, &types.UserGroup{
+		Identifier:  identifier,
+		Name:        dirGroup.Name,
+		Description: "Synchronized from the directory group " + dirGroup.DN,
+		SpaceID:     spaceID,
+		Created:     now,
+		Updated:     now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create or update user group: %w", err)
+	}
+
+	userGroup, err := s.userGroupStore.FindByIdentifier(ctx, spaceID, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find user group: %w", err)
+	}
+
+	currentIDs, err := s.userGroupMemberStore.ListPrincipalIDs(ctx, []int64{userGroup.ID})
+	if err != nil {
+		return fmt.Errorf("failed to list user group members: %w", err)
+	}
+
+	added, removed := diffMembers(currentIDs, memberIDs)
+
+	for _, id := range added {
+		if err := s.userGroupMemberStore.Add(ctx, userGroup.ID, id); err != nil {
+			return fmt.Errorf("failed to add user group member: %w", err)
+		}
+	}
+
+	for _, id := range removed {
+		if err := s.userGroupMemberStore.Remove(ctx, userGroup.ID, id); err != nil {
+			return fmt.Errorf("failed to remove user group member: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// diffMembers returns the principals that need to be added to and removed from a user group.
+func diffMembers(currentIDs []int64, desiredIDs map[int64]struct{}) ([]int64, []int64) {
+	current := make(map[int64]struct{}, len(currentIDs))
+	removed := make([]int64, 0)
+	for _, id := range currentIDs {
+		current[id] = struct{}{}
+		if _, ok := desiredIDs[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+
+	added := make([]int64, 0)
+	for id := range desiredIDs {
+		if _, ok := current[id]; !ok {
+			added = append(added, id)
+		}
+	}
+
+	return added, removed
+}
+
+// userGroupIdentifier derives the identifier of the user group from the name of the directory group.
+func userGroupIdentifier(name string) string {
+	identifier := strings.Trim(illegalIdentifierChars.ReplaceAllString(name, "-"), "-.")
+	if len(identifier) > check.MaxIdentifierLength {
+		identifier = identifier[:check.MaxIdentifierLength]
+	}
+
+	return identifier
+}
+
+// userFinder finds users by email and caches the results, users are looked up once per synchronization.
+type userFinder struct {
+	principalStore store.PrincipalStore
+	users          map[string]*types.User
+}
+
+func newUserFinder(principalStore store.PrincipalStore) *userFinder {
+	return &userFinder{
+		principalStore: principalStore,
+		users:          make(map[string]*types.User),
+	}
+}
+
+// find returns the user with the email address or nil if no such user exists.
+func (f *userFinder) find(ctx context.Context, email string) (*types.User, error) {
+	if email == "" {
+		return nil, nil //nolint:nilnil // no user is a valid result
+	}
+
+	key := strings.ToLower(email)
+	if user, ok := f.users[key]; ok {
+		return user, nil
+	}
+
+	user, err := f.principalStore.FindUserByEmail(ctx, email)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		user = nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find user by email: %w", err)
+	}
+
+	f.users[key] = user
+
+	return user, nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldapsync
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestUserGroupIdentifier(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+	}{
+		{name: "developers", exp: "developers"},
+		{name: "Platform Team", exp: "Platform-Team"},
+		{name: " .hidden/group ", exp: "hidden-group"},
+		{name: "***", exp: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := userGroupIdentifier(test.name); got != test.exp {
+				t.Errorf("want=%q got=%q", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestDiffMembers(t *testing.T) {
+	added, removed := diffMembers([]int64{1, 2, 3}, map[int64]struct{}{2: {}, 4: {}, 5: {}})
+
+	sort.Slice(added, func(i, j int) bool { return added[i] < added[j] })
+
+	if len(added) != 2 || added[0] != 4 || added[1] != 5 {
+		t.Errorf("unexpected added members: %v", added)
+	}
+
+	if len(removed) != 2 || removed[0] != 1 || removed[1] != 3 {
+		t.Errorf("unexpected removed members: %v", removed)
+	}
+}
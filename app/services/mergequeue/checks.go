@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"sort"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type checksStatus int
+
+const (
+	checksPending checksStatus = iota
+	checksFailed
+	checksSucceeded
+)
+
+// evaluateChecks returns the combined status of the required status checks of a speculative merge commit.
+// All checks required by the protection rules must pass, bypassing isn't possible in the merge queue.
+// If any of the checks has failed, the function returns their identifiers.
+func evaluateChecks(
+	required protection.RequiredChecksOutput,
+	results []types.CheckResult,
+) (checksStatus, []string) {
+	statuses := make(map[string]enum.CheckStatus, len(results))
+	for _, result := range results {
+		statuses[result.Identifier] = result.Status
+	}
+
+	var failed []string
+	pending := false
+
+	evaluate := func(ids map[string]struct{}) {
+		for id := range ids {
+			status, ok := statuses[id]
+			switch {
+			case !ok || !status.IsCompleted():
+				pending = true
+			case status != enum.CheckStatusSuccess:
+				failed = append(failed, id)
+			}
+		}
+	}
+
+	evaluate(required.RequiredIdentifiers)
+	evaluate(required.BypassableIdentifiers)
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return checksFailed, failed
+	}
+
+	if pending {
+		return checksPending, nil
+	}
+
+	return checksSucceeded, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestEvaluateChecks(t *testing.T) {
+	tests := []struct {
+		name      string
+		required  protection.RequiredChecksOutput
+		results   []types.CheckResult
+		expStatus checksStatus
+		expFailed []string
+	}{
+		{
+			name:      "no-required-checks",
+			results:   []types.CheckResult{{Identifier: "lint", Status: enum.CheckStatusFailure}},
+			expStatus: checksSucceeded,
+		},
+		{
+			name: "all-passed",
+			required: protection.RequiredChecksOutput{
+				RequiredIdentifiers:   map[string]struct{}{"build": {}},
+				BypassableIdentifiers: map[string]struct{}{"test": {}},
+			},
+			results: []types.CheckResult{
+				{Identifier: "build", Status: enum.CheckStatusSuccess},
+				{Identifier: "test", Status: enum.CheckStatusSuccess},
+			},
+			expStatus: checksSucceeded,
+		},
+		{
+			name: "missing-result",
+			required: protection.RequiredChecksOutput{
+				RequiredIdentifiers: map[string]struct{}{"build": {}, "test": {}},
+			},
+			results:   []types.CheckResult{{Identifier: "build", Status: enum.CheckStatusSuccess}},
+			expStatus: checksPending,
+		},
+		{
+			name: "running",
+			required: protection.RequiredChecksOutput{
+				RequiredIdentifiers: map[string]struct{}{"build": {}},
+			},
+			results:   []types.CheckResult{{Identifier: "build", Status: enum.CheckStatusRunning}},
+			expStatus: checksPending,
+		},
+		{
+			name: "failed-bypassable-and-pending",
+			required: protection.RequiredChecksOutput{
+				RequiredIdentifiers:   map[string]struct{}{"build": {}},
+				BypassableIdentifiers: map[string]struct{}{"test": {}, "lint": {}},
+			},
+			results: []types.CheckResult{
+				{Identifier: "lint", Status: enum.CheckStatusError},
+				{Identifier: "test", Status: enum.CheckStatusFailure},
+			},
+			expStatus: checksFailed,
+			expFailed: []string{"lint", "test"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, failed := evaluateChecks(test.required, test.results)
+			if status != test.expStatus {
+				t.Errorf("status mismatch: want=%d got=%d", test.expStatus, status)
+			}
+			if !reflect.DeepEqual(failed, test.expFailed) {
+				t.Errorf("failed checks mismatch: want=%v got=%v", test.expFailed, failed)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Handle processes all merge queues that have at least one entry.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	queues, err := s.mergeQueueStore.ListQueues(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list merge queues: %w", err)
+	}
+
+	for _, q := range queues {
+		if err := s.processQueue(ctx, q); err != nil {
+			// one broken queue shouldn't block the others
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", q.RepoID).
+				Str("target_branch", q.TargetBranch).
+				Msg("failed to process merge queue")
+		}
+	}
+
+	return "", nil
+}
+
+// processQueue advances all entries of a single merge queue, in order.
+// The queue is processed under the same lock that is used for merging pull requests.
+// The lock doesn't expire before the job is stopped, so no other runner can process the queue concurrently.
+func (s *Service) processQueue(ctx context.Context, q types.MergeQueue) error {
+	unlock, err := s.locker.LockPR(ctx, q.RepoID, 0, jobMaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to lock repository for merge queue processing: %w", err)
+	}
+	defer unlock()
+
+	repo, err := s.repoStore.Find(ctx, q.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	entries, err := s.mergeQueueStore.List(ctx, repo.ID, &types.MergeQueueFilter{TargetBranch: q.TargetBranch})
+	if err != nil {
+		return fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	targetRef, err := s.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: git.CreateReadParams(repo),
+		Name:       q.TargetBranch,
+		Type:       gitenum.RefTypeBranch,
+	})
+	if errors.IsNotFound(err) {
+		for _, entry := range entries {
+			if errEject := s.ejectByID(ctx, repo, entry, "The target branch no longer exists."); errEject != nil {
+				return errEject
+			}
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get target branch: %w", err)
+	}
+
+	rules, err := s.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	targetSHA := targetRef.SHA
+	baseSHA := targetSHA
+
+	for _, entry := range entries {
+		baseSHA, err = s.processEntry(ctx, repo, rules, entry, baseSHA, &targetSHA)
+		if err != nil {
+			return fmt.Errorf("failed to process merge queue entry of pull request #%d: %w",
+				entry.PullReqNumber, err)
+		}
+	}
+
+	return nil
+}
+
+// processEntry advances a single merge queue entry. It returns the commit on top of which the next entry
+// in the queue should be built: either the speculative merge commit of the entry
+// or, if the entry got ejected, the provided base commit.
+// The targetSHA is updated if the entry got merged to the target branch.
+func (s *Service) processEntry(
+	ctx context.Context,
+	repo *types.Repository,
+	rules protection.Protection,
+	entry *types.MergeQueueEntry,
+	baseSHA sha.SHA,
+	targetSHA *sha.SHA,
+) (sha.SHA, error) {
+	pr, err := s.pullreqStore.Find(ctx, entry.PullReqID)
+	if err != nil {
+		return baseSHA, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return baseSHA, s.eject(ctx, repo, pr, entry, "The pull request is no longer open.")
+	}
+
+	if pr.SourceSHA != entry.SourceSHA {
+		return baseSHA, s.eject(ctx, repo, pr, entry,
+			"New commits have been pushed to the source branch after the pull request was added to the merge queue.")
+	}
+
+	// (re)build the speculative merge commit if there's none yet or if the commit it was built on has changed,
+	// for example because an entry ahead in the queue got ejected.
+	if entry.State == enum.MergeQueueEntryStateQueued || entry.BaseSHA != baseSHA.String() {
+		return s.build(ctx, repo, pr, entry, baseSHA)
+	}
+
+	mergeSHA := sha.Must(entry.MergeSHA)
+
+	requiredChecks, err := rules.RequiredChecks(ctx, protection.RequiredChecksInput{
+		Actor:   &bootstrap.NewSystemServiceSession().Principal,
+		Repo:    repo,
+		PullReq: pr,
+	})
+	if err != nil {
+		return baseSHA, fmt.Errorf("failed to get required status checks: %w", err)
+	}
+
+	checkResults, err := s.checkStore.ListResults(ctx, repo.ID, entry.MergeSHA)
+	if err != nil {
+		return baseSHA, fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	status, failed := evaluateChecks(requiredChecks, checkResults)
+	switch status {
+	case checksFailed:
+		return baseSHA, s.eject(ctx, repo, pr, entry, fmt.Sprintf(
+			"The following required status checks have failed: %s", strings.Join(failed, ", ")))
+	case checksPending:
+		checkStarted := time.UnixMilli(entry.CheckStarted)
+		if time.Since(checkStarted) > s.config.CheckTimeout {
+			return baseSHA, s.eject(ctx, repo, pr, entry, fmt.Sprintf(
+				"The required status checks haven't completed within %s.", s.config.CheckTimeout))
+		}
+		return mergeSHA, nil
+	case checksSucceeded:
+	}
+
+	// The checks have passed, but only the entry at the head of the queue can be merged.
+	if !baseSHA.Equal(*targetSHA) {
+		return mergeSHA, nil
+	}
+
+	_, err = s.pullreqCtrl.MergeQueueMergeNoAuth(ctx, repo, pr, entry)
+	if errors.IsPreconditionFailed(err) {
+		return baseSHA, s.eject(ctx, repo, pr, entry, errors.Message(err))
+	}
+	if err != nil {
+		return mergeSHA, fmt.Errorf("failed to merge pull request: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("merged pull request #%d from the merge queue of branch %q",
+		pr.Number, entry.TargetBranch)
+
+	*targetSHA = mergeSHA
+
+	return mergeSHA, nil
+}
+
+// build creates the speculative merge commit of an entry on top of the provided base commit.
+func (s *Service) build(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	baseSHA sha.SHA,
+) (sha.SHA, error) {
+	mergeOutput, err := s.pullreqCtrl.MergeQueueBuildNoAuth(ctx, repo, pr, entry, baseSHA)
+	if errors.IsInvalidArgument(err) || errors.IsPreconditionFailed(err) ||
+		errors.IsNotFound(err) || errors.IsConflict(err) {
+		return baseSHA, s.eject(ctx, repo, pr, entry, fmt.Sprintf(
+			"Failed to create the merge commit: %s", errors.Message(err)))
+	}
+	if err != nil {
+		return baseSHA, err
+	}
+
+	if len(mergeOutput.ConflictFiles) > 0 {
+		return baseSHA, s.eject(ctx, repo, pr, entry, fmt.Sprintf(
+			"The pull request conflicts with the target branch or with the pull requests ahead in the queue: %s",
+			strings.Join(mergeOutput.ConflictFiles, ", ")))
+	}
+
+	entry.State = enum.MergeQueueEntryStateChecking
+	entry.BaseSHA = mergeOutput.BaseSHA.String()
+	entry.MergeBaseSHA = mergeOutput.MergeBaseSHA.String()
+	entry.MergeSHA = mergeOutput.MergeSHA.String()
+	entry.CheckStarted = time.Now().UnixMilli()
+
+	if err := s.mergeQueueStore.Update(ctx, entry); err != nil {
+		return baseSHA, fmt.Errorf("failed to update merge queue entry: %w", err)
+	}
+
+	return mergeOutput.MergeSHA, nil
+}
+
+// ejectByID removes an entry from the merge queue when its pull request isn't loaded.
+func (s *Service) ejectByID(
+	ctx context.Context,
+	repo *types.Repository,
+	entry *types.MergeQueueEntry,
+	reason string,
+) error {
+	pr, err := s.pullreqStore.Find(ctx, entry.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	return s.eject(ctx, repo, pr, entry, reason)
+}
+
+// eject removes an entry from the merge queue and explains the reason with a pull request activity.
+func (s *Service) eject(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	reason string,
+) error {
+	if err := s.mergeQueueStore.Delete(ctx, entry.ID); err != nil {
+		return fmt.Errorf("failed to remove entry from the merge queue: %w", err)
+	}
+
+	s.pullreqCtrl.MergeQueueDeleteRefNoAuth(ctx, repo, pr.Number)
+
+	log.Ctx(ctx).Info().Msgf("ejected pull request #%d from the merge queue of branch %q: %s",
+		pr.Number, entry.TargetBranch, reason)
+
+	err := func() error {
+		pr, err := s.pullreqStore.UpdateActivitySeq(ctx, pr)
+		if err != nil {
+			return fmt.Errorf("failed to update pull request activity sequence: %w", err)
+		}
+
+		_, err = s.activityStore.CreateWithPayload(ctx, pr, bootstrap.NewSystemServiceSession().Principal.ID,
+			&types.PullRequestActivityPayloadMergeQueueEject{
+				TargetBranch: entry.TargetBranch,
+				MergeSHA:     entry.MergeSHA,
+				Reason:       reason,
+			}, nil)
+		if err != nil {
+			return err
+		}
+
+		s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+		return nil
+	}()
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity for merge queue ejection")
+	}
+
+	return nil
+}
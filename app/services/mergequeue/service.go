@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+)
+
+const (
+	jobType        = "gitness:merge-queue"
+	jobCron        = "* * * * *" // Every minute.
+	jobMaxDuration = 10 * time.Minute
+)
+
+type Config struct {
+	CheckTimeout time.Duration
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.CheckTimeout <= 0 {
+		return errors.New("config.CheckTimeout has to be provided")
+	}
+	return nil
+}
+
+// Service is responsible for processing the merge queues: it builds speculative merge commits for queued
+// pull requests, waits for their required status checks and merges them in order.
+type Service struct {
+	config            Config
+	scheduler         *job.Scheduler
+	executor          *job.Executor
+	locker            *locker.Locker
+	repoStore         store.RepoStore
+	pullreqStore      store.PullReqStore
+	activityStore     store.PullReqActivityStore
+	mergeQueueStore   store.MergeQueueStore
+	checkStore        store.CheckStore
+	protectionManager *protection.Manager
+	git               git.Interface
+	sseStreamer       sse.Streamer
+	pullreqCtrl       *pullreq.Controller
+}
+
+func NewService(
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	locker *locker.Locker,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	mergeQueueStore store.MergeQueueStore,
+	checkStore store.CheckStore,
+	protectionManager *protection.Manager,
+	git git.Interface,
+	sseStreamer sse.Streamer,
+	pullreqCtrl *pullreq.Controller,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided merge queue config is invalid: %w", err)
+	}
+
+	return &Service{
+		config: config,
+
+		scheduler:         scheduler,
+		executor:          executor,
+		locker:            locker,
+		repoStore:         repoStore,
+		pullreqStore:      pullreqStore,
+		activityStore:     activityStore,
+		mergeQueueStore:   mergeQueueStore,
+		checkStore:        checkStore,
+		protectionManager: protectionManager,
+		git:               git,
+		sseStreamer:       sseStreamer,
+		pullreqCtrl:       pullreqCtrl,
+	}, nil
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if err := s.executor.Register(jobType, s); err != nil {
+		return fmt.Errorf("failed to register merge queue job handler: %w", err)
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, jobCron, jobMaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to schedule merge queue job: %w", err)
+	}
+
+	return nil
+}
@@ -85,6 +85,7 @@ func (migrate Webhook) Import(
 			Insecure:              whook.SkipVerify,
 			Triggers:              webhook.DeduplicateTriggers(triggers),
 			LatestExecutionResult: nil,
+			PayloadFormat:         enum.WebhookPayloadFormatDefault,
 		}
 
 		hooks[i] = hook
@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type PullReqBranchUpdatedPayload struct {
@@ -43,11 +44,26 @@ func (s *Service) notifyPullReqBranchUpdated(
 		)
 	}
 
-	if len(reviewers) == 0 {
+	prefs, err := s.loadPullReqPreferences(ctx, payload.Base)
+	if err != nil {
+		return fmt.Errorf("failed to load notification preferences: %w", err)
+	}
+
+	recipients, err := s.addWatchers(ctx, prefs, payload.Base, reviewers, event.Payload.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to add pull request watchers: %w", err)
+	}
+
+	if len(recipients) == 0 {
 		return nil
 	}
 
-	err = s.notificationClient.SendPullReqBranchUpdated(ctx, reviewers, payload)
+	err = s.deliver(ctx, prefs, enum.NotificationEventBranchUpdated,
+		digestEventID(pullreqevents.BranchUpdatedEvent, event.ID), recipients,
+		TemplatePullReqBranchUpdated, payload.Base, payload,
+		func(recipients []*types.PrincipalInfo) error {
+			return s.notificationClient.SendPullReqBranchUpdated(ctx, recipients, payload)
+		})
 	if err != nil {
 		return fmt.Errorf(
 			"failed to send email for event %s for pullReqID %d: %w",
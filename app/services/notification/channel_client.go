@@ -0,0 +1,252 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/services/chatops"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/slack-go/slack"
+)
+
+// channelMessageMaxTextLength limits the number of characters of comment texts quoted in chat-ops messages.
+const channelMessageMaxTextLength = 500
+
+// ChannelMessage is the channel agnostic content of a notification posted to a chat-ops channel.
+type ChannelMessage struct {
+	Event      enum.NotificationChannelTrigger `json:"event"`
+	Repo       string                          `json:"repo"`
+	PullReq    int64                           `json:"pullreq_number"`
+	Title      string                          `json:"title"`
+	Text       string                          `json:"text"`
+	Quote      string                          `json:"quote,omitempty"`
+	PullReqURL string                          `json:"pullreq_url"`
+}
+
+// channelClient implements Client on top of an incoming webhook of a chat-ops tool.
+// The recipients of the notifications are ignored, the messages are posted to the channel instead.
+type channelClient struct {
+	httpClient *http.Client
+	url        string
+	post       func(ctx context.Context, httpClient *http.Client, url string, msg ChannelMessage) error
+}
+
+// SlackClient posts notifications to a Slack incoming webhook.
+type SlackClient struct {
+	channelClient
+}
+
+func NewSlackClient(httpClient *http.Client, url string) SlackClient {
+	return SlackClient{channelClient{httpClient: httpClient, url: url, post: postSlack}}
+}
+
+// TeamsClient posts notifications to a Microsoft Teams incoming webhook.
+type TeamsClient struct {
+	channelClient
+}
+
+func NewTeamsClient(httpClient *http.Client, url string) TeamsClient {
+	return TeamsClient{channelClient{httpClient: httpClient, url: url, post: postTeams}}
+}
+
+// GenericClient posts notifications as plain JSON ChannelMessage objects to an arbitrary URL.
+type GenericClient struct {
+	channelClient
+}
+
+func NewGenericClient(httpClient *http.Client, url string) GenericClient {
+	return GenericClient{channelClient{httpClient: httpClient, url: url, post: postGeneric}}
+}
+
+// NewChannelClient returns the Client that posts notifications to a channel of the provided type.
+func NewChannelClient(
+	httpClient *http.Client,
+	channelType enum.NotificationChannelType,
+	url string,
+) (Client, error) {
+	switch channelType {
+	case enum.NotificationChannelTypeSlack:
+		return NewSlackClient(httpClient, url), nil
+	case enum.NotificationChannelTypeTeams:
+		return NewTeamsClient(httpClient, url), nil
+	case enum.NotificationChannelTypeGeneric:
+		return NewGenericClient(httpClient, url), nil
+	default:
+		return nil, fmt.Errorf("unsupported notification channel type %q", channelType)
+	}
+}
+
+// SendCommentPRAuthor doesn't post anything, channels only receive the mentions of a comment.
+func (c channelClient) SendCommentPRAuthor(context.Context, []*types.PrincipalInfo, *CommentPayload) error {
+	return nil
+}
+
+func (c channelClient) SendCommentMentions(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.send(ctx, enum.NotificationChannelTriggerCommentMention, payload.Base,
+		fmt.Sprintf("%s mentioned %s in a comment.", payload.Commenter.DisplayName, displayNames(recipients)),
+		payload.Text)
+}
+
+// SendCommentParticipants doesn't post anything, channels only receive the mentions of a comment.
+func (c channelClient) SendCommentParticipants(context.Context, []*types.PrincipalInfo, *CommentPayload) error {
+	return nil
+}
+
+func (c channelClient) SendReviewerAdded(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	return c.send(ctx, enum.NotificationChannelTriggerReviewerAdded, payload.Base,
+		fmt.Sprintf("%s was added as a reviewer.", payload.Reviewer.DisplayName), "")
+}
+
+// SendPullReqBranchUpdated doesn't post anything, channels don't subscribe to source branch updates.
+func (c channelClient) SendPullReqBranchUpdated(
+	context.Context,
+	[]*types.PrincipalInfo,
+	*PullReqBranchUpdatedPayload,
+) error {
+	return nil
+}
+
+func (c channelClient) SendReviewSubmitted(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *ReviewSubmittedPayload,
+) error {
+	var verb string
+	switch payload.Decision {
+	case enum.PullReqReviewDecisionApproved:
+		verb = "approved the pull request"
+	case enum.PullReqReviewDecisionChangeReq:
+		verb = "requested changes"
+	case enum.PullReqReviewDecisionReviewed, enum.PullReqReviewDecisionPending:
+		verb = "reviewed the pull request"
+	}
+
+	return c.send(ctx, enum.NotificationChannelTriggerReviewSubmitted, payload.Base,
+		fmt.Sprintf("%s %s.", payload.Reviewer.DisplayName, verb), "")
+}
+
+func (c channelClient) SendPullReqStateChanged(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	return c.send(ctx, enum.NotificationChannelTriggerStateChanged, payload.Base,
+		fmt.Sprintf("%s %s the pull request.", payload.ChangedBy.DisplayName, payload.State), "")
+}
+
+func (c channelClient) send(
+	ctx context.Context,
+	event enum.NotificationChannelTrigger,
+	base *BasePullReqPayload,
+	text string,
+	quote string,
+) error {
+	if runes := []rune(quote); len(runes) > channelMessageMaxTextLength {
+		quote = string(runes[:channelMessageMaxTextLength]) + "…"
+	}
+
+	msg := ChannelMessage{
+		Event:      event,
+		Repo:       base.Repo.Path,
+		PullReq:    base.PullReq.Number,
+		Title:      GetSubjectPullRequest(base.Repo.Identifier, base.PullReq.Number, base.PullReq.Title),
+		Text:       text,
+		Quote:      quote,
+		PullReqURL: base.PullReqURL,
+	}
+
+	if err := c.post(ctx, c.httpClient, c.url, msg); err != nil {
+		return fmt.Errorf("failed to post %s notification to channel: %w", event, err)
+	}
+
+	return nil
+}
+
+func displayNames(principals []*types.PrincipalInfo) string {
+	names := make([]string, len(principals))
+	for i, principal := range principals {
+		names[i] = principal.DisplayName
+	}
+	return strings.Join(names, ", ")
+}
+
+func postSlack(ctx context.Context, httpClient *http.Client, url string, msg ChannelMessage) error {
+	text := msg.chatMessage().SlackText()
+	return slack.PostWebhookCustomHTTPContext(ctx, url, httpClient, &slack.WebhookMessage{Text: text})
+}
+
+func postTeams(ctx context.Context, httpClient *http.Client, url string, msg ChannelMessage) error {
+	return postJSON(ctx, httpClient, url, msg.chatMessage().TeamsCard())
+}
+
+func (msg ChannelMessage) chatMessage() chatops.Message {
+	return chatops.Message{
+		Title:    msg.Title,
+		Text:     msg.Text,
+		Quote:    msg.Quote,
+		URL:      msg.PullReqURL,
+		LinkName: "View pull request",
+	}
+}
+
+func postGeneric(ctx context.Context, httpClient *http.Client, url string, msg ChannelMessage) error {
+	return postJSON(ctx, httpClient, url, msg)
+}
+
+func postJSON(ctx context.Context, httpClient *http.Client, url string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// drain the body to allow reuse of the connection
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("received unexpected response status %d", resp.StatusCode)
+	}
+
+	return nil
+}
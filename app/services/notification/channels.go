@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// notifyChannels posts a notification to all enabled notification channels subscribed to the trigger
+// that are configured in the space of the repository or in any of its ancestor spaces.
+// Failures are only logged, to avoid sending the emails again when the event is retried.
+func (s *Service) notifyChannels(
+	ctx context.Context,
+	repo *types.Repository,
+	trigger enum.NotificationChannelTrigger,
+	send func(client Client) error,
+) {
+	channels, err := s.listChannels(ctx, repo, trigger)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Str("repo", repo.Path).
+			Msg("failed to list notification channels")
+		return
+	}
+
+	for _, channel := range channels {
+		if err := s.notifyChannel(channel, send); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("notification_channel_id", channel.ID).
+				Str("notification_channel", channel.Identifier).
+				Msgf("failed to post %s notification to channel", trigger)
+		}
+	}
+}
+
+func (s *Service) listChannels(
+	ctx context.Context,
+	repo *types.Repository,
+	trigger enum.NotificationChannelTrigger,
+) ([]*types.NotificationChannel, error) {
+	spaceIDs, err := s.spaceStore.GetAncestorIDs(ctx, repo.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestor spaces of the repository: %w", err)
+	}
+
+	channels, err := s.notificationChannelStore.ListEnabled(ctx, spaceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list enabled notification channels: %w", err)
+	}
+
+	subscribed := make([]*types.NotificationChannel, 0, len(channels))
+	for _, channel := range channels {
+		if channel.HasTrigger(trigger) {
+			subscribed = append(subscribed, channel)
+		}
+	}
+
+	return subscribed, nil
+}
+
+func (s *Service) notifyChannel(channel *types.NotificationChannel, send func(client Client) error) error {
+	url, err := s.encrypter.Decrypt([]byte(channel.URL))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt notification channel url: %w", err)
+	}
+
+	client, err := NewChannelClient(s.channelHTTPClient, channel.Type, url)
+	if err != nil {
+		return err
+	}
+
+	return send(client)
+}
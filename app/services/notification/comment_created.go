@@ -48,8 +48,19 @@ func (s *Service) notifyCommentCreated(
 		)
 	}
 
+	prefs, err := s.loadPullReqPreferences(ctx, payload.Base)
+	if err != nil {
+		return fmt.Errorf("failed to load notification preferences: %w", err)
+	}
+
+	eventID := digestEventID(pullreqevents.CommentCreatedEvent, event.ID)
+
 	if len(mentions) > 0 {
-		err = s.notificationClient.SendCommentMentions(ctx, mentions, payload)
+		err = s.deliver(ctx, prefs, gitnessenum.NotificationEventCommentMention, eventID, mentions,
+			TemplateCommentMentions, payload.Base, payload,
+			func(recipients []*types.PrincipalInfo) error {
+				return s.notificationClient.SendCommentMentions(ctx, recipients, payload)
+			})
 		if err != nil {
 			return fmt.Errorf(
 				"failed to send notification to mentions for event %s for pullReqID %d: %w",
@@ -58,10 +69,19 @@ func (s *Service) notifyCommentCreated(
 				err,
 			)
 		}
+
+		s.notifyChannels(ctx, payload.Base.Repo, gitnessenum.NotificationChannelTriggerCommentMention,
+			func(client Client) error {
+				return client.SendCommentMentions(ctx, mentions, payload)
+			})
 	}
 
 	if len(participants) > 0 {
-		err = s.notificationClient.SendCommentParticipants(ctx, participants, payload)
+		err = s.deliver(ctx, prefs, gitnessenum.NotificationEventCommentCreated, eventID, participants,
+			TemplateCommentParticipants, payload.Base, payload,
+			func(recipients []*types.PrincipalInfo) error {
+				return s.notificationClient.SendCommentParticipants(ctx, recipients, payload)
+			})
 		if err != nil {
 			return fmt.Errorf(
 				"failed to send notification to participants for event %s for pullReqID %d: %w",
@@ -72,12 +92,26 @@ func (s *Service) notifyCommentCreated(
 		}
 	}
 
+	// the watchers get the same notification as the pull request author, unless they're already notified.
+	notified := make([]*types.PrincipalInfo, 0, len(mentions)+len(participants)+1)
+	notified = append(notified, mentions...)
+	notified = append(notified, participants...)
 	if author != nil {
-		err = s.notificationClient.SendCommentPRAuthor(
-			ctx,
-			[]*types.PrincipalInfo{author},
-			payload,
-		)
+		notified = append(notified, author)
+	}
+
+	recipients, err := s.addWatchers(ctx, prefs, payload.Base, notified, payload.Commenter.ID)
+	if err != nil {
+		return fmt.Errorf("failed to add pull request watchers: %w", err)
+	}
+
+	recipients = recipients[len(mentions)+len(participants):]
+	if len(recipients) > 0 {
+		err = s.deliver(ctx, prefs, gitnessenum.NotificationEventCommentCreated, eventID, recipients,
+			TemplateCommentPRAuthor, payload.Base, payload,
+			func(recipients []*types.PrincipalInfo) error {
+				return s.notificationClient.SendCommentPRAuthor(ctx, recipients, payload)
+			})
 		if err != nil {
 			return fmt.Errorf(
 				"failed to send notification to author for event %s for pullReqID %d: %w",
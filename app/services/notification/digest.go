@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	TemplateDigest = "digest.html"
+
+	subjectDigest = "Pull request notifications digest (%d)"
+
+	jobTypeDigest        = "gitness:notification-digest"
+	jobMaxDurationDigest = 30 * time.Minute
+)
+
+type DigestPayload struct {
+	Items []DigestPayloadItem
+}
+
+type DigestPayloadItem struct {
+	Subject string
+	Created time.Time
+	Body    template.HTML
+}
+
+// Register registers the digest job handler and schedules the recurring digest job.
+func (s *Service) Register(ctx context.Context) error {
+	if err := s.executor.Register(jobTypeDigest, &digestJob{service: s}); err != nil {
+		return fmt.Errorf("failed to register notification digest job handler: %w", err)
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobTypeDigest, jobTypeDigest, s.config.DigestCron, jobMaxDurationDigest)
+	if err != nil {
+		return fmt.Errorf("failed to schedule notification digest job: %w", err)
+	}
+
+	return nil
+}
+
+type digestJob struct {
+	service *Service
+}
+
+// Handle sends the digest email to every principal with pending digest items.
+func (j *digestJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	principalIDs, err := j.service.notificationDigestStore.ListPrincipalIDs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list principals with pending digest notifications: %w", err)
+	}
+
+	var sent int
+	for _, principalID := range principalIDs {
+		if err := j.service.sendDigest(ctx, principalID); err != nil {
+			// one failed digest shouldn't block the others
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("principal_id", principalID).
+				Msg("failed to send notification digest")
+			continue
+		}
+		sent++
+	}
+
+	return fmt.Sprintf("sent %d notification digests", sent), nil
+}
+
+// sendDigest batches all pending digest items of a principal into a single email.
+func (s *Service) sendDigest(ctx context.Context, principalID int64) error {
+	items, err := s.notificationDigestStore.List(ctx, principalID)
+	if err != nil {
+		return fmt.Errorf("failed to list digest items: %w", err)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	recipient, err := s.principalInfoCache.Get(ctx, principalID)
+	if err != nil {
+		return fmt.Errorf("failed to get principal info: %w", err)
+	}
+
+	payload := DigestPayload{Items: make([]DigestPayloadItem, len(items))}
+	for i, item := range items {
+		payload.Items[i] = DigestPayloadItem{
+			Subject: item.Subject,
+			Created: time.UnixMilli(item.Created),
+			Body:    template.HTML(htmlBodyContent(item.Body)), // #nosec G203 (rendered by html templates when queued)
+		}
+	}
+
+	body, err := GetHTMLBody(TemplateDigest, payload)
+	if err != nil {
+		return err
+	}
+
+	err = s.mailer.Send(ctx, mailer.Payload{
+		ToRecipients: RetrieveEmailsFromPrincipals([]*types.PrincipalInfo{recipient}),
+		Subject:      fmt.Sprintf(subjectDigest, len(items)),
+		Body:         string(body),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send digest email: %w", err)
+	}
+
+	err = s.notificationDigestStore.Delete(ctx, principalID, items[len(items)-1].ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete sent digest items: %w", err)
+	}
+
+	return nil
+}
+
+// htmlBodyContent returns the content of the body element of an html document.
+// The notification templates are complete html documents, the digest embeds just their bodies.
+func htmlBodyContent(doc string) string {
+	const openTag, closeTag = "<body>", "</body>"
+
+	start := strings.Index(doc, openTag)
+	end := strings.LastIndex(doc, closeTag)
+	if start < 0 || end < start {
+		return doc
+	}
+
+	return strings.TrimSpace(doc[start+len(openTag) : end])
+}
@@ -0,0 +1,282 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// pullReqPreferences contains the notification subscriptions that apply to a single pull request.
+type pullReqPreferences struct {
+	// subscriptions maps principal IDs to their most specific subscription for the pull request.
+	subscriptions map[int64]*types.NotificationSubscription
+}
+
+// loadPullReqPreferences returns the notification subscriptions of all principals for the pull request.
+// A subscription for the pull request wins over the one for the repository,
+// which wins over the subscription of the closest space.
+func (s *Service) loadPullReqPreferences(
+	ctx context.Context,
+	base *BasePullReqPayload,
+) (*pullReqPreferences, error) {
+	spaces, err := s.spaceStore.GetAncestors(ctx, base.Repo.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestor spaces of the repository: %w", err)
+	}
+
+	spaceMap := make(map[int64]*types.Space, len(spaces))
+	spaceIDs := make([]int64, len(spaces))
+	for i, space := range spaces {
+		spaceMap[space.ID] = space
+		spaceIDs[i] = space.ID
+	}
+
+	// rank the spaces by their distance from the repository, the pull request and the repository come first.
+	const (
+		rankPullReq = iota
+		rankRepo
+		rankSpace
+	)
+	spaceRank := make(map[int64]int, len(spaces))
+	for id, rank := base.Repo.ParentID, rankSpace; id != 0; rank++ {
+		space, ok := spaceMap[id]
+		if !ok {
+			break
+		}
+		spaceRank[id] = rank
+		id = space.ParentID
+	}
+
+	subscriptions, err := s.notificationPreferenceStore.ListSubscriptionsForPullReq(ctx,
+		base.PullReq.ID, base.Repo.ID, spaceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification subscriptions: %w", err)
+	}
+
+	rankOf := func(sub *types.NotificationSubscription) int {
+		switch sub.Scope {
+		case enum.NotificationScopePullReq:
+			return rankPullReq
+		case enum.NotificationScopeRepo:
+			return rankRepo
+		case enum.NotificationScopeSpace:
+			return spaceRank[sub.ScopeID]
+		}
+		return rankSpace
+	}
+
+	prefs := &pullReqPreferences{
+		subscriptions: make(map[int64]*types.NotificationSubscription),
+	}
+	for _, sub := range subscriptions {
+		current, ok := prefs.subscriptions[sub.PrincipalID]
+		if !ok || rankOf(sub) < rankOf(current) {
+			prefs.subscriptions[sub.PrincipalID] = sub
+		}
+	}
+
+	return prefs, nil
+}
+
+// watcherIDs returns the IDs of the principals that watch the pull request.
+func (p *pullReqPreferences) watcherIDs() []int64 {
+	var ids []int64
+	for principalID, sub := range p.subscriptions {
+		if sub.Level == enum.NotificationLevelWatching {
+			ids = append(ids, principalID)
+		}
+	}
+	return ids
+}
+
+// addWatchers appends the watchers of the pull request to the recipients.
+// Watchers that are already among the recipients or that are excluded (e.g. the principal that caused the event)
+// are skipped, as are the watchers that lost access to the repository.
+func (s *Service) addWatchers(
+	ctx context.Context,
+	prefs *pullReqPreferences,
+	base *BasePullReqPayload,
+	recipients []*types.PrincipalInfo,
+	exclude ...int64,
+) ([]*types.PrincipalInfo, error) {
+	skip := make(map[int64]bool, len(recipients)+len(exclude))
+	for _, recipient := range recipients {
+		skip[recipient.ID] = true
+	}
+	for _, id := range exclude {
+		skip[id] = true
+	}
+
+	for _, watcherID := range prefs.watcherIDs() {
+		if skip[watcherID] {
+			continue
+		}
+
+		watcher, err := s.principalStore.Find(ctx, watcherID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find watcher %d: %w", watcherID, err)
+		}
+
+		if watcher.Blocked {
+			continue
+		}
+
+		session := &auth.Session{Principal: *watcher}
+		err = apiauth.CheckRepo(ctx, s.authorizer, session, base.Repo, enum.PermissionRepoView)
+		if errors.Is(err, apiauth.ErrNotAuthorized) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to check repository access of watcher %d: %w", watcherID, err)
+		}
+
+		recipients = append(recipients, watcher.ToPrincipalInfo())
+	}
+
+	return recipients, nil
+}
+
+// routeRecipients drops the recipients that don't want the notification and splits the rest into the ones
+// that should get it right away and the ones that receive it with the daily digest.
+func (s *Service) routeRecipients(
+	ctx context.Context,
+	prefs *pullReqPreferences,
+	event enum.NotificationEvent,
+	recipients []*types.PrincipalInfo,
+) (immediate []*types.PrincipalInfo, digest []*types.PrincipalInfo, err error) {
+	ids := make([]int64, len(recipients))
+	for i, recipient := range recipients {
+		ids[i] = recipient.ID
+	}
+
+	settingsList, err := s.notificationPreferenceStore.ListSettings(ctx, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list notification settings: %w", err)
+	}
+
+	settingsMap := make(map[int64]*types.NotificationSettings, len(settingsList))
+	for _, settings := range settingsList {
+		settingsMap[settings.PrincipalID] = settings
+	}
+
+	seen := make(map[int64]bool, len(recipients))
+	for _, recipient := range recipients {
+		if seen[recipient.ID] {
+			continue
+		}
+		seen[recipient.ID] = true
+
+		settings := settingsMap[recipient.ID]
+
+		if sub, ok := prefs.subscriptions[recipient.ID]; ok {
+			if sub.Level == enum.NotificationLevelMuted || sub.IsEventDisabled(event) {
+				continue
+			}
+		} else if settings != nil && settings.IsEventDisabled(event) {
+			continue
+		}
+
+		if settings != nil && settings.Delivery == enum.NotificationDeliveryDigest {
+			digest = append(digest, recipient)
+		} else {
+			immediate = append(immediate, recipient)
+		}
+	}
+
+	return immediate, digest, nil
+}
+
+// deliver sends a notification to the recipients according to their notification preferences.
+// The recipients that opted for the daily digest get the notification rendered with the provided email template
+// and queued for the next digest. The digest is queued once per event, so that retries of a failed event
+// don't duplicate it.
+func (s *Service) deliver(
+	ctx context.Context,
+	prefs *pullReqPreferences,
+	event enum.NotificationEvent,
+	eventID string,
+	recipients []*types.PrincipalInfo,
+	templateName string,
+	base *BasePullReqPayload,
+	payload interface{},
+	send func(recipients []*types.PrincipalInfo) error,
+) error {
+	immediate, digest, err := s.routeRecipients(ctx, prefs, event, recipients)
+	if err != nil {
+		return err
+	}
+
+	if len(digest) > 0 {
+		if err := s.queueDigest(ctx, digest, eventID, templateName, base, payload); err != nil {
+			return err
+		}
+	}
+
+	if len(immediate) == 0 {
+		return nil
+	}
+
+	return send(immediate)
+}
+
+// queueDigest renders the notification and stores it for the next digest email of every recipient.
+func (s *Service) queueDigest(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	eventID string,
+	templateName string,
+	base *BasePullReqPayload,
+	payload interface{},
+) error {
+	email, err := GenerateEmailFromPayload(templateName, nil, base, payload)
+	if err != nil {
+		return fmt.Errorf("failed to render notification for the digest: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	for _, recipient := range recipients {
+		err = s.notificationDigestStore.Create(ctx, &types.NotificationDigestItem{
+			PrincipalID: recipient.ID,
+			EventID:     eventID,
+			Subject:     email.Subject,
+			Body:        email.Body,
+			Created:     now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to queue notification for the digest of principal %d: %w", recipient.ID, err)
+		}
+	}
+
+	log.Ctx(ctx).Debug().Msgf("queued notification for the digest of %d recipients", len(recipients))
+
+	return nil
+}
+
+// digestEventID returns the ID of the event that the digest items are queued for.
+// Event IDs are unique only within the stream of an event type, so the type is included.
+func digestEventID(eventType events.EventType, eventID string) string {
+	return string(eventType) + ":" + eventID
+}
@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type PullReqState string
@@ -51,11 +52,8 @@ func (s *Service) notifyPullReqStateMerged(
 		)
 	}
 
-	if err = s.notificationClient.SendPullReqStateChanged(
-		ctx,
-		recipients,
-		payload,
-	); err != nil {
+	if err = s.deliverPullReqStateChanged(ctx, event.Payload.PrincipalID,
+		digestEventID(pullreqevents.MergedEvent, event.ID), recipients, payload); err != nil {
 		return fmt.Errorf(
 			"failed to send email for event %s for pullReqID %d: %w",
 			pullreqevents.MergedEvent,
@@ -63,6 +61,12 @@ func (s *Service) notifyPullReqStateMerged(
 			err,
 		)
 	}
+
+	s.notifyChannels(ctx, payload.Base.Repo, enum.NotificationChannelTriggerStateChanged,
+		func(client Client) error {
+			return client.SendPullReqStateChanged(ctx, recipients, payload)
+		})
+
 	return nil
 }
 
@@ -80,11 +84,8 @@ func (s *Service) notifyPullReqStateClosed(
 		)
 	}
 
-	if err = s.notificationClient.SendPullReqStateChanged(
-		ctx,
-		recipients,
-		payload,
-	); err != nil {
+	if err = s.deliverPullReqStateChanged(ctx, event.Payload.PrincipalID,
+		digestEventID(pullreqevents.ClosedEvent, event.ID), recipients, payload); err != nil {
 		return fmt.Errorf(
 			"failed to send email for event %s for pullReqID %d: %w",
 			pullreqevents.ClosedEvent,
@@ -92,6 +93,12 @@ func (s *Service) notifyPullReqStateClosed(
 			err,
 		)
 	}
+
+	s.notifyChannels(ctx, payload.Base.Repo, enum.NotificationChannelTriggerStateChanged,
+		func(client Client) error {
+			return client.SendPullReqStateChanged(ctx, recipients, payload)
+		})
+
 	return nil
 }
 
@@ -109,11 +116,8 @@ func (s *Service) notifyPullReqStateReOpened(
 		)
 	}
 
-	if err = s.notificationClient.SendPullReqStateChanged(
-		ctx,
-		recipients,
-		payload,
-	); err != nil {
+	if err = s.deliverPullReqStateChanged(ctx, event.Payload.PrincipalID,
+		digestEventID(pullreqevents.ReopenedEvent, event.ID), recipients, payload); err != nil {
 		return fmt.Errorf(
 			"failed to send email for event %s for pullReqID %d: %w",
 			pullreqevents.ReopenedEvent,
@@ -121,9 +125,41 @@ func (s *Service) notifyPullReqStateReOpened(
 			err,
 		)
 	}
+
+	s.notifyChannels(ctx, payload.Base.Repo, enum.NotificationChannelTriggerStateChanged,
+		func(client Client) error {
+			return client.SendPullReqStateChanged(ctx, recipients, payload)
+		})
+
 	return nil
 }
 
+// deliverPullReqStateChanged sends the state change notification to the participants and watchers
+// of the pull request.
+func (s *Service) deliverPullReqStateChanged(
+	ctx context.Context,
+	changedBy int64,
+	eventID string,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	prefs, err := s.loadPullReqPreferences(ctx, payload.Base)
+	if err != nil {
+		return fmt.Errorf("failed to load notification preferences: %w", err)
+	}
+
+	recipients, err = s.addWatchers(ctx, prefs, payload.Base, recipients, changedBy)
+	if err != nil {
+		return fmt.Errorf("failed to add pull request watchers: %w", err)
+	}
+
+	return s.deliver(ctx, prefs, enum.NotificationEventStateChanged, eventID, recipients,
+		TemplatePullReqStateChanged, payload.Base, payload,
+		func(recipients []*types.PrincipalInfo) error {
+			return s.notificationClient.SendPullReqStateChanged(ctx, recipients, payload)
+		})
+}
+
 func (s *Service) processPullReqStateChangedEvent(
 	ctx context.Context,
 	baseEvent pullreqevents.Base,
@@ -45,12 +45,22 @@ func (s *Service) notifyReviewSubmitted(
 		)
 	}
 
-	err = s.notificationClient.SendReviewSubmitted(
-		ctx,
-		recipients,
-		notificationPayload,
-	)
+	prefs, err := s.loadPullReqPreferences(ctx, notificationPayload.Base)
+	if err != nil {
+		return fmt.Errorf("failed to load notification preferences: %w", err)
+	}
 
+	recipients, err = s.addWatchers(ctx, prefs, notificationPayload.Base, recipients, event.Payload.ReviewerID)
+	if err != nil {
+		return fmt.Errorf("failed to add pull request watchers: %w", err)
+	}
+
+	err = s.deliver(ctx, prefs, enum.NotificationEventReviewSubmitted,
+		digestEventID(pullreqevents.ReviewSubmittedEvent, event.ID), recipients,
+		TemplateNameReviewSubmitted, notificationPayload.Base, notificationPayload,
+		func(recipients []*types.PrincipalInfo) error {
+			return s.notificationClient.SendReviewSubmitted(ctx, recipients, notificationPayload)
+		})
 	if err != nil {
 		return fmt.Errorf(
 			"failed to send notification for event %s for pullReqID %d: %w",
@@ -59,6 +69,12 @@ func (s *Service) notifyReviewSubmitted(
 			err,
 		)
 	}
+
+	s.notifyChannels(ctx, notificationPayload.Base.Repo, enum.NotificationChannelTriggerReviewSubmitted,
+		func(client Client) error {
+			return client.SendReviewSubmitted(ctx, recipients, notificationPayload)
+		})
+
 	return nil
 }
 
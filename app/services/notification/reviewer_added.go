@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type ReviewerAddedPayload struct {
@@ -42,7 +43,22 @@ func (s *Service) notifyReviewerAdded(
 		)
 	}
 
-	err = s.notificationClient.SendReviewerAdded(ctx, recipients, payload)
+	prefs, err := s.loadPullReqPreferences(ctx, payload.Base)
+	if err != nil {
+		return fmt.Errorf("failed to load notification preferences: %w", err)
+	}
+
+	recipients, err = s.addWatchers(ctx, prefs, payload.Base, recipients, event.Payload.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to add pull request watchers: %w", err)
+	}
+
+	err = s.deliver(ctx, prefs, enum.NotificationEventReviewerAdded,
+		digestEventID(pullreqevents.ReviewerAddedEvent, event.ID), recipients,
+		TemplateReviewerAdded, payload.Base, payload,
+		func(recipients []*types.PrincipalInfo) error {
+			return s.notificationClient.SendReviewerAdded(ctx, recipients, payload)
+		})
 	if err != nil {
 		return fmt.Errorf(
 			"failed to send email for event %s for pullReqID %d: %w",
@@ -52,6 +68,11 @@ func (s *Service) notifyReviewerAdded(
 		)
 	}
 
+	s.notifyChannels(ctx, payload.Base.Repo, enum.NotificationChannelTriggerReviewerAdded,
+		func(client Client) error {
+			return client.SendReviewerAdded(ctx, recipients, payload)
+		})
+
 	return nil
 }
 
@@ -20,12 +20,19 @@ import (
 	"fmt"
 	"html/template"
 	"io/fs"
+	"net/http"
 	"path"
 
+	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	webhookevents "github.com/harness/gitness/app/events/webhook"
+	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/stream"
 	"github.com/harness/gitness/types"
 )
@@ -82,6 +89,14 @@ type Config struct {
 	EventReaderName string
 	Concurrency     int
 	MaxRetries      int
+
+	// ChannelAllowLoopback and ChannelAllowPrivateNetwork control
+	// whether notification channels can post to internal addresses.
+	ChannelAllowLoopback       bool
+	ChannelAllowPrivateNetwork bool
+
+	// DigestCron defines when the daily digest emails are sent.
+	DigestCron string
 }
 
 type Service struct {
@@ -96,6 +111,19 @@ type Service struct {
 	pullReqActivityStore  store.PullReqActivityStore
 	spacePathStore        store.SpacePathStore
 	urlProvider           url.Provider
+
+	spaceStore               store.SpaceStore
+	notificationChannelStore store.NotificationChannelStore
+	encrypter                encrypt.Encrypter
+	channelHTTPClient        *http.Client
+
+	principalStore              store.PrincipalStore
+	authorizer                  authz.Authorizer
+	notificationPreferenceStore store.NotificationPreferenceStore
+	notificationDigestStore     store.NotificationDigestStore
+	mailer                      mailer.Mailer
+	scheduler                   *job.Scheduler
+	executor                    *job.Executor
 }
 
 func NewService(
@@ -111,6 +139,17 @@ func NewService(
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
 	urlProvider url.Provider,
+	spaceStore store.SpaceStore,
+	notificationChannelStore store.NotificationChannelStore,
+	encrypter encrypt.Encrypter,
+	principalStore store.PrincipalStore,
+	authorizer authz.Authorizer,
+	notificationPreferenceStore store.NotificationPreferenceStore,
+	notificationDigestStore store.NotificationDigestStore,
+	mailer mailer.Mailer,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	webhookReaderFactory *events.ReaderFactory[*webhookevents.Reader],
 ) (*Service, error) {
 	service := &Service{
 		config:                config,
@@ -124,6 +163,20 @@ func NewService(
 		pullReqActivityStore:  pullReqActivityStore,
 		spacePathStore:        spacePathStore,
 		urlProvider:           urlProvider,
+
+		spaceStore:               spaceStore,
+		notificationChannelStore: notificationChannelStore,
+		encrypter:                encrypter,
+		channelHTTPClient: webhook.NewHTTPClient(
+			config.ChannelAllowLoopback, config.ChannelAllowPrivateNetwork, false),
+
+		principalStore:              principalStore,
+		authorizer:                  authorizer,
+		notificationPreferenceStore: notificationPreferenceStore,
+		notificationDigestStore:     notificationDigestStore,
+		mailer:                      mailer,
+		scheduler:                   scheduler,
+		executor:                    executor,
 	}
 
 	_, err := service.prReaderFactory.Launch(
@@ -153,6 +206,24 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch event reader for %s: %w", eventReaderGroupName, err)
 	}
 
+	_, err = webhookReaderFactory.Launch(
+		ctx,
+		eventReaderGroupName,
+		config.EventReaderName,
+		func(r *webhookevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterDisabled(service.notifyWebhookDisabled)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch webhook event reader for %s: %w", eventReaderGroupName, err)
+	}
+
 	return service, nil
 }
 
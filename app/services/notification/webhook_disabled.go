@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	webhookevents "github.com/harness/gitness/app/events/webhook"
+	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	TemplateWebhookDisabled = "webhook_disabled.html"
+
+	subjectWebhookDisabled = "[%s] Webhook %s has been disabled"
+)
+
+type WebhookDisabledPayload struct {
+	WebhookIdentifier   string
+	ParentPath          string
+	ConsecutiveFailures int
+	LastError           string
+}
+
+// notifyWebhookDisabled sends an email to the creator of a webhook that got disabled
+// after too many consecutive failed deliveries.
+func (s *Service) notifyWebhookDisabled(
+	ctx context.Context,
+	event *events.Event[*webhookevents.DisabledPayload],
+) error {
+	owner, err := s.principalInfoCache.Get(ctx, event.Payload.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook owner %d: %w", event.Payload.CreatedBy, err)
+	}
+
+	if owner.Email == "" {
+		return nil
+	}
+
+	parentPath, err := s.getWebhookParentPath(ctx, event.Payload.ParentType, event.Payload.ParentID)
+	if err != nil {
+		return err
+	}
+
+	body, err := GetHTMLBody(TemplateWebhookDisabled, &WebhookDisabledPayload{
+		WebhookIdentifier:   event.Payload.WebhookIdentifier,
+		ParentPath:          parentPath,
+		ConsecutiveFailures: event.Payload.ConsecutiveFailures,
+		LastError:           event.Payload.LastError,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.mailer.Send(ctx, mailer.Payload{
+		ToRecipients: RetrieveEmailsFromPrincipals([]*types.PrincipalInfo{owner}),
+		Subject:      fmt.Sprintf(subjectWebhookDisabled, parentPath, event.Payload.WebhookIdentifier),
+		Body:         string(body),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send webhook disabled email: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) getWebhookParentPath(
+	ctx context.Context,
+	parentType enum.WebhookParent,
+	parentID int64,
+) (string, error) {
+	if parentType == enum.WebhookParentRepo {
+		repo, err := s.repoStore.Find(ctx, parentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find repository %d: %w", parentID, err)
+		}
+		return repo.Path, nil
+	}
+
+	space, err := s.spaceStore.Find(ctx, parentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find space %d: %w", parentID, err)
+	}
+
+	return space.Path, nil
+}
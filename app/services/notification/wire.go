@@ -17,11 +17,15 @@ package notification
 import (
 	"context"
 
+	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	webhookevents "github.com/harness/gitness/app/events/webhook"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
 )
@@ -44,6 +48,17 @@ func ProvideNotificationService(
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
 	urlProvider url.Provider,
+	spaceStore store.SpaceStore,
+	notificationChannelStore store.NotificationChannelStore,
+	encrypter encrypt.Encrypter,
+	principalStore store.PrincipalStore,
+	authorizer authz.Authorizer,
+	notificationPreferenceStore store.NotificationPreferenceStore,
+	notificationDigestStore store.NotificationDigestStore,
+	mailer mailer.Mailer,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	webhookReaderFactory *events.ReaderFactory[*webhookevents.Reader],
 ) (*Service, error) {
 	return NewService(
 		ctx,
@@ -58,6 +73,17 @@ func ProvideNotificationService(
 		pullReqActivityStore,
 		spacePathStore,
 		urlProvider,
+		spaceStore,
+		notificationChannelStore,
+		encrypter,
+		principalStore,
+		authorizer,
+		notificationPreferenceStore,
+		notificationDigestStore,
+		mailer,
+		scheduler,
+		executor,
+		webhookReaderFactory,
 	)
 }
 
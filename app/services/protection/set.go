@@ -58,6 +58,7 @@ func (s ruleSet) MergeVerify(
 			out.RequiresCodeOwnersApprovalLatest = out.RequiresCodeOwnersApprovalLatest || rOut.RequiresCodeOwnersApprovalLatest
 			out.RequiresCommentResolution = out.RequiresCommentResolution || rOut.RequiresCommentResolution
 			out.RequiresNoChangeRequests = out.RequiresNoChangeRequests || rOut.RequiresNoChangeRequests
+			out.RequiresMergeQueue = out.RequiresMergeQueue || rOut.RequiresMergeQueue
 
 			return nil
 		})
@@ -107,6 +108,8 @@ func (s ruleSet) RequiredChecks(
 func (s ruleSet) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
 	var violations []types.RuleViolations
 
+	in.isProtectedBranch = s.isProtectedBranch(in.Repo.DefaultBranch)
+
 	err := s.forEachRuleMatchRefs(in.Repo.DefaultBranch, in.RefNames,
 		func(r *types.RuleInfoInternal, p Protection, matched []string) error {
 			ruleIn := in
@@ -128,6 +131,24 @@ func (s ruleSet) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) (
 	return violations, nil
 }
 
+// isProtectedBranch returns a function that reports whether a branch is protected by any branch rule of the set.
+func (s ruleSet) isProtectedBranch(defaultBranch string) func(branchName string) bool {
+	return func(branchName string) bool {
+		for i := range s.rules {
+			if s.rules[i].Type != TypeBranch {
+				continue
+			}
+
+			// Invalid patterns are ignored, they're validated when the rules are created.
+			if matches, _ := matchesName(s.rules[i].Pattern, defaultBranch, branchName); matches {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
 func (s ruleSet) UserIDs() ([]int64, error) {
 	mapIDs := make(map[int64]struct{})
 	err := s.forEachRule(func(_ *types.RuleInfoInternal, p Protection) error {
@@ -210,6 +231,11 @@ func (s ruleSet) forEachRuleMatchBranch(
 	for i := range s.rules {
 		r := s.rules[i]
 
+		// Only branch rules apply to pull request target branches.
+		if r.Type != TypeBranch {
+			continue
+		}
+
 		matches, err := matchesName(r.Pattern, defaultBranch, branchName)
 		if err != nil {
 			return err
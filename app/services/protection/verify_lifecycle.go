@@ -34,6 +34,17 @@ type (
 		RefAction          RefAction
 		RefType            RefType
 		RefNames           []string
+
+		// FindUnverifiedCommits returns SHAs of commits added to the provided reference
+		// that don't carry a verified signature. It's optional and only provided for git pushes.
+		FindUnverifiedCommits func(ctx context.Context, refName string) ([]string, error)
+
+		// IsTagOnBranch reports whether the commit of the provided tag is reachable from any branch
+		// accepted by the branch filter. It's optional and only used by tag rules.
+		IsTagOnBranch func(ctx context.Context, tagName string, branchFilter func(branchName string) bool) (bool, error)
+
+		// isProtectedBranch reports whether a branch is protected by any branch rule. It's set by the rule set.
+		isProtectedBranch func(branchName string) bool
 	}
 
 	RefType int
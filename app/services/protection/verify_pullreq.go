@@ -46,6 +46,9 @@ type (
 		Method             enum.MergeMethod
 		CheckResults       []types.CheckResult
 		CodeOwners         *codeowners.Evaluation
+
+		// FindUnverifiedCommits returns SHAs of the pull request commits that don't carry a verified signature.
+		FindUnverifiedCommits func(ctx context.Context) ([]string, error)
 	}
 
 	MergeVerifyOutput struct {
@@ -57,6 +60,7 @@ type (
 		RequiresCodeOwnersApprovalLatest    bool
 		RequiresCommentResolution           bool
 		RequiresNoChangeRequests            bool
+		RequiresMergeQueue                  bool
 	}
 
 	RequiredChecksInput struct {
@@ -96,11 +100,13 @@ const (
 
 	codePullReqCommentsReqResolveAll      = "pullreq.comments.require_resolve_all"
 	codePullReqStatusChecksReqIdentifiers = "pullreq.status_checks.required_identifiers"
+
+	codePullReqCommitsReqSigned = "pullreq.commits.require_signed"
 )
 
 //nolint:gocognit,gocyclo,cyclop // well aware of this
 func (v *DefPullReq) MergeVerify(
-	_ context.Context,
+	ctx context.Context,
 	in MergeVerifyInput,
 ) (MergeVerifyOutput, []types.RuleViolations, error) {
 	var out MergeVerifyOutput
@@ -108,6 +114,7 @@ func (v *DefPullReq) MergeVerify(
 
 	// set static merge verify output that comes from the PR definition
 	out.DeleteSourceBranch = v.Merge.DeleteBranch
+	out.RequiresMergeQueue = v.Merge.RequireMergeQueue
 	out.RequiresCommentResolution = v.Comments.RequireResolveAll
 	out.RequiresNoChangeRequests = v.Approvals.RequireNoChangeRequest
 
@@ -226,6 +233,21 @@ func (v *DefPullReq) MergeVerify(
 		)
 	}
 
+	// pullreq.commits
+
+	if v.Commits.RequireSigned && in.FindUnverifiedCommits != nil {
+		commitSHAs, err := in.FindUnverifiedCommits(ctx)
+		if err != nil {
+			return out, nil, fmt.Errorf("failed to find unverified commits: %w", err)
+		}
+
+		if len(commitSHAs) > 0 {
+			violations.Addf(codePullReqCommitsReqSigned,
+				"All commits must have a verified signature. Unverified commits: %s",
+				strings.Join(commitSHAs, ", "))
+		}
+	}
+
 	// pullreq.merge
 
 	out.AllowedMethods = enum.MergeMethods
@@ -345,10 +367,19 @@ func (c *DefStatusChecks) Sanitize() error {
 	return nil
 }
 
+type DefCommits struct {
+	RequireSigned bool `json:"require_signed,omitempty"`
+}
+
+func (DefCommits) Sanitize() error {
+	return nil
+}
+
 type DefMerge struct {
 	StrategiesAllowed []enum.MergeMethod `json:"strategies_allowed,omitempty"`
 	DeleteBranch      bool               `json:"delete_branch,omitempty"`
 	Block             bool               `json:"block,omitempty"`
+	RequireMergeQueue bool               `json:"require_merge_queue,omitempty"`
 }
 
 func (v *DefMerge) Sanitize() error {
@@ -370,19 +401,12 @@ func (v *DefMerge) Sanitize() error {
 	return nil
 }
 
-type DefPush struct {
-	Block bool `json:"block,omitempty"`
-}
-
-func (v *DefPush) Sanitize() error {
-	return nil
-}
-
 type DefPullReq struct {
 	Approvals    DefApprovals    `json:"approvals"`
 	Comments     DefComments     `json:"comments"`
 	StatusChecks DefStatusChecks `json:"status_checks"`
 	Merge        DefMerge        `json:"merge"`
+	Commits      DefCommits      `json:"commits"`
 }
 
 func (v *DefPullReq) Sanitize() error {
@@ -402,6 +426,10 @@ func (v *DefPullReq) Sanitize() error {
 		return fmt.Errorf("merge: %w", err)
 	}
 
+	if err := v.Commits.Sanitize(); err != nil {
+		return fmt.Errorf("commits: %w", err)
+	}
+
 	return nil
 }
 
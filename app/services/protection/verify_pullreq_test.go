@@ -368,6 +368,17 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				DeleteSourceBranch: true,
 			},
 		},
+		{
+			name: "merge.require_merge_queue",
+			def:  DefPullReq{Merge: DefMerge{RequireMergeQueue: true}},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodSquash,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:     enum.MergeMethods,
+				RequiresMergeQueue: true,
+			},
+		},
 		{
 			name: codePullReqApprovalReqChangeRequested + "-true",
 			def: DefPullReq{
@@ -463,6 +474,36 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				AllowedMethods: enum.MergeMethods,
 			},
 		},
+		{
+			name: codePullReqCommitsReqSigned + "-fail",
+			def:  DefPullReq{Commits: DefCommits{RequireSigned: true}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{SourceSHA: "abc"},
+				Method:  enum.MergeMethodMerge,
+				FindUnverifiedCommits: func(context.Context) ([]string, error) {
+					return []string{"abc", "def"}, nil
+				},
+			},
+			expCodes:  []string{codePullReqCommitsReqSigned},
+			expParams: [][]any{{"abc, def"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqCommitsReqSigned + "-success",
+			def:  DefPullReq{Commits: DefCommits{RequireSigned: true}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{SourceSHA: "abc"},
+				Method:  enum.MergeMethodMerge,
+				FindUnverifiedCommits: func(context.Context) ([]string, error) {
+					return nil, nil
+				},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/types"
+)
+
+type DefPush struct {
+	RequireSignedCommits bool `json:"require_signed_commits,omitempty"`
+}
+
+// ensures that the DefPush type implements Sanitizer and RefChangeVerifier interfaces.
+var (
+	_ Sanitizer         = (*DefPush)(nil)
+	_ RefChangeVerifier = (*DefPush)(nil)
+)
+
+const (
+	codePushCommitsRequireSigned = "push.commits.require_signed"
+)
+
+func (v *DefPush) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
+	// Note: The check is skipped if the caller can't provide the commits (e.g. commits created by Harness itself).
+	if !v.RequireSignedCommits || in.FindUnverifiedCommits == nil || in.RefAction == RefActionDelete {
+		return nil, nil
+	}
+
+	var violations types.RuleViolations
+
+	for _, refName := range in.RefNames {
+		commitSHAs, err := in.FindUnverifiedCommits(ctx, refName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find unverified commits for %q: %w", refName, err)
+		}
+
+		if len(commitSHAs) == 0 {
+			continue
+		}
+
+		violations.Addf(codePushCommitsRequireSigned,
+			"Commits pushed to branch %q must have a verified signature. Unverified commits: %s",
+			refName, strings.Join(commitSHAs, ", "))
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+func (*DefPush) Sanitize() error {
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDefPush_RefChangeVerify(t *testing.T) {
+	const refName = "a"
+	unverified := map[string][]string{refName: {"abc", "def"}}
+	tests := []struct {
+		name      string
+		def       DefPush
+		action    RefAction
+		find      bool
+		expCodes  []string
+		expParams [][]any
+	}{
+		{
+			name:   "empty",
+			action: RefActionUpdate,
+			find:   true,
+		},
+		{
+			name:      "push.commits.require_signed-fail",
+			def:       DefPush{RequireSignedCommits: true},
+			action:    RefActionUpdate,
+			find:      true,
+			expCodes:  []string{"push.commits.require_signed"},
+			expParams: [][]any{{refName, "abc, def"}},
+		},
+		{
+			name:      "push.commits.require_signed-create-fail",
+			def:       DefPush{RequireSignedCommits: true},
+			action:    RefActionCreate,
+			find:      true,
+			expCodes:  []string{"push.commits.require_signed"},
+			expParams: [][]any{{refName, "abc, def"}},
+		},
+		{
+			name:   "push.commits.require_signed-delete",
+			def:    DefPush{RequireSignedCommits: true},
+			action: RefActionDelete,
+			find:   true,
+		},
+		{
+			name:   "push.commits.require_signed-no-commits-available",
+			def:    DefPush{RequireSignedCommits: true},
+			action: RefActionUpdate,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := RefChangeVerifyInput{
+				RefNames:  []string{refName},
+				RefAction: test.action,
+				RefType:   RefTypeBranch,
+			}
+
+			if test.find {
+				in.FindUnverifiedCommits = func(_ context.Context, refName string) ([]string, error) {
+					return unverified[refName], nil
+				}
+			}
+
+			if err := test.def.Sanitize(); err != nil {
+				t.Errorf("def invalid: %s", err.Error())
+				return
+			}
+
+			violations, err := test.def.RefChangeVerify(context.Background(), in)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
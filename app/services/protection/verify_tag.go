@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+)
+
+// ensures that the DefTagLifecycle type implements Sanitizer and RefChangeVerifier interfaces.
+var (
+	_ Sanitizer         = (*DefTagLifecycle)(nil)
+	_ RefChangeVerifier = (*DefTagLifecycle)(nil)
+)
+
+const (
+	codeTagCreate                = "tag.create"
+	codeTagDelete                = "tag.delete"
+	codeTagUpdate                = "tag.update"
+	codeTagProtectedBranchCommit = "tag.protected_branch_commit"
+)
+
+type DefTagLifecycle struct {
+	CreateForbidden bool `json:"create_forbidden,omitempty"`
+	DeleteForbidden bool `json:"delete_forbidden,omitempty"`
+	UpdateForbidden bool `json:"update_forbidden,omitempty"`
+
+	// RequireProtectedBranchCommit allows only tags that point to a commit
+	// reachable from a branch protected with a branch rule.
+	RequireProtectedBranchCommit bool `json:"require_protected_branch_commit,omitempty"`
+}
+
+func (v *DefTagLifecycle) RefChangeVerify(
+	ctx context.Context,
+	in RefChangeVerifyInput,
+) ([]types.RuleViolations, error) {
+	var violations types.RuleViolations
+
+	switch in.RefAction {
+	case RefActionCreate:
+		if v.CreateForbidden {
+			violations.Addf(codeTagCreate,
+				"Creation of tag %q is not allowed.", in.RefNames[0])
+		}
+	case RefActionDelete:
+		if v.DeleteForbidden {
+			violations.Addf(codeTagDelete,
+				"Delete of tag %q is not allowed.", in.RefNames[0])
+		}
+	case RefActionUpdate, RefActionUpdateForce:
+		if v.UpdateForbidden {
+			violations.Addf(codeTagUpdate,
+				"Update of tag %q is not allowed.", in.RefNames[0])
+		}
+	}
+
+	// Note: The check is skipped if the caller can't resolve the commit of the tag.
+	if v.RequireProtectedBranchCommit && in.RefAction != RefActionDelete && in.IsTagOnBranch != nil {
+		isProtectedBranch := in.isProtectedBranch
+		if isProtectedBranch == nil {
+			isProtectedBranch = func(string) bool { return false }
+		}
+
+		for _, tagName := range in.RefNames {
+			ok, err := in.IsTagOnBranch(ctx, tagName, isProtectedBranch)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check branches of tag %q: %w", tagName, err)
+			}
+
+			if !ok {
+				violations.Addf(codeTagProtectedBranchCommit,
+					"Tag %q must point to a commit that is reachable from a protected branch.", tagName)
+			}
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+func (*DefTagLifecycle) Sanitize() error {
+	return nil
+}
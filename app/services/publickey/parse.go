@@ -15,11 +15,17 @@
 package publickey
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"strings"
 
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types/enum"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
 	"github.com/gliderlabs/ssh"
 	gossh "golang.org/x/crypto/ssh"
 	"golang.org/x/exp/slices"
@@ -39,6 +45,16 @@ var DisallowedTypes = []string{
 	gossh.KeyAlgoDSA,
 }
 
+// AllowedPGPTypes are the allowed algorithms of PGP primary keys.
+var AllowedPGPTypes = map[packet.PublicKeyAlgorithm]string{
+	packet.PubKeyAlgoRSA:         "rsa",
+	packet.PubKeyAlgoRSASignOnly: "rsa",
+	packet.PubKeyAlgoECDSA:       "ecdsa",
+	packet.PubKeyAlgoEdDSA:       "eddsa",
+}
+
+const pgpPublicKeyBlockHeader = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+
 func From(key gossh.PublicKey) KeyInfo {
 	return KeyInfo{
 		Key: key,
@@ -49,7 +65,17 @@ func ParseString(keyData string) (KeyInfo, string, error) {
 	return Parse([]byte(keyData))
 }
 
+// Parse parses a public key. The key data can either be an SSH public key in the authorized keys format,
+// or an ASCII armored PGP public key.
 func Parse(keyData []byte) (KeyInfo, string, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(keyData), []byte(pgpPublicKeyBlockHeader)) {
+		return parsePGP(keyData)
+	}
+
+	return parseSSH(keyData)
+}
+
+func parseSSH(keyData []byte) (KeyInfo, string, error) {
 	publicKey, comment, _, _, err := gossh.ParseAuthorizedKey(keyData)
 	if err != nil {
 		return KeyInfo{}, "", err
@@ -72,29 +98,105 @@ func Parse(keyData []byte) (KeyInfo, string, error) {
 	}, comment, nil
 }
 
+func parsePGP(keyData []byte) (KeyInfo, string, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(keyData))
+	if err != nil {
+		return KeyInfo{}, "", err
+	}
+
+	if len(entities) != 1 {
+		return KeyInfo{}, "", errors.InvalidArgument("exactly one PGP public key must be provided")
+	}
+
+	entity := entities[0]
+
+	if entity.PrivateKey != nil {
+		return KeyInfo{}, "", errors.InvalidArgument("PGP private keys are not allowed")
+	}
+
+	if _, ok := AllowedPGPTypes[entity.PrimaryKey.PubKeyAlgo]; !ok {
+		return KeyInfo{}, "", errors.InvalidArgument("allowed PGP key types are RSA, ECDSA and EdDSA")
+	}
+
+	var comment string
+	if identity := entity.PrimaryIdentity(); identity != nil {
+		comment = identity.Name
+	}
+
+	return KeyInfo{
+		Entity: entity,
+	}, comment, nil
+}
+
+// KeyInfo holds a parsed public key. Exactly one of Key (for SSH keys) and Entity (for PGP keys) is set.
 type KeyInfo struct {
-	Key gossh.PublicKey
+	Key    gossh.PublicKey
+	Entity *openpgp.Entity
 }
 
 func (key KeyInfo) Matches(s string) bool {
-	otherKey, _, _, _, err := gossh.ParseAuthorizedKey([]byte(s))
+	otherKey, _, err := ParseString(s)
 	if err != nil {
 		return false
 	}
 
-	return key.MatchesKey(otherKey)
+	if key.Entity != nil || otherKey.Entity != nil {
+		return key.Entity != nil && otherKey.Entity != nil &&
+			bytes.Equal(key.Entity.PrimaryKey.Fingerprint, otherKey.Entity.PrimaryKey.Fingerprint)
+	}
+
+	return key.MatchesKey(otherKey.Key)
 }
 
 func (key KeyInfo) MatchesKey(otherKey gossh.PublicKey) bool {
+	if key.Key == nil {
+		return false
+	}
+
 	return ssh.KeysEqual(key.Key, otherKey)
 }
 
+// HasKeyID returns true if the key is a PGP key and either its primary key or one of its subkeys has the key ID.
+func (key KeyInfo) HasKeyID(keyID uint64) bool {
+	if key.Entity == nil {
+		return false
+	}
+
+	if key.Entity.PrimaryKey.KeyId == keyID {
+		return true
+	}
+
+	for _, subkey := range key.Entity.Subkeys {
+		if subkey.PublicKey.KeyId == keyID {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (key KeyInfo) Fingerprint() string {
+	if key.Entity != nil {
+		return strings.ToUpper(hex.EncodeToString(key.Entity.PrimaryKey.Fingerprint))
+	}
+
 	sum := sha256.New()
 	sum.Write(key.Key.Marshal())
 	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum.Sum(nil))
 }
 
 func (key KeyInfo) Type() string {
+	if key.Entity != nil {
+		return AllowedPGPTypes[key.Entity.PrimaryKey.PubKeyAlgo]
+	}
+
 	return key.Key.Type()
 }
+
+func (key KeyInfo) Scheme() enum.PublicKeyScheme {
+	if key.Entity != nil {
+		return enum.PublicKeySchemePGP
+	}
+
+	return enum.PublicKeySchemeSSH
+}
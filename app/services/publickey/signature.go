@@ -0,0 +1,388 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	pgperrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+const pgpSignatureHeader = "-----BEGIN PGP SIGNATURE-----"
+
+// SignatureVerifier verifies git commit signatures using the signing keys registered by the principals.
+// A commit signature is considered verified if it's valid and if it's created with a signing key
+// that belongs to the principal with the same email address as the committer of the commit.
+type SignatureVerifier struct {
+	git            git.Interface
+	publicKeyStore store.PublicKeyStore
+	principalStore store.PrincipalStore
+	pCache         store.PrincipalInfoCache
+}
+
+func NewSignatureVerifier(
+	git git.Interface,
+	publicKeyStore store.PublicKeyStore,
+	principalStore store.PrincipalStore,
+	pCache store.PrincipalInfoCache,
+) *SignatureVerifier {
+	return &SignatureVerifier{
+		git:            git,
+		publicKeyStore: publicKeyStore,
+		principalStore: principalStore,
+		pCache:         pCache,
+	}
+}
+
+// VerifyCommits verifies signatures of the provided commits.
+// The results are returned in the same order as the provided commit SHAs.
+func (v *SignatureVerifier) VerifyCommits(
+	ctx context.Context,
+	readParams git.ReadParams,
+	commitSHAs []string,
+) ([]types.CommitVerification, error) {
+	if len(commitSHAs) == 0 {
+		return []types.CommitVerification{}, nil
+	}
+
+	out, err := v.git.GetCommitSignatures(ctx, &git.GetCommitSignaturesParams{
+		ReadParams: readParams,
+		CommitSHAs: commitSHAs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit signatures: %w", err)
+	}
+
+	// signing keys of committers are cached for the duration of the call,
+	// as the same committer is very likely to be the committer of many of the commits.
+	committerKeys := make(map[string][]types.PublicKey)
+
+	results := make([]types.CommitVerification, len(out.Signatures))
+	for i := range out.Signatures {
+		results[i], err = v.verify(ctx, &out.Signatures[i], committerKeys)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify signature of commit %s: %w",
+				out.Signatures[i].CommitSHA, err)
+		}
+	}
+
+	return results, nil
+}
+
+// VerifyCommit verifies the signature of a single commit.
+func (v *SignatureVerifier) VerifyCommit(
+	ctx context.Context,
+	readParams git.ReadParams,
+	commitSHA string,
+) (types.CommitVerification, error) {
+	results, err := v.VerifyCommits(ctx, readParams, []string{commitSHA})
+	if err != nil {
+		return types.CommitVerification{}, err
+	}
+
+	return results[0], nil
+}
+
+// SetVerifications verifies signatures of the provided commits and sets the verification result of each commit.
+func (v *SignatureVerifier) SetVerifications(
+	ctx context.Context,
+	readParams git.ReadParams,
+	commits []types.Commit,
+) error {
+	commitSHAs := make([]string, len(commits))
+	for i := range commits {
+		commitSHAs[i] = commits[i].SHA
+	}
+
+	results, err := v.VerifyCommits(ctx, readParams, commitSHAs)
+	if err != nil {
+		return err
+	}
+
+	for i := range commits {
+		commits[i].Verification = &results[i]
+	}
+
+	return nil
+}
+
+// FindUnverifiedCommits returns SHAs of all commits reachable from gitRef, but not from after,
+// that don't carry a verified signature.
+func (v *SignatureVerifier) FindUnverifiedCommits(
+	ctx context.Context,
+	readParams git.ReadParams,
+	gitRef string,
+	after string,
+) ([]string, error) {
+	out, err := v.git.ListCommitSHAs(ctx, &git.ListCommitSHAsParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		After:      after,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	results, err := v.VerifyCommits(ctx, readParams, out.CommitSHAs)
+	if err != nil {
+		return nil, err
+	}
+
+	var unverified []string
+	for i := range results {
+		if results[i].Status != enum.CommitSignatureStatusVerified {
+			unverified = append(unverified, out.CommitSHAs[i])
+		}
+	}
+
+	return unverified, nil
+}
+
+func (v *SignatureVerifier) verify(
+	ctx context.Context,
+	signature *git.CommitSignature,
+	committerKeys map[string][]types.PublicKey,
+) (types.CommitVerification, error) {
+	if !signature.IsSigned() {
+		return types.CommitVerification{Status: enum.CommitSignatureStatusUnverified}, nil
+	}
+
+	sig := bytes.TrimSpace(signature.Signature)
+
+	switch {
+	case bytes.HasPrefix(sig, []byte(sshSignatureHeader)):
+		return v.verifySSH(ctx, signature)
+	case bytes.HasPrefix(sig, []byte(pgpSignatureHeader)):
+		return v.verifyPGP(ctx, signature, committerKeys)
+	default:
+		// other signature formats (e.g. x509) aren't supported, so no registered key can match the signature.
+		return types.CommitVerification{Status: enum.CommitSignatureStatusUnknownKey}, nil
+	}
+}
+
+func (v *SignatureVerifier) verifySSH(
+	ctx context.Context,
+	signature *git.CommitSignature,
+) (types.CommitVerification, error) {
+	sshSig, err := parseSSHSignature(signature.Signature)
+	if err != nil {
+		return types.CommitVerification{Status: enum.CommitSignatureStatusBadSignature}, nil //nolint:nilerr
+	}
+
+	key := From(sshSig.PublicKey)
+	result := types.CommitVerification{
+		KeyScheme:      enum.PublicKeySchemeSSH,
+		KeyFingerprint: key.Fingerprint(),
+	}
+
+	existingKeys, err := v.publicKeyStore.ListByFingerprint(ctx, result.KeyFingerprint)
+	if err != nil {
+		return types.CommitVerification{}, fmt.Errorf("failed to read keys by fingerprint: %w", err)
+	}
+
+	var signingKey *types.PublicKey
+	for i := range existingKeys {
+		if existingKeys[i].Usage == enum.PublicKeyUsageSign && key.Matches(existingKeys[i].Content) {
+			signingKey = &existingKeys[i]
+			break
+		}
+	}
+
+	if signingKey == nil {
+		result.Status = enum.CommitSignatureStatusUnknownKey
+		return result, nil
+	}
+
+	if err = sshSig.Verify(signature.SignedData, sshSignatureNamespace); err != nil {
+		result.Status = enum.CommitSignatureStatusBadSignature
+		return result, nil
+	}
+
+	return v.verifySigner(ctx, signature, signingKey, result)
+}
+
+func (v *SignatureVerifier) verifyPGP(
+	ctx context.Context,
+	signature *git.CommitSignature,
+	committerKeys map[string][]types.PublicKey,
+) (types.CommitVerification, error) {
+	result := types.CommitVerification{
+		KeyScheme: enum.PublicKeySchemePGP,
+	}
+
+	sigPacket, err := parsePGPSignature(signature.Signature)
+	if err != nil {
+		result.Status = enum.CommitSignatureStatusBadSignature
+		return result, nil
+	}
+
+	// PGP signatures reference the signing key only by its key ID, so the key is searched for
+	// among the signing keys of the committer.
+	keys, err := v.getCommitterKeys(ctx, signature.Committer.Identity.Email, committerKeys)
+	if err != nil {
+		return types.CommitVerification{}, err
+	}
+
+	var signingKey *types.PublicKey
+	var keyInfo KeyInfo
+	for i := range keys {
+		keyInfo, _, err = ParseString(keys[i].Content)
+		if err != nil || !keyInfo.HasKeyID(*sigPacket.IssuerKeyId) {
+			continue
+		}
+
+		signingKey = &keys[i]
+		result.KeyFingerprint = keyInfo.Fingerprint()
+
+		break
+	}
+
+	if signingKey == nil {
+		result.Status = enum.CommitSignatureStatusUnknownKey
+		return result, nil
+	}
+
+	err = checkPGPSignature(keyInfo.Entity, sigPacket, signature.Signature, signature.SignedData)
+	if errors.Is(err, pgperrors.ErrUnknownIssuer) {
+		// the key exists, but it's not allowed to create signatures.
+		result.Status = enum.CommitSignatureStatusUnknownKey
+		return result, nil
+	}
+	if err != nil {
+		result.Status = enum.CommitSignatureStatusBadSignature
+		return result, nil
+	}
+
+	return v.verifySigner(ctx, signature, signingKey, result)
+}
+
+// verifySigner completes the verification of a valid signature
+// by checking whether the signing key belongs to the committer.
+func (v *SignatureVerifier) verifySigner(
+	ctx context.Context,
+	signature *git.CommitSignature,
+	signingKey *types.PublicKey,
+	result types.CommitVerification,
+) (types.CommitVerification, error) {
+	signer, err := v.pCache.Get(ctx, signingKey.PrincipalID)
+	if err != nil {
+		return types.CommitVerification{}, fmt.Errorf("failed to get principal info of the signing key: %w", err)
+	}
+
+	if !strings.EqualFold(signer.Email, signature.Committer.Identity.Email) {
+		result.Status = enum.CommitSignatureStatusUnverified
+		return result, nil
+	}
+
+	result.Status = enum.CommitSignatureStatusVerified
+	result.Signer = signer
+
+	return result, nil
+}
+
+// getCommitterKeys returns all PGP signing keys of the principal with the provided email address.
+func (v *SignatureVerifier) getCommitterKeys(
+	ctx context.Context,
+	email string,
+	committerKeys map[string][]types.PublicKey,
+) ([]types.PublicKey, error) {
+	email = strings.ToLower(email)
+
+	if keys, ok := committerKeys[email]; ok {
+		return keys, nil
+	}
+
+	principal, err := v.principalStore.FindByEmail(ctx, email)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		committerKeys[email] = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal by email: %w", err)
+	}
+
+	keys, err := v.publicKeyStore.List(ctx, principal.ID, &types.PublicKeyFilter{
+		Usages:  []enum.PublicKeyUsage{enum.PublicKeyUsageSign},
+		Schemes: []enum.PublicKeyScheme{enum.PublicKeySchemePGP},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys of principal: %w", err)
+	}
+
+	committerKeys[email] = keys
+
+	return keys, nil
+}
+
+// checkPGPSignature verifies the armored PGP signature of the signed data using the provided key.
+func checkPGPSignature(
+	entity *openpgp.Entity,
+	sigPacket *packet.Signature,
+	armored []byte,
+	signedData []byte,
+) error {
+	// the signature is checked at the time it was created,
+	// so that commits signed with a key that has expired since then remain verified.
+	config := &packet.Config{
+		Time: func() time.Time { return sigPacket.CreationTime },
+	}
+
+	_, err := openpgp.CheckArmoredDetachedSignature(openpgp.EntityList{entity},
+		bytes.NewReader(signedData), bytes.NewReader(armored), config)
+
+	return err
+}
+
+// parsePGPSignature parses the signature packet of an armored PGP signature.
+func parsePGPSignature(armored []byte) (*packet.Signature, error) {
+	block, err := armor.Decode(bytes.NewReader(armored))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode armored PGP signature: %w", err)
+	}
+
+	if block.Type != openpgp.SignatureType {
+		return nil, fmt.Errorf("unexpected PGP armor block type %q", block.Type)
+	}
+
+	p, err := packet.Read(block.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PGP signature packet: %w", err)
+	}
+
+	sig, ok := p.(*packet.Signature)
+	if !ok {
+		return nil, errors.New("PGP signature block doesn't contain a signature packet")
+	}
+
+	if sig.IssuerKeyId == nil {
+		return nil, errors.New("PGP signature doesn't have an issuer")
+	}
+
+	return sig, nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	testSSHKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAITpbtxysNj7yIMti9hwbSJe9Wvvvs+YKnnYhqKf2/6 jane@example.com"
+
+	testSSHSignedCommit = `tree aaff74984cccd156a469afa7d9ab10e4777beb24
+author Jane Doe <jane@example.com> 1792156949 +0000
+committer Jane Doe <jane@example.com> 1792156949 +0000
+gpgsig -----BEGIN SSH SIGNATURE-----
+ U1NIU0lHAAAAAQAAADMAAAALc3NoLWVkMjU1MTkAAAAgAhOlu3HKw2PvIgy2L2HBtIl71a
+ +++z5gqediGop/b/oAAAADZ2l0AAAAAAAAAAZzaGE1MTIAAABTAAAAC3NzaC1lZDI1NTE5
+ AAAAQOSKcYgJ4NvaaPHcVgzbPzbrOpKP5WH2PAhSq6n9RNE6ezO2PKuqfUTvAVKMW5Bw5r
+ onbAy45y9Ov5zYaqtzjwU=
+ -----END SSH SIGNATURE-----
+
+ssh signed
+`
+
+	testPGPKey = `-----BEGIN PGP PUBLIC KEY BLOCK-----
+
+mDMEatIlFRYJKwYBBAHaRw8BAQdAu4q2W9d1pDHw4QojqmGddII2j96HoNXUUHQN
+xKsplIG0G0phbmUgRG9lIDxqYW5lQGV4YW1wbGUuY29tPoiQBBMWCAA4FiEEuaC0
+KrKsYdqRD3eAZgwq7zbUjfAFAmrSJRUCGwMFCwkIBwIGFQoJCAsCBBYCAwECHgEC
+F4AACgkQZgwq7zbUjfDcZwD9ESpmFmVWTr9bjwk3pbfq7qCzKosbP2txWKbOqW3c
+mY4BANYzBReNzXnrXYVEgwqsrTEkoekpa9UCGMySoN3BJm0J
+=Ldfh
+-----END PGP PUBLIC KEY BLOCK-----
+`
+
+	testPGPSignedCommit = `tree 3683f870be446c7cc05ffaef9fa06415276e1828
+parent 6c4b07b5946e6180c41a1beab97f19d6c95dab1f
+author Jane Doe <jane@example.com> 1792156949 +0000
+committer Jane Doe <jane@example.com> 1792156949 +0000
+gpgsig -----BEGIN PGP SIGNATURE-----
+ 
+ iIcEABYIAC8WIQS5oLQqsqxh2pEPd4BmDCrvNtSN8AUCatIlFREcamFuZUBleGFt
+ cGxlLmNvbQAKCRBmDCrvNtSN8IvpAQCi7C2z4Xckocq6yUyjIq3kQwmj7m+YNcG3
+ ZH13xiJqIQD/bNfjeUfI9AfvEEVdMPnactBkAlRNz8pVg+ghGisWYAQ=
+ =4W1V
+ -----END PGP SIGNATURE-----
+
+gpg signed
+`
+)
+
+func readSignedCommit(t *testing.T, data string) *api.CommitGPGSignature {
+	t.Helper()
+
+	commit, err := api.CommitFromReader(sha.None, strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to read commit: %s", err)
+	}
+
+	if commit.Signature == nil {
+		t.Fatalf("commit isn't signed")
+	}
+
+	return commit.Signature
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		scheme      enum.PublicKeyScheme
+		keyType     string
+		fingerprint string
+		comment     string
+	}{
+		{
+			name:        "ssh",
+			key:         testSSHKey,
+			scheme:      enum.PublicKeySchemeSSH,
+			keyType:     "ssh-ed25519",
+			fingerprint: "SHA256:repJzVVA+Ca1jU/yA88qRSq+z0oGLbMhObOLdAfdoOk",
+			comment:     "jane@example.com",
+		},
+		{
+			name:        "pgp",
+			key:         testPGPKey,
+			scheme:      enum.PublicKeySchemePGP,
+			keyType:     "eddsa",
+			fingerprint: "B9A0B42AB2AC61DA910F7780660C2AEF36D48DF0",
+			comment:     "Jane Doe <jane@example.com>",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, comment, err := ParseString(test.key)
+			if err != nil {
+				t.Fatalf("failed to parse key: %s", err)
+			}
+
+			if want, got := test.scheme, key.Scheme(); want != got {
+				t.Errorf("scheme mismatch: want=%s got=%s", want, got)
+			}
+			if want, got := test.keyType, key.Type(); want != got {
+				t.Errorf("type mismatch: want=%s got=%s", want, got)
+			}
+			if want, got := test.fingerprint, key.Fingerprint(); want != got {
+				t.Errorf("fingerprint mismatch: want=%s got=%s", want, got)
+			}
+			if want, got := test.comment, comment; want != got {
+				t.Errorf("comment mismatch: want=%s got=%s", want, got)
+			}
+			if !key.Matches(test.key) {
+				t.Errorf("key doesn't match itself")
+			}
+		})
+	}
+
+	sshKey, _, _ := ParseString(testSSHKey)
+	if sshKey.Matches(testPGPKey) {
+		t.Errorf("ssh key matches pgp key")
+	}
+}
+
+func TestSSHSignature(t *testing.T) {
+	signature := readSignedCommit(t, testSSHSignedCommit)
+
+	sig, err := parseSSHSignature([]byte(signature.Signature))
+	if err != nil {
+		t.Fatalf("failed to parse signature: %s", err)
+	}
+
+	key, _, _ := ParseString(testSSHKey)
+	if !key.MatchesKey(sig.PublicKey) {
+		t.Errorf("signature public key doesn't match the signing key")
+	}
+
+	if err = sig.Verify([]byte(signature.Payload), sshSignatureNamespace); err != nil {
+		t.Errorf("failed to verify signature: %s", err)
+	}
+
+	if err = sig.Verify([]byte(signature.Payload), "file"); err == nil {
+		t.Errorf("expected signature with a different namespace to fail")
+	}
+
+	tampered := strings.Replace(signature.Payload, "ssh signed", "ssh signed!", 1)
+	if err = sig.Verify([]byte(tampered), sshSignatureNamespace); err == nil {
+		t.Errorf("expected signature of tampered payload to fail")
+	}
+}
+
+func TestPGPSignature(t *testing.T) {
+	signature := readSignedCommit(t, testPGPSignedCommit)
+
+	sig, err := parsePGPSignature([]byte(signature.Signature))
+	if err != nil {
+		t.Fatalf("failed to parse signature: %s", err)
+	}
+
+	key, _, _ := ParseString(testPGPKey)
+	if !key.HasKeyID(*sig.IssuerKeyId) {
+		t.Fatalf("signing key doesn't have the signature issuer key ID")
+	}
+
+	err = checkPGPSignature(key.Entity, sig, []byte(signature.Signature), []byte(signature.Payload))
+	if err != nil {
+		t.Errorf("failed to verify signature: %s", err)
+	}
+
+	tampered := strings.Replace(signature.Payload, "gpg signed", "gpg signed!", 1)
+	err = checkPGPSignature(key.Entity, sig, []byte(signature.Signature), []byte(tampered))
+	if err == nil {
+		t.Errorf("expected signature of tampered payload to fail")
+	}
+}
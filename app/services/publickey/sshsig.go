@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/pem"
+	"fmt"
+	"hash"
+
+	"github.com/harness/gitness/errors"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// SSH signatures are created by "ssh-keygen -Y sign" and follow the format described in
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+
+const (
+	sshSignatureMagic     = "SSHSIG"
+	sshSignatureVersion   = 1
+	sshSignaturePEMType   = "SSH SIGNATURE"
+	sshSignatureHeader    = "-----BEGIN SSH SIGNATURE-----"
+	sshSignatureNamespace = "git"
+)
+
+type sshSignatureBlob struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+type sshSignedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+// sshSignature is a parsed SSH signature.
+type sshSignature struct {
+	PublicKey     gossh.PublicKey
+	Namespace     string
+	HashAlgorithm string
+	Signature     *gossh.Signature
+}
+
+// parseSSHSignature parses an armored SSH signature.
+func parseSSHSignature(armored []byte) (*sshSignature, error) {
+	block, _ := pem.Decode(armored)
+	if block == nil || block.Type != sshSignaturePEMType {
+		return nil, errors.InvalidArgument("not an armored SSH signature")
+	}
+
+	data, ok := bytes.CutPrefix(block.Bytes, []byte(sshSignatureMagic))
+	if !ok {
+		return nil, errors.InvalidArgument("invalid SSH signature magic preamble")
+	}
+
+	blob := sshSignatureBlob{}
+	if err := gossh.Unmarshal(data, &blob); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal SSH signature: %w", err)
+	}
+
+	if blob.Version != sshSignatureVersion {
+		return nil, errors.InvalidArgument("unsupported SSH signature version %d", blob.Version)
+	}
+
+	publicKey, err := gossh.ParsePublicKey(blob.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SSH signature public key: %w", err)
+	}
+
+	signature := &gossh.Signature{}
+	if err := gossh.Unmarshal(blob.Signature, signature); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal SSH signature blob: %w", err)
+	}
+
+	return &sshSignature{
+		PublicKey:     publicKey,
+		Namespace:     blob.Namespace,
+		HashAlgorithm: blob.HashAlgorithm,
+		Signature:     signature,
+	}, nil
+}
+
+// Verify verifies that the signature is valid for the message and that it has been created for the namespace.
+func (s *sshSignature) Verify(message []byte, namespace string) error {
+	if s.Namespace != namespace {
+		return errors.InvalidArgument("SSH signature namespace %q doesn't match %q", s.Namespace, namespace)
+	}
+
+	var h hash.Hash
+	switch s.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return errors.InvalidArgument("unsupported SSH signature hash algorithm %q", s.HashAlgorithm)
+	}
+
+	_, _ = h.Write(message)
+
+	signedData := append([]byte(sshSignatureMagic), gossh.Marshal(sshSignedData{
+		Namespace:     s.Namespace,
+		HashAlgorithm: s.HashAlgorithm,
+		Hash:          h.Sum(nil),
+	})...)
+
+	return s.PublicKey.Verify(signedData, s.Signature)
+}
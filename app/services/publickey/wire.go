@@ -16,12 +16,14 @@ package publickey
 
 import (
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
 
 	"github.com/google/wire"
 )
 
 var WireSet = wire.NewSet(
 	ProvidePublicKey,
+	ProvideSignatureVerifier,
 )
 
 func ProvidePublicKey(
@@ -30,3 +32,12 @@ func ProvidePublicKey(
 ) Service {
 	return NewService(publicKeyStore, pCache)
 }
+
+func ProvideSignatureVerifier(
+	git git.Interface,
+	publicKeyStore store.PublicKeyStore,
+	principalStore store.PrincipalStore,
+	pCache store.PrincipalInfoCache,
+) *SignatureVerifier {
+	return NewSignatureVerifier(git, publicKeyStore, principalStore, pCache)
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullmirror
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const dispatchBatchSize = 100
+
+// dispatchJob starts the sync jobs of all pull mirrors whose periodic sync is due.
+type dispatchJob struct {
+	service *Service
+}
+
+var _ job.Handler = (*dispatchJob)(nil)
+
+func (j *dispatchJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	s := j.service
+	now := time.Now()
+
+	mirrors, err := s.pullMirrorStore.ListDue(ctx, now.UnixMilli(), dispatchBatchSize)
+	if err != nil {
+		return "", fmt.Errorf("failed to list pull mirrors due for sync: %w", err)
+	}
+
+	for _, mirror := range mirrors {
+		_, err = s.pullMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PullMirror) error {
+			mirror.Status = enum.PullMirrorStatusPending
+			mirror.NextSync = nextSync(now, mirror.Interval)
+			return nil
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", mirror.RepoID).
+				Msg("failed to update next sync time of pull mirror")
+			continue
+		}
+
+		if err = s.runSyncJob(ctx, mirror.RepoID); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", mirror.RepoID).
+				Msg("failed to start pull mirror sync")
+		}
+	}
+
+	return "", nil
+}
+
+// nextSync returns the time of the next periodic sync of a pull mirror.
+func nextSync(now time.Time, interval int64) int64 {
+	return now.Add(time.Duration(interval) * time.Minute).UnixMilli()
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullmirror
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	jobTypeDispatch        = "gitness:pull-mirror-dispatch"
+	jobCronDispatch        = "* * * * *" // Every minute.
+	jobMaxDurationDispatch = time.Minute
+
+	jobTypeSync        = "gitness:pull-mirror-sync"
+	jobMaxDurationSync = 45 * time.Minute
+)
+
+const (
+	// DefaultInterval is the default time between two periodic syncs of a pull mirror, in minutes.
+	DefaultInterval = 60
+	// MinInterval is the minimal time between two periodic syncs of a pull mirror, in minutes.
+	MinInterval = 10
+	// MaxInterval is the maximal time between two periodic syncs of a pull mirror, in minutes.
+	MaxInterval = 7 * 24 * 60
+)
+
+// Service keeps pull mirrors in sync with their remote repositories.
+// Every minute the mirrors whose periodic sync is due are dispatched to background sync jobs.
+type Service struct {
+	scheduler       *job.Scheduler
+	executor        *job.Executor
+	pullMirrorStore store.PullMirrorStore
+	repoStore       store.RepoStore
+	secretStore     store.SecretStore
+	encrypter       encrypt.Encrypter
+	urlProvider     url.Provider
+	git             git.Interface
+}
+
+func NewService(
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	pullMirrorStore store.PullMirrorStore,
+	repoStore store.RepoStore,
+	secretStore store.SecretStore,
+	encrypter encrypt.Encrypter,
+	urlProvider url.Provider,
+	git git.Interface,
+) *Service {
+	return &Service{
+		scheduler:       scheduler,
+		executor:        executor,
+		pullMirrorStore: pullMirrorStore,
+		repoStore:       repoStore,
+		secretStore:     secretStore,
+		encrypter:       encrypter,
+		urlProvider:     urlProvider,
+		git:             git,
+	}
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if err := s.executor.Register(jobTypeDispatch, &dispatchJob{service: s}); err != nil {
+		return fmt.Errorf("failed to register pull mirror dispatch job handler: %w", err)
+	}
+
+	if err := s.executor.Register(jobTypeSync, &syncJob{service: s}); err != nil {
+		return fmt.Errorf("failed to register pull mirror sync job handler: %w", err)
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobTypeDispatch, jobTypeDispatch, jobCronDispatch, jobMaxDurationDispatch)
+	if err != nil {
+		return fmt.Errorf("failed to schedule pull mirror dispatch job: %w", err)
+	}
+
+	return nil
+}
+
+type syncJobInput struct {
+	RepoID int64 `json:"repo_id"`
+}
+
+// Sync starts a background job that syncs the pull mirror from its remote repository.
+func (s *Service) Sync(ctx context.Context, mirror *types.PullMirror) (*types.PullMirror, error) {
+	mirror, err := s.pullMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PullMirror) error {
+		mirror.Status = enum.PullMirrorStatusPending
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull mirror status: %w", err)
+	}
+
+	if err := s.runSyncJob(ctx, mirror.RepoID); err != nil {
+		return nil, err
+	}
+
+	return mirror, nil
+}
+
+func (s *Service) runSyncJob(ctx context.Context, repoID int64) error {
+	data, err := json.Marshal(syncJobInput{RepoID: repoID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal pull mirror sync job input: %w", err)
+	}
+
+	jobUID, err := job.UID()
+	if err != nil {
+		return fmt.Errorf("failed to generate pull mirror sync job UID: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:     "pull-mirror-sync-" + jobUID,
+		Type:    jobTypeSync,
+		Timeout: jobMaxDurationSync,
+		Data:    string(data),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run pull mirror sync job: %w", err)
+	}
+
+	return nil
+}
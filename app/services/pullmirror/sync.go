@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullmirror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/gitremote"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// syncJob fetches all branches and tags of a pull mirror from its remote repository.
+// References that no longer exist in the remote repository are removed.
+// The references are updated with a fetch, so no git hooks are executed and no events are produced.
+type syncJob struct {
+	service *Service
+}
+
+var _ job.Handler = (*syncJob)(nil)
+
+func (j *syncJob) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	s := j.service
+
+	var input syncJobInput
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal pull mirror sync job input: %w", err)
+	}
+
+	mirror, err := s.pullMirrorStore.Find(ctx, input.RepoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find pull mirror: %w", err)
+	}
+
+	mirror, err = s.pullMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PullMirror) error {
+		mirror.Status = enum.PullMirrorStatusRunning
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to update pull mirror status: %w", err)
+	}
+
+	password, errSync := s.sync(ctx, mirror)
+
+	status := enum.PullMirrorStatusSuccess
+	lastError := ""
+	if errSync != nil {
+		status = enum.PullMirrorStatusFailed
+		lastError = gitremote.Redact(errSync.Error(), password)
+
+		log.Ctx(ctx).Warn().Err(errors.New(lastError)).Int64("repo_id", mirror.RepoID).
+			Msg("failed to sync pull mirror")
+	}
+
+	_, err = s.pullMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PullMirror) error {
+		mirror.Status = status
+		mirror.LastSync = time.Now().UnixMilli()
+		mirror.LastError = lastError
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to update pull mirror status: %w", err)
+	}
+
+	return "", nil
+}
+
+// sync fetches the references of the pull mirror and updates the repository accordingly.
+// It returns the password used to access the remote repository, so that it can be redacted from errors.
+func (s *Service) sync(ctx context.Context, mirror *types.PullMirror) (string, error) {
+	repo, err := s.repoStore.Find(ctx, mirror.RepoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if repo.State != enum.RepoStateActive {
+		return "", fmt.Errorf("repository is not active")
+	}
+
+	password, err := gitremote.Password(ctx, s.secretStore, s.encrypter, mirror.SecretID)
+	if err != nil {
+		return "", err
+	}
+
+	source, err := gitremote.URL(mirror.URL, mirror.Username, password)
+	if err != nil {
+		return password, err
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, s.urlProvider,
+		bootstrap.NewSystemServiceSession(), repo)
+	if err != nil {
+		return password, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	// an empty repository follows the default branch of the remote repository.
+	defaultBranch := repo.DefaultBranch
+	if repo.IsEmpty {
+		defaultBranch = ""
+	}
+
+	out, err := s.git.SyncRepository(ctx, &git.SyncRepositoryParams{
+		WriteParams:       writeParams,
+		Source:            source,
+		CreateIfNotExists: false,
+		RefSpecs:          []string{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"},
+		DefaultBranch:     defaultBranch,
+	})
+	if err != nil {
+		return password, fmt.Errorf("failed to sync repository: %w", err)
+	}
+
+	_, err = s.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+		repo.IsEmpty = false
+		if out.DefaultBranch != "" {
+			repo.DefaultBranch = out.DefaultBranch
+		}
+		return nil
+	})
+	if err != nil {
+		return password, fmt.Errorf("failed to update repository: %w", err)
+	}
+
+	return password, nil
+}
@@ -80,9 +80,6 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 		log.Ctx(ctx).Warn().Err(err).Msgf("failed to get commit info from git")
 	}
 
-	// TODO: This function is currently executed directly on branch update event.
-	// TODO: But it should be executed after the PR's head ref has been updated.
-	// TODO: This is to make sure the commit exists on the target repository for forked repositories.
 	s.forEveryOpenPR(ctx, event.Payload.RepoID, event.Payload.Ref, func(pr *types.PullReq) error {
 		// First check if the merge base has changed
 
@@ -91,6 +88,19 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 			return fmt.Errorf("failed to get target repo git info: %w", err)
 		}
 
+		// For pull requests from forks the new commit must be present in the target repository.
+		if pr.SourceRepoID != pr.TargetRepoID {
+			writeParams, err := createSystemRPCWriteParams(ctx, s.urlProvider, targetRepo.ID, targetRepo.GitUID)
+			if err != nil {
+				return fmt.Errorf("failed to generate rpc write params: %w", err)
+			}
+
+			err = s.fetchSourceObjects(ctx, writeParams, pr.SourceRepoID, pr.TargetRepoID, event.Payload.NewSHA)
+			if err != nil {
+				return err
+			}
+		}
+
 		mergeBaseInfo, err := s.git.MergeBase(ctx, git.MergeBaseParams{
 			ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
 			Ref1:       event.Payload.NewSHA,
@@ -41,8 +41,12 @@ func (s *Service) createHeadRefOnCreated(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo).
-	// This is because commits from the source repository must be first pulled into the target repository.
+	err = s.fetchSourceObjects(ctx, writeParams,
+		event.Payload.SourceRepoID, event.Payload.TargetRepoID, event.Payload.SourceSHA)
+	if err != nil {
+		return err
+	}
+
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
@@ -72,8 +76,12 @@ func (s *Service) updateHeadRefOnBranchUpdate(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo)
-	// This is because commits from the source repository must be first pulled into the target repository.
+	err = s.fetchSourceObjects(ctx, writeParams,
+		event.Payload.SourceRepoID, event.Payload.TargetRepoID, event.Payload.NewSHA)
+	if err != nil {
+		return err
+	}
+
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
@@ -103,8 +111,12 @@ func (s *Service) updateHeadRefOnReopen(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo)
-	// This is because commits from the source repository must be first pulled into the target repository.
+	err = s.fetchSourceObjects(ctx, writeParams,
+		event.Payload.SourceRepoID, event.Payload.TargetRepoID, event.Payload.SourceSHA)
+	if err != nil {
+		return err
+	}
+
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
@@ -118,3 +130,32 @@ func (s *Service) updateHeadRefOnReopen(ctx context.Context,
 
 	return nil
 }
+
+// fetchSourceObjects copies the provided commit of a pull request opened from a fork to the target repository.
+// Commits of the source repository must be present in the target repository before the PR refs can point to them.
+func (s *Service) fetchSourceObjects(
+	ctx context.Context,
+	writeParams git.WriteParams,
+	sourceRepoID, targetRepoID int64,
+	commitSHA string,
+) error {
+	if sourceRepoID == targetRepoID {
+		return nil
+	}
+
+	sourceRepoGit, err := s.repoGitInfoCache.Get(ctx, sourceRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get source repo git info: %w", err)
+	}
+
+	err = s.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: sourceRepoGit.GitUID,
+		ObjectSHAs:    []sha.SHA{sha.Must(commitSHA)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch source commit to the target repository: %w", err)
+	}
+
+	return nil
+}
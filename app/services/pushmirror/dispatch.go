@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pushmirror
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const dispatchBatchSize = 100
+
+// dispatchJob starts the push jobs of all push mirrors whose periodic push is due.
+type dispatchJob struct {
+	service *Service
+}
+
+var _ job.Handler = (*dispatchJob)(nil)
+
+func (j *dispatchJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	s := j.service
+	now := time.Now()
+
+	mirrors, err := s.pushMirrorStore.ListDue(ctx, now.UnixMilli(), dispatchBatchSize)
+	if err != nil {
+		return "", fmt.Errorf("failed to list push mirrors due for push: %w", err)
+	}
+
+	for _, mirror := range mirrors {
+		prevStatus := mirror.Status
+		prevNextSync := mirror.NextSync
+
+		pending, err := s.pushMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PushMirror) error {
+			mirror.Status = enum.PushMirrorStatusPending
+			mirror.NextSync = nextSync(now, mirror.Interval)
+			return nil
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("push_mirror_id", mirror.ID).
+				Msg("failed to update next push time of push mirror")
+			continue
+		}
+
+		if err = s.runPushJob(ctx, mirror.ID); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("push_mirror_id", mirror.ID).
+				Msg("failed to start push mirror push")
+
+			// the push is retried by the next dispatch
+			s.revertPending(ctx, pending, prevStatus, prevNextSync)
+		}
+	}
+
+	return "", nil
+}
+
+// nextSync returns the time of the next periodic push to a push mirror.
+func nextSync(now time.Time, interval int64) int64 {
+	return now.Add(time.Duration(interval) * time.Minute).UnixMilli()
+}
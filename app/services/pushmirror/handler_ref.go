@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pushmirror
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (s *Service) handleEventBranchCreated(ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload]) error {
+	return s.pushRef(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
+	return s.pushRef(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventBranchDeleted(ctx context.Context,
+	event *events.Event[*gitevents.BranchDeletedPayload]) error {
+	return s.pushRef(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventTagCreated(ctx context.Context,
+	event *events.Event[*gitevents.TagCreatedPayload]) error {
+	return s.pushRef(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventTagUpdated(ctx context.Context,
+	event *events.Event[*gitevents.TagUpdatedPayload]) error {
+	return s.pushRef(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventTagDeleted(ctx context.Context,
+	event *events.Event[*gitevents.TagDeletedPayload]) error {
+	return s.pushRef(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+// pushRef starts a push to all push mirrors of the repository that have the push trigger
+// and whose reference filter matches the changed reference.
+// Mirrors with a push that hasn't started yet are skipped, the pending push will include the change.
+// Pushes that have been pending for too long are considered lost and aren't waited for.
+func (s *Service) pushRef(ctx context.Context, repoID int64, ref string) error {
+	mirrors, err := s.pushMirrorStore.List(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to list push mirrors: %w", err)
+	}
+
+	now := time.Now()
+
+	for _, mirror := range mirrors {
+		if !mirror.Enabled ||
+			mirror.Trigger != enum.PushMirrorTriggerPush ||
+			isPending(mirror, now) {
+			continue
+		}
+
+		if mirror.RefFilter != "" {
+			refFilter, err := regexp.Compile(mirror.RefFilter)
+			if err != nil || !refFilter.MatchString(ref) {
+				continue
+			}
+		}
+
+		if _, err = s.Push(ctx, mirror); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("push_mirror_id", mirror.ID).
+				Msg("failed to start push to push mirror")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pushmirror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/gitremote"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// defaultRefFilter selects all branches and tags, it's used for push mirrors without a reference filter.
+const defaultRefFilter = "^refs/(heads|tags)/"
+
+// pushJob force pushes the matching branches and tags of a repository to a push mirror.
+// Matching branches and tags that no longer exist in the repository are deleted from the remote repository.
+// A failed push returns an error, so that the job gets retried.
+type pushJob struct {
+	service *Service
+}
+
+var _ job.Handler = (*pushJob)(nil)
+
+func (j *pushJob) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	s := j.service
+
+	var input pushJobInput
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal push mirror job input: %w", err)
+	}
+
+	mirror, err := s.pushMirrorStore.Find(ctx, input.MirrorID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the push mirror has been deleted in the meantime.
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find push mirror: %w", err)
+	}
+
+	if !mirror.Enabled {
+		return "", nil
+	}
+
+	mirror, err = s.pushMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PushMirror) error {
+		mirror.Status = enum.PushMirrorStatusRunning
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to update push mirror status: %w", err)
+	}
+
+	password, errPush := s.push(ctx, mirror)
+
+	status := enum.PushMirrorStatusSuccess
+	lastError := ""
+	if errPush != nil {
+		status = enum.PushMirrorStatusFailed
+		lastError = gitremote.Redact(errPush.Error(), password)
+	}
+
+	_, err = s.pushMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PushMirror) error {
+		mirror.Status = status
+		mirror.LastError = lastError
+		if status == enum.PushMirrorStatusSuccess {
+			mirror.LastSuccess = time.Now().UnixMilli()
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to update push mirror status: %w", err)
+	}
+
+	if errPush != nil {
+		log.Ctx(ctx).Warn().Str("error", lastError).Int64("push_mirror_id", mirror.ID).
+			Msg("failed to push to push mirror")
+
+		return "", errors.New(lastError)
+	}
+
+	return "", nil
+}
+
+// push pushes the matching references of the repository to the push mirror.
+// It returns the password used to access the remote repository, so that it can be redacted from errors.
+func (s *Service) push(ctx context.Context, mirror *types.PushMirror) (string, error) {
+	repo, err := s.repoStore.Find(ctx, mirror.RepoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if repo.IsEmpty {
+		return "", nil
+	}
+
+	password, err := gitremote.Password(ctx, s.secretStore, s.encrypter, mirror.SecretID)
+	if err != nil {
+		return "", err
+	}
+
+	remoteURL, err := gitremote.URL(mirror.URL, mirror.Username, password)
+	if err != nil {
+		return password, err
+	}
+
+	refFilter := mirror.RefFilter
+	if refFilter == "" {
+		refFilter = defaultRefFilter
+	}
+
+	err = s.git.PushRemote(ctx, &git.PushRemoteParams{
+		ReadParams: git.CreateReadParams(repo),
+		RemoteURL:  remoteURL,
+		RefFilter:  refFilter,
+	})
+	if err != nil {
+		return password, fmt.Errorf("failed to push to remote repository: %w", err)
+	}
+
+	return password, nil
+}
@@ -0,0 +1,242 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pushmirror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const groupGitEvents = "gitness:pushmirror"
+
+const (
+	jobTypeDispatch        = "gitness:push-mirror-dispatch"
+	jobCronDispatch        = "* * * * *" // Every minute.
+	jobMaxDurationDispatch = time.Minute
+
+	jobTypePush        = "gitness:push-mirror-push"
+	jobMaxDurationPush = 45 * time.Minute
+	jobMaxRetriesPush  = 3
+
+	// pendingTimeout is the time after which a push that hasn't started is considered lost,
+	// for example if the instance was shut down before the push job could run.
+	pendingTimeout = 15 * time.Minute
+)
+
+const (
+	// DefaultInterval is the default time between two periodic pushes to a push mirror, in minutes.
+	DefaultInterval = 60
+	// MinInterval is the minimal time between two periodic pushes to a push mirror, in minutes.
+	MinInterval = 10
+	// MaxInterval is the maximal time between two periodic pushes to a push mirror, in minutes.
+	MaxInterval = 7 * 24 * 60
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	return nil
+}
+
+// Service keeps push mirrors up to date with the branches and tags of their repositories.
+// Push mirrors with the push trigger are updated when a matching branch or tag changes,
+// the ones with the interval trigger are updated periodically. Failed pushes are retried a few times.
+type Service struct {
+	scheduler       *job.Scheduler
+	executor        *job.Executor
+	pushMirrorStore store.PushMirrorStore
+	repoStore       store.RepoStore
+	secretStore     store.SecretStore
+	encrypter       encrypt.Encrypter
+	git             git.Interface
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	pushMirrorStore store.PushMirrorStore,
+	repoStore store.RepoStore,
+	secretStore store.SecretStore,
+	encrypter encrypt.Encrypter,
+	git git.Interface,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided push mirror service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		scheduler:       scheduler,
+		executor:        executor,
+		pushMirrorStore: pushMirrorStore,
+		repoStore:       repoStore,
+		secretStore:     secretStore,
+		encrypter:       encrypter,
+		git:             git,
+	}
+
+	_, err := gitReaderFactory.Launch(ctx, groupGitEvents, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterBranchCreated(service.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+			_ = r.RegisterBranchDeleted(service.handleEventBranchDeleted)
+			_ = r.RegisterTagCreated(service.handleEventTagCreated)
+			_ = r.RegisterTagUpdated(service.handleEventTagUpdated)
+			_ = r.RegisterTagDeleted(service.handleEventTagDeleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for push mirrors: %w", err)
+	}
+
+	return service, nil
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if err := s.executor.Register(jobTypeDispatch, &dispatchJob{service: s}); err != nil {
+		return fmt.Errorf("failed to register push mirror dispatch job handler: %w", err)
+	}
+
+	if err := s.executor.Register(jobTypePush, &pushJob{service: s}); err != nil {
+		return fmt.Errorf("failed to register push mirror push job handler: %w", err)
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobTypeDispatch, jobTypeDispatch, jobCronDispatch, jobMaxDurationDispatch)
+	if err != nil {
+		return fmt.Errorf("failed to schedule push mirror dispatch job: %w", err)
+	}
+
+	return nil
+}
+
+type pushJobInput struct {
+	MirrorID int64 `json:"mirror_id"`
+}
+
+// Push starts a background job that pushes the branches and tags of the repository to the push mirror.
+func (s *Service) Push(ctx context.Context, mirror *types.PushMirror) (*types.PushMirror, error) {
+	prevStatus := mirror.Status
+
+	mirror, err := s.pushMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PushMirror) error {
+		mirror.Status = enum.PushMirrorStatusPending
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update push mirror status: %w", err)
+	}
+
+	if err := s.runPushJob(ctx, mirror.ID); err != nil {
+		s.revertPending(ctx, mirror, prevStatus, mirror.NextSync)
+		return nil, err
+	}
+
+	return mirror, nil
+}
+
+// revertPending restores the status of a push mirror whose push job couldn't be started,
+// otherwise the push mirror would stay pending and later changes wouldn't be pushed to it.
+func (s *Service) revertPending(
+	ctx context.Context,
+	mirror *types.PushMirror,
+	status enum.PushMirrorStatus,
+	nextSync int64,
+) {
+	_, err := s.pushMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PushMirror) error {
+		if mirror.Status != enum.PushMirrorStatusPending {
+			return nil // a push has started in the meantime
+		}
+
+		mirror.Status = status
+		mirror.NextSync = nextSync
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("push_mirror_id", mirror.ID).
+			Msg("failed to revert pending status of push mirror")
+	}
+}
+
+// isPending returns true if a push to the push mirror has been requested recently, but hasn't started yet.
+func isPending(mirror *types.PushMirror, now time.Time) bool {
+	return mirror.Status == enum.PushMirrorStatusPending &&
+		now.Sub(time.UnixMilli(mirror.Updated)) < pendingTimeout
+}
+
+func (s *Service) runPushJob(ctx context.Context, mirrorID int64) error {
+	data, err := json.Marshal(pushJobInput{MirrorID: mirrorID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal push mirror job input: %w", err)
+	}
+
+	jobUID, err := job.UID()
+	if err != nil {
+		return fmt.Errorf("failed to generate push mirror job UID: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        "push-mirror-" + jobUID,
+		Type:       jobTypePush,
+		MaxRetries: jobMaxRetriesPush,
+		Timeout:    jobMaxDurationPush,
+		Data:       string(data),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run push mirror job: %w", err)
+	}
+
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -98,6 +99,15 @@ func (s *Service) parseRule(rule *types.Rule) (protection.Protection, error) {
 	return protection, nil
 }
 
+// auditResourceType returns the audit log resource type of a protection rule.
+func auditResourceType(ruleType types.RuleType) audit.ResourceType {
+	if ruleType == protection.TypeTag {
+		return audit.ResourceTypeTagRule
+	}
+
+	return audit.ResourceTypeBranchRule
+}
+
 func (s *Service) sendSSE(
 	ctx context.Context,
 	parentID int64,
@@ -133,7 +133,7 @@ func (s *Service) Create(ctx context.Context,
 
 	err = s.auditService.Log(ctx,
 		*principal,
-		audit.NewResource(audit.ResourceTypeBranchRule, rule.Identifier, nameKey, scopeIdentifier),
+		audit.NewResource(auditResourceType(rule.Type), rule.Identifier, nameKey, scopeIdentifier),
 		audit.ActionCreated,
 		paths.Parent(path),
 		audit.WithNewObject(rule),
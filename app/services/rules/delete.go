@@ -52,7 +52,7 @@ func (s *Service) Delete(ctx context.Context,
 	err = s.auditService.Log(ctx,
 		*principal,
 		audit.NewResource(
-			audit.ResourceTypeBranchRule,
+			auditResourceType(rule.Type),
 			rule.Identifier,
 			nameKey,
 			scopeIdentifier,
@@ -153,7 +153,7 @@ func (s *Service) Update(ctx context.Context,
 	}
 	err = s.auditService.Log(ctx,
 		*principal,
-		audit.NewResource(audit.ResourceTypeBranchRule, rule.Identifier, nameKey, scopeIdentifier),
+		audit.NewResource(auditResourceType(rule.Type), rule.Identifier, nameKey, scopeIdentifier),
 		audit.ActionUpdated,
 		paths.Parent(path),
 		audit.WithOldObject(oldRule),
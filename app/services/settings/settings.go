@@ -24,4 +24,13 @@ var (
 	DefaultFileSizeLimit             = int64(1e+8) // 100 MB
 	KeyInstallID                 Key = "install_id"
 	DefaultInstallID                 = string("")
+	// KeyLocalLoginDisabled [bool] disables the login with local passwords if set to true.
+	KeyLocalLoginDisabled     Key = "local_login_disabled"
+	DefaultLocalLoginDisabled     = false
+	// KeyForkPullReqPipelinesEnabled [bool] allows pull requests from forks to trigger pipelines if set to true.
+	KeyForkPullReqPipelinesEnabled     Key = "fork_pullreq_pipelines_enabled"
+	DefaultForkPullReqPipelinesEnabled     = false
+	// KeyForkPullReqSecretsEnabled [bool] exposes secrets to pipelines of pull requests from forks if set to true.
+	KeyForkPullReqSecretsEnabled     Key = "fork_pullreq_secrets_enabled"
+	DefaultForkPullReqSecretsEnabled     = false
 )
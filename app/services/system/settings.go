@@ -24,23 +24,26 @@ import (
 )
 
 type Settings struct {
-	InstallID *string `json:"install_id" yaml:"install_id"`
+	InstallID          *string `json:"install_id" yaml:"install_id"`
+	LocalLoginDisabled *bool   `json:"local_login_disabled" yaml:"local_login_disabled"`
 }
 
 func getDefaultSystemSettings() *Settings {
 	return &Settings{
-		InstallID: ptr.String(settings.DefaultInstallID),
+		InstallID:          ptr.String(settings.DefaultInstallID),
+		LocalLoginDisabled: ptr.Bool(settings.DefaultLocalLoginDisabled),
 	}
 }
 
 func getSystemSettingsMappings(s *Settings) []settings.SettingHandler {
 	return []settings.SettingHandler{
 		settings.Mapping(settings.KeyInstallID, s.InstallID),
+		settings.Mapping(settings.KeyLocalLoginDisabled, s.LocalLoginDisabled),
 	}
 }
 
 func getSystemSettingsAsKeyValues(s *Settings) []settings.KeyValue {
-	kvs := make([]settings.KeyValue, 0, 1)
+	kvs := make([]settings.KeyValue, 0, 2)
 
 	if s.InstallID != nil {
 		kvs = append(kvs, settings.KeyValue{
@@ -48,6 +51,13 @@ func getSystemSettingsAsKeyValues(s *Settings) []settings.KeyValue {
 			Value: s.InstallID,
 		})
 	}
+
+	if s.LocalLoginDisabled != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyLocalLoginDisabled,
+			Value: s.LocalLoginDisabled,
+		})
+	}
 	return kvs
 }
 
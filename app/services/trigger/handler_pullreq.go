@@ -21,8 +21,11 @@ import (
 	"github.com/harness/gitness/app/bootstrap"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 func (s *Service) handleEventPullReqCreated(ctx context.Context,
@@ -37,7 +40,7 @@ func (s *Service) handleEventPullReqCreated(ctx context.Context,
 	if err != nil {
 		return fmt.Errorf("could not augment pull request info: %w", err)
 	}
-	return s.trigger(ctx, event.Payload.SourceRepoID, enum.TriggerActionPullReqCreated, hook)
+	return s.triggerPullReq(ctx, event.Payload.Base, enum.TriggerActionPullReqCreated, hook)
 }
 
 func (s *Service) handleEventPullReqReopened(ctx context.Context,
@@ -52,7 +55,7 @@ func (s *Service) handleEventPullReqReopened(ctx context.Context,
 	if err != nil {
 		return fmt.Errorf("could not augment pull request info: %w", err)
 	}
-	return s.trigger(ctx, event.Payload.SourceRepoID, enum.TriggerActionPullReqReopened, hook)
+	return s.triggerPullReq(ctx, event.Payload.Base, enum.TriggerActionPullReqReopened, hook)
 }
 
 func (s *Service) handleEventPullReqBranchUpdated(ctx context.Context,
@@ -67,7 +70,7 @@ func (s *Service) handleEventPullReqBranchUpdated(ctx context.Context,
 	if err != nil {
 		return fmt.Errorf("could not augment pull request info: %w", err)
 	}
-	return s.trigger(ctx, event.Payload.SourceRepoID, enum.TriggerActionPullReqBranchUpdated, hook)
+	return s.triggerPullReq(ctx, event.Payload.Base, enum.TriggerActionPullReqBranchUpdated, hook)
 }
 
 func (s *Service) handleEventPullReqClosed(ctx context.Context,
@@ -82,7 +85,7 @@ func (s *Service) handleEventPullReqClosed(ctx context.Context,
 	if err != nil {
 		return fmt.Errorf("could not augment pull request info: %w", err)
 	}
-	return s.trigger(ctx, event.Payload.SourceRepoID, enum.TriggerActionPullReqClosed, hook)
+	return s.triggerPullReq(ctx, event.Payload.Base, enum.TriggerActionPullReqClosed, hook)
 }
 
 func (s *Service) handleEventPullReqMerged(
@@ -99,7 +102,28 @@ func (s *Service) handleEventPullReqMerged(
 	if err != nil {
 		return fmt.Errorf("could not augment pull request info: %w", err)
 	}
-	return s.trigger(ctx, event.Payload.SourceRepoID, enum.TriggerActionPullReqMerged, hook)
+	return s.triggerPullReq(ctx, event.Payload.Base, enum.TriggerActionPullReqMerged, hook)
+}
+
+// handleEventPullReqMergeQueueCheck triggers pipelines for the speculative merge commit of a queued pull request.
+// The pipelines are run in the target repository because that's where the merge commit is.
+func (s *Service) handleEventPullReqMergeQueueCheck(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergeQueueCheckPayload],
+) error {
+	hook := &triggerer.Hook{
+		Trigger:     enum.TriggerHook,
+		Action:      enum.TriggerActionPullReqMergeQueue,
+		TriggeredBy: bootstrap.NewSystemServiceSession().Principal.ID,
+		After:       event.Payload.MergeSHA,
+	}
+	err := s.augmentPullReqInfo(ctx, hook, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("could not augment pull request info: %w", err)
+	}
+	hook.Before = event.Payload.BaseSHA
+	hook.Ref = event.Payload.Ref
+	return s.trigger(ctx, event.Payload.TargetRepoID, enum.TriggerActionPullReqMergeQueue, hook)
 }
 
 // augmentPullReqInfo adds in information into the hook pertaining to the pull request
@@ -120,9 +144,52 @@ func (s *Service) augmentPullReqInfo(
 	hook.AuthorEmail = pullreq.Author.Email
 	hook.Message = pullreq.Description
 	hook.Before = pullreq.MergeBaseSHA
+	// not all pull request events provide the commit, default to the latest commit of the source branch.
+	if hook.After == "" {
+		hook.After = pullreq.SourceSHA
+	}
 	hook.Target = pullreq.TargetBranch
 	hook.Source = pullreq.SourceBranch
 	// expand the branch to a git reference.
 	hook.Ref = fmt.Sprintf("refs/pullreq/%d/head", pullreq.Number)
+	if pullreq.SourceRepoID != pullreq.TargetRepoID {
+		sourceRepo, err := s.repoStore.Find(ctx, pullreq.SourceRepoID)
+		if err != nil {
+			return fmt.Errorf("could not find source repository: %w", err)
+		}
+		hook.Fork = sourceRepo.Path
+	}
 	return nil
 }
+
+// triggerPullReq triggers the pipelines for a pull request event.
+// Pull requests opened from a fork run the pipelines of the target repository,
+// because the pull request and its head ref live there, unless that's disabled in the target repository.
+func (s *Service) triggerPullReq(
+	ctx context.Context,
+	base pullreqevents.Base,
+	action enum.TriggerAction,
+	hook *triggerer.Hook,
+) error {
+	if base.SourceRepoID == base.TargetRepoID {
+		return s.trigger(ctx, base.SourceRepoID, action, hook)
+	}
+
+	enabled, err := settings.RepoGet(
+		ctx,
+		s.settings,
+		base.TargetRepoID,
+		settings.KeyForkPullReqPipelinesEnabled,
+		settings.DefaultForkPullReqPipelinesEnabled,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to check settings whether pipelines are enabled for forks: %w", err)
+	}
+	if !enabled {
+		log.Ctx(ctx).Debug().Msgf("pipelines for pull requests from forks are disabled, skipping pull request #%d",
+			base.Number)
+		return nil
+	}
+
+	return s.trigger(ctx, base.TargetRepoID, action, hook)
+}
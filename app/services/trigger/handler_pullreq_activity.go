@@ -0,0 +1,173 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Pipeline environment variables exposing the pull request activity that triggered the execution.
+const (
+	EnvPullReqComment        = "DRONE_PULL_REQUEST_COMMENT"
+	EnvPullReqCommentID      = "DRONE_PULL_REQUEST_COMMENT_ID"
+	EnvPullReqReviewer       = "DRONE_PULL_REQUEST_REVIEWER"
+	EnvPullReqReviewDecision = "DRONE_PULL_REQUEST_REVIEW_DECISION"
+	EnvPullReqLabel          = "DRONE_PULL_REQUEST_LABEL"
+	EnvPullReqLabelValue     = "DRONE_PULL_REQUEST_LABEL_VALUE"
+)
+
+func (s *Service) handleEventPullReqCommentCreated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.CommentCreatedPayload],
+) error {
+	activity, err := s.activityStore.Find(ctx, event.Payload.ActivityID)
+	if err != nil {
+		return fmt.Errorf("could not find pull request activity: %w", err)
+	}
+
+	hook, err := s.pullReqActivityHook(ctx, enum.TriggerActionPullReqCommentCreated,
+		event.Payload.Base, event.Payload.PrincipalID)
+	if err != nil {
+		return err
+	}
+	hook.After = event.Payload.SourceSHA
+	hook.Params = map[string]string{
+		EnvPullReqComment:   activity.Text,
+		EnvPullReqCommentID: fmt.Sprint(activity.ID),
+	}
+
+	return s.triggerPullReq(ctx, event.Payload.Base, enum.TriggerActionPullReqCommentCreated, hook)
+}
+
+func (s *Service) handleEventPullReqReviewSubmitted(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
+) error {
+	hook, err := s.pullReqActivityHook(ctx, enum.TriggerActionPullReqReviewSubmitted,
+		event.Payload.Base, event.Payload.ReviewerID)
+	if err != nil {
+		return err
+	}
+	hook.Params = map[string]string{
+		EnvPullReqReviewer:       hook.Sender,
+		EnvPullReqReviewDecision: string(event.Payload.Decision),
+	}
+
+	return s.triggerPullReq(ctx, event.Payload.Base, enum.TriggerActionPullReqReviewSubmitted, hook)
+}
+
+func (s *Service) handleEventPullReqLabelAssigned(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.LabelAssignedPayload],
+) error {
+	return s.triggerForLabel(ctx, enum.TriggerActionPullReqLabelAssigned,
+		event.Payload.Base, event.Payload.LabelID, event.Payload.ValueID)
+}
+
+func (s *Service) handleEventPullReqLabelUnassigned(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.LabelUnassignedPayload],
+) error {
+	return s.triggerForLabel(ctx, enum.TriggerActionPullReqLabelUnassigned,
+		event.Payload.Base, event.Payload.LabelID, event.Payload.ValueID)
+}
+
+func (s *Service) triggerForLabel(
+	ctx context.Context,
+	action enum.TriggerAction,
+	base pullreqevents.Base,
+	labelID int64,
+	valueID *int64,
+) error {
+	label, err := s.labelStore.FindByID(ctx, labelID)
+	if err != nil {
+		return fmt.Errorf("could not find label: %w", err)
+	}
+
+	var value string
+	if valueID != nil {
+		labelValue, err := s.labelValueStore.FindByID(ctx, *valueID)
+		if err != nil {
+			return fmt.Errorf("could not find label value: %w", err)
+		}
+		value = labelValue.Value
+	}
+
+	hook, err := s.pullReqActivityHook(ctx, action, base, base.PrincipalID)
+	if err != nil {
+		return err
+	}
+	hook.Params = map[string]string{
+		EnvPullReqLabel:      label.Key,
+		EnvPullReqLabelValue: value,
+	}
+
+	return s.triggerPullReq(ctx, base, action, hook)
+}
+
+// pullReqActivityHook creates the hook for an activity on a pull request, sent by the provided principal.
+func (s *Service) pullReqActivityHook(
+	ctx context.Context,
+	action enum.TriggerAction,
+	base pullreqevents.Base,
+	senderID int64,
+) (*triggerer.Hook, error) {
+	hook := &triggerer.Hook{
+		Trigger:     enum.TriggerHook,
+		Action:      action,
+		TriggeredBy: bootstrap.NewSystemServiceSession().Principal.ID,
+	}
+
+	err := s.augmentPullReqInfo(ctx, hook, base.PullReqID)
+	if err != nil {
+		return nil, fmt.Errorf("could not augment pull request info: %w", err)
+	}
+
+	sender, err := s.principalInfoCache.Get(ctx, senderID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find principal: %w", err)
+	}
+	hook.Sender = sender.UID
+
+	return hook, nil
+}
+
+// matchesCommentPattern returns whether the pull request comment that triggered the hook
+// matches the comment pattern of the trigger.
+func matchesCommentPattern(ctx context.Context, t *types.Trigger, hook *triggerer.Hook) bool {
+	if hook.Action != enum.TriggerActionPullReqCommentCreated || t.CommentPattern == "" {
+		return true
+	}
+
+	pattern, err := regexp.Compile(t.CommentPattern)
+	if err != nil {
+		// the pattern is validated when the trigger is saved, so this shouldn't happen.
+		log.Ctx(ctx).Warn().Err(err).Msgf("invalid comment pattern of trigger %d", t.ID)
+		return false
+	}
+
+	return pattern.MatchString(hook.Params[EnvPullReqComment])
+}
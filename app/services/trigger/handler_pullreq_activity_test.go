@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestMatchesCommentPattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  enum.TriggerAction
+		pattern string
+		comment string
+		want    bool
+	}{
+		{name: "no pattern", action: enum.TriggerActionPullReqCommentCreated, comment: "lgtm", want: true},
+		{name: "match", action: enum.TriggerActionPullReqCommentCreated, pattern: `^/retest\b`,
+			comment: "/retest please", want: true},
+		{name: "no match", action: enum.TriggerActionPullReqCommentCreated, pattern: `^/retest\b`,
+			comment: "please /retest", want: false},
+		{name: "other action", action: enum.TriggerActionPullReqCreated, pattern: `^/retest\b`, want: true},
+		{name: "invalid pattern", action: enum.TriggerActionPullReqCommentCreated, pattern: `(`,
+			comment: "(", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hook := &triggerer.Hook{
+				Action: test.action,
+				Params: map[string]string{EnvPullReqComment: test.comment},
+			}
+
+			got := matchesCommentPattern(context.Background(), &types.Trigger{CommentPattern: test.pattern}, hook)
+			if got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
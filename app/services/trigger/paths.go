@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// changedFiles returns the files changed between the before and the after commit of the hook.
+// For pull requests the before commit is the merge base, hence only the changes of the pull request are returned.
+// The second return value is false if the changed files can't be determined, for example for newly created branches
+// or tags, in which case the path filters aren't applied.
+func (s *Service) changedFiles(
+	ctx context.Context,
+	repoID int64,
+	hook *triggerer.Hook,
+) ([]string, bool, error) {
+	switch hook.Action.GetTriggerEvent() {
+	case enum.TriggerEventPush, enum.TriggerEventPullRequest:
+	case enum.TriggerEventCron, enum.TriggerEventManual, enum.TriggerEventTag:
+		return nil, false, nil
+	}
+
+	if hook.Before == "" || hook.After == "" || hook.Before == hook.After || hook.Before == sha.Nil.String() {
+		return nil, false, nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	out, err := s.git.DiffFileNames(ctx, &git.DiffParams{
+		ReadParams: git.CreateReadParams(repo),
+		BaseRef:    hook.Before,
+		HeadRef:    hook.After,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get changed files: %w", err)
+	}
+
+	return out.Files, true, nil
+}
+
+// matchesPaths returns true if at least one of the changed files matches the path filter:
+// the file must match one of the include patterns (if there are any) and none of the exclude patterns.
+func matchesPaths(filter types.TriggerPathFilter, files []string) bool {
+	if filter.IsEmpty() {
+		return true
+	}
+
+	for _, file := range files {
+		if matchesAnyPath(filter.Exclude, file) {
+			continue
+		}
+		if len(filter.Include) == 0 || matchesAnyPath(filter.Include, file) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesAnyPath(patterns []string, file string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := doublestar.Match(pattern, file); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// pathsSkipReason returns the reason stored with the execution skipped due to the path filter of the trigger.
+func pathsSkipReason(t *types.Trigger, files []string) string {
+	return fmt.Sprintf("Skipped by trigger %q: none of the %d changed files match its path filters.",
+		t.Identifier, len(files))
+}
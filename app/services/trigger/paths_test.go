@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestMatchesPaths(t *testing.T) {
+	files := []string{"services/api/main.go", "docs/README.md"}
+
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		files   []string
+		want    bool
+	}{
+		{name: "no filter", files: files, want: true},
+		{name: "no filter, no files", want: true},
+		{name: "include match", include: []string{"services/api/**"}, files: files, want: true},
+		{name: "include no match", include: []string{"services/web/**"}, files: files, want: false},
+		{name: "exclude all", exclude: []string{"**/*.go", "docs/**"}, files: files, want: false},
+		{name: "exclude some", exclude: []string{"docs/**"}, files: files, want: true},
+		{name: "include and exclude", include: []string{"services/**"}, exclude: []string{"**/*.go"},
+			files: files, want: false},
+		{name: "filter, no files", include: []string{"**"}, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := types.TriggerPathFilter{Include: test.include, Exclude: test.exclude}
+			if got := matchesPaths(filter, test.files); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
@@ -24,13 +24,16 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/stream"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog/log"
 )
 
 const (
@@ -67,6 +70,13 @@ type Service struct {
 	pipelineStore store.PipelineStore
 	triggerSvc    triggerer.Triggerer
 	commitSvc     commit.Service
+
+	activityStore      store.PullReqActivityStore
+	labelStore         store.LabelStore
+	labelValueStore    store.LabelValueStore
+	principalInfoCache store.PrincipalInfoCache
+	git                git.Interface
+	settings           *settings.Service
 }
 
 func New(
@@ -80,6 +90,12 @@ func New(
 	commitSvc commit.Service,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	activityStore store.PullReqActivityStore,
+	labelStore store.LabelStore,
+	labelValueStore store.LabelValueStore,
+	principalInfoCache store.PrincipalInfoCache,
+	git git.Interface,
+	settings *settings.Service,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided trigger service config is invalid: %w", err)
@@ -92,6 +108,13 @@ func New(
 		commitSvc:     commitSvc,
 		pipelineStore: pipelineStore,
 		triggerSvc:    triggerSvc,
+
+		activityStore:      activityStore,
+		labelStore:         labelStore,
+		labelValueStore:    labelValueStore,
+		principalInfoCache: principalInfoCache,
+		git:                git,
+		settings:           settings,
 	}
 
 	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
@@ -132,6 +155,12 @@ func New(
 			_ = r.RegisterReopened(service.handleEventPullReqReopened)
 			_ = r.RegisterClosed(service.handleEventPullReqClosed)
 			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+			_ = r.RegisterMergeQueueCheck(service.handleEventPullReqMergeQueueCheck)
+
+			_ = r.RegisterCommentCreated(service.handleEventPullReqCommentCreated)
+			_ = r.RegisterReviewSubmitted(service.handleEventPullReqReviewSubmitted)
+			_ = r.RegisterLabelAssigned(service.handleEventPullReqLabelAssigned)
+			_ = r.RegisterLabelUnassigned(service.handleEventPullReqLabelUnassigned)
 
 			return nil
 		})
@@ -158,12 +187,29 @@ func (s *Service) trigger(ctx context.Context, repoID int64,
 	for _, t := range ret {
 		for _, a := range t.Actions {
 			if a == action {
-				validTriggers = append(validTriggers, t)
+				if matchesCommentPattern(ctx, t, hook) {
+					validTriggers = append(validTriggers, t)
+				}
 				break
 			}
 		}
 	}
 
+	// The changed files are needed only if any of the triggers has a path filter.
+	var changedFiles []string
+	var changesKnown bool
+	for _, t := range validTriggers {
+		if t.Paths.IsEmpty() {
+			continue
+		}
+		changedFiles, changesKnown, err = s.changedFiles(ctx, repoID, hook)
+		if err != nil {
+			// run the pipelines rather than silently skipping them
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to get changed files, trigger path filters are ignored")
+		}
+		break
+	}
+
 	var errs error
 	for _, t := range validTriggers {
 		// TODO: We can make a minor optimization here to not fetch a pipeline each time
@@ -179,6 +225,14 @@ func (s *Service) trigger(ctx context.Context, repoID int64,
 			continue
 		}
 
+		if changesKnown && !matchesPaths(t.Paths, changedFiles) {
+			_, err = s.triggerSvc.Skip(ctx, pipeline, hook, pathsSkipReason(t, changedFiles))
+			if err != nil {
+				errs = multierror.Append(errs, err)
+			}
+			continue
+		}
+
 		_, err = s.triggerSvc.Trigger(ctx, pipeline, hook)
 		if err != nil {
 			errs = multierror.Append(errs, err)
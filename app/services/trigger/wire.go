@@ -21,8 +21,10 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
 
 	"github.com/google/wire"
 )
@@ -42,7 +44,14 @@ func ProvideService(
 	triggerSvc triggerer.Triggerer,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	pullReqEvFactory *events.ReaderFactory[*pullreqevents.Reader],
+	activityStore store.PullReqActivityStore,
+	labelStore store.LabelStore,
+	labelValueStore store.LabelValueStore,
+	principalInfoCache store.PrincipalInfoCache,
+	git git.Interface,
+	settings *settings.Service,
 ) (*Service, error) {
 	return New(ctx, config, triggerStore, pullReqStore, repoStore, pipelineStore, triggerSvc,
-		commitSvc, gitReaderFactory, pullReqEvFactory)
+		commitSvc, gitReaderFactory, pullReqEvFactory, activityStore, labelStore, labelValueStore,
+		principalInfoCache, git, settings)
 }
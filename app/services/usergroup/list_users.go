@@ -22,13 +22,13 @@ import (
 )
 
 func (s *searchService) ListUsers(
-	_ context.Context,
+	ctx context.Context,
 	_ *auth.Session,
-	_ *types.UserGroup,
+	userGroup *types.UserGroup,
 ) ([]string, error) {
-	return nil, nil
+	return s.memberStore.ListPrincipalUIDs(ctx, userGroup.ID)
 }
 
-func (s *searchService) ListUserIDsByGroupIDs(_ context.Context, _ []int64) ([]int64, error) {
-	return nil, nil
+func (s *searchService) ListUserIDsByGroupIDs(ctx context.Context, userGroupIDs []int64) ([]int64, error) {
+	return s.memberStore.ListPrincipalIDs(ctx, userGroupIDs)
 }
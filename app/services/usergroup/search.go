@@ -18,14 +18,18 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 )
 
 type searchService struct {
+	memberStore store.UserGroupMemberStore
 }
 
-func NewSearchService() SearchService {
-	return &searchService{}
+func NewSearchService(memberStore store.UserGroupMemberStore) SearchService {
+	return &searchService{
+		memberStore: memberStore,
+	}
 }
 
 func (s *searchService) Search(
@@ -15,6 +15,8 @@
 package usergroup
 
 import (
+	"github.com/harness/gitness/app/store"
+
 	"github.com/google/wire"
 )
 
@@ -28,6 +30,6 @@ func ProvideUserGroupResolver() Resolver {
 	return NewGitnessResolver()
 }
 
-func ProvideSearchService() SearchService {
-	return NewSearchService()
+func ProvideSearchService(memberStore store.UserGroupMemberStore) SearchService {
+	return NewSearchService(memberStore)
 }
@@ -93,6 +93,16 @@ func CheckSecret(secret string) error {
 	return nil
 }
 
+// CheckMaxRetries validates the number of automatic redeliveries of a webhook.
+func CheckMaxRetries(maxRetries int) error {
+	if maxRetries < 0 || maxRetries > MaxRetriesLimit {
+		return check.NewValidationErrorf("The max retries of a webhook have to be between 0 and %d.",
+			MaxRetriesLimit)
+	}
+
+	return nil
+}
+
 // CheckTriggers validates the triggers of a webhook.
 func CheckTriggers(triggers []enum.WebhookTrigger) error {
 	// ignore duplicates here, should be deduplicated later
@@ -65,7 +65,15 @@ func (s *Service) sanitizeCreateInput(in *types.WebhookCreateInput, internal boo
 	if err := CheckSecret(in.Secret); err != nil {
 		return err
 	}
-	if err := CheckTriggers(in.Triggers); err != nil { //nolint:revive
+	if err := CheckTriggers(in.Triggers); err != nil {
+		return err
+	}
+	if err := CheckMaxRetries(in.MaxRetries); err != nil {
+		return err
+	}
+
+	in.PayloadFormat, _ = in.PayloadFormat.Sanitize()
+	if err := CheckPayloadFormat(in.PayloadFormat, in.PayloadTemplate); err != nil { //nolint:revive
 		return err
 	}
 
@@ -121,6 +129,9 @@ func (s *Service) Create(
 		Insecure:              in.Insecure,
 		Triggers:              DeduplicateTriggers(in.Triggers),
 		LatestExecutionResult: nil,
+		PayloadFormat:         in.PayloadFormat,
+		PayloadTemplate:       in.PayloadTemplate,
+		MaxRetries:            in.MaxRetries,
 	}
 
 	err = s.webhookStore.Create(ctx, hook)
@@ -199,7 +199,8 @@ func (s *Service) triggerForEvent(
 					result.Execution.ID, result.Webhook.ID, result.Execution.Result, result.Err))
 		}
 
-		if result.Execution.Result == enum.WebhookExecutionResultRetriableError {
+		// executions with a scheduled automatic retry are redelivered by a job, not by reprocessing the event.
+		if result.Execution.Result == enum.WebhookExecutionResultRetriableError && result.Execution.NextRetryAt == nil {
 			retryRequired = true
 		}
 	}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -104,3 +105,112 @@ func (s *Service) RetriggerExecution(
 
 	return executionResult.Execution, nil
 }
+
+// ListFailedExecutions returns the failed executions of all webhooks in the space, its sub-spaces
+// and their repositories, that won't be redelivered automatically.
+func (s *Service) ListFailedExecutions(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.WebhookFailedExecutionFilter,
+) ([]*types.WebhookFailedExecution, int64, error) {
+	spaceIDs, err := s.spaceStore.GetDescendantsIDs(ctx, spaceID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get descendants of space %d: %w", spaceID, err)
+	}
+
+	total, err := s.webhookExecutionStore.CountFailed(ctx, spaceIDs, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count failed webhook executions: %w", err)
+	}
+
+	executions, err := s.webhookExecutionStore.ListFailed(ctx, spaceIDs, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list failed webhook executions: %w", err)
+	}
+
+	return executions, total, nil
+}
+
+// RetriggerFailedExecutions retriggers the provided failed webhook executions.
+// All executions have to belong to webhooks of the space, its sub-spaces or their repositories.
+func (s *Service) RetriggerFailedExecutions(
+	ctx context.Context,
+	spaceID int64,
+	executionIDs []int64,
+) ([]*types.WebhookExecution, error) {
+	if len(executionIDs) == 0 {
+		return nil, errors.InvalidArgument("At least one webhook execution has to be provided.")
+	}
+	if len(executionIDs) > maxRetriggerExecutions {
+		return nil, errors.InvalidArgument("At most %d webhook executions can be retriggered at once.",
+			maxRetriggerExecutions)
+	}
+
+	spaceIDs, err := s.spaceStore.GetDescendantsIDs(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get descendants of space %d: %w", spaceID, err)
+	}
+
+	inScope := make(map[int64]bool, len(spaceIDs))
+	for _, id := range spaceIDs {
+		inScope[id] = true
+	}
+
+	// verify all executions first, to not retrigger only some of them.
+	for _, executionID := range executionIDs {
+		if err := s.verifyExecutionInSpaces(ctx, executionID, inScope); err != nil {
+			return nil, err
+		}
+	}
+
+	executions := make([]*types.WebhookExecution, 0, len(executionIDs))
+	for _, executionID := range executionIDs {
+		result, err := s.RetriggerWebhookExecution(ctx, executionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrigger webhook execution %d: %w", executionID, err)
+		}
+
+		if result.Err != nil {
+			log.Ctx(ctx).Warn().Err(result.Err).Msgf(
+				"retrigger of webhook %d execution %d (new id: %d) had an error",
+				result.Webhook.ID, executionID, result.Execution.ID)
+		}
+
+		executions = append(executions, result.Execution)
+	}
+
+	return executions, nil
+}
+
+// verifyExecutionInSpaces verifies that the webhook execution belongs to a webhook of one of the provided spaces
+// or of one of their repositories.
+func (s *Service) verifyExecutionInSpaces(ctx context.Context, executionID int64, spaceIDs map[int64]bool) error {
+	execution, err := s.webhookExecutionStore.Find(ctx, executionID)
+	if err != nil {
+		return fmt.Errorf("failed to find webhook execution %d: %w", executionID, err)
+	}
+
+	webhook, err := s.webhookStore.Find(ctx, execution.WebhookID)
+	if err != nil {
+		return fmt.Errorf("failed to find webhook %d: %w", execution.WebhookID, err)
+	}
+
+	spaceID := webhook.ParentID
+	if webhook.ParentType == enum.WebhookParentRepo {
+		repo, err := s.repoStore.Find(ctx, webhook.ParentID)
+		if err != nil {
+			return fmt.Errorf("failed to find repository %d: %w", webhook.ParentID, err)
+		}
+		spaceID = repo.ParentID
+	}
+
+	if !spaceIDs[spaceID] {
+		return errors.NotFound("Webhook execution %d not found.", executionID)
+	}
+
+	if !execution.Retriggerable {
+		return errors.InvalidArgument("Webhook execution %d is incomplete and can't be retriggered.", executionID)
+	}
+
+	return nil
+}
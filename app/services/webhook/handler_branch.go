@@ -169,7 +169,17 @@ func (s *Service) fetchCommitInfoForEvent(ctx context.Context, repoUID string, c
 		return CommitInfo{}, fmt.Errorf("failed to get commit with targetSha '%s': %w", commitSHA, err)
 	}
 
-	return commitInfoFrom(out.Commit), nil
+	commitInfo := commitInfoFrom(out.Commit)
+
+	verification, err := s.signatureVerifier.VerifyCommit(ctx, git.ReadParams{RepoUID: repoUID},
+		out.Commit.SHA.String())
+	if err != nil {
+		return CommitInfo{}, fmt.Errorf("failed to verify signature of commit '%s': %w", out.Commit.SHA, err)
+	}
+
+	commitInfo.Verification = commitVerificationInfoFrom(verification)
+
+	return commitInfo, nil
 }
 
 func (s *Service) fetchCommitsInfoForEvent(
@@ -202,5 +212,20 @@ func (s *Service) fetchCommitsInfoForEvent(
 		return nil, 0, fmt.Errorf("no commit found between %s and %s", oldSHA, newSHA)
 	}
 
-	return commitsInfoFrom(listCommitsOutput.Commits), listCommitsOutput.TotalCommits, nil
+	commitSHAs := make([]string, len(listCommitsOutput.Commits))
+	for i := range listCommitsOutput.Commits {
+		commitSHAs[i] = listCommitsOutput.Commits[i].SHA.String()
+	}
+
+	verifications, err := s.signatureVerifier.VerifyCommits(ctx, git.ReadParams{RepoUID: repoUID}, commitSHAs)
+	if err != nil {
+		return []CommitInfo{}, 0, fmt.Errorf("failed to verify commit signatures: %w", err)
+	}
+
+	commitsInfo := commitsInfoFrom(listCommitsOutput.Commits)
+	for i := range commitsInfo {
+		commitsInfo[i].Verification = commitVerificationInfoFrom(verifications[i])
+	}
+
+	return commitsInfo, listCommitsOutput.TotalCommits, nil
 }
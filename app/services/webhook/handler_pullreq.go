@@ -27,6 +27,9 @@ import (
 const (
 	// gitReferenceNamePrefixBranch is the prefix of references of type branch.
 	gitReferenceNamePrefixBranch = "refs/heads/"
+
+	// gitReferenceNamePrefixTag is the prefix of references of type tag.
+	gitReferenceNamePrefixTag = "refs/tags/"
 )
 
 // PullReqCreatedPayload describes the body of the pullreq created trigger.
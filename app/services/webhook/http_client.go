@@ -30,7 +30,8 @@ var (
 	errPrivateNetworkNotAllowed = errors.New("private network not allowed")
 )
 
-func newHTTPClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerification bool) *http.Client {
+// NewHTTPClient returns an http client that optionally blocks requests to loopback and private network addresses.
+func NewHTTPClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerification bool) *http.Client {
 	// no customizations? use default client
 	if allowLoopback && allowPrivateNetwork && !disableSSLVerification {
 		return http.DefaultClient
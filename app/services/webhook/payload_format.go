@@ -0,0 +1,294 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/harness/gitness/app/services/chatops"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// webhookMaxPayloadTemplateLength defines the max allowed length of a webhook payload template.
+	webhookMaxPayloadTemplateLength = 16 * 1024
+
+	// webhookMaxRenderedPayloadLength defines the max allowed length of a payload rendered from a template.
+	webhookMaxRenderedPayloadLength = 1024 * 1024
+
+	// messageMaxQuoteLength limits the number of characters of comment texts quoted in chat messages.
+	messageMaxQuoteLength = 500
+)
+
+// PayloadTemplateError is returned in case the payload template of a webhook failed to render.
+// As the template is provided by the user, the error details are safe to be shown in the execution.
+type PayloadTemplateError struct {
+	Err error
+}
+
+func (e *PayloadTemplateError) Error() string {
+	return fmt.Sprintf("failed to render payload template: %s", e.Err)
+}
+
+func (e *PayloadTemplateError) Unwrap() error {
+	return e.Err
+}
+
+// CheckPayloadFormat validates the payload format and the payload template of a webhook.
+func CheckPayloadFormat(format enum.WebhookPayloadFormat, payloadTemplate string) error {
+	if _, ok := format.Sanitize(); !ok {
+		return check.NewValidationErrorf("The provided webhook payload format '%s' is invalid.", format)
+	}
+
+	if format != enum.WebhookPayloadFormatCustom {
+		if payloadTemplate != "" {
+			return check.NewValidationError("A payload template can only be used with the custom payload format.")
+		}
+		return nil
+	}
+
+	if strings.TrimSpace(payloadTemplate) == "" {
+		return check.NewValidationError("The custom payload format requires a payload template.")
+	}
+
+	if len(payloadTemplate) > webhookMaxPayloadTemplateLength {
+		return check.NewValidationErrorf("The payload template of a webhook can be at most %d characters long.",
+			webhookMaxPayloadTemplateLength)
+	}
+
+	if _, err := parsePayloadTemplate(payloadTemplate); err != nil {
+		return check.NewValidationErrorf("The provided payload template is invalid: %s", err)
+	}
+
+	return nil
+}
+
+func parsePayloadTemplate(payloadTemplate string) (*template.Template, error) {
+	return template.New("payload").
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"json": func(v any) (string, error) {
+				data, err := json.Marshal(v)
+				return string(data), err
+			},
+		}).
+		Parse(payloadTemplate)
+}
+
+// renderPayload serializes the body of a webhook trigger in the provided payload format.
+func renderPayload(format enum.WebhookPayloadFormat, payloadTemplate string, body any) ([]byte, error) {
+	switch format {
+	case enum.WebhookPayloadFormatSlack, enum.WebhookPayloadFormatTeams, enum.WebhookPayloadFormatDiscord:
+		msg, err := messageFrom(body)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(msg.format(format))
+
+	case enum.WebhookPayloadFormatCustom:
+		return renderPayloadTemplate(payloadTemplate, body)
+
+	case enum.WebhookPayloadFormatDefault, "":
+	}
+
+	bBuff := &bytes.Buffer{}
+	if err := json.NewEncoder(bBuff).Encode(body); err != nil {
+		return nil, fmt.Errorf("failed to serialize body to json: %w", err)
+	}
+
+	return bBuff.Bytes(), nil
+}
+
+// renderPayloadTemplate executes the user-defined template against the payload of the trigger.
+// The output of the template has to be a valid JSON document.
+func renderPayloadTemplate(payloadTemplate string, body any) ([]byte, error) {
+	tmpl, err := parsePayloadTemplate(payloadTemplate)
+	if err != nil {
+		return nil, &PayloadTemplateError{Err: err}
+	}
+
+	bBuff := &bytes.Buffer{}
+	w := &limitedWriter{w: bBuff, remaining: webhookMaxRenderedPayloadLength}
+	if err := tmpl.Execute(w, body); err != nil {
+		return nil, &PayloadTemplateError{Err: err}
+	}
+
+	if !json.Valid(bBuff.Bytes()) {
+		return nil, &PayloadTemplateError{Err: fmt.Errorf("output is not a valid JSON document")}
+	}
+
+	return bBuff.Bytes(), nil
+}
+
+// payloadSummary contains the union of the payload fields used to describe a trigger in a chat message.
+// Any payload can be decoded into it, as all payloads are composed of the same segments.
+type payloadSummary struct {
+	BaseSegment
+	ReferenceSegment
+	ReferenceDetailsSegment
+	ReferenceUpdateSegment
+	PullReq        *PullReqInfo               `json:"pull_req"`
+	Comment        *CommentInfo               `json:"comment"`
+	Label          *LabelInfo                 `json:"label"`
+	ReviewDecision enum.PullReqReviewDecision `json:"review_decision"`
+	Status         enum.PullReqCommentStatus  `json:"status"`
+}
+
+// limitedWriter fails writes once the total size of the written data exceeds the limit.
+// It stops the execution of templates that would otherwise produce unbounded output.
+type limitedWriter struct {
+	w         io.Writer
+	remaining int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if len(p) > l.remaining {
+		return 0, fmt.Errorf("output exceeds the maximum allowed size of %d bytes", webhookMaxRenderedPayloadLength)
+	}
+
+	l.remaining -= len(p)
+
+	return l.w.Write(p)
+}
+
+// message is the format agnostic content of a chat message describing a webhook trigger.
+type message struct {
+	Title string
+	URL   string
+	Text  string
+	Quote string
+}
+
+func messageFrom(body any) (message, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return message{}, fmt.Errorf("failed to serialize body to json: %w", err)
+	}
+
+	var p payloadSummary
+	if err := json.Unmarshal(data, &p); err != nil {
+		return message{}, fmt.Errorf("failed to decode payload summary: %w", err)
+	}
+
+	msg := message{
+		Title: p.Repo.Path,
+		URL:   p.Repo.URL,
+	}
+
+	if p.PullReq != nil {
+		msg.Title = fmt.Sprintf("[%s] #%d %s", p.Repo.Identifier, p.PullReq.Number, p.PullReq.Title)
+		msg.URL = p.PullReq.PrURL
+	}
+
+	if p.Comment != nil {
+		msg.Quote = p.Comment.Text
+		if runes := []rune(msg.Quote); len(runes) > messageMaxQuoteLength {
+			msg.Quote = string(runes[:messageMaxQuoteLength]) + "…"
+		}
+	}
+
+	msg.Text = messageText(&p)
+
+	return msg, nil
+}
+
+//nolint:gocyclo,cyclop // it's a simple mapping of triggers to sentences.
+func messageText(p *payloadSummary) string {
+	actor := p.Principal.DisplayName
+	ref := strings.TrimPrefix(strings.TrimPrefix(p.Ref.Name, gitReferenceNamePrefixBranch), gitReferenceNamePrefixTag)
+
+	switch p.Trigger {
+	case enum.WebhookTriggerBranchCreated:
+		return fmt.Sprintf("%s created branch %s.", actor, ref)
+	case enum.WebhookTriggerBranchUpdated:
+		if p.Forced {
+			return fmt.Sprintf("%s force pushed to branch %s.", actor, ref)
+		}
+		return fmt.Sprintf("%s pushed to branch %s.", actor, ref)
+	case enum.WebhookTriggerBranchDeleted:
+		return fmt.Sprintf("%s deleted branch %s.", actor, ref)
+	case enum.WebhookTriggerTagCreated:
+		return fmt.Sprintf("%s created tag %s.", actor, ref)
+	case enum.WebhookTriggerTagUpdated:
+		return fmt.Sprintf("%s updated tag %s.", actor, ref)
+	case enum.WebhookTriggerTagDeleted:
+		return fmt.Sprintf("%s deleted tag %s.", actor, ref)
+	case enum.WebhookTriggerPullReqCreated:
+		return fmt.Sprintf("%s opened the pull request.", actor)
+	case enum.WebhookTriggerPullReqReopened:
+		return fmt.Sprintf("%s reopened the pull request.", actor)
+	case enum.WebhookTriggerPullReqBranchUpdated:
+		return fmt.Sprintf("%s pushed to the source branch of the pull request.", actor)
+	case enum.WebhookTriggerPullReqClosed:
+		return fmt.Sprintf("%s closed the pull request.", actor)
+	case enum.WebhookTriggerPullReqMerged:
+		return fmt.Sprintf("%s merged the pull request.", actor)
+	case enum.WebhookTriggerPullReqUpdated:
+		return fmt.Sprintf("%s updated the pull request.", actor)
+	case enum.WebhookTriggerPullReqCommentCreated:
+		return fmt.Sprintf("%s commented on the pull request.", actor)
+	case enum.WebhookTriggerPullReqCommentUpdated:
+		return fmt.Sprintf("%s edited a comment on the pull request.", actor)
+	case enum.WebhookTriggerPullReqCommentStatusUpdated:
+		return fmt.Sprintf("%s marked a comment as %s.", actor, p.Status)
+	case enum.WebhookTriggerPullReqLabelAssigned:
+		if p.Label != nil && p.Label.Value != nil {
+			return fmt.Sprintf("%s assigned the label %s:%s.", actor, p.Label.Key, *p.Label.Value)
+		}
+		if p.Label != nil {
+			return fmt.Sprintf("%s assigned the label %s.", actor, p.Label.Key)
+		}
+	case enum.WebhookTriggerPullReqReviewSubmitted:
+		switch p.ReviewDecision {
+		case enum.PullReqReviewDecisionApproved:
+			return fmt.Sprintf("%s approved the pull request.", actor)
+		case enum.PullReqReviewDecisionChangeReq:
+			return fmt.Sprintf("%s requested changes.", actor)
+		case enum.PullReqReviewDecisionReviewed, enum.PullReqReviewDecisionPending:
+		}
+		return fmt.Sprintf("%s reviewed the pull request.", actor)
+	}
+
+	return fmt.Sprintf("%s triggered %s.", actor, p.Trigger)
+}
+
+// format returns the request body of the message in the provided chat tool format.
+func (m message) format(format enum.WebhookPayloadFormat) any {
+	chatMsg := chatops.Message{
+		Title:    m.Title,
+		Text:     m.Text,
+		Quote:    m.Quote,
+		URL:      m.URL,
+		LinkName: "View",
+	}
+
+	switch format {
+	case enum.WebhookPayloadFormatSlack:
+		return map[string]any{"text": chatMsg.SlackText()}
+	case enum.WebhookPayloadFormatTeams:
+		return chatMsg.TeamsCard()
+	case enum.WebhookPayloadFormatDiscord:
+		return chatMsg.DiscordBody()
+	case enum.WebhookPayloadFormatDefault, enum.WebhookPayloadFormatCustom:
+	}
+
+	return m
+}
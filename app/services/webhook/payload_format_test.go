@@ -0,0 +1,144 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestRenderPayload(t *testing.T) {
+	body := &PullReqCommentPayload{
+		BaseSegment: BaseSegment{
+			Trigger:   enum.WebhookTriggerPullReqCommentCreated,
+			Repo:      RepositoryInfo{Identifier: "repo", Path: "space/repo"},
+			Principal: PrincipalInfo{DisplayName: "Jane <Doe>"},
+		},
+		PullReqSegment: PullReqSegment{
+			PullReq: PullReqInfo{Number: 7, Title: "Fix bug", PrURL: "https://example.com/pr/7"},
+		},
+		PullReqCommentSegment: PullReqCommentSegment{
+			CommentInfo: CommentInfo{Text: "looks good"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		format   enum.WebhookPayloadFormat
+		template string
+		expected map[string]any
+		expTmpl  bool
+	}{
+		{
+			name:   "slack",
+			format: enum.WebhookPayloadFormatSlack,
+			expected: map[string]any{
+				"text": "*<https://example.com/pr/7|[repo] #7 Fix bug>*\n" +
+					"Jane &lt;Doe&gt; commented on the pull request.\n>looks good",
+			},
+		},
+		{
+			name:   "discord",
+			format: enum.WebhookPayloadFormatDiscord,
+			expected: map[string]any{
+				"embeds": []any{map[string]any{
+					"title":       "[repo] #7 Fix bug",
+					"url":         "https://example.com/pr/7",
+					"description": "Jane <Doe> commented on the pull request.\n> looks good",
+				}},
+			},
+		},
+		{
+			name:     "custom",
+			format:   enum.WebhookPayloadFormatCustom,
+			template: `{"pr": {{.PullReq.Number}}, "title": {{json .PullReq.Title}}}`,
+			expected: map[string]any{"pr": float64(7), "title": "Fix bug"},
+		},
+		{
+			name:     "custom-invalid-json",
+			format:   enum.WebhookPayloadFormatCustom,
+			template: `pr {{.PullReq.Number}}`,
+			expTmpl:  true,
+		},
+		{
+			name:     "custom-unknown-field",
+			format:   enum.WebhookPayloadFormatCustom,
+			template: `{"x": {{.Unknown}}}`,
+			expTmpl:  true,
+		},
+		{
+			name:     "custom-output-too-large",
+			format:   enum.WebhookPayloadFormatCustom,
+			template: `{"x": "{{range 100000000}}0123456789{{end}}"}`,
+			expTmpl:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := renderPayload(test.format, test.template, body)
+
+			var tmplErr *PayloadTemplateError
+			if test.expTmpl {
+				if !errors.As(err, &tmplErr) {
+					t.Fatalf("expected payload template error, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got any
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("rendered payload isn't valid JSON: %v", err)
+			}
+
+			gotJSON, _ := json.Marshal(got)
+			expJSON, _ := json.Marshal(test.expected)
+			if string(gotJSON) != string(expJSON) {
+				t.Errorf("expected %s, got %s", expJSON, gotJSON)
+			}
+		})
+	}
+}
+
+func TestCheckPayloadFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   enum.WebhookPayloadFormat
+		template string
+		expErr   bool
+	}{
+		{name: "default", format: enum.WebhookPayloadFormatDefault},
+		{name: "unknown-format", format: "irc", expErr: true},
+		{name: "template-without-custom", format: enum.WebhookPayloadFormatSlack, template: "{}", expErr: true},
+		{name: "custom-without-template", format: enum.WebhookPayloadFormatCustom, expErr: true},
+		{name: "custom-unparsable", format: enum.WebhookPayloadFormatCustom, template: "{{.Repo", expErr: true},
+		{name: "custom", format: enum.WebhookPayloadFormatCustom, template: `{"repo": {{json .Repo.Path}}}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckPayloadFormat(test.format, test.template)
+			if test.expErr != (err != nil) {
+				t.Errorf("expected error=%t, got: %v", test.expErr, err)
+			}
+		})
+	}
+}
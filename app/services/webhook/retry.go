@@ -0,0 +1,226 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	webhookevents "github.com/harness/gitness/app/events/webhook"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// MaxRetriesLimit is the maximum number of automatic redeliveries that can be configured for a webhook.
+	MaxRetriesLimit = 10
+
+	// maxRetriggerExecutions is the maximum number of failed executions that can be retriggered at once.
+	maxRetriggerExecutions = 100
+
+	jobTypeRetry        = "gitness:webhook-retry"
+	jobMaxDurationRetry = time.Minute
+)
+
+// Register registers the job handler for automatic redeliveries of failed webhook executions.
+func (s *Service) Register(_ context.Context) error {
+	if err := s.executor.Register(jobTypeRetry, &retryJob{service: s}); err != nil {
+		return fmt.Errorf("failed to register webhook retry job handler: %w", err)
+	}
+
+	return nil
+}
+
+// retryBackoff returns the delay before the redelivery of the provided failed attempt.
+// The delay doubles with every attempt, starting with the base delay, and is capped at the max delay.
+func retryBackoff(attempt int, base, maxBackoff time.Duration) time.Duration {
+	backoff := base
+	for i := 1; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxBackoff)
+}
+
+// sendErrorResult returns the result of an execution that failed to send the request (timeout, connection issue).
+// Such executions are only retried if the webhook opted into automatic retries.
+func sendErrorResult(webhook *types.Webhook, attempt int) enum.WebhookExecutionResult {
+	if attempt > 0 && webhook.MaxRetries > 0 {
+		return enum.WebhookExecutionResultRetriableError
+	}
+
+	return enum.WebhookExecutionResultFatalError
+}
+
+// storeExecution stores the webhook execution. In case the execution should be retried automatically,
+// the retry job is scheduled in the same transaction.
+func (s *Service) storeExecution(ctx context.Context, webhook *types.Webhook, execution *types.WebhookExecution) error {
+	retry := execution.Result == enum.WebhookExecutionResultRetriableError &&
+		execution.Attempt > 0 && execution.Attempt <= webhook.MaxRetries
+
+	if !retry {
+		return s.webhookExecutionStore.Create(ctx, execution)
+	}
+
+	backoff := retryBackoff(execution.Attempt, s.config.RetryBackoff, s.config.RetryMaxBackoff)
+	nextRetryAt := time.Now().Add(backoff).UnixMilli()
+	execution.NextRetryAt = &nextRetryAt
+
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.webhookExecutionStore.Create(ctx, execution); err != nil {
+			return err
+		}
+
+		err := s.scheduler.RunJob(ctx, job.Definition{
+			UID:        jobTypeRetry + "-" + strconv.FormatInt(execution.ID, 10),
+			Type:       jobTypeRetry,
+			MaxRetries: 0,
+			Timeout:    jobMaxDurationRetry,
+			Data:       strconv.FormatInt(execution.ID, 10),
+			Delay:      backoff,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to schedule webhook retry job: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// updateWebhookAfterExecution updates the latest execution result and the consecutive failures of the webhook
+// and disables the webhook in case it failed too many times in a row (best effort).
+func (s *Service) updateWebhookAfterExecution(
+	ctx context.Context,
+	webhook *types.Webhook,
+	execution *types.WebhookExecution,
+) {
+	failed := execution.Result != enum.WebhookExecutionResultSuccess
+	// manual deliveries and failed executions that are retried automatically don't count as failures.
+	countFailure := failed && execution.Attempt > 0 && execution.NextRetryAt == nil
+	resetFailures := !failed && webhook.ConsecutiveFailures > 0
+	resultChanged := webhook.LatestExecutionResult == nil || *webhook.LatestExecutionResult != execution.Result
+
+	// update the webhook IFF something changed
+	if !resultChanged && !countFailure && !resetFailures {
+		return
+	}
+
+	var disabled bool
+	updated, err := s.webhookStore.UpdateOptLock(ctx, webhook, func(hook *types.Webhook) error {
+		hook.LatestExecutionResult = &execution.Result
+
+		switch {
+		case !failed:
+			hook.ConsecutiveFailures = 0
+		case countFailure:
+			hook.ConsecutiveFailures++
+		}
+
+		disabled = s.config.AutoDisableAfter > 0 &&
+			hook.Enabled &&
+			hook.Type != enum.WebhookTypeInternal &&
+			hook.ConsecutiveFailures >= s.config.AutoDisableAfter
+		if disabled {
+			hook.Enabled = false
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf(
+			"failed to update latest execution result to %s for webhook %d",
+			execution.Result, webhook.ID)
+		return
+	}
+
+	if !disabled {
+		return
+	}
+
+	log.Ctx(ctx).Info().Msgf("disabled webhook %d after %d consecutive failed deliveries",
+		updated.ID, updated.ConsecutiveFailures)
+
+	s.sendSSE(ctx, updated.ParentID, updated.ParentType, enum.SSETypeWebhookUpdated, updated)
+
+	s.eventReporter.Disabled(ctx, &webhookevents.DisabledPayload{
+		WebhookID:           updated.ID,
+		WebhookIdentifier:   updated.Identifier,
+		ParentType:          updated.ParentType,
+		ParentID:            updated.ParentID,
+		CreatedBy:           updated.CreatedBy,
+		ConsecutiveFailures: updated.ConsecutiveFailures,
+		LastError:           execution.Error,
+	})
+}
+
+type retryJob struct {
+	service *Service
+}
+
+// Handle redelivers the failed webhook execution with the id provided as job data.
+func (j *retryJob) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	executionID, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid webhook execution id %q: %w", data, err)
+	}
+
+	return j.service.retryExecution(ctx, executionID)
+}
+
+func (s *Service) retryExecution(ctx context.Context, executionID int64) (string, error) {
+	prev, err := s.webhookExecutionStore.Find(ctx, executionID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		// the execution got purged in the meantime, nothing to retry.
+		return "webhook execution not found", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find webhook execution %d: %w", executionID, err)
+	}
+
+	webhook, err := s.webhookStore.Find(ctx, prev.WebhookID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return "webhook not found", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find webhook %d: %w", prev.WebhookID, err)
+	}
+
+	if !webhook.Enabled {
+		// the execution won't be retried anymore, make it show up as a failed delivery.
+		if err := s.webhookExecutionStore.ClearNextRetry(ctx, prev.ID); err != nil {
+			return "", fmt.Errorf("failed to clear next retry of webhook execution %d: %w", prev.ID, err)
+		}
+		return "webhook is disabled", nil
+	}
+
+	body := bytes.NewBufferString(prev.Request.Body)
+
+	execution, err := s.executeWebhook(ctx, webhook, prev.TriggerID, prev.TriggerType, body, &prev.ID,
+		prev.Attempt+1)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("retry %d of webhook %d execution %d had an error",
+			execution.Attempt, webhook.ID, prev.ID)
+	}
+
+	return fmt.Sprintf("webhook execution %d resulted in %s", execution.ID, execution.Result), nil
+}
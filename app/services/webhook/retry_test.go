@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	const base, maxBackoff = 30 * time.Second, 5 * time.Minute
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 30 * time.Second},
+		{attempt: 2, want: time.Minute},
+		{attempt: 3, want: 2 * time.Minute},
+		{attempt: 4, want: 4 * time.Minute},
+		{attempt: 5, want: 5 * time.Minute},
+		{attempt: 100, want: 5 * time.Minute},
+	}
+
+	for _, test := range tests {
+		if got := retryBackoff(test.attempt, base, maxBackoff); got != test.want {
+			t.Errorf("attempt %d: expected backoff %s, got %s", test.attempt, test.want, got)
+		}
+	}
+}
+
+func TestSendErrorResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries int
+		attempt    int
+		want       enum.WebhookExecutionResult
+	}{
+		{name: "no retries", maxRetries: 0, attempt: 1, want: enum.WebhookExecutionResultFatalError},
+		{name: "manual delivery", maxRetries: 3, attempt: 0, want: enum.WebhookExecutionResultFatalError},
+		{name: "automatic delivery", maxRetries: 3, attempt: 2, want: enum.WebhookExecutionResultRetriableError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := sendErrorResult(&types.Webhook{MaxRetries: test.maxRetries}, test.attempt)
+			if got != test.want {
+				t.Errorf("expected %s, got %s", test.want, got)
+			}
+		})
+	}
+}
@@ -23,12 +23,15 @@ import (
 
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	webhookevents "github.com/harness/gitness/app/events/webhook"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/stream"
 )
@@ -52,6 +55,14 @@ type Config struct {
 
 	// InternalWebhooksURL specifies the internal webhook URL which will be used if webhook is marked internal
 	InternalWebhooksURL string
+
+	// RetryBackoff is the delay before the first automatic redelivery of a failed execution.
+	// The delay doubles with every further attempt, up to RetryMaxBackoff.
+	RetryBackoff    time.Duration
+	RetryMaxBackoff time.Duration
+	// AutoDisableAfter is the number of consecutive failed deliveries after which a webhook gets disabled.
+	// Zero means webhooks are never disabled automatically.
+	AutoDisableAfter int
 }
 
 func (c *Config) Prepare() error {
@@ -70,6 +81,15 @@ func (c *Config) Prepare() error {
 	if c.MaxRetries < 0 {
 		return errors.New("config.MaxRetries can't be negative")
 	}
+	if c.RetryBackoff <= 0 {
+		return errors.New("config.RetryBackoff has to be a positive duration")
+	}
+	if c.RetryMaxBackoff < c.RetryBackoff {
+		return errors.New("config.RetryMaxBackoff can't be smaller than config.RetryBackoff")
+	}
+	if c.AutoDisableAfter < 0 {
+		return errors.New("config.AutoDisableAfter can't be negative")
+	}
 
 	// Backfill data
 	if c.HeaderIdentity == "" {
@@ -106,6 +126,12 @@ type Service struct {
 	webhookURLProvider URLProvider
 
 	sseStreamer sse.Streamer
+
+	signatureVerifier *publickey.SignatureVerifier
+
+	scheduler     *job.Scheduler
+	executor      *job.Executor
+	eventReporter *webhookevents.Reporter
 }
 
 func NewService(
@@ -128,6 +154,10 @@ func NewService(
 	webhookURLProvider URLProvider,
 	labelValueStore store.LabelValueStore,
 	sseStreamer sse.Streamer,
+	signatureVerifier *publickey.SignatureVerifier,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	eventReporter *webhookevents.Reporter,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided webhook service config is invalid: %w", err)
@@ -145,11 +175,11 @@ func NewService(
 		git:                   git,
 		encrypter:             encrypter,
 
-		secureHTTPClient:   newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
-		insecureHTTPClient: newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
+		secureHTTPClient:   NewHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
+		insecureHTTPClient: NewHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
 
-		secureHTTPClientInternal:   newHTTPClient(config.AllowLoopback, true, false),
-		insecureHTTPClientInternal: newHTTPClient(config.AllowLoopback, true, true),
+		secureHTTPClientInternal:   NewHTTPClient(config.AllowLoopback, true, false),
+		insecureHTTPClientInternal: NewHTTPClient(config.AllowLoopback, true, true),
 
 		config: config,
 
@@ -158,6 +188,12 @@ func NewService(
 		webhookURLProvider: webhookURLProvider,
 
 		sseStreamer: sseStreamer,
+
+		signatureVerifier: signatureVerifier,
+
+		scheduler:     scheduler,
+		executor:      executor,
+		eventReporter: eventReporter,
 	}
 
 	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
@@ -0,0 +1,317 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/google/uuid"
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// testTriggerIDPrefix is the prefix of the trigger ID of test deliveries.
+	testTriggerIDPrefix = "test-"
+
+	// sampleSHA is the commit SHA used in the sample payloads of test deliveries.
+	sampleSHA = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+	// sampleOldSHA is the previous commit SHA used in the sample payloads of test deliveries.
+	sampleOldSHA = "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391"
+	// sampleSourceBranch is the source branch of the pull request used in the sample payloads of test deliveries.
+	sampleSourceBranch = "feature"
+)
+
+func (s *Service) sanitizeTestInput(in *types.WebhookTestInput, hook *types.Webhook) error {
+	if in.Trigger == "" {
+		in.Trigger = enum.WebhookTriggerBranchUpdated
+		if len(hook.Triggers) > 0 {
+			in.Trigger = hook.Triggers[0]
+		}
+	}
+
+	if _, ok := in.Trigger.Sanitize(); !ok {
+		return check.NewValidationErrorf("The provided webhook trigger '%s' is invalid.", in.Trigger)
+	}
+
+	if (in.PayloadFormat != nil || in.PayloadTemplate != nil) && !in.DryRun {
+		return check.NewValidationError("The payload format can only be overridden for dry runs.")
+	}
+
+	return nil
+}
+
+// TestDelivery delivers a sample payload of the provided trigger to the webhook.
+// The sample payload is built for the provided repository, or for a placeholder repository for space webhooks.
+// In case of a dry run, the request is only prepared and returned, it's neither sent nor stored.
+func (s *Service) TestDelivery(
+	ctx context.Context,
+	parentID int64,
+	parentType enum.WebhookParent,
+	webhookIdentifier string,
+	principal *types.Principal,
+	repo *types.Repository,
+	in *types.WebhookTestInput,
+) (*types.WebhookExecution, error) {
+	hook, err := s.GetWebhookVerifyOwnership(ctx, parentID, parentType, webhookIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sanitizeTestInput(in, hook); err != nil {
+		return nil, err
+	}
+
+	triggerID := testTriggerIDPrefix + uuid.NewString()
+	body := s.samplePayload(ctx, in.Trigger, principal, repo)
+
+	if in.DryRun {
+		return s.previewWebhook(ctx, hook, triggerID, body, in)
+	}
+
+	execution, err := s.executeWebhook(ctx, hook, triggerID, in.Trigger, body, nil, 0)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("test delivery of webhook %d had an error", hook.ID)
+	}
+
+	return execution, nil
+}
+
+// previewWebhook prepares the request of the webhook without sending it.
+// The payload format and the template of the webhook can be overridden with the values of the input.
+func (s *Service) previewWebhook(
+	ctx context.Context,
+	hook *types.Webhook,
+	triggerID string,
+	body any,
+	in *types.WebhookTestInput,
+) (*types.WebhookExecution, error) {
+	preview := *hook
+	if in.PayloadFormat != nil {
+		preview.PayloadFormat = *in.PayloadFormat
+		preview.PayloadTemplate = ""
+	}
+	if in.PayloadTemplate != nil {
+		preview.PayloadTemplate = *in.PayloadTemplate
+	}
+	if err := CheckPayloadFormat(preview.PayloadFormat, preview.PayloadTemplate); err != nil {
+		return nil, err
+	}
+
+	execution := &types.WebhookExecution{
+		Created:     time.Now().UnixMilli(),
+		WebhookID:   hook.ID,
+		TriggerID:   triggerID,
+		TriggerType: in.Trigger,
+		Result:      enum.WebhookExecutionResultSuccess,
+	}
+
+	_, err := s.prepareHTTPRequest(ctx, execution, in.Trigger, &preview, body)
+	if err != nil && execution.Error == "" {
+		return nil, err
+	}
+
+	// the execution isn't stored, it can't be retriggered
+	execution.Retriggerable = false
+
+	return execution, nil
+}
+
+// samplePayload returns a payload of the provided trigger filled with placeholder data.
+//
+//nolint:funlen // it's a simple mapping of triggers to payloads.
+func (s *Service) samplePayload(
+	ctx context.Context,
+	trigger enum.WebhookTrigger,
+	principal *types.Principal,
+	repo *types.Repository,
+) any {
+	now := time.Now()
+	principalInfo := principalInfoFrom(principal.ToPrincipalInfo())
+	repoInfo := repositoryInfoFrom(ctx, repo, s.urlProvider)
+
+	commit := CommitInfo{
+		SHA:     sampleSHA,
+		Message: "Sample commit message",
+		Author: SignatureInfo{
+			Identity: IdentityInfo{Name: principal.DisplayName, Email: principal.Email},
+			When:     now,
+		},
+		Committer: SignatureInfo{
+			Identity: IdentityInfo{Name: principal.DisplayName, Email: principal.Email},
+			When:     now,
+		},
+		Added:    []string{},
+		Removed:  []string{},
+		Modified: []string{"README.md"},
+	}
+
+	base := BaseSegment{
+		Trigger:   trigger,
+		Repo:      repoInfo,
+		Principal: principalInfo,
+	}
+	details := ReferenceDetailsSegment{
+		SHA:               commit.SHA,
+		HeadCommit:        &commit,
+		Commits:           &[]CommitInfo{commit},
+		TotalCommitsCount: 1,
+		Commit:            &commit,
+	}
+
+	switch trigger {
+	case enum.WebhookTriggerBranchCreated, enum.WebhookTriggerBranchUpdated, enum.WebhookTriggerBranchDeleted:
+		return &ReferencePayload{
+			BaseSegment: base,
+			ReferenceSegment: ReferenceSegment{
+				Ref: ReferenceInfo{Name: gitReferenceNamePrefixBranch + repo.DefaultBranch, Repo: repoInfo},
+			},
+			ReferenceDetailsSegment: details,
+			ReferenceUpdateSegment:  ReferenceUpdateSegment{OldSHA: sampleOldSHA},
+		}
+	case enum.WebhookTriggerTagCreated, enum.WebhookTriggerTagUpdated, enum.WebhookTriggerTagDeleted:
+		return &ReferencePayload{
+			BaseSegment: base,
+			ReferenceSegment: ReferenceSegment{
+				Ref: ReferenceInfo{Name: gitReferenceNamePrefixTag + "v1.0.0", Repo: repoInfo},
+			},
+			ReferenceDetailsSegment: details,
+			ReferenceUpdateSegment:  ReferenceUpdateSegment{OldSHA: sampleOldSHA},
+		}
+	}
+
+	prSegment := PullReqSegment{
+		PullReq: PullReqInfo{
+			Number:       1,
+			State:        enum.PullReqStateOpen,
+			Title:        "Sample pull request",
+			Description:  "Sample pull request description",
+			SourceRepoID: repo.ID,
+			SourceBranch: sampleSourceBranch,
+			TargetRepoID: repo.ID,
+			TargetBranch: repo.DefaultBranch,
+			Author:       principalInfo,
+			PrURL:        s.urlProvider.GenerateUIPRURL(ctx, repo.Path, 1),
+		},
+	}
+	targetRef := PullReqTargetReferenceSegment{
+		TargetRef: ReferenceInfo{Name: gitReferenceNamePrefixBranch + repo.DefaultBranch, Repo: repoInfo},
+	}
+	sourceRef := ReferenceSegment{
+		Ref: ReferenceInfo{Name: gitReferenceNamePrefixBranch + sampleSourceBranch, Repo: repoInfo},
+	}
+	comment := PullReqCommentSegment{
+		CommentInfo: CommentInfo{
+			ID:      1,
+			Text:    "Sample comment",
+			Created: now.UnixMilli(),
+			Updated: now.UnixMilli(),
+			Kind:    enum.PullReqActivityKindComment,
+		},
+	}
+
+	switch trigger {
+	case enum.WebhookTriggerPullReqBranchUpdated:
+		return &PullReqBranchUpdatedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                prSegment,
+			PullReqTargetReferenceSegment: targetRef,
+			ReferenceSegment:              sourceRef,
+			ReferenceDetailsSegment:       details,
+			ReferenceUpdateSegment:        ReferenceUpdateSegment{OldSHA: sampleOldSHA},
+		}
+	case enum.WebhookTriggerPullReqCommentCreated, enum.WebhookTriggerPullReqCommentUpdated:
+		return &PullReqCommentPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                prSegment,
+			PullReqTargetReferenceSegment: targetRef,
+			ReferenceSegment:              sourceRef,
+			ReferenceDetailsSegment:       details,
+			PullReqCommentSegment:         comment,
+		}
+	case enum.WebhookTriggerPullReqCommentStatusUpdated:
+		return &PullReqActivityStatusUpdatedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                prSegment,
+			PullReqTargetReferenceSegment: targetRef,
+			ReferenceSegment:              sourceRef,
+			PullReqCommentSegment:         comment,
+			PullReqCommentStatusUpdatedSegment: PullReqCommentStatusUpdatedSegment{
+				Status: enum.PullReqCommentStatusResolved,
+			},
+		}
+	case enum.WebhookTriggerPullReqLabelAssigned:
+		return &PullReqLabelAssignedPayload{
+			BaseSegment:    base,
+			PullReqSegment: prSegment,
+			PullReqLabelSegment: PullReqLabelSegment{
+				LabelInfo: LabelInfo{ID: 1, Key: "priority", Value: ptr.String("high")},
+			},
+		}
+	case enum.WebhookTriggerPullReqUpdated:
+		return &PullReqUpdatedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                prSegment,
+			PullReqTargetReferenceSegment: targetRef,
+			ReferenceSegment:              sourceRef,
+			PullReqUpdateSegment: PullReqUpdateSegment{
+				TitleChanged: true,
+				TitleOld:     "Old sample pull request",
+				TitleNew:     prSegment.PullReq.Title,
+			},
+		}
+	case enum.WebhookTriggerPullReqReviewSubmitted:
+		return &PullReqReviewSubmittedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                prSegment,
+			PullReqTargetReferenceSegment: targetRef,
+			ReferenceSegment:              sourceRef,
+			PullReqReviewSegment: PullReqReviewSegment{
+				ReviewDecision: enum.PullReqReviewDecisionApproved,
+				ReviewerInfo:   principalInfo,
+			},
+		}
+	case enum.WebhookTriggerPullReqClosed, enum.WebhookTriggerPullReqMerged:
+		prSegment.PullReq.State = enum.PullReqStateClosed
+		if trigger == enum.WebhookTriggerPullReqMerged {
+			prSegment.PullReq.State = enum.PullReqStateMerged
+			prSegment.PullReq.MergeStrategy = ptr.Of(enum.MergeMethodMerge)
+		}
+	}
+
+	return &PullReqCreatedPayload{
+		BaseSegment:                   base,
+		PullReqSegment:                prSegment,
+		PullReqTargetReferenceSegment: targetRef,
+		ReferenceSegment:              sourceRef,
+		ReferenceDetailsSegment:       details,
+	}
+}
+
+// SampleRepository returns the placeholder repository used for test deliveries of space webhooks.
+func SampleRepository(space *types.Space) *types.Repository {
+	return &types.Repository{
+		ParentID:      space.ID,
+		Identifier:    "example",
+		Path:          fmt.Sprintf("%s/example", space.Path),
+		DefaultBranch: "main",
+	}
+}
@@ -17,7 +17,6 @@ package webhook
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -96,9 +95,10 @@ func (s *Service) triggerWebhooks(ctx context.Context, webhooks []*types.Webhook
 	// precalculate whether a webhook should be executed
 	skipExecution := make(map[int64]bool)
 	for _, execution := range executions {
-		// skip execution in case of success or unrecoverable error
+		// skip execution in case of success, unrecoverable error or if the execution is retried automatically
 		if execution.Result == enum.WebhookExecutionResultSuccess ||
-			execution.Result == enum.WebhookExecutionResultFatalError {
+			execution.Result == enum.WebhookExecutionResultFatalError ||
+			execution.NextRetryAt != nil {
 			skipExecution[execution.WebhookID] = true
 		}
 	}
@@ -116,7 +116,7 @@ func (s *Service) triggerWebhooks(ctx context.Context, webhooks []*types.Webhook
 			continue
 		}
 
-		// check if webhook already got executed (success or fatal error)
+		// check if webhook already got executed (success, fatal error or automatic retry)
 		if skipExecution[webhook.ID] {
 			continue
 		}
@@ -134,7 +134,7 @@ func (s *Service) triggerWebhooks(ctx context.Context, webhooks []*types.Webhook
 		}
 
 		// execute trigger and store output in result
-		results[i].Execution, results[i].Err = s.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil)
+		results[i].Execution, results[i].Err = s.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil, 1)
 	}
 
 	return results, nil
@@ -167,7 +167,7 @@ func (s *Service) RetriggerWebhookExecution(ctx context.Context, webhookExecutio
 	// NOTE: bBuff.Write(v) will always return (len(v), nil) - no need to error handle
 	body.WriteString(webhookExecution.Request.Body)
 
-	newExecution, err := s.executeWebhook(ctx, webhook, triggerID, triggerType, body, &webhookExecution.ID)
+	newExecution, err := s.executeWebhook(ctx, webhook, triggerID, triggerType, body, &webhookExecution.ID, 0)
 	return &TriggerResult{
 		TriggerID:   triggerID,
 		TriggerType: triggerType,
@@ -177,9 +177,13 @@ func (s *Service) RetriggerWebhookExecution(ctx context.Context, webhookExecutio
 	}, nil
 }
 
+// executeWebhook sends the body to the webhook and stores the execution.
+// The attempt is the number of the automatic delivery attempt, or 0 for manual deliveries.
+// Only failed automatic deliveries are retried automatically and counted as consecutive failures.
+//
 //nolint:gocognit // refactor into smaller chunks if necessary.
 func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, triggerID string,
-	triggerType enum.WebhookTrigger, body any, rerunOfID *int64) (*types.WebhookExecution, error) {
+	triggerType enum.WebhookTrigger, body any, rerunOfID *int64, attempt int) (*types.WebhookExecution, error) {
 	// build execution entry on the fly (save no matter what)
 	execution := types.WebhookExecution{
 		RetriggerOf: rerunOfID,
@@ -187,8 +191,9 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 		TriggerID:   triggerID,
 		TriggerType: triggerType,
 		// for unexpected errors we don't retry - protect the system. User can retrigger manually (if body was set)
-		Result: enum.WebhookExecutionResultFatalError,
-		Error:  "An unknown error occurred",
+		Result:  enum.WebhookExecutionResultFatalError,
+		Error:   "An unknown error occurred",
+		Attempt: attempt,
 	}
 	defer func(oCtx context.Context, start time.Time) {
 		// set total execution time
@@ -196,25 +201,14 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 		execution.Created = time.Now().UnixMilli()
 
 		// TODO: what if saving execution failed? For now we will rerun it in case of error or not show it in history
-		err := s.webhookExecutionStore.Create(oCtx, &execution)
+		err := s.storeExecution(oCtx, webhook, &execution)
 		if err != nil {
 			log.Ctx(ctx).Warn().Err(err).Msgf(
 				"failed to store webhook execution that ended with Result: %s, Response.Status: '%s', Error: '%s'",
 				execution.Result, execution.Response.Status, execution.Error)
 		}
 
-		// update latest execution result of webhook IFF it's different from before (best effort)
-		if webhook.LatestExecutionResult == nil || *webhook.LatestExecutionResult != execution.Result {
-			_, err = s.webhookStore.UpdateOptLock(oCtx, webhook, func(hook *types.Webhook) error {
-				hook.LatestExecutionResult = &execution.Result
-				return nil
-			})
-			if err != nil {
-				log.Ctx(ctx).Warn().Err(err).Msgf(
-					"failed to update latest execution result to %s for webhook %d",
-					execution.Result, webhook.ID)
-			}
-		}
+		s.updateWebhookAfterExecution(oCtx, webhook, &execution)
 	}(ctx, time.Now())
 
 	// derive context with time limit
@@ -254,10 +248,11 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 	var dnsError *net.DNSError
 	switch {
 	case errors.Is(err, context.DeadlineExceeded):
-		// we assume timeout without any response is not worth retrying - protect the system
+		// we assume timeout without any response is not worth retrying, unless the webhook opted into
+		// automatic retries with backoff - protect the system.
 		tErr := fmt.Errorf("request exceeded time limit of %s", webhookTimeLimit)
 		execution.Error = tErr.Error()
-		execution.Result = enum.WebhookExecutionResultFatalError
+		execution.Result = sendErrorResult(webhook, attempt)
 		return &execution, tErr
 
 	case errors.As(err, &dnsError) && dnsError.IsNotFound:
@@ -267,10 +262,11 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 		return &execution, fmt.Errorf("failed to resolve host name '%s': %w", dnsError.Name, err)
 
 	case err != nil:
-		// for all other errors we don't retry - protect the system. User can retrigger manually (if body was set)
+		// for all other errors we don't retry, unless the webhook opted into automatic retries with backoff
+		// - protect the system. User can retrigger manually (if body was set)
 		tErr := fmt.Errorf("an error occurred while sending the request: %w", err)
 		execution.Error = tErr.Error()
-		execution.Result = enum.WebhookExecutionResultFatalError
+		execution.Result = sendErrorResult(webhook, attempt)
 		return &execution, tErr
 	}
 
@@ -282,7 +278,8 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 
 // prepareHTTPRequest prepares a new http.Request object for the webhook using the provided body as request body.
 // All execution.Request.XXX values are set accordingly.
-// NOTE: if the body is an io.Reader, the value is used as response body as is, otherwise it'll be JSON serialized.
+// NOTE: if the body is an io.Reader, the value is used as response body as is,
+// otherwise it'll be serialized in the payload format of the webhook.
 func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.WebhookExecution,
 	triggerType enum.WebhookTrigger, webhook *types.Webhook, body any) (*http.Request, error) {
 	url, err := s.webhookURLProvider.GetWebhookURL(ctx, webhook)
@@ -312,14 +309,24 @@ func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.Webho
 		bBuff.Write(bBytes)
 
 	default:
-		// all other types we json serialize
-		err := json.NewEncoder(bBuff).Encode(body)
+		// all other types we serialize in the payload format of the webhook
+		bBytes, err := renderPayload(webhook.PayloadFormat, webhook.PayloadTemplate, body)
+		var tmplErr *PayloadTemplateError
+		if errors.As(err, &tmplErr) {
+			// ASSUMPTION: there was an issue with the user provided template, not retriable
+			execution.Error = tmplErr.Error()
+			execution.Result = enum.WebhookExecutionResultFatalError
+			return nil, err
+		}
 		if err != nil {
 			// this is an internal issue, nothing the user can do - don't expose error details
 			execution.Error = "an error occurred preparing the request body"
 			execution.Result = enum.WebhookExecutionResultFatalError
-			return nil, fmt.Errorf("failed to serialize body to json: %w", err)
+			return nil, fmt.Errorf("failed to serialize body: %w", err)
 		}
+
+		// NOTE: bBuff.Write(v) will always return (len(v), nil) - no need to error handle
+		bBuff.Write(bBytes)
 	}
 	// set executioon body and mark it as retriggerable
 	execution.Request.Body = bBuff.String()
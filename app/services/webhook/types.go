@@ -221,6 +221,33 @@ type CommitInfo struct {
 	Added    []string `json:"added"`
 	Removed  []string `json:"removed"`
 	Modified []string `json:"modified"`
+
+	Verification *CommitVerificationInfo `json:"verification,omitempty"`
+}
+
+// CommitVerificationInfo describes the commit signature verification related info for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type CommitVerificationInfo struct {
+	Status         enum.CommitSignatureStatus `json:"status"`
+	KeyScheme      enum.PublicKeyScheme       `json:"key_scheme,omitempty"`
+	KeyFingerprint string                     `json:"key_fingerprint,omitempty"`
+	Signer         *PrincipalInfo             `json:"signer,omitempty"`
+}
+
+// commitVerificationInfoFrom gets the CommitVerificationInfo from a types.CommitVerification.
+func commitVerificationInfoFrom(verification types.CommitVerification) *CommitVerificationInfo {
+	info := &CommitVerificationInfo{
+		Status:         verification.Status,
+		KeyScheme:      verification.KeyScheme,
+		KeyFingerprint: verification.KeyFingerprint,
+	}
+
+	if verification.Signer != nil {
+		signer := principalInfoFrom(verification.Signer)
+		info.Signer = &signer
+	}
+
+	return info
 }
 
 // commitInfoFrom gets the CommitInfo from a git.Commit.
@@ -61,6 +61,16 @@ func (s *Service) sanitizeUpdateInput(in *types.WebhookUpdateInput) error {
 			return err
 		}
 	}
+	if in.MaxRetries != nil {
+		if err := CheckMaxRetries(*in.MaxRetries); err != nil {
+			return err
+		}
+	}
+	if in.PayloadFormat != nil {
+		if _, ok := in.PayloadFormat.Sanitize(); !ok {
+			return check.NewValidationErrorf("The provided webhook payload format '%s' is invalid.", *in.PayloadFormat)
+		}
+	}
 
 	return nil
 }
@@ -107,6 +117,10 @@ func (s *Service) Update(
 		hook.Secret = string(encryptedSecret)
 	}
 	if in.Enabled != nil {
+		if *in.Enabled && !hook.Enabled {
+			// give a (re-)enabled webhook a fresh start, it might have been disabled after too many failures.
+			hook.ConsecutiveFailures = 0
+		}
 		hook.Enabled = *in.Enabled
 	}
 	if in.Insecure != nil {
@@ -115,6 +129,22 @@ func (s *Service) Update(
 	if in.Triggers != nil {
 		hook.Triggers = DeduplicateTriggers(in.Triggers)
 	}
+	if in.MaxRetries != nil {
+		hook.MaxRetries = *in.MaxRetries
+	}
+	if in.PayloadFormat != nil {
+		hook.PayloadFormat = *in.PayloadFormat
+		if hook.PayloadFormat != enum.WebhookPayloadFormatCustom {
+			// the template is only used by the custom payload format
+			hook.PayloadTemplate = ""
+		}
+	}
+	if in.PayloadTemplate != nil {
+		hook.PayloadTemplate = *in.PayloadTemplate
+	}
+	if err := CheckPayloadFormat(hook.PayloadFormat, hook.PayloadTemplate); err != nil {
+		return nil, err
+	}
 
 	if err := s.webhookStore.Update(ctx, hook); err != nil {
 		return nil, err
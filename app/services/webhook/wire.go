@@ -19,12 +19,15 @@ import (
 
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	webhookevents "github.com/harness/gitness/app/events/webhook"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/store/database/dbtx"
 
 	"github.com/google/wire"
@@ -56,6 +59,10 @@ func ProvideService(
 	webhookURLProvider URLProvider,
 	labelValueStore store.LabelValueStore,
 	sseStreamer sse.Streamer,
+	signatureVerifier *publickey.SignatureVerifier,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	eventReporter *webhookevents.Reporter,
 ) (*Service, error) {
 	return NewService(
 		ctx,
@@ -76,6 +83,10 @@ func ProvideService(
 		webhookURLProvider,
 		labelValueStore,
 		sseStreamer,
+		signatureVerifier,
+		scheduler,
+		executor,
+		eventReporter,
 	)
 }
 
@@ -15,6 +15,7 @@
 package services
 
 import (
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/gitspaceevent"
@@ -22,9 +23,13 @@ import (
 	"github.com/harness/gitness/app/services/infraprovider"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/ldapsync"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/pullmirror"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pushmirror"
 	"github.com/harness/gitness/app/services/repo"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
@@ -46,6 +51,11 @@ type Services struct {
 	RepoSizeCalculator    *repo.SizeCalculator
 	Repo                  *repo.Service
 	Cleanup               *cleanup.Service
+	MergeQueue            *mergequeue.Service
+	PullMirror            *pullmirror.Service
+	PushMirror            *pushmirror.Service
+	LDAPSync              *ldapsync.Service
+	AutoMerge             *automerge.Service
 	Notification          *notification.Service
 	Keywordsearch         *keywordsearch.Service
 	GitspaceService       *GitspaceServices
@@ -84,6 +94,11 @@ func ProvideServices(
 	repoSizeCalculator *repo.SizeCalculator,
 	repo *repo.Service,
 	cleanupSvc *cleanup.Service,
+	mergeQueueSvc *mergequeue.Service,
+	pullMirrorSvc *pullmirror.Service,
+	pushMirrorSvc *pushmirror.Service,
+	ldapSyncSvc *ldapsync.Service,
+	autoMergeSvc *automerge.Service,
 	notificationSvc *notification.Service,
 	keywordsearchSvc *keywordsearch.Service,
 	gitspaceSvc *GitspaceServices,
@@ -100,6 +115,11 @@ func ProvideServices(
 		RepoSizeCalculator:    repoSizeCalculator,
 		Repo:                  repo,
 		Cleanup:               cleanupSvc,
+		MergeQueue:            mergeQueueSvc,
+		PullMirror:            pullMirrorSvc,
+		PushMirror:            pushMirrorSvc,
+		LDAPSync:              ldapSyncSvc,
+		AutoMerge:             autoMergeSvc,
 		Notification:          notificationSvc,
 		Keywordsearch:         keywordsearchSvc,
 		GitspaceService:       gitspaceSvc,
@@ -290,6 +290,12 @@ type (
 
 		// ListSizeInfos returns a list of all active repo sizes.
 		ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeInfo, error)
+
+		// CountForks returns the number of active forks of a repo.
+		CountForks(ctx context.Context, repoID int64, opts *types.RepoFilter) (int64, error)
+
+		// ListForks returns a list of active forks of a repo, from all spaces.
+		ListForks(ctx context.Context, repoID int64, opts *types.RepoFilter) ([]*types.Repository, error)
 	}
 
 	// SettingsStore defines the settings storage.
@@ -368,6 +374,9 @@ type (
 		// If tokenTypes are provided, then only tokens of that type are deleted.
 		DeleteExpiredBefore(ctx context.Context, before time.Time, tknTypes []enum.TokenType) (int64, error)
 
+		// DeleteForPrincipal deletes all tokens of the principal.
+		DeleteForPrincipal(ctx context.Context, principalID int64) (int64, error)
+
 		// List returns a list of tokens of a specific type for a specific principal.
 		List(ctx context.Context, principalID int64, tokenType enum.TokenType) ([]*types.Token, error)
 
@@ -626,6 +635,9 @@ type (
 		// Create creates a new webhook execution entry.
 		Create(ctx context.Context, hook *types.WebhookExecution) error
 
+		// ClearNextRetry marks the webhook execution as not being retried automatically anymore.
+		ClearNextRetry(ctx context.Context, id int64) error
+
 		// DeleteOld removes all executions that are older than the provided time.
 		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
 
@@ -639,6 +651,18 @@ type (
 
 		// ListForTrigger lists the webhook executions for a given trigger id.
 		ListForTrigger(ctx context.Context, triggerID string) ([]*types.WebhookExecution, error)
+
+		// ListFailed lists the failed webhook executions of the external webhooks in the provided spaces
+		// and in their repositories, that won't be redelivered automatically and haven't been retriggered.
+		ListFailed(
+			ctx context.Context,
+			spaceIDs []int64,
+			opts *types.WebhookFailedExecutionFilter,
+		) ([]*types.WebhookFailedExecution, error)
+
+		// CountFailed counts the failed webhook executions of the external webhooks in the provided spaces
+		// and in their repositories, that won't be redelivered automatically and haven't been retriggered.
+		CountFailed(ctx context.Context, spaceIDs []int64, opts *types.WebhookFailedExecutionFilter) (int64, error)
 	}
 
 	CheckStore interface {
@@ -877,6 +901,10 @@ type (
 		// ListInSpace lists the executions in a given space.
 		ListInSpace(ctx context.Context, spaceID int64, filter types.ListExecutionsFilter) ([]*types.Execution, error)
 
+		// ListIncompleteInConcurrencyGroup lists the pending and running executions of a concurrency group
+		// in a repository, ordered by execution ID.
+		ListIncompleteInConcurrencyGroup(ctx context.Context, repoID int64, group string) ([]*types.Execution, error)
+
 		ListByPipelineIDs(
 			ctx context.Context,
 			pipelineIDs []int64,
@@ -1296,4 +1324,249 @@ type (
 			end int64,
 		) ([]types.UsageMetric, error)
 	}
+
+	LFSObjectStore interface {
+		// Find finds an LFS object with a specified oid and repo-id.
+		Find(ctx context.Context, repoID int64, oid string) (*types.LFSObject, error)
+
+		// FindMany finds LFS objects for a specified repo.
+		FindMany(ctx context.Context, repoID int64, oids []string) ([]*types.LFSObject, error)
+
+		// Create creates an LFS object.
+		Create(ctx context.Context, lfsObject *types.LFSObject) error
+	}
+
+	MergeQueueStore interface {
+		// Find finds a merge queue entry by id.
+		Find(ctx context.Context, id int64) (*types.MergeQueueEntry, error)
+
+		// FindByPullReqID finds the merge queue entry of a pull request.
+		FindByPullReqID(ctx context.Context, pullReqID int64) (*types.MergeQueueEntry, error)
+
+		// Create adds a new entry to the end of a merge queue.
+		Create(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Update updates a merge queue entry.
+		Update(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Delete removes an entry from a merge queue.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns merge queue entries of a repository in the queue order.
+		List(ctx context.Context, repoID int64, filter *types.MergeQueueFilter) ([]*types.MergeQueueEntry, error)
+
+		// ListQueues returns all merge queues that have at least one entry.
+		ListQueues(ctx context.Context) ([]types.MergeQueue, error)
+	}
+
+	AutoMergeStore interface {
+		// Find finds the auto-merge of a pull request.
+		Find(ctx context.Context, pullReqID int64) (*types.AutoMerge, error)
+
+		// Upsert enables the auto-merge of a pull request or replaces its merge options.
+		Upsert(ctx context.Context, am *types.AutoMerge) error
+
+		// Delete disables the auto-merge of a pull request.
+		Delete(ctx context.Context, pullReqID int64) error
+
+		// ListByCommitSHA returns auto-merges of all pull requests in a repository
+		// that have the provided commit at the tip of the source branch.
+		ListByCommitSHA(ctx context.Context, repoID int64, commitSHA string) ([]*types.AutoMerge, error)
+	}
+
+	// UserGroupMemberStore defines the user group membership data storage.
+	UserGroupMemberStore interface {
+		// Add adds the principal to the user group. It's a no-op if the principal is already a member.
+		Add(ctx context.Context, userGroupID, principalID int64) error
+
+		// Remove removes the principal from the user group.
+		Remove(ctx context.Context, userGroupID, principalID int64) error
+
+		// ListUserGroupIDs returns IDs of all user groups the principal is a member of.
+		ListUserGroupIDs(ctx context.Context, principalID int64) ([]int64, error)
+
+		// ListPrincipalIDs returns IDs of all principals that are members of any of the user groups.
+		ListPrincipalIDs(ctx context.Context, userGroupIDs []int64) ([]int64, error)
+
+		// ListPrincipalUIDs returns UIDs of all principals that are members of the user group.
+		ListPrincipalUIDs(ctx context.Context, userGroupID int64) ([]string, error)
+	}
+
+	// AuditEventStore defines the audit event data storage.
+	AuditEventStore interface {
+		// Create records a new audit event.
+		Create(ctx context.Context, event *types.AuditEvent) error
+
+		// List returns the audit events matching the filter, the most recent first.
+		List(ctx context.Context, filter *types.AuditEventFilter) ([]*types.AuditEvent, error)
+
+		// Count returns the number of audit events matching the filter.
+		Count(ctx context.Context, filter *types.AuditEventFilter) (int64, error)
+	}
+
+	// NotificationChannelStore defines the notification channel data storage.
+	NotificationChannelStore interface {
+		// Find finds the notification channel by id.
+		Find(ctx context.Context, id int64) (*types.NotificationChannel, error)
+
+		// FindByIdentifier finds the notification channel of a space by its identifier.
+		FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.NotificationChannel, error)
+
+		// Create creates a new notification channel.
+		Create(ctx context.Context, channel *types.NotificationChannel) error
+
+		// Update updates the notification channel.
+		Update(ctx context.Context, channel *types.NotificationChannel) error
+
+		// Delete deletes the notification channel.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the notification channels of a space.
+		List(ctx context.Context, spaceID int64, filter types.ListQueryFilter) ([]*types.NotificationChannel, error)
+
+		// Count returns the number of notification channels of a space.
+		Count(ctx context.Context, spaceID int64, filter types.ListQueryFilter) (int64, error)
+
+		// ListEnabled returns all enabled notification channels of the provided spaces.
+		ListEnabled(ctx context.Context, spaceIDs []int64) ([]*types.NotificationChannel, error)
+	}
+
+	// NotificationPreferenceStore defines the notification preferences data storage.
+	NotificationPreferenceStore interface {
+		// FindSettings returns the user level notification settings of a principal.
+		FindSettings(ctx context.Context, principalID int64) (*types.NotificationSettings, error)
+
+		// ListSettings returns the user level notification settings of the provided principals.
+		// Principals without stored settings are omitted.
+		ListSettings(ctx context.Context, principalIDs []int64) ([]*types.NotificationSettings, error)
+
+		// UpsertSettings creates or updates the user level notification settings of a principal.
+		UpsertSettings(ctx context.Context, settings *types.NotificationSettings) error
+
+		// UpsertSubscription creates or updates a notification subscription.
+		UpsertSubscription(ctx context.Context, subscription *types.NotificationSubscription) error
+
+		// DeleteSubscription deletes the notification subscription of a principal for the provided resource.
+		DeleteSubscription(ctx context.Context, principalID int64, scope enum.NotificationScope, scopeID int64) error
+
+		// ListSubscriptions returns all notification subscriptions of a principal.
+		ListSubscriptions(ctx context.Context, principalID int64) ([]*types.NotificationSubscription, error)
+
+		// ListSubscriptionsForPullReq returns the notification subscriptions of all principals
+		// that apply to a pull request: the subscriptions for the pull request itself,
+		// for its target repository and for the provided spaces.
+		ListSubscriptionsForPullReq(
+			ctx context.Context,
+			pullReqID int64,
+			repoID int64,
+			spaceIDs []int64,
+		) ([]*types.NotificationSubscription, error)
+	}
+
+	// NotificationDigestStore defines the storage of notifications waiting for the daily digest email.
+	NotificationDigestStore interface {
+		// Create stores a new digest item. It's a no-op if the principal already has a digest item for the event.
+		Create(ctx context.Context, item *types.NotificationDigestItem) error
+
+		// ListPrincipalIDs returns the IDs of all principals that have pending digest items.
+		ListPrincipalIDs(ctx context.Context) ([]int64, error)
+
+		// List returns the pending digest items of a principal, oldest first.
+		List(ctx context.Context, principalID int64) ([]*types.NotificationDigestItem, error)
+
+		// Delete deletes the digest items of a principal up to and including the provided item ID.
+		Delete(ctx context.Context, principalID int64, maxID int64) error
+	}
+
+	// PullMirrorStore defines the pull mirror data storage.
+	PullMirrorStore interface {
+		// Find finds the pull mirror of a repository.
+		Find(ctx context.Context, repoID int64) (*types.PullMirror, error)
+
+		// Create creates the pull mirror of a repository.
+		Create(ctx context.Context, mirror *types.PullMirror) error
+
+		// Update updates the pull mirror of a repository.
+		Update(ctx context.Context, mirror *types.PullMirror) error
+
+		// UpdateOptLock updates the pull mirror using the optimistic locking mechanism.
+		UpdateOptLock(
+			ctx context.Context,
+			mirror *types.PullMirror,
+			mutateFn func(mirror *types.PullMirror) error,
+		) (*types.PullMirror, error)
+
+		// Delete deletes the pull mirror of a repository.
+		Delete(ctx context.Context, repoID int64) error
+
+		// ListDue returns up to limit enabled pull mirrors whose periodic sync is due at the provided time.
+		ListDue(ctx context.Context, now int64, limit int) ([]*types.PullMirror, error)
+	}
+
+	PushMirrorStore interface {
+		// Find finds a push mirror by its ID.
+		Find(ctx context.Context, id int64) (*types.PushMirror, error)
+
+		// FindByIdentifier finds a push mirror of a repository by its identifier.
+		FindByIdentifier(ctx context.Context, repoID int64, identifier string) (*types.PushMirror, error)
+
+		// Create creates a new push mirror of a repository.
+		Create(ctx context.Context, mirror *types.PushMirror) error
+
+		// Update updates a push mirror.
+		Update(ctx context.Context, mirror *types.PushMirror) error
+
+		// UpdateOptLock updates the push mirror using the optimistic locking mechanism.
+		UpdateOptLock(
+			ctx context.Context,
+			mirror *types.PushMirror,
+			mutateFn func(mirror *types.PushMirror) error,
+		) (*types.PushMirror, error)
+
+		// Delete deletes a push mirror.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all push mirrors of a repository.
+		List(ctx context.Context, repoID int64) ([]*types.PushMirror, error)
+
+		// ListDue returns up to limit enabled push mirrors with the interval trigger
+		// whose periodic push is due at the provided time.
+		ListDue(ctx context.Context, now int64, limit int) ([]*types.PushMirror, error)
+	}
+
+	PipelineArtifactStore interface {
+		// Find returns an artifact of an execution by its name.
+		Find(ctx context.Context, executionID int64, name string) (*types.Artifact, error)
+
+		// Create creates a new artifact. It returns store.ErrDuplicate if the execution
+		// already has an artifact with the same name.
+		Create(ctx context.Context, artifact *types.Artifact) error
+
+		// List lists the artifacts of an execution.
+		List(ctx context.Context, executionID int64) ([]*types.Artifact, error)
+
+		// ListExpired returns up to limit artifacts that expired before the provided time.
+		ListExpired(ctx context.Context, before int64, limit int) ([]*types.Artifact, error)
+
+		// Delete deletes an artifact.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	PipelineCacheStore interface {
+		// Find returns a build cache entry of a pipeline by its key.
+		Find(ctx context.Context, pipelineID int64, key string) (*types.PipelineCache, error)
+
+		// Upsert creates a new build cache entry or replaces the content of the existing one with the same key.
+		// It returns the blob UID of the replaced content, or an empty string if a new entry was created.
+		Upsert(ctx context.Context, cache *types.PipelineCache) (string, error)
+
+		// Touch updates the last used time of a build cache entry.
+		Touch(ctx context.Context, id int64, lastUsed int64) error
+
+		// ListUnused returns up to limit build cache entries that haven't been used since the provided time.
+		ListUnused(ctx context.Context, before int64, limit int) ([]*types.PipelineCache, error)
+
+		// Delete deletes a build cache entry.
+		Delete(ctx context.Context, id int64) error
+	}
 )
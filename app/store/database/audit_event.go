@@ -0,0 +1,318 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.AuditEventStore = (*AuditEventStore)(nil)
+
+// NewAuditEventStore returns a new AuditEventStore.
+func NewAuditEventStore(db *sqlx.DB) *AuditEventStore {
+	return &AuditEventStore{
+		db: db,
+	}
+}
+
+// AuditEventStore implements a store.AuditEventStore backed by a relational database.
+type AuditEventStore struct {
+	db *sqlx.DB
+}
+
+type auditEvent struct {
+	ID                   int64              `db:"audit_event_id"`
+	Timestamp            int64              `db:"audit_event_timestamp"`
+	Action               string             `db:"audit_event_action"`
+	PrincipalID          int64              `db:"audit_event_principal_id"`
+	PrincipalUID         string             `db:"audit_event_principal_uid"`
+	PrincipalType        enum.PrincipalType `db:"audit_event_principal_type"`
+	PrincipalEmail       string             `db:"audit_event_principal_email"`
+	PrincipalDisplayName string             `db:"audit_event_principal_display_name"`
+	SpacePath            string             `db:"audit_event_space_path"`
+	ResourceType         string             `db:"audit_event_resource_type"`
+	ResourceIdentifier   string             `db:"audit_event_resource_identifier"`
+	ResourceData         string             `db:"audit_event_resource_data"`
+	OldObject            null.String        `db:"audit_event_old_object"`
+	NewObject            null.String        `db:"audit_event_new_object"`
+	ClientIP             string             `db:"audit_event_client_ip"`
+	RequestMethod        string             `db:"audit_event_request_method"`
+	Data                 string             `db:"audit_event_data"`
+}
+
+const (
+	auditEventColumns = `
+		 audit_event_id
+		,audit_event_timestamp
+		,audit_event_action
+		,audit_event_principal_id
+		,audit_event_principal_uid
+		,audit_event_principal_type
+		,audit_event_principal_email
+		,audit_event_principal_display_name
+		,audit_event_space_path
+		,audit_event_resource_type
+		,audit_event_resource_identifier
+		,audit_event_resource_data
+		,audit_event_old_object
+		,audit_event_new_object
+		,audit_event_client_ip
+		,audit_event_request_method
+		,audit_event_data`
+)
+
+// Create records a new audit event.
+func (s *AuditEventStore) Create(ctx context.Context, event *types.AuditEvent) error {
+	const sqlQuery = `
+		INSERT INTO audit_events (
+			 audit_event_timestamp
+			,audit_event_action
+			,audit_event_principal_id
+			,audit_event_principal_uid
+			,audit_event_principal_type
+			,audit_event_principal_email
+			,audit_event_principal_display_name
+			,audit_event_space_path
+			,audit_event_resource_type
+			,audit_event_resource_identifier
+			,audit_event_resource_data
+			,audit_event_old_object
+			,audit_event_new_object
+			,audit_event_client_ip
+			,audit_event_request_method
+			,audit_event_data
+		) VALUES (
+			 :audit_event_timestamp
+			,:audit_event_action
+			,:audit_event_principal_id
+			,:audit_event_principal_uid
+			,:audit_event_principal_type
+			,:audit_event_principal_email
+			,:audit_event_principal_display_name
+			,:audit_event_space_path
+			,:audit_event_resource_type
+			,:audit_event_resource_identifier
+			,:audit_event_resource_data
+			,:audit_event_old_object
+			,:audit_event_new_object
+			,:audit_event_client_ip
+			,:audit_event_request_method
+			,:audit_event_data
+		) RETURNING audit_event_id`
+
+	dbEvent, err := mapInternalAuditEvent(event)
+	if err != nil {
+		return err
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbEvent)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind audit event object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&event.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert audit event query failed")
+	}
+
+	return nil
+}
+
+// List returns the audit events matching the filter, the most recent first.
+func (s *AuditEventStore) List(ctx context.Context, filter *types.AuditEventFilter) ([]*types.AuditEvent, error) {
+	stmt := database.Builder.
+		Select(auditEventColumns).
+		From("audit_events").
+		OrderBy("audit_event_timestamp DESC", "audit_event_id DESC")
+
+	stmt = applyAuditEventFilter(stmt, filter)
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*auditEvent
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list audit events")
+	}
+
+	return mapAuditEvents(dst)
+}
+
+// Count returns the number of audit events matching the filter.
+func (s *AuditEventStore) Count(ctx context.Context, filter *types.AuditEventFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("audit_events")
+
+	stmt = applyAuditEventFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count audit events")
+	}
+
+	return count, nil
+}
+
+func applyAuditEventFilter(stmt squirrel.SelectBuilder, filter *types.AuditEventFilter) squirrel.SelectBuilder {
+	if filter.SpacePath != "" {
+		if filter.Recursive {
+			prefixPred, prefix := PrefixMatch("audit_event_space_path", filter.SpacePath+"/")
+			stmt = stmt.Where(squirrel.Or{
+				squirrel.Expr("LOWER(audit_event_space_path) = LOWER(?)", filter.SpacePath),
+				squirrel.Expr(prefixPred, prefix),
+			})
+		} else {
+			stmt = stmt.Where("LOWER(audit_event_space_path) = LOWER(?)", filter.SpacePath)
+		}
+	}
+
+	if len(filter.Actions) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_action": filter.Actions})
+	}
+
+	if len(filter.ResourceTypes) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_resource_type": filter.ResourceTypes})
+	}
+
+	if filter.PrincipalUID != "" {
+		stmt = stmt.Where("LOWER(audit_event_principal_uid) = ?", strings.ToLower(filter.PrincipalUID))
+	}
+
+	if filter.From > 0 {
+		stmt = stmt.Where("audit_event_timestamp >= ?", filter.From)
+	}
+
+	if filter.To > 0 {
+		stmt = stmt.Where("audit_event_timestamp < ?", filter.To)
+	}
+
+	return stmt
+}
+
+func mapInternalAuditEvent(event *types.AuditEvent) (*auditEvent, error) {
+	resourceData, err := json.Marshal(emptyIfNil(event.ResourceData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit event resource data: %w", err)
+	}
+
+	data, err := json.Marshal(emptyIfNil(event.Data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit event data: %w", err)
+	}
+
+	return &auditEvent{
+		ID:                   event.ID,
+		Timestamp:            event.Timestamp,
+		Action:               event.Action,
+		PrincipalID:          event.Principal.ID,
+		PrincipalUID:         event.Principal.UID,
+		PrincipalType:        event.Principal.Type,
+		PrincipalEmail:       event.Principal.Email,
+		PrincipalDisplayName: event.Principal.DisplayName,
+		SpacePath:            event.SpacePath,
+		ResourceType:         event.ResourceType,
+		ResourceIdentifier:   event.ResourceIdentifier,
+		ResourceData:         string(resourceData),
+		OldObject:            null.NewString(string(event.OldObject), len(event.OldObject) > 0),
+		NewObject:            null.NewString(string(event.NewObject), len(event.NewObject) > 0),
+		ClientIP:             event.ClientIP,
+		RequestMethod:        event.RequestMethod,
+		Data:                 string(data),
+	}, nil
+}
+
+func mapAuditEvent(in *auditEvent) (*types.AuditEvent, error) {
+	event := &types.AuditEvent{
+		ID:        in.ID,
+		Timestamp: in.Timestamp,
+		Action:    in.Action,
+		Principal: types.PrincipalInfo{
+			ID:          in.PrincipalID,
+			UID:         in.PrincipalUID,
+			DisplayName: in.PrincipalDisplayName,
+			Email:       in.PrincipalEmail,
+			Type:        in.PrincipalType,
+		},
+		SpacePath:          in.SpacePath,
+		ResourceType:       in.ResourceType,
+		ResourceIdentifier: in.ResourceIdentifier,
+		ClientIP:           in.ClientIP,
+		RequestMethod:      in.RequestMethod,
+	}
+
+	if in.OldObject.Valid {
+		event.OldObject = json.RawMessage(in.OldObject.String)
+	}
+	if in.NewObject.Valid {
+		event.NewObject = json.RawMessage(in.NewObject.String)
+	}
+
+	if err := json.Unmarshal([]byte(in.ResourceData), &event.ResourceData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit event resource data: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(in.Data), &event.Data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit event data: %w", err)
+	}
+
+	return event, nil
+}
+
+func mapAuditEvents(in []*auditEvent) ([]*types.AuditEvent, error) {
+	events := make([]*types.AuditEvent, len(in))
+	for i := range in {
+		var err error
+		events[i], err = mapAuditEvent(in[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+func emptyIfNil(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
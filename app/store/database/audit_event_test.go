@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditEventStore_List(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+
+	auditEventStore := database.NewAuditEventStore(db)
+
+	events := []*types.AuditEvent{
+		{Timestamp: 1, Action: "created", SpacePath: "acme", ResourceType: "secret", ResourceIdentifier: "s1"},
+		{Timestamp: 2, Action: "deleted", SpacePath: "acme/team", ResourceType: "webhook", ResourceIdentifier: "w1"},
+		{Timestamp: 3, Action: "created", SpacePath: "acme_x", ResourceType: "secret", ResourceIdentifier: "s2"},
+		{Timestamp: 4, Action: "logged_in", ResourceType: "user", ResourceIdentifier: "admin"},
+	}
+	for _, event := range events {
+		event.Principal = types.PrincipalInfo{ID: 1, UID: "admin", Email: "admin@example.com", Type: "user"}
+		event.NewObject = json.RawMessage(`{"identifier":"` + event.ResourceIdentifier + `"}`)
+		require.NoError(t, auditEventStore.Create(ctx, event))
+		require.NotZero(t, event.ID)
+	}
+
+	identifiers := func(filter *types.AuditEventFilter) []string {
+		list, err := auditEventStore.List(ctx, filter)
+		require.NoError(t, err)
+
+		count, err := auditEventStore.Count(ctx, filter)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(list)), count)
+
+		result := make([]string, len(list))
+		for i, event := range list {
+			result[i] = event.ResourceIdentifier
+		}
+		return result
+	}
+
+	require.Equal(t, []string{"admin", "s2", "w1", "s1"}, identifiers(&types.AuditEventFilter{}))
+	require.Equal(t, []string{"s1"}, identifiers(&types.AuditEventFilter{SpacePath: "ACME"}))
+	require.Equal(t, []string{"w1", "s1"}, identifiers(&types.AuditEventFilter{SpacePath: "acme", Recursive: true}))
+	require.Equal(t, []string{"s2", "s1"}, identifiers(&types.AuditEventFilter{ResourceTypes: []string{"secret"}}))
+	require.Equal(t, []string{"w1"}, identifiers(&types.AuditEventFilter{Actions: []string{"deleted"}}))
+	require.Equal(t, []string{"s2", "w1"}, identifiers(&types.AuditEventFilter{From: 2, To: 4}))
+
+	list, err := auditEventStore.List(ctx, &types.AuditEventFilter{Actions: []string{"logged_in"}})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "admin@example.com", list[0].Principal.Email)
+	require.JSONEq(t, `{"identifier":"admin"}`, string(list[0].NewObject))
+	require.Nil(t, list[0].OldObject)
+}
@@ -0,0 +1,213 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.AutoMergeStore = (*AutoMergeStore)(nil)
+
+// NewAutoMergeStore returns a new AutoMergeStore.
+func NewAutoMergeStore(db *sqlx.DB) *AutoMergeStore {
+	return &AutoMergeStore{
+		db: db,
+	}
+}
+
+// AutoMergeStore implements a store.AutoMergeStore backed by a relational database.
+type AutoMergeStore struct {
+	db *sqlx.DB
+}
+
+type autoMerge struct {
+	PullReqID int64            `db:"auto_merge_pullreq_id"`
+	RepoID    int64            `db:"auto_merge_repo_id"`
+	Method    enum.MergeMethod `db:"auto_merge_method"`
+	Title     string           `db:"auto_merge_title"`
+	Message   string           `db:"auto_merge_message"`
+	CreatedBy int64            `db:"auto_merge_created_by"`
+	Created   int64            `db:"auto_merge_created"`
+}
+
+const (
+	autoMergeColumns = `
+		 auto_merge_pullreq_id
+		,auto_merge_repo_id
+		,auto_merge_method
+		,auto_merge_title
+		,auto_merge_message
+		,auto_merge_created_by
+		,auto_merge_created`
+)
+
+// Find finds the auto-merge of a pull request.
+func (s *AutoMergeStore) Find(ctx context.Context, pullReqID int64) (*types.AutoMerge, error) {
+	stmt := database.Builder.
+		Select(autoMergeColumns).
+		From("auto_merges").
+		Where("auto_merge_pullreq_id = ?", pullReqID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &autoMerge{}
+	if err := db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find auto-merge")
+	}
+
+	return mapAutoMerge(dst), nil
+}
+
+// Upsert enables the auto-merge of a pull request or replaces its merge options.
+func (s *AutoMergeStore) Upsert(ctx context.Context, am *types.AutoMerge) error {
+	const sqlQuery = `
+		INSERT INTO auto_merges (
+			 auto_merge_pullreq_id
+			,auto_merge_repo_id
+			,auto_merge_method
+			,auto_merge_title
+			,auto_merge_message
+			,auto_merge_created_by
+			,auto_merge_created
+		) VALUES (
+			 :auto_merge_pullreq_id
+			,:auto_merge_repo_id
+			,:auto_merge_method
+			,:auto_merge_title
+			,:auto_merge_message
+			,:auto_merge_created_by
+			,:auto_merge_created
+		)
+		ON CONFLICT (auto_merge_pullreq_id) DO
+		UPDATE SET
+			 auto_merge_method = :auto_merge_method
+			,auto_merge_title = :auto_merge_title
+			,auto_merge_message = :auto_merge_message
+			,auto_merge_created_by = :auto_merge_created_by
+			,auto_merge_created = :auto_merge_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalAutoMerge(am))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind auto-merge object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert auto-merge query failed")
+	}
+
+	return nil
+}
+
+// Delete disables the auto-merge of a pull request.
+// It returns store.ErrResourceNotFound if the auto-merge wasn't enabled.
+func (s *AutoMergeStore) Delete(ctx context.Context, pullReqID int64) error {
+	const sqlQuery = `
+		DELETE FROM auto_merges
+		WHERE auto_merge_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, pullReqID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListByCommitSHA returns auto-merges of all pull requests in a repository
+// that have the provided commit at the tip of the source branch.
+func (s *AutoMergeStore) ListByCommitSHA(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+) ([]*types.AutoMerge, error) {
+	stmt := database.Builder.
+		Select(autoMergeColumns).
+		From("auto_merges").
+		InnerJoin("pullreqs ON pullreq_id = auto_merge_pullreq_id").
+		Where("auto_merge_repo_id = ?", repoID).
+		Where("pullreq_source_sha = ?", commitSHA).
+		OrderBy("auto_merge_pullreq_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*autoMerge
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list auto-merges")
+	}
+
+	res := make([]*types.AutoMerge, len(dst))
+	for i := range dst {
+		res[i] = mapAutoMerge(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapInternalAutoMerge(am *types.AutoMerge) *autoMerge {
+	return &autoMerge{
+		PullReqID: am.PullReqID,
+		RepoID:    am.RepoID,
+		Method:    am.Method,
+		Title:     am.Title,
+		Message:   am.Message,
+		CreatedBy: am.CreatedBy,
+		Created:   am.Created,
+	}
+}
+
+func mapAutoMerge(am *autoMerge) *types.AutoMerge {
+	return &types.AutoMerge{
+		PullReqID: am.PullReqID,
+		RepoID:    am.RepoID,
+		Method:    am.Method,
+		Title:     am.Title,
+		Message:   am.Message,
+		CreatedBy: am.CreatedBy,
+		Created:   am.Created,
+	}
+}
@@ -33,6 +33,37 @@ const (
 // https://www.postgresql.org/docs/current/functions-matching.html#FUNCTIONS-LIKE
 // https://www.sqlite.org/lang_expr.html#the_like_glob_regexp_match_and_extract_operators
 func PartialMatch(column, value string) (string, string) {
+	value, escaped := escapeLikeValue(value)
+
+	sb := strings.Builder{}
+	sb.WriteString("LOWER(")
+	sb.WriteString(column)
+	sb.WriteString(") LIKE '%' || LOWER(?) || '%'")
+	if escaped {
+		sb.WriteString(` ESCAPE '\'`)
+	}
+
+	return sb.String(), value
+}
+
+// PrefixMatch builds a string pair that can be passed as a parameter to squirrel's Where() function
+// for a case-insensitive SQL "LIKE" expression that matches values starting with the provided prefix.
+// The metacharacters are escaped the same way as in PartialMatch.
+func PrefixMatch(column, prefix string) (string, string) {
+	prefix, escaped := escapeLikeValue(prefix)
+
+	sb := strings.Builder{}
+	sb.WriteString("LOWER(")
+	sb.WriteString(column)
+	sb.WriteString(") LIKE LOWER(?) || '%'")
+	if escaped {
+		sb.WriteString(` ESCAPE '\'`)
+	}
+
+	return sb.String(), prefix
+}
+
+func escapeLikeValue(value string) (string, bool) {
 	var (
 		n       int
 		escaped bool
@@ -48,13 +79,5 @@ func PartialMatch(column, value string) (string, string) {
 		escaped = true
 	}
 
-	sb := strings.Builder{}
-	sb.WriteString("LOWER(")
-	sb.WriteString(column)
-	sb.WriteString(") LIKE '%' || LOWER(?) || '%'")
-	if escaped {
-		sb.WriteString(` ESCAPE '\'`)
-	}
-
-	return sb.String(), value
+	return value, escaped
 }
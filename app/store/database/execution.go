@@ -79,6 +79,8 @@ type execution struct {
 	Deploy       string             `db:"execution_deploy"`
 	DeployID     int64              `db:"execution_deploy_id"`
 	Debug        bool               `db:"execution_debug"`
+	Concurrency  string             `db:"execution_concurrency_group"`
+	SupersededBy int64              `db:"execution_superseded_by"`
 	Started      int64              `db:"execution_started"`
 	Finished     int64              `db:"execution_finished"`
 	Created      int64              `db:"execution_created"`
@@ -125,6 +127,8 @@ const (
 		,execution_deploy
 		,execution_deploy_id
 		,execution_debug
+		,execution_concurrency_group
+		,execution_superseded_by
 		,execution_started
 		,execution_finished
 		,execution_created
@@ -213,6 +217,8 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		,execution_deploy
 		,execution_deploy_id
 		,execution_debug
+		,execution_concurrency_group
+		,execution_superseded_by
 		,execution_started
 		,execution_finished
 		,execution_created
@@ -249,6 +255,8 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		,:execution_deploy
 		,:execution_deploy_id
 		,:execution_debug
+		,:execution_concurrency_group
+		,:execution_superseded_by
 		,:execution_started
 		,:execution_finished
 		,:execution_created
@@ -279,6 +287,7 @@ func (s *executionStore) Update(ctx context.Context, e *types.Execution) error {
 		,execution_event = :execution_event
 		,execution_started = :execution_started
 		,execution_finished = :execution_finished
+		,execution_superseded_by = :execution_superseded_by
 		,execution_updated = :execution_updated
 		,execution_version = :execution_version
 	WHERE execution_id = :execution_id AND execution_version = :execution_version - 1`
@@ -398,6 +407,31 @@ func (s *executionStore) ListInSpace(
 	return convertExecutionPipelineRepoJoins(dst)
 }
 
+// ListIncompleteInConcurrencyGroup lists the pending and running executions of a concurrency group
+// in a repository, ordered by execution ID.
+func (s *executionStore) ListIncompleteInConcurrencyGroup(
+	ctx context.Context,
+	repoID int64,
+	group string,
+) ([]*types.Execution, error) {
+	const queryListIncomplete = `
+	SELECT` + executionColumns + `
+	FROM executions
+	WHERE execution_repo_id = $1 AND
+		execution_concurrency_group = $2 AND
+		execution_status IN ('pending','running')
+	ORDER BY execution_id ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*execution{}
+	if err := db.SelectContext(ctx, &dst, queryListIncomplete, repoID, group); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list incomplete executions in concurrency group")
+	}
+
+	return mapInternalToExecutionList(dst)
+}
+
 func (s executionStore) ListByPipelineIDs(
 	ctx context.Context,
 	pipelineIDs []int64,
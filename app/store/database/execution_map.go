@@ -61,6 +61,9 @@ func mapInternalToExecution(in *execution) (*types.Execution, error) {
 		Created:      in.Created,
 		Updated:      in.Updated,
 		Version:      in.Version,
+
+		ConcurrencyGroup: in.Concurrency,
+		SupersededBy:     in.SupersededBy,
 	}, nil
 }
 
@@ -102,6 +105,8 @@ func mapExecutionToInternal(in *types.Execution) *execution {
 		Created:      in.Created,
 		Updated:      in.Updated,
 		Version:      in.Version,
+		Concurrency:  in.ConcurrencyGroup,
+		SupersededBy: in.SupersededBy,
 	}
 }
 
@@ -0,0 +1,369 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MergeQueueStore = (*MergeQueueStore)(nil)
+
+// NewMergeQueueStore returns a new MergeQueueStore.
+func NewMergeQueueStore(db *sqlx.DB) *MergeQueueStore {
+	return &MergeQueueStore{
+		db: db,
+	}
+}
+
+// MergeQueueStore implements a store.MergeQueueStore backed by a relational database.
+type MergeQueueStore struct {
+	db *sqlx.DB
+}
+
+type mergeQueueEntry struct {
+	ID                 int64                     `db:"merge_queue_entry_id"`
+	RepoID             int64                     `db:"merge_queue_entry_repo_id"`
+	PullReqID          int64                     `db:"merge_queue_entry_pullreq_id"`
+	PullReqNumber      int64                     `db:"merge_queue_entry_pullreq_number"`
+	TargetBranch       string                    `db:"merge_queue_entry_target_branch"`
+	State              enum.MergeQueueEntryState `db:"merge_queue_entry_state"`
+	Method             enum.MergeMethod          `db:"merge_queue_entry_method"`
+	Title              string                    `db:"merge_queue_entry_title"`
+	Message            string                    `db:"merge_queue_entry_message"`
+	RulesBypassed      bool                      `db:"merge_queue_entry_rules_bypassed"`
+	DeleteSourceBranch bool                      `db:"merge_queue_entry_delete_source_branch"`
+	SourceSHA          string                    `db:"merge_queue_entry_source_sha"`
+	BaseSHA            string                    `db:"merge_queue_entry_base_sha"`
+	MergeBaseSHA       string                    `db:"merge_queue_entry_merge_base_sha"`
+	MergeSHA           string                    `db:"merge_queue_entry_merge_sha"`
+	CheckStarted       int64                     `db:"merge_queue_entry_check_started"`
+	CreatedBy          int64                     `db:"merge_queue_entry_created_by"`
+	Created            int64                     `db:"merge_queue_entry_created"`
+	Updated            int64                     `db:"merge_queue_entry_updated"`
+	Version            int64                     `db:"merge_queue_entry_version"`
+}
+
+const (
+	mergeQueueEntryColumns = `
+		 merge_queue_entry_id
+		,merge_queue_entry_repo_id
+		,merge_queue_entry_pullreq_id
+		,merge_queue_entry_pullreq_number
+		,merge_queue_entry_target_branch
+		,merge_queue_entry_state
+		,merge_queue_entry_method
+		,merge_queue_entry_title
+		,merge_queue_entry_message
+		,merge_queue_entry_rules_bypassed
+		,merge_queue_entry_delete_source_branch
+		,merge_queue_entry_source_sha
+		,merge_queue_entry_base_sha
+		,merge_queue_entry_merge_base_sha
+		,merge_queue_entry_merge_sha
+		,merge_queue_entry_check_started
+		,merge_queue_entry_created_by
+		,merge_queue_entry_created
+		,merge_queue_entry_updated
+		,merge_queue_entry_version`
+)
+
+// Find finds a merge queue entry by id.
+func (s *MergeQueueStore) Find(ctx context.Context, id int64) (*types.MergeQueueEntry, error) {
+	return s.find(ctx, "merge_queue_entry_id = ?", id)
+}
+
+// FindByPullReqID finds the merge queue entry of a pull request.
+func (s *MergeQueueStore) FindByPullReqID(ctx context.Context, pullReqID int64) (*types.MergeQueueEntry, error) {
+	return s.find(ctx, "merge_queue_entry_pullreq_id = ?", pullReqID)
+}
+
+func (s *MergeQueueStore) find(ctx context.Context, pred string, arg any) (*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where(pred, arg)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &mergeQueueEntry{}
+	if err := db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find merge queue entry")
+	}
+
+	return mapMergeQueueEntry(dst), nil
+}
+
+// Create adds a new entry to the end of a merge queue.
+func (s *MergeQueueStore) Create(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		INSERT INTO merge_queue_entries (
+			 merge_queue_entry_repo_id
+			,merge_queue_entry_pullreq_id
+			,merge_queue_entry_pullreq_number
+			,merge_queue_entry_target_branch
+			,merge_queue_entry_state
+			,merge_queue_entry_method
+			,merge_queue_entry_title
+			,merge_queue_entry_message
+			,merge_queue_entry_rules_bypassed
+			,merge_queue_entry_delete_source_branch
+			,merge_queue_entry_source_sha
+			,merge_queue_entry_base_sha
+			,merge_queue_entry_merge_base_sha
+			,merge_queue_entry_merge_sha
+			,merge_queue_entry_check_started
+			,merge_queue_entry_created_by
+			,merge_queue_entry_created
+			,merge_queue_entry_updated
+			,merge_queue_entry_version
+		) VALUES (
+			 :merge_queue_entry_repo_id
+			,:merge_queue_entry_pullreq_id
+			,:merge_queue_entry_pullreq_number
+			,:merge_queue_entry_target_branch
+			,:merge_queue_entry_state
+			,:merge_queue_entry_method
+			,:merge_queue_entry_title
+			,:merge_queue_entry_message
+			,:merge_queue_entry_rules_bypassed
+			,:merge_queue_entry_delete_source_branch
+			,:merge_queue_entry_source_sha
+			,:merge_queue_entry_base_sha
+			,:merge_queue_entry_merge_base_sha
+			,:merge_queue_entry_merge_sha
+			,:merge_queue_entry_check_started
+			,:merge_queue_entry_created_by
+			,:merge_queue_entry_created
+			,:merge_queue_entry_updated
+			,:merge_queue_entry_version
+		) RETURNING merge_queue_entry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalMergeQueueEntry(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&entry.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert merge queue entry query failed")
+	}
+
+	return nil
+}
+
+// Update updates a merge queue entry.
+func (s *MergeQueueStore) Update(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		UPDATE merge_queue_entries
+		SET
+			 merge_queue_entry_version = :merge_queue_entry_version
+			,merge_queue_entry_updated = :merge_queue_entry_updated
+			,merge_queue_entry_state = :merge_queue_entry_state
+			,merge_queue_entry_base_sha = :merge_queue_entry_base_sha
+			,merge_queue_entry_merge_base_sha = :merge_queue_entry_merge_base_sha
+			,merge_queue_entry_merge_sha = :merge_queue_entry_merge_sha
+			,merge_queue_entry_check_started = :merge_queue_entry_check_started
+		WHERE merge_queue_entry_id = :merge_queue_entry_id AND
+			merge_queue_entry_version = :merge_queue_entry_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbEntry := mapInternalMergeQueueEntry(entry)
+
+	// update Version (used for optimistic locking) and Updated time
+	dbEntry.Version++
+	dbEntry.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbEntry)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update merge queue entry")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	entry.Version = dbEntry.Version
+	entry.Updated = dbEntry.Updated
+
+	return nil
+}
+
+// Delete removes an entry from a merge queue.
+func (s *MergeQueueStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM merge_queue_entries
+		WHERE merge_queue_entry_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// List returns merge queue entries of a repository in the queue order.
+func (s *MergeQueueStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.MergeQueueFilter,
+) ([]*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where("merge_queue_entry_repo_id = ?", repoID).
+		OrderBy("merge_queue_entry_id ASC")
+
+	if filter != nil && filter.TargetBranch != "" {
+		stmt = stmt.Where("merge_queue_entry_target_branch = ?", filter.TargetBranch)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*mergeQueueEntry
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queue entries")
+	}
+
+	return mapMergeQueueEntries(dst), nil
+}
+
+// ListQueues returns all merge queues that have at least one entry.
+func (s *MergeQueueStore) ListQueues(ctx context.Context) ([]types.MergeQueue, error) {
+	stmt := database.Builder.
+		Select("DISTINCT merge_queue_entry_repo_id, merge_queue_entry_target_branch").
+		From("merge_queue_entries").
+		OrderBy("merge_queue_entry_repo_id", "merge_queue_entry_target_branch")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queues")
+	}
+	defer rows.Close()
+
+	var queues []types.MergeQueue
+	for rows.Next() {
+		var q types.MergeQueue
+		if err := rows.Scan(&q.RepoID, &q.TargetBranch); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan merge queue")
+		}
+		queues = append(queues, q)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queues")
+	}
+
+	return queues, nil
+}
+
+func mapInternalMergeQueueEntry(entry *types.MergeQueueEntry) *mergeQueueEntry {
+	return &mergeQueueEntry{
+		ID:                 entry.ID,
+		RepoID:             entry.RepoID,
+		PullReqID:          entry.PullReqID,
+		PullReqNumber:      entry.PullReqNumber,
+		TargetBranch:       entry.TargetBranch,
+		State:              entry.State,
+		Method:             entry.Method,
+		Title:              entry.Title,
+		Message:            entry.Message,
+		RulesBypassed:      entry.RulesBypassed,
+		DeleteSourceBranch: entry.DeleteSourceBranch,
+		SourceSHA:          entry.SourceSHA,
+		BaseSHA:            entry.BaseSHA,
+		MergeBaseSHA:       entry.MergeBaseSHA,
+		MergeSHA:           entry.MergeSHA,
+		CheckStarted:       entry.CheckStarted,
+		CreatedBy:          entry.CreatedBy,
+		Created:            entry.Created,
+		Updated:            entry.Updated,
+		Version:            entry.Version,
+	}
+}
+
+func mapMergeQueueEntry(entry *mergeQueueEntry) *types.MergeQueueEntry {
+	return &types.MergeQueueEntry{
+		ID:                 entry.ID,
+		RepoID:             entry.RepoID,
+		PullReqID:          entry.PullReqID,
+		PullReqNumber:      entry.PullReqNumber,
+		TargetBranch:       entry.TargetBranch,
+		State:              entry.State,
+		Method:             entry.Method,
+		Title:              entry.Title,
+		Message:            entry.Message,
+		RulesBypassed:      entry.RulesBypassed,
+		DeleteSourceBranch: entry.DeleteSourceBranch,
+		SourceSHA:          entry.SourceSHA,
+		BaseSHA:            entry.BaseSHA,
+		MergeBaseSHA:       entry.MergeBaseSHA,
+		MergeSHA:           entry.MergeSHA,
+		CheckStarted:       entry.CheckStarted,
+		CreatedBy:          entry.CreatedBy,
+		Created:            entry.Created,
+		Updated:            entry.Updated,
+		Version:            entry.Version,
+	}
+}
+
+func mapMergeQueueEntries(entries []*mergeQueueEntry) []*types.MergeQueueEntry {
+	res := make([]*types.MergeQueueEntry, len(entries))
+	for i := range entries {
+		res[i] = mapMergeQueueEntry(entries[i])
+	}
+	return res
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeQueueStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+	createRepo(ctx, t, repoStore, 2, 1, 0)
+
+	pullReqStore := database.NewPullReqStore(db, nil)
+	mergeQueueStore := database.NewMergeQueueStore(db)
+
+	prs := []*types.PullReq{
+		{Number: 1, TargetRepoID: 1, SourceRepoID: 1, SourceBranch: "a", TargetBranch: "main"},
+		{Number: 2, TargetRepoID: 1, SourceRepoID: 1, SourceBranch: "b", TargetBranch: "main"},
+		{Number: 3, TargetRepoID: 1, SourceRepoID: 1, SourceBranch: "c", TargetBranch: "release"},
+		{Number: 1, TargetRepoID: 2, SourceRepoID: 2, SourceBranch: "a", TargetBranch: "main"},
+	}
+	for _, pr := range prs {
+		pr.CreatedBy = userID
+		pr.State = enum.PullReqStateOpen
+		pr.Title = "title"
+		pr.SourceSHA = "1111111111111111111111111111111111111111"
+		require.NoError(t, pullReqStore.Create(ctx, pr))
+	}
+
+	entries := make([]*types.MergeQueueEntry, len(prs))
+	for i, pr := range prs {
+		entries[i] = &types.MergeQueueEntry{
+			RepoID:             pr.TargetRepoID,
+			PullReqID:          pr.ID,
+			PullReqNumber:      pr.Number,
+			TargetBranch:       pr.TargetBranch,
+			State:              enum.MergeQueueEntryStateQueued,
+			Method:             enum.MergeMethodSquash,
+			Title:              "title",
+			Message:            "message",
+			RulesBypassed:      i == 1,
+			DeleteSourceBranch: true,
+			SourceSHA:          pr.SourceSHA,
+			CreatedBy:          userID,
+			Created:            int64(i + 1),
+			Updated:            int64(i + 1),
+		}
+		require.NoError(t, mergeQueueStore.Create(ctx, entries[i]))
+		require.NotZero(t, entries[i].ID)
+	}
+
+	// a pull request can be in the merge queue only once.
+	duplicate := *entries[0]
+	duplicate.ID = 0
+	require.ErrorIs(t, mergeQueueStore.Create(ctx, &duplicate), gitness_store.ErrDuplicate)
+
+	entry, err := mergeQueueStore.Find(ctx, entries[1].ID)
+	require.NoError(t, err)
+	require.Equal(t, entries[1], entry)
+
+	entry, err = mergeQueueStore.FindByPullReqID(ctx, prs[2].ID)
+	require.NoError(t, err)
+	require.Equal(t, entries[2], entry)
+
+	list, err := mergeQueueStore.List(ctx, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, []*types.MergeQueueEntry{entries[0], entries[1], entries[2]}, list)
+
+	list, err = mergeQueueStore.List(ctx, 1, &types.MergeQueueFilter{TargetBranch: "main"})
+	require.NoError(t, err)
+	require.Equal(t, []*types.MergeQueueEntry{entries[0], entries[1]}, list)
+
+	queues, err := mergeQueueStore.ListQueues(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []types.MergeQueue{
+		{RepoID: 1, TargetBranch: "main"},
+		{RepoID: 1, TargetBranch: "release"},
+		{RepoID: 2, TargetBranch: "main"},
+	}, queues)
+
+	// update
+
+	entry = entries[0]
+	entry.State = enum.MergeQueueEntryStateChecking
+	entry.BaseSHA = "2222222222222222222222222222222222222222"
+	entry.MergeBaseSHA = "3333333333333333333333333333333333333333"
+	entry.MergeSHA = "4444444444444444444444444444444444444444"
+	entry.CheckStarted = 100
+	require.NoError(t, mergeQueueStore.Update(ctx, entry))
+	require.Equal(t, int64(1), entry.Version)
+
+	updated, err := mergeQueueStore.Find(ctx, entry.ID)
+	require.NoError(t, err)
+	require.Equal(t, entry, updated)
+
+	stale := *entry
+	stale.Version = 0
+	require.ErrorIs(t, mergeQueueStore.Update(ctx, &stale), gitness_store.ErrVersionConflict)
+
+	// delete
+
+	require.NoError(t, mergeQueueStore.Delete(ctx, entries[0].ID))
+
+	_, err = mergeQueueStore.Find(ctx, entries[0].ID)
+	require.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+
+	list, err = mergeQueueStore.List(ctx, 1, &types.MergeQueueFilter{TargetBranch: "main"})
+	require.NoError(t, err)
+	require.Equal(t, []*types.MergeQueueEntry{entries[1]}, list)
+}
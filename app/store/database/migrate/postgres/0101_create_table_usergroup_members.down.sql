@@ -0,0 +1 @@
+DROP TABLE usergroup_members;
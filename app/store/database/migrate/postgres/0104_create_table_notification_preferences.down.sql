@@ -0,0 +1,3 @@
+DROP TABLE notification_digest_items;
+DROP TABLE notification_subscriptions;
+DROP TABLE notification_settings;
@@ -0,0 +1,48 @@
+CREATE TABLE notification_settings (
+ notification_settings_principal_id INTEGER PRIMARY KEY
+,notification_settings_delivery TEXT NOT NULL
+,notification_settings_disabled_events TEXT NOT NULL
+,notification_settings_updated BIGINT NOT NULL
+,CONSTRAINT fk_notification_settings_principal_id FOREIGN KEY (notification_settings_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE TABLE notification_subscriptions (
+ notification_subscription_id SERIAL PRIMARY KEY
+,notification_subscription_principal_id INTEGER NOT NULL
+,notification_subscription_scope TEXT NOT NULL
+,notification_subscription_scope_id INTEGER NOT NULL
+,notification_subscription_level TEXT NOT NULL
+,notification_subscription_disabled_events TEXT NOT NULL
+,notification_subscription_created BIGINT NOT NULL
+,notification_subscription_updated BIGINT NOT NULL
+,CONSTRAINT fk_notification_subscription_principal_id FOREIGN KEY (notification_subscription_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX notification_subscriptions_principal_id_scope_scope_id
+    ON notification_subscriptions(notification_subscription_principal_id
+        , notification_subscription_scope
+        , notification_subscription_scope_id);
+
+CREATE INDEX notification_subscriptions_scope_scope_id
+    ON notification_subscriptions(notification_subscription_scope, notification_subscription_scope_id);
+
+CREATE TABLE notification_digest_items (
+ notification_digest_item_id SERIAL PRIMARY KEY
+,notification_digest_item_principal_id INTEGER NOT NULL
+,notification_digest_item_subject TEXT NOT NULL
+,notification_digest_item_body TEXT NOT NULL
+,notification_digest_item_created BIGINT NOT NULL
+,CONSTRAINT fk_notification_digest_item_principal_id FOREIGN KEY (notification_digest_item_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX notification_digest_items_principal_id
+    ON notification_digest_items(notification_digest_item_principal_id);
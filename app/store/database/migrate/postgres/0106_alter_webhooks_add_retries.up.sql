@@ -0,0 +1,8 @@
+ALTER TABLE webhooks ADD COLUMN webhook_max_retries INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE webhooks ADD COLUMN webhook_consecutive_failures INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE webhook_executions ADD COLUMN webhook_execution_attempt INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE webhook_executions ADD COLUMN webhook_execution_next_retry_at BIGINT;
+
+CREATE INDEX webhook_executions_retrigger_of
+    ON webhook_executions(webhook_execution_retrigger_of);
@@ -0,0 +1 @@
+ALTER TABLE triggers ADD COLUMN trigger_paths TEXT NOT NULL DEFAULT '{}';
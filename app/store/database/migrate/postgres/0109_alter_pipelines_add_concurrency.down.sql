@@ -0,0 +1,9 @@
+DROP INDEX executions_repo_id_concurrency_group;
+
+ALTER TABLE stages DROP COLUMN stage_concurrency_group;
+
+ALTER TABLE executions DROP COLUMN execution_superseded_by;
+ALTER TABLE executions DROP COLUMN execution_concurrency_group;
+
+ALTER TABLE pipelines DROP COLUMN pipeline_concurrency_policy;
+ALTER TABLE pipelines DROP COLUMN pipeline_concurrency_group;
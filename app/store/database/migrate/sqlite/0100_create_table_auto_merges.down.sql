@@ -0,0 +1 @@
+DROP TABLE auto_merges;
@@ -0,0 +1,2 @@
+ALTER TABLE webhooks DROP COLUMN webhook_payload_template;
+ALTER TABLE webhooks DROP COLUMN webhook_payload_format;
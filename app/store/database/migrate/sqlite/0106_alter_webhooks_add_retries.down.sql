@@ -0,0 +1,7 @@
+DROP INDEX webhook_executions_retrigger_of;
+
+ALTER TABLE webhook_executions DROP COLUMN webhook_execution_next_retry_at;
+ALTER TABLE webhook_executions DROP COLUMN webhook_execution_attempt;
+
+ALTER TABLE webhooks DROP COLUMN webhook_consecutive_failures;
+ALTER TABLE webhooks DROP COLUMN webhook_max_retries;
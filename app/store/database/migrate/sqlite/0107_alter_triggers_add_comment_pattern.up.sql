@@ -0,0 +1 @@
+ALTER TABLE triggers ADD COLUMN trigger_comment_pattern TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,37 @@
+CREATE TABLE pipeline_artifacts (
+ artifact_id INTEGER PRIMARY KEY AUTOINCREMENT
+,artifact_repo_id INTEGER NOT NULL
+,artifact_execution_id INTEGER NOT NULL
+,artifact_stage_number INTEGER NOT NULL
+,artifact_name TEXT NOT NULL
+,artifact_blob_uid TEXT NOT NULL
+,artifact_size BIGINT NOT NULL
+,artifact_created_by INTEGER NOT NULL
+,artifact_created BIGINT NOT NULL
+,artifact_expires BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX pipeline_artifacts_execution_id_name
+    ON pipeline_artifacts(artifact_execution_id, artifact_name);
+
+CREATE INDEX pipeline_artifacts_expires
+    ON pipeline_artifacts(artifact_expires);
+
+CREATE TABLE pipeline_caches (
+ cache_id INTEGER PRIMARY KEY AUTOINCREMENT
+,cache_repo_id INTEGER NOT NULL
+,cache_pipeline_id INTEGER NOT NULL
+,cache_key TEXT NOT NULL
+,cache_blob_uid TEXT NOT NULL
+,cache_size BIGINT NOT NULL
+,cache_created_by INTEGER NOT NULL
+,cache_created BIGINT NOT NULL
+,cache_updated BIGINT NOT NULL
+,cache_last_used BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX pipeline_caches_pipeline_id_key
+    ON pipeline_caches(cache_pipeline_id, cache_key);
+
+CREATE INDEX pipeline_caches_last_used
+    ON pipeline_caches(cache_last_used);
@@ -0,0 +1,39 @@
+CREATE TABLE push_mirrors (
+ push_mirror_id INTEGER PRIMARY KEY AUTOINCREMENT
+,push_mirror_repo_id INTEGER NOT NULL
+,push_mirror_identifier TEXT NOT NULL
+,push_mirror_url TEXT NOT NULL
+,push_mirror_username TEXT NOT NULL
+,push_mirror_secret_id INTEGER
+,push_mirror_ref_filter TEXT NOT NULL
+,push_mirror_trigger TEXT NOT NULL
+,push_mirror_interval INTEGER NOT NULL
+,push_mirror_enabled BOOLEAN NOT NULL
+,push_mirror_status TEXT NOT NULL
+,push_mirror_last_success BIGINT NOT NULL
+,push_mirror_last_error TEXT NOT NULL
+,push_mirror_next_sync BIGINT NOT NULL
+,push_mirror_created_by INTEGER NOT NULL
+,push_mirror_created BIGINT NOT NULL
+,push_mirror_updated BIGINT NOT NULL
+,push_mirror_version INTEGER NOT NULL
+,CONSTRAINT fk_push_mirror_repo_id FOREIGN KEY (push_mirror_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_push_mirror_secret_id FOREIGN KEY (push_mirror_secret_id)
+    REFERENCES secrets (secret_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE SET NULL
+,CONSTRAINT fk_push_mirror_created_by FOREIGN KEY (push_mirror_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX push_mirrors_repo_id_identifier
+    ON push_mirrors(push_mirror_repo_id, LOWER(push_mirror_identifier));
+
+CREATE INDEX push_mirrors_next_sync
+    ON push_mirrors(push_mirror_next_sync)
+    WHERE push_mirror_enabled = TRUE AND push_mirror_trigger = 'interval';
@@ -0,0 +1,3 @@
+DROP INDEX notification_digest_items_principal_id_event_id;
+
+ALTER TABLE notification_digest_items DROP COLUMN notification_digest_item_event_id;
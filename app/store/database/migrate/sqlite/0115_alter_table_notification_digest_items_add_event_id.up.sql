@@ -0,0 +1,5 @@
+ALTER TABLE notification_digest_items ADD COLUMN notification_digest_item_event_id TEXT NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX notification_digest_items_principal_id_event_id
+    ON notification_digest_items(notification_digest_item_principal_id, notification_digest_item_event_id)
+    WHERE notification_digest_item_event_id <> '';
@@ -0,0 +1,357 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationChannelStore = (*NotificationChannelStore)(nil)
+
+// NewNotificationChannelStore returns a new NotificationChannelStore.
+func NewNotificationChannelStore(db *sqlx.DB) *NotificationChannelStore {
+	return &NotificationChannelStore{
+		db: db,
+	}
+}
+
+// NotificationChannelStore implements a store.NotificationChannelStore backed by a relational database.
+type NotificationChannelStore struct {
+	db *sqlx.DB
+}
+
+type notificationChannel struct {
+	ID          int64                        `db:"notification_channel_id"`
+	SpaceID     int64                        `db:"notification_channel_space_id"`
+	Identifier  string                       `db:"notification_channel_uid"`
+	Description string                       `db:"notification_channel_description"`
+	Type        enum.NotificationChannelType `db:"notification_channel_type"`
+	URL         string                       `db:"notification_channel_url"`
+	Enabled     bool                         `db:"notification_channel_enabled"`
+	Triggers    string                       `db:"notification_channel_triggers"`
+	CreatedBy   int64                        `db:"notification_channel_created_by"`
+	Created     int64                        `db:"notification_channel_created"`
+	Updated     int64                        `db:"notification_channel_updated"`
+	Version     int64                        `db:"notification_channel_version"`
+}
+
+const (
+	notificationChannelColumns = `
+		 notification_channel_id
+		,notification_channel_space_id
+		,notification_channel_uid
+		,notification_channel_description
+		,notification_channel_type
+		,notification_channel_url
+		,notification_channel_enabled
+		,notification_channel_triggers
+		,notification_channel_created_by
+		,notification_channel_created
+		,notification_channel_updated
+		,notification_channel_version`
+
+	notificationChannelSelectBase = `
+	SELECT` + notificationChannelColumns + `
+	FROM notification_channels`
+)
+
+// Find finds the notification channel by id.
+func (s *NotificationChannelStore) Find(ctx context.Context, id int64) (*types.NotificationChannel, error) {
+	const sqlQuery = notificationChannelSelectBase + `
+		WHERE notification_channel_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notificationChannel{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification channel")
+	}
+
+	return mapNotificationChannel(dst), nil
+}
+
+// FindByIdentifier finds the notification channel of a space by its identifier.
+func (s *NotificationChannelStore) FindByIdentifier(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+) (*types.NotificationChannel, error) {
+	const sqlQuery = notificationChannelSelectBase + `
+		WHERE notification_channel_space_id = $1 AND LOWER(notification_channel_uid) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notificationChannel{}
+	if err := db.GetContext(ctx, dst, sqlQuery, spaceID, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification channel by identifier")
+	}
+
+	return mapNotificationChannel(dst), nil
+}
+
+// Create creates a new notification channel.
+func (s *NotificationChannelStore) Create(ctx context.Context, channel *types.NotificationChannel) error {
+	const sqlQuery = `
+		INSERT INTO notification_channels (
+			 notification_channel_space_id
+			,notification_channel_uid
+			,notification_channel_description
+			,notification_channel_type
+			,notification_channel_url
+			,notification_channel_enabled
+			,notification_channel_triggers
+			,notification_channel_created_by
+			,notification_channel_created
+			,notification_channel_updated
+			,notification_channel_version
+		) VALUES (
+			 :notification_channel_space_id
+			,:notification_channel_uid
+			,:notification_channel_description
+			,:notification_channel_type
+			,:notification_channel_url
+			,:notification_channel_enabled
+			,:notification_channel_triggers
+			,:notification_channel_created_by
+			,:notification_channel_created
+			,:notification_channel_updated
+			,:notification_channel_version
+		) RETURNING notification_channel_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalNotificationChannel(channel))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification channel object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&channel.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert notification channel query failed")
+	}
+
+	return nil
+}
+
+// Update updates the notification channel. It returns gitness_store.ErrVersionConflict
+// if the channel has been updated in the meantime.
+func (s *NotificationChannelStore) Update(ctx context.Context, channel *types.NotificationChannel) error {
+	const sqlQuery = `
+		UPDATE notification_channels
+		SET
+			 notification_channel_uid = :notification_channel_uid
+			,notification_channel_description = :notification_channel_description
+			,notification_channel_url = :notification_channel_url
+			,notification_channel_enabled = :notification_channel_enabled
+			,notification_channel_triggers = :notification_channel_triggers
+			,notification_channel_updated = :notification_channel_updated
+			,notification_channel_version = :notification_channel_version
+		WHERE notification_channel_id = :notification_channel_id AND
+			notification_channel_version = :notification_channel_version - 1`
+
+	dbChannel := mapInternalNotificationChannel(channel)
+	dbChannel.Version++
+	dbChannel.Updated = time.Now().UnixMilli()
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbChannel)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification channel object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update notification channel")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	channel.Version = dbChannel.Version
+	channel.Updated = dbChannel.Updated
+
+	return nil
+}
+
+// Delete deletes the notification channel.
+func (s *NotificationChannelStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM notification_channels
+		WHERE notification_channel_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification channel")
+	}
+
+	return nil
+}
+
+// List returns the notification channels of a space.
+func (s *NotificationChannelStore) List(
+	ctx context.Context,
+	spaceID int64,
+	filter types.ListQueryFilter,
+) ([]*types.NotificationChannel, error) {
+	stmt := database.Builder.
+		Select(notificationChannelColumns).
+		From("notification_channels").
+		Where("notification_channel_space_id = ?", spaceID).
+		OrderBy("LOWER(notification_channel_uid)")
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("notification_channel_uid", filter.Query))
+	}
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	return s.list(ctx, stmt)
+}
+
+// Count returns the number of notification channels of a space.
+func (s *NotificationChannelStore) Count(
+	ctx context.Context,
+	spaceID int64,
+	filter types.ListQueryFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("notification_channels").
+		Where("notification_channel_space_id = ?", spaceID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("notification_channel_uid", filter.Query))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count notification channels")
+	}
+
+	return count, nil
+}
+
+// ListEnabled returns all enabled notification channels of the provided spaces.
+func (s *NotificationChannelStore) ListEnabled(
+	ctx context.Context,
+	spaceIDs []int64,
+) ([]*types.NotificationChannel, error) {
+	stmt := database.Builder.
+		Select(notificationChannelColumns).
+		From("notification_channels").
+		Where(squirrel.Eq{"notification_channel_space_id": spaceIDs}).
+		Where("notification_channel_enabled = ?", true).
+		OrderBy("notification_channel_id")
+
+	return s.list(ctx, stmt)
+}
+
+func (s *NotificationChannelStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]*types.NotificationChannel, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationChannel
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification channels")
+	}
+
+	channels := make([]*types.NotificationChannel, len(dst))
+	for i, channel := range dst {
+		channels[i] = mapNotificationChannel(channel)
+	}
+
+	return channels, nil
+}
+
+func mapNotificationChannel(channel *notificationChannel) *types.NotificationChannel {
+	var triggers []enum.NotificationChannelTrigger
+	if channel.Triggers != "" {
+		for _, trigger := range strings.Split(channel.Triggers, triggersSeparator) {
+			triggers = append(triggers, enum.NotificationChannelTrigger(trigger))
+		}
+	}
+
+	return &types.NotificationChannel{
+		ID:          channel.ID,
+		SpaceID:     channel.SpaceID,
+		Identifier:  channel.Identifier,
+		Description: channel.Description,
+		Type:        channel.Type,
+		URL:         channel.URL,
+		Enabled:     channel.Enabled,
+		Triggers:    triggers,
+		CreatedBy:   channel.CreatedBy,
+		Created:     channel.Created,
+		Updated:     channel.Updated,
+		Version:     channel.Version,
+	}
+}
+
+func mapInternalNotificationChannel(channel *types.NotificationChannel) *notificationChannel {
+	triggers := make([]string, len(channel.Triggers))
+	for i, trigger := range channel.Triggers {
+		triggers[i] = string(trigger)
+	}
+
+	return &notificationChannel{
+		ID:          channel.ID,
+		SpaceID:     channel.SpaceID,
+		Identifier:  channel.Identifier,
+		Description: channel.Description,
+		Type:        channel.Type,
+		URL:         channel.URL,
+		Enabled:     channel.Enabled,
+		Triggers:    strings.Join(triggers, triggersSeparator),
+		CreatedBy:   channel.CreatedBy,
+		Created:     channel.Created,
+		Updated:     channel.Updated,
+		Version:     channel.Version,
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationChannelStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 2, 1)
+
+	channelStore := database.NewNotificationChannelStore(db)
+
+	channels := []*types.NotificationChannel{
+		{
+			SpaceID:    1,
+			Identifier: "Team-Slack",
+			Type:       enum.NotificationChannelTypeSlack,
+			URL:        "encrypted",
+			Enabled:    true,
+			Triggers: []enum.NotificationChannelTrigger{
+				enum.NotificationChannelTriggerReviewerAdded,
+				enum.NotificationChannelTriggerStateChanged,
+			},
+		},
+		{SpaceID: 1, Identifier: "teams", Type: enum.NotificationChannelTypeTeams, Enabled: false},
+		{SpaceID: 2, Identifier: "generic", Type: enum.NotificationChannelTypeGeneric, Enabled: true},
+	}
+	for _, channel := range channels {
+		channel.CreatedBy = userID
+		require.NoError(t, channelStore.Create(ctx, channel))
+		require.NotZero(t, channel.ID)
+	}
+
+	channel, err := channelStore.FindByIdentifier(ctx, 1, "team-slack")
+	require.NoError(t, err)
+	require.Equal(t, channels[0].ID, channel.ID)
+	require.Equal(t, channels[0].Triggers, channel.Triggers)
+	require.True(t, channel.HasTrigger(enum.NotificationChannelTriggerStateChanged))
+	require.False(t, channel.HasTrigger(enum.NotificationChannelTriggerCommentMention))
+
+	list, err := channelStore.List(ctx, 1, types.ListQueryFilter{})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	count, err := channelStore.Count(ctx, 1, types.ListQueryFilter{Query: "team"})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	enabled, err := channelStore.ListEnabled(ctx, []int64{1, 2})
+	require.NoError(t, err)
+	require.Len(t, enabled, 2)
+	require.Equal(t, "Team-Slack", enabled[0].Identifier)
+	require.Equal(t, "generic", enabled[1].Identifier)
+
+	channel.Enabled = false
+	require.NoError(t, channelStore.Update(ctx, channel))
+	require.Equal(t, int64(1), channel.Version)
+
+	stale := *channel
+	stale.Version = 0
+	require.ErrorIs(t, channelStore.Update(ctx, &stale), gitness_store.ErrVersionConflict)
+
+	require.NoError(t, channelStore.Delete(ctx, channels[2].ID))
+
+	enabled, err = channelStore.ListEnabled(ctx, []int64{1, 2})
+	require.NoError(t, err)
+	require.Empty(t, enabled)
+}
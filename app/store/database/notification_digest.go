@@ -0,0 +1,156 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationDigestStore = (*NotificationDigestStore)(nil)
+
+// NewNotificationDigestStore returns a new NotificationDigestStore.
+func NewNotificationDigestStore(db *sqlx.DB) *NotificationDigestStore {
+	return &NotificationDigestStore{
+		db: db,
+	}
+}
+
+// NotificationDigestStore implements a store.NotificationDigestStore backed by a relational database.
+type NotificationDigestStore struct {
+	db *sqlx.DB
+}
+
+type notificationDigestItem struct {
+	ID          int64  `db:"notification_digest_item_id"`
+	PrincipalID int64  `db:"notification_digest_item_principal_id"`
+	EventID     string `db:"notification_digest_item_event_id"`
+	Subject     string `db:"notification_digest_item_subject"`
+	Body        string `db:"notification_digest_item_body"`
+	Created     int64  `db:"notification_digest_item_created"`
+}
+
+const notificationDigestItemColumns = `
+	 notification_digest_item_id
+	,notification_digest_item_principal_id
+	,notification_digest_item_event_id
+	,notification_digest_item_subject
+	,notification_digest_item_body
+	,notification_digest_item_created`
+
+// Create stores a new digest item. It's a no-op if the principal already has a digest item for the event,
+// in which case the ID of the provided item is left unset.
+func (s *NotificationDigestStore) Create(ctx context.Context, item *types.NotificationDigestItem) error {
+	const sqlQuery = `
+		INSERT INTO notification_digest_items (
+			 notification_digest_item_principal_id
+			,notification_digest_item_event_id
+			,notification_digest_item_subject
+			,notification_digest_item_body
+			,notification_digest_item_created
+		) VALUES (
+			 :notification_digest_item_principal_id
+			,:notification_digest_item_event_id
+			,:notification_digest_item_subject
+			,:notification_digest_item_body
+			,:notification_digest_item_created
+		)
+		ON CONFLICT DO NOTHING
+		RETURNING notification_digest_item_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, notificationDigestItem(*item))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification digest item object")
+	}
+
+	err = db.QueryRowContext(ctx, query, args...).Scan(&item.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil // the digest item of the event has already been stored
+	}
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert notification digest item")
+	}
+
+	return nil
+}
+
+// ListPrincipalIDs returns the IDs of all principals that have pending digest items.
+func (s *NotificationDigestStore) ListPrincipalIDs(ctx context.Context) ([]int64, error) {
+	const sqlQuery = `
+		SELECT DISTINCT notification_digest_item_principal_id
+		FROM notification_digest_items`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var principalIDs []int64
+	if err := db.SelectContext(ctx, &principalIDs, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list principals with notification digest items")
+	}
+
+	return principalIDs, nil
+}
+
+// List returns the pending digest items of a principal, oldest first.
+func (s *NotificationDigestStore) List(
+	ctx context.Context,
+	principalID int64,
+) ([]*types.NotificationDigestItem, error) {
+	const sqlQuery = `
+		SELECT` + notificationDigestItemColumns + `
+		FROM notification_digest_items
+		WHERE notification_digest_item_principal_id = $1
+		ORDER BY notification_digest_item_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationDigestItem
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification digest items")
+	}
+
+	items := make([]*types.NotificationDigestItem, len(dst))
+	for i := range dst {
+		item := types.NotificationDigestItem(*dst[i])
+		items[i] = &item
+	}
+
+	return items, nil
+}
+
+// Delete deletes the digest items of a principal up to and including the provided item ID.
+func (s *NotificationDigestStore) Delete(ctx context.Context, principalID int64, maxID int64) error {
+	const sqlQuery = `
+		DELETE FROM notification_digest_items
+		WHERE notification_digest_item_principal_id = $1
+		  AND notification_digest_item_id <= $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID, maxID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification digest items")
+	}
+
+	return nil
+}
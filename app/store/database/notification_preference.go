@@ -0,0 +1,373 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationPreferenceStore = (*NotificationPreferenceStore)(nil)
+
+// NewNotificationPreferenceStore returns a new NotificationPreferenceStore.
+func NewNotificationPreferenceStore(db *sqlx.DB) *NotificationPreferenceStore {
+	return &NotificationPreferenceStore{
+		db: db,
+	}
+}
+
+// NotificationPreferenceStore implements a store.NotificationPreferenceStore backed by a relational database.
+type NotificationPreferenceStore struct {
+	db *sqlx.DB
+}
+
+// notificationEventsSeparator defines the character that's used to join notification events for storing them in the DB.
+const notificationEventsSeparator = ","
+
+type notificationSettings struct {
+	PrincipalID    int64                     `db:"notification_settings_principal_id"`
+	Delivery       enum.NotificationDelivery `db:"notification_settings_delivery"`
+	DisabledEvents string                    `db:"notification_settings_disabled_events"`
+	Updated        int64                     `db:"notification_settings_updated"`
+}
+
+type notificationSubscription struct {
+	ID             int64                  `db:"notification_subscription_id"`
+	PrincipalID    int64                  `db:"notification_subscription_principal_id"`
+	Scope          enum.NotificationScope `db:"notification_subscription_scope"`
+	ScopeID        int64                  `db:"notification_subscription_scope_id"`
+	Level          enum.NotificationLevel `db:"notification_subscription_level"`
+	DisabledEvents string                 `db:"notification_subscription_disabled_events"`
+	Created        int64                  `db:"notification_subscription_created"`
+	Updated        int64                  `db:"notification_subscription_updated"`
+}
+
+const (
+	notificationSettingsColumns = `
+		 notification_settings_principal_id
+		,notification_settings_delivery
+		,notification_settings_disabled_events
+		,notification_settings_updated`
+
+	notificationSubscriptionColumns = `
+		 notification_subscription_id
+		,notification_subscription_principal_id
+		,notification_subscription_scope
+		,notification_subscription_scope_id
+		,notification_subscription_level
+		,notification_subscription_disabled_events
+		,notification_subscription_created
+		,notification_subscription_updated`
+)
+
+// FindSettings returns the user level notification settings of a principal.
+func (s *NotificationPreferenceStore) FindSettings(
+	ctx context.Context,
+	principalID int64,
+) (*types.NotificationSettings, error) {
+	const sqlQuery = `
+		SELECT` + notificationSettingsColumns + `
+		FROM notification_settings
+		WHERE notification_settings_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notificationSettings{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification settings")
+	}
+
+	return mapNotificationSettings(dst), nil
+}
+
+// ListSettings returns the user level notification settings of the provided principals.
+func (s *NotificationPreferenceStore) ListSettings(
+	ctx context.Context,
+	principalIDs []int64,
+) ([]*types.NotificationSettings, error) {
+	if len(principalIDs) == 0 {
+		return nil, nil
+	}
+
+	stmt := database.Builder.
+		Select(notificationSettingsColumns).
+		From("notification_settings").
+		Where(squirrel.Eq{"notification_settings_principal_id": principalIDs})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationSettings
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification settings")
+	}
+
+	settings := make([]*types.NotificationSettings, len(dst))
+	for i := range dst {
+		settings[i] = mapNotificationSettings(dst[i])
+	}
+
+	return settings, nil
+}
+
+// UpsertSettings creates or updates the user level notification settings of a principal.
+func (s *NotificationPreferenceStore) UpsertSettings(ctx context.Context, settings *types.NotificationSettings) error {
+	const sqlQuery = `
+		INSERT INTO notification_settings (` + notificationSettingsColumns + `
+		) VALUES (
+			 :notification_settings_principal_id
+			,:notification_settings_delivery
+			,:notification_settings_disabled_events
+			,:notification_settings_updated
+		)
+		ON CONFLICT (notification_settings_principal_id) DO UPDATE SET
+			 notification_settings_delivery = EXCLUDED.notification_settings_delivery
+			,notification_settings_disabled_events = EXCLUDED.notification_settings_disabled_events
+			,notification_settings_updated = EXCLUDED.notification_settings_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalNotificationSettings(settings))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification settings object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert notification settings")
+	}
+
+	return nil
+}
+
+// UpsertSubscription creates or updates a notification subscription.
+func (s *NotificationPreferenceStore) UpsertSubscription(
+	ctx context.Context,
+	subscription *types.NotificationSubscription,
+) error {
+	const sqlQuery = `
+		INSERT INTO notification_subscriptions (
+			 notification_subscription_principal_id
+			,notification_subscription_scope
+			,notification_subscription_scope_id
+			,notification_subscription_level
+			,notification_subscription_disabled_events
+			,notification_subscription_created
+			,notification_subscription_updated
+		) VALUES (
+			 :notification_subscription_principal_id
+			,:notification_subscription_scope
+			,:notification_subscription_scope_id
+			,:notification_subscription_level
+			,:notification_subscription_disabled_events
+			,:notification_subscription_created
+			,:notification_subscription_updated
+		)
+		ON CONFLICT (
+			 notification_subscription_principal_id
+			,notification_subscription_scope
+			,notification_subscription_scope_id
+		) DO UPDATE SET
+			 notification_subscription_level = EXCLUDED.notification_subscription_level
+			,notification_subscription_disabled_events = EXCLUDED.notification_subscription_disabled_events
+			,notification_subscription_updated = EXCLUDED.notification_subscription_updated
+		RETURNING notification_subscription_id, notification_subscription_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalNotificationSubscription(subscription))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification subscription object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&subscription.ID, &subscription.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert notification subscription")
+	}
+
+	return nil
+}
+
+// DeleteSubscription deletes the notification subscription of a principal for the provided resource.
+func (s *NotificationPreferenceStore) DeleteSubscription(
+	ctx context.Context,
+	principalID int64,
+	scope enum.NotificationScope,
+	scopeID int64,
+) error {
+	const sqlQuery = `
+		DELETE FROM notification_subscriptions
+		WHERE notification_subscription_principal_id = $1
+		  AND notification_subscription_scope = $2
+		  AND notification_subscription_scope_id = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID, scope, scopeID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification subscription")
+	}
+
+	return nil
+}
+
+// ListSubscriptions returns all notification subscriptions of a principal.
+func (s *NotificationPreferenceStore) ListSubscriptions(
+	ctx context.Context,
+	principalID int64,
+) ([]*types.NotificationSubscription, error) {
+	stmt := database.Builder.
+		Select(notificationSubscriptionColumns).
+		From("notification_subscriptions").
+		Where("notification_subscription_principal_id = ?", principalID).
+		OrderBy("notification_subscription_id")
+
+	return s.listSubscriptions(ctx, stmt)
+}
+
+// ListSubscriptionsForPullReq returns the notification subscriptions of all principals that apply to a pull request.
+func (s *NotificationPreferenceStore) ListSubscriptionsForPullReq(
+	ctx context.Context,
+	pullReqID int64,
+	repoID int64,
+	spaceIDs []int64,
+) ([]*types.NotificationSubscription, error) {
+	scopes := squirrel.Or{
+		squirrel.Eq{
+			"notification_subscription_scope":    enum.NotificationScopePullReq,
+			"notification_subscription_scope_id": pullReqID,
+		},
+		squirrel.Eq{
+			"notification_subscription_scope":    enum.NotificationScopeRepo,
+			"notification_subscription_scope_id": repoID,
+		},
+	}
+	if len(spaceIDs) > 0 {
+		scopes = append(scopes, squirrel.Eq{
+			"notification_subscription_scope":    enum.NotificationScopeSpace,
+			"notification_subscription_scope_id": spaceIDs,
+		})
+	}
+
+	stmt := database.Builder.
+		Select(notificationSubscriptionColumns).
+		From("notification_subscriptions").
+		Where(scopes).
+		OrderBy("notification_subscription_id")
+
+	return s.listSubscriptions(ctx, stmt)
+}
+
+func (s *NotificationPreferenceStore) listSubscriptions(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]*types.NotificationSubscription, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationSubscription
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification subscriptions")
+	}
+
+	subscriptions := make([]*types.NotificationSubscription, len(dst))
+	for i := range dst {
+		subscriptions[i] = mapNotificationSubscription(dst[i])
+	}
+
+	return subscriptions, nil
+}
+
+func mapNotificationSettings(settings *notificationSettings) *types.NotificationSettings {
+	return &types.NotificationSettings{
+		PrincipalID:    settings.PrincipalID,
+		Delivery:       settings.Delivery,
+		DisabledEvents: splitNotificationEvents(settings.DisabledEvents),
+		Updated:        settings.Updated,
+	}
+}
+
+func mapInternalNotificationSettings(settings *types.NotificationSettings) *notificationSettings {
+	return &notificationSettings{
+		PrincipalID:    settings.PrincipalID,
+		Delivery:       settings.Delivery,
+		DisabledEvents: joinNotificationEvents(settings.DisabledEvents),
+		Updated:        settings.Updated,
+	}
+}
+
+func mapNotificationSubscription(subscription *notificationSubscription) *types.NotificationSubscription {
+	return &types.NotificationSubscription{
+		ID:             subscription.ID,
+		PrincipalID:    subscription.PrincipalID,
+		Scope:          subscription.Scope,
+		ScopeID:        subscription.ScopeID,
+		Level:          subscription.Level,
+		DisabledEvents: splitNotificationEvents(subscription.DisabledEvents),
+		Created:        subscription.Created,
+		Updated:        subscription.Updated,
+	}
+}
+
+func mapInternalNotificationSubscription(subscription *types.NotificationSubscription) *notificationSubscription {
+	return &notificationSubscription{
+		ID:             subscription.ID,
+		PrincipalID:    subscription.PrincipalID,
+		Scope:          subscription.Scope,
+		ScopeID:        subscription.ScopeID,
+		Level:          subscription.Level,
+		DisabledEvents: joinNotificationEvents(subscription.DisabledEvents),
+		Created:        subscription.Created,
+		Updated:        subscription.Updated,
+	}
+}
+
+func splitNotificationEvents(s string) []enum.NotificationEvent {
+	if s == "" {
+		return []enum.NotificationEvent{}
+	}
+
+	parts := strings.Split(s, notificationEventsSeparator)
+	events := make([]enum.NotificationEvent, len(parts))
+	for i, part := range parts {
+		events[i] = enum.NotificationEvent(part)
+	}
+
+	return events
+}
+
+func joinNotificationEvents(events []enum.NotificationEvent) string {
+	parts := make([]string, len(events))
+	for i, event := range events {
+		parts[i] = string(event)
+	}
+
+	return strings.Join(parts, notificationEventsSeparator)
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationPreferenceStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	prefStore := database.NewNotificationPreferenceStore(db)
+
+	_, err := prefStore.FindSettings(ctx, userID)
+	require.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+
+	settings := &types.NotificationSettings{
+		PrincipalID:    userID,
+		Delivery:       enum.NotificationDeliveryImmediate,
+		DisabledEvents: []enum.NotificationEvent{enum.NotificationEventBranchUpdated},
+	}
+	require.NoError(t, prefStore.UpsertSettings(ctx, settings))
+
+	settings.Delivery = enum.NotificationDeliveryDigest
+	settings.DisabledEvents = nil
+	require.NoError(t, prefStore.UpsertSettings(ctx, settings))
+
+	found, err := prefStore.FindSettings(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, enum.NotificationDeliveryDigest, found.Delivery)
+	require.Empty(t, found.DisabledEvents)
+
+	list, err := prefStore.ListSettings(ctx, []int64{userID, userID + 1})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	subscriptions := []*types.NotificationSubscription{
+		{Scope: enum.NotificationScopeSpace, ScopeID: 1, Level: enum.NotificationLevelWatching},
+		{Scope: enum.NotificationScopeRepo, ScopeID: 1, Level: enum.NotificationLevelParticipating,
+			DisabledEvents: []enum.NotificationEvent{
+				enum.NotificationEventCommentCreated,
+				enum.NotificationEventReviewSubmitted,
+			}},
+		{Scope: enum.NotificationScopePullReq, ScopeID: 1, Level: enum.NotificationLevelMuted},
+		{Scope: enum.NotificationScopeRepo, ScopeID: 2, Level: enum.NotificationLevelWatching},
+	}
+	for _, subscription := range subscriptions {
+		subscription.PrincipalID = userID
+		require.NoError(t, prefStore.UpsertSubscription(ctx, subscription))
+		require.NotZero(t, subscription.ID)
+	}
+
+	// upserting the same resource updates the existing subscription
+	update := &types.NotificationSubscription{
+		PrincipalID: userID,
+		Scope:       enum.NotificationScopeSpace,
+		ScopeID:     1,
+		Level:       enum.NotificationLevelMuted,
+	}
+	require.NoError(t, prefStore.UpsertSubscription(ctx, update))
+	require.Equal(t, subscriptions[0].ID, update.ID)
+
+	all, err := prefStore.ListSubscriptions(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, all, 4)
+	require.Equal(t, enum.NotificationLevelMuted, all[0].Level)
+	require.Equal(t, subscriptions[1].DisabledEvents, all[1].DisabledEvents)
+
+	forPullReq, err := prefStore.ListSubscriptionsForPullReq(ctx, 1, 1, []int64{1})
+	require.NoError(t, err)
+	require.Len(t, forPullReq, 3)
+
+	forPullReq, err = prefStore.ListSubscriptionsForPullReq(ctx, 2, 2, nil)
+	require.NoError(t, err)
+	require.Len(t, forPullReq, 1)
+
+	require.NoError(t, prefStore.DeleteSubscription(ctx, userID, enum.NotificationScopePullReq, 1))
+
+	all, err = prefStore.ListSubscriptions(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+}
+
+func TestNotificationDigestStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	digestStore := database.NewNotificationDigestStore(db)
+
+	items := []*types.NotificationDigestItem{
+		{PrincipalID: userID, EventID: "closed:1", Subject: "first", Body: "<p>first</p>", Created: 1},
+		{PrincipalID: userID, EventID: "closed:2", Subject: "second", Body: "<p>second</p>", Created: 2},
+	}
+	for _, item := range items {
+		require.NoError(t, digestStore.Create(ctx, item))
+		require.NotZero(t, item.ID)
+	}
+
+	// a retried event must not queue the same notification twice
+	duplicate := &types.NotificationDigestItem{
+		PrincipalID: userID, EventID: "closed:1", Subject: "first", Body: "<p>first</p>", Created: 3,
+	}
+	require.NoError(t, digestStore.Create(ctx, duplicate))
+	require.Zero(t, duplicate.ID)
+
+	principalIDs, err := digestStore.ListPrincipalIDs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []int64{userID}, principalIDs)
+
+	list, err := digestStore.List(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "first", list[0].Subject)
+
+	require.NoError(t, digestStore.Delete(ctx, userID, items[0].ID))
+
+	list, err = digestStore.List(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "second", list[0].Subject)
+}
@@ -47,6 +47,8 @@ const (
 	,pipeline_repo_id
 	,pipeline_default_branch
 	,pipeline_config_path
+	,pipeline_concurrency_group
+	,pipeline_concurrency_policy
 	,pipeline_created
 	,pipeline_updated
 	,pipeline_version
@@ -106,6 +108,8 @@ func (s *pipelineStore) Create(ctx context.Context, pipeline *types.Pipeline) er
 		,pipeline_created_by
 		,pipeline_default_branch
 		,pipeline_config_path
+		,pipeline_concurrency_group
+		,pipeline_concurrency_policy
 		,pipeline_created
 		,pipeline_updated
 		,pipeline_version
@@ -118,6 +122,8 @@ func (s *pipelineStore) Create(ctx context.Context, pipeline *types.Pipeline) er
 		:pipeline_created_by,
 		:pipeline_default_branch,
 		:pipeline_config_path,
+		:pipeline_concurrency_group,
+		:pipeline_concurrency_policy,
 		:pipeline_created,
 		:pipeline_updated,
 		:pipeline_version
@@ -147,6 +153,8 @@ func (s *pipelineStore) Update(ctx context.Context, p *types.Pipeline) error {
 		pipeline_disabled = :pipeline_disabled,
 		pipeline_default_branch = :pipeline_default_branch,
 		pipeline_config_path = :pipeline_config_path,
+		pipeline_concurrency_group = :pipeline_concurrency_group,
+		pipeline_concurrency_policy = :pipeline_concurrency_policy,
 		pipeline_updated = :pipeline_updated,
 		pipeline_version = :pipeline_version
 	WHERE pipeline_id = :pipeline_id AND pipeline_version = :pipeline_version - 1`
@@ -0,0 +1,238 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PipelineArtifactStore = (*PipelineArtifactStore)(nil)
+
+// NewPipelineArtifactStore returns a new PipelineArtifactStore.
+func NewPipelineArtifactStore(db *sqlx.DB) *PipelineArtifactStore {
+	return &PipelineArtifactStore{
+		db: db,
+	}
+}
+
+// PipelineArtifactStore implements a store.PipelineArtifactStore backed by a relational database.
+type PipelineArtifactStore struct {
+	db *sqlx.DB
+}
+
+type pipelineArtifact struct {
+	ID          int64  `db:"artifact_id"`
+	RepoID      int64  `db:"artifact_repo_id"`
+	ExecutionID int64  `db:"artifact_execution_id"`
+	StageNumber int64  `db:"artifact_stage_number"`
+	Name        string `db:"artifact_name"`
+	BlobUID     string `db:"artifact_blob_uid"`
+	Size        int64  `db:"artifact_size"`
+	CreatedBy   int64  `db:"artifact_created_by"`
+	Created     int64  `db:"artifact_created"`
+	Expires     int64  `db:"artifact_expires"`
+}
+
+const (
+	pipelineArtifactColumns = `
+		 artifact_id
+		,artifact_repo_id
+		,artifact_execution_id
+		,artifact_stage_number
+		,artifact_name
+		,artifact_blob_uid
+		,artifact_size
+		,artifact_created_by
+		,artifact_created
+		,artifact_expires`
+)
+
+// Find returns an artifact of an execution by its name.
+func (s *PipelineArtifactStore) Find(
+	ctx context.Context,
+	executionID int64,
+	name string,
+) (*types.Artifact, error) {
+	stmt := database.Builder.
+		Select(pipelineArtifactColumns).
+		From("pipeline_artifacts").
+		Where("artifact_execution_id = ? AND artifact_name = ?", executionID, name)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pipelineArtifact{}
+	if err := db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pipeline artifact")
+	}
+
+	return mapPipelineArtifact(dst), nil
+}
+
+// Create creates a new artifact. It returns store.ErrDuplicate if the execution
+// already has an artifact with the same name.
+func (s *PipelineArtifactStore) Create(ctx context.Context, artifact *types.Artifact) error {
+	const sqlQuery = `
+		INSERT INTO pipeline_artifacts (
+			 artifact_repo_id
+			,artifact_execution_id
+			,artifact_stage_number
+			,artifact_name
+			,artifact_blob_uid
+			,artifact_size
+			,artifact_created_by
+			,artifact_created
+			,artifact_expires
+		) VALUES (
+			 :artifact_repo_id
+			,:artifact_execution_id
+			,:artifact_stage_number
+			,:artifact_name
+			,:artifact_blob_uid
+			,:artifact_size
+			,:artifact_created_by
+			,:artifact_created
+			,:artifact_expires
+		) RETURNING artifact_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalPipelineArtifact(artifact))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pipeline artifact")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&artifact.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert pipeline artifact query failed")
+	}
+
+	return nil
+}
+
+// List lists the artifacts of an execution.
+func (s *PipelineArtifactStore) List(ctx context.Context, executionID int64) ([]*types.Artifact, error) {
+	stmt := database.Builder.
+		Select(pipelineArtifactColumns).
+		From("pipeline_artifacts").
+		Where("artifact_execution_id = ?", executionID).
+		OrderBy("artifact_stage_number ASC", "artifact_name ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pipelineArtifact
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pipeline artifacts")
+	}
+
+	return mapPipelineArtifacts(dst), nil
+}
+
+// ListExpired returns up to limit artifacts that expired before the provided time.
+func (s *PipelineArtifactStore) ListExpired(
+	ctx context.Context,
+	before int64,
+	limit int,
+) ([]*types.Artifact, error) {
+	stmt := database.Builder.
+		Select(pipelineArtifactColumns).
+		From("pipeline_artifacts").
+		Where("artifact_expires < ?", before).
+		OrderBy("artifact_expires ASC").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pipelineArtifact
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list expired pipeline artifacts")
+	}
+
+	return mapPipelineArtifacts(dst), nil
+}
+
+// Delete deletes an artifact.
+func (s *PipelineArtifactStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM pipeline_artifacts
+		WHERE artifact_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	return nil
+}
+
+func mapInternalPipelineArtifact(a *types.Artifact) *pipelineArtifact {
+	return &pipelineArtifact{
+		ID:          a.ID,
+		RepoID:      a.RepoID,
+		ExecutionID: a.ExecutionID,
+		StageNumber: a.StageNumber,
+		Name:        a.Name,
+		BlobUID:     a.BlobUID,
+		Size:        a.Size,
+		CreatedBy:   a.CreatedBy,
+		Created:     a.Created,
+		Expires:     a.Expires,
+	}
+}
+
+func mapPipelineArtifact(a *pipelineArtifact) *types.Artifact {
+	return &types.Artifact{
+		ID:          a.ID,
+		RepoID:      a.RepoID,
+		ExecutionID: a.ExecutionID,
+		StageNumber: a.StageNumber,
+		Name:        a.Name,
+		BlobUID:     a.BlobUID,
+		Size:        a.Size,
+		CreatedBy:   a.CreatedBy,
+		Created:     a.Created,
+		Expires:     a.Expires,
+	}
+}
+
+func mapPipelineArtifacts(artifacts []*pipelineArtifact) []*types.Artifact {
+	res := make([]*types.Artifact, len(artifacts))
+	for i := range artifacts {
+		res[i] = mapPipelineArtifact(artifacts[i])
+	}
+	return res
+}
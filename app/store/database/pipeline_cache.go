@@ -0,0 +1,317 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PipelineCacheStore = (*PipelineCacheStore)(nil)
+
+// NewPipelineCacheStore returns a new PipelineCacheStore.
+func NewPipelineCacheStore(db *sqlx.DB) *PipelineCacheStore {
+	return &PipelineCacheStore{
+		db: db,
+	}
+}
+
+// PipelineCacheStore implements a store.PipelineCacheStore backed by a relational database.
+type PipelineCacheStore struct {
+	db *sqlx.DB
+}
+
+type pipelineCache struct {
+	ID         int64  `db:"cache_id"`
+	RepoID     int64  `db:"cache_repo_id"`
+	PipelineID int64  `db:"cache_pipeline_id"`
+	Key        string `db:"cache_key"`
+	BlobUID    string `db:"cache_blob_uid"`
+	Size       int64  `db:"cache_size"`
+	CreatedBy  int64  `db:"cache_created_by"`
+	Created    int64  `db:"cache_created"`
+	Updated    int64  `db:"cache_updated"`
+	LastUsed   int64  `db:"cache_last_used"`
+}
+
+const (
+	pipelineCacheColumns = `
+		 cache_id
+		,cache_repo_id
+		,cache_pipeline_id
+		,cache_key
+		,cache_blob_uid
+		,cache_size
+		,cache_created_by
+		,cache_created
+		,cache_updated
+		,cache_last_used`
+)
+
+// Find returns a build cache entry of a pipeline by its key.
+func (s *PipelineCacheStore) Find(
+	ctx context.Context,
+	pipelineID int64,
+	key string,
+) (*types.PipelineCache, error) {
+	stmt := database.Builder.
+		Select(pipelineCacheColumns).
+		From("pipeline_caches").
+		Where("cache_pipeline_id = ? AND cache_key = ?", pipelineID, key)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pipelineCache{}
+	if err := db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pipeline cache")
+	}
+
+	return mapPipelineCache(dst), nil
+}
+
+// Upsert creates a new build cache entry or replaces the content of the existing one with the same key.
+// It returns the blob UID of the replaced content, or an empty string if a new entry was created.
+func (s *PipelineCacheStore) Upsert(ctx context.Context, cache *types.PipelineCache) (string, error) {
+	// the content is swapped optimistically to ensure that the replaced content is returned to exactly one caller.
+	for {
+		existing, err := s.Find(ctx, cache.PipelineID, cache.Key)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			created, err := s.create(ctx, cache)
+			if err != nil {
+				return "", err
+			}
+			if created {
+				return "", nil
+			}
+
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		replaced, err := s.replace(ctx, cache, existing)
+		if err != nil {
+			return "", err
+		}
+		if replaced {
+			return existing.BlobUID, nil
+		}
+	}
+}
+
+// create inserts a new build cache entry. It returns false if an entry with the same key already exists.
+func (s *PipelineCacheStore) create(ctx context.Context, cache *types.PipelineCache) (bool, error) {
+	const sqlQuery = `
+		INSERT INTO pipeline_caches (
+			 cache_repo_id
+			,cache_pipeline_id
+			,cache_key
+			,cache_blob_uid
+			,cache_size
+			,cache_created_by
+			,cache_created
+			,cache_updated
+			,cache_last_used
+		) VALUES (
+			 :cache_repo_id
+			,:cache_pipeline_id
+			,:cache_key
+			,:cache_blob_uid
+			,:cache_size
+			,:cache_created_by
+			,:cache_created
+			,:cache_updated
+			,:cache_last_used
+		)
+		ON CONFLICT (cache_pipeline_id, cache_key) DO NOTHING
+		RETURNING cache_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalPipelineCache(cache))
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to bind pipeline cache")
+	}
+
+	err = db.QueryRowContext(ctx, query, arg...).Scan(&cache.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Insert pipeline cache query failed")
+	}
+
+	return true, nil
+}
+
+// replace replaces the content of the existing build cache entry.
+// It returns false if the content of the entry has been replaced concurrently.
+func (s *PipelineCacheStore) replace(
+	ctx context.Context,
+	cache *types.PipelineCache,
+	existing *types.PipelineCache,
+) (bool, error) {
+	const sqlQuery = `
+		UPDATE pipeline_caches
+		SET
+			 cache_blob_uid = $1
+			,cache_size = $2
+			,cache_created_by = $3
+			,cache_updated = $4
+			,cache_last_used = $5
+		WHERE cache_id = $6 AND cache_blob_uid = $7`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery,
+		cache.BlobUID,
+		cache.Size,
+		cache.CreatedBy,
+		cache.Updated,
+		cache.LastUsed,
+		existing.ID,
+		existing.BlobUID,
+	)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Update pipeline cache query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated pipeline caches")
+	}
+
+	if count == 0 {
+		return false, nil
+	}
+
+	cache.ID = existing.ID
+	cache.Created = existing.Created
+
+	return true, nil
+}
+
+// Touch updates the last used time of a build cache entry.
+func (s *PipelineCacheStore) Touch(ctx context.Context, id int64, lastUsed int64) error {
+	const sqlQuery = `
+		UPDATE pipeline_caches
+		SET cache_last_used = $1
+		WHERE cache_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, lastUsed, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update pipeline cache last used time")
+	}
+
+	return nil
+}
+
+// ListUnused returns up to limit build cache entries that haven't been used since the provided time.
+func (s *PipelineCacheStore) ListUnused(
+	ctx context.Context,
+	before int64,
+	limit int,
+) ([]*types.PipelineCache, error) {
+	stmt := database.Builder.
+		Select(pipelineCacheColumns).
+		From("pipeline_caches").
+		Where("cache_last_used < ?", before).
+		OrderBy("cache_last_used ASC").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pipelineCache
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list unused pipeline caches")
+	}
+
+	return mapPipelineCaches(dst), nil
+}
+
+// Delete deletes a build cache entry.
+func (s *PipelineCacheStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM pipeline_caches
+		WHERE cache_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	return nil
+}
+
+func mapInternalPipelineCache(c *types.PipelineCache) *pipelineCache {
+	return &pipelineCache{
+		ID:         c.ID,
+		RepoID:     c.RepoID,
+		PipelineID: c.PipelineID,
+		Key:        c.Key,
+		BlobUID:    c.BlobUID,
+		Size:       c.Size,
+		CreatedBy:  c.CreatedBy,
+		Created:    c.Created,
+		Updated:    c.Updated,
+		LastUsed:   c.LastUsed,
+	}
+}
+
+func mapPipelineCache(c *pipelineCache) *types.PipelineCache {
+	return &types.PipelineCache{
+		ID:         c.ID,
+		RepoID:     c.RepoID,
+		PipelineID: c.PipelineID,
+		Key:        c.Key,
+		BlobUID:    c.BlobUID,
+		Size:       c.Size,
+		CreatedBy:  c.CreatedBy,
+		Created:    c.Created,
+		Updated:    c.Updated,
+		LastUsed:   c.LastUsed,
+	}
+}
+
+func mapPipelineCaches(caches []*pipelineCache) []*types.PipelineCache {
+	res := make([]*types.PipelineCache, len(caches))
+	for i := range caches {
+		res[i] = mapPipelineCache(caches[i])
+	}
+	return res
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineCacheStoreUpsert(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+
+	cacheStore := database.NewPipelineCacheStore(db)
+
+	newCache := func(blobUID string, size int64) *types.PipelineCache {
+		return &types.PipelineCache{
+			RepoID:     1,
+			PipelineID: 1,
+			Key:        "deps",
+			BlobUID:    blobUID,
+			Size:       size,
+			CreatedBy:  1,
+			Created:    1,
+			Updated:    1,
+			LastUsed:   1,
+		}
+	}
+
+	first := newCache("blob-1", 10)
+	replaced, err := cacheStore.Upsert(ctx, first)
+	require.NoError(t, err)
+	require.Empty(t, replaced, "a new entry doesn't replace any content")
+	require.NotZero(t, first.ID)
+
+	second := newCache("blob-2", 20)
+	replaced, err = cacheStore.Upsert(ctx, second)
+	require.NoError(t, err)
+	require.Equal(t, "blob-1", replaced)
+	require.Equal(t, first.ID, second.ID)
+
+	cache, err := cacheStore.Find(ctx, 1, "deps")
+	require.NoError(t, err)
+	require.Equal(t, "blob-2", cache.BlobUID)
+	require.Equal(t, int64(20), cache.Size)
+
+	other := newCache("blob-3", 30)
+	other.Key = "tools"
+	replaced, err = cacheStore.Upsert(ctx, other)
+	require.NoError(t, err)
+	require.Empty(t, replaced)
+	require.NotEqual(t, first.ID, other.ID)
+}
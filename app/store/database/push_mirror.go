@@ -0,0 +1,394 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PushMirrorStore = (*PushMirrorStore)(nil)
+
+// NewPushMirrorStore returns a new PushMirrorStore.
+func NewPushMirrorStore(db *sqlx.DB) *PushMirrorStore {
+	return &PushMirrorStore{
+		db: db,
+	}
+}
+
+// PushMirrorStore implements a store.PushMirrorStore backed by a relational database.
+type PushMirrorStore struct {
+	db *sqlx.DB
+}
+
+type pushMirror struct {
+	ID          int64                  `db:"push_mirror_id"`
+	RepoID      int64                  `db:"push_mirror_repo_id"`
+	Identifier  string                 `db:"push_mirror_identifier"`
+	URL         string                 `db:"push_mirror_url"`
+	Username    string                 `db:"push_mirror_username"`
+	SecretID    null.Int               `db:"push_mirror_secret_id"`
+	Secret      string                 `db:"push_mirror_secret"`
+	RefFilter   string                 `db:"push_mirror_ref_filter"`
+	Trigger     enum.PushMirrorTrigger `db:"push_mirror_trigger"`
+	Interval    int64                  `db:"push_mirror_interval"`
+	Enabled     bool                   `db:"push_mirror_enabled"`
+	Status      enum.PushMirrorStatus  `db:"push_mirror_status"`
+	LastSuccess int64                  `db:"push_mirror_last_success"`
+	LastError   string                 `db:"push_mirror_last_error"`
+	NextSync    int64                  `db:"push_mirror_next_sync"`
+	CreatedBy   int64                  `db:"push_mirror_created_by"`
+	Created     int64                  `db:"push_mirror_created"`
+	Updated     int64                  `db:"push_mirror_updated"`
+	Version     int64                  `db:"push_mirror_version"`
+}
+
+const (
+	pushMirrorColumns = `
+		 push_mirror_id
+		,push_mirror_repo_id
+		,push_mirror_identifier
+		,push_mirror_url
+		,push_mirror_username
+		,push_mirror_secret_id
+		,COALESCE(secret_identifier, '') AS push_mirror_secret
+		,push_mirror_ref_filter
+		,push_mirror_trigger
+		,push_mirror_interval
+		,push_mirror_enabled
+		,push_mirror_status
+		,push_mirror_last_success
+		,push_mirror_last_error
+		,push_mirror_next_sync
+		,push_mirror_created_by
+		,push_mirror_created
+		,push_mirror_updated
+		,push_mirror_version`
+)
+
+// Find finds a push mirror by its ID.
+func (s *PushMirrorStore) Find(ctx context.Context, id int64) (*types.PushMirror, error) {
+	return s.find(ctx, squirrel.Eq{"push_mirror_id": id})
+}
+
+// FindByIdentifier finds a push mirror of a repository by its identifier.
+func (s *PushMirrorStore) FindByIdentifier(
+	ctx context.Context,
+	repoID int64,
+	identifier string,
+) (*types.PushMirror, error) {
+	return s.find(ctx, squirrel.And{
+		squirrel.Eq{"push_mirror_repo_id": repoID},
+		squirrel.Expr("LOWER(push_mirror_identifier) = ?", strings.ToLower(identifier)),
+	})
+}
+
+func (s *PushMirrorStore) find(ctx context.Context, pred squirrel.Sqlizer) (*types.PushMirror, error) {
+	stmt := database.Builder.
+		Select(pushMirrorColumns).
+		From("push_mirrors").
+		LeftJoin("secrets ON secret_id = push_mirror_secret_id").
+		Where(pred)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pushMirror{}
+	if err := db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find push mirror")
+	}
+
+	return mapPushMirror(dst), nil
+}
+
+// Create creates a new push mirror of a repository.
+func (s *PushMirrorStore) Create(ctx context.Context, mirror *types.PushMirror) error {
+	const sqlQuery = `
+		INSERT INTO push_mirrors (
+			 push_mirror_repo_id
+			,push_mirror_identifier
+			,push_mirror_url
+			,push_mirror_username
+			,push_mirror_secret_id
+			,push_mirror_ref_filter
+			,push_mirror_trigger
+			,push_mirror_interval
+			,push_mirror_enabled
+			,push_mirror_status
+			,push_mirror_last_success
+			,push_mirror_last_error
+			,push_mirror_next_sync
+			,push_mirror_created_by
+			,push_mirror_created
+			,push_mirror_updated
+			,push_mirror_version
+		) VALUES (
+			 :push_mirror_repo_id
+			,:push_mirror_identifier
+			,:push_mirror_url
+			,:push_mirror_username
+			,:push_mirror_secret_id
+			,:push_mirror_ref_filter
+			,:push_mirror_trigger
+			,:push_mirror_interval
+			,:push_mirror_enabled
+			,:push_mirror_status
+			,:push_mirror_last_success
+			,:push_mirror_last_error
+			,:push_mirror_next_sync
+			,:push_mirror_created_by
+			,:push_mirror_created
+			,:push_mirror_updated
+			,:push_mirror_version
+		) RETURNING push_mirror_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalPushMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind push mirror object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&mirror.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert push mirror query failed")
+	}
+
+	return nil
+}
+
+// Update updates a push mirror.
+func (s *PushMirrorStore) Update(ctx context.Context, mirror *types.PushMirror) error {
+	const sqlQuery = `
+		UPDATE push_mirrors
+		SET
+			 push_mirror_version = :push_mirror_version
+			,push_mirror_updated = :push_mirror_updated
+			,push_mirror_identifier = :push_mirror_identifier
+			,push_mirror_url = :push_mirror_url
+			,push_mirror_username = :push_mirror_username
+			,push_mirror_secret_id = :push_mirror_secret_id
+			,push_mirror_ref_filter = :push_mirror_ref_filter
+			,push_mirror_trigger = :push_mirror_trigger
+			,push_mirror_interval = :push_mirror_interval
+			,push_mirror_enabled = :push_mirror_enabled
+			,push_mirror_status = :push_mirror_status
+			,push_mirror_last_success = :push_mirror_last_success
+			,push_mirror_last_error = :push_mirror_last_error
+			,push_mirror_next_sync = :push_mirror_next_sync
+		WHERE push_mirror_id = :push_mirror_id AND push_mirror_version = :push_mirror_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbMirror := mapInternalPushMirror(mirror)
+
+	// update Version (used for optimistic locking) and Updated time
+	dbMirror.Version++
+	dbMirror.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbMirror)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind push mirror object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update push mirror")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	mirror.Version = dbMirror.Version
+	mirror.Updated = dbMirror.Updated
+
+	return nil
+}
+
+// UpdateOptLock updates the push mirror using the optimistic locking mechanism.
+func (s *PushMirrorStore) UpdateOptLock(
+	ctx context.Context,
+	mirror *types.PushMirror,
+	mutateFn func(mirror *types.PushMirror) error,
+) (*types.PushMirror, error) {
+	for {
+		dup := *mirror
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		mirror, err = s.Find(ctx, mirror.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Delete deletes a push mirror.
+func (s *PushMirrorStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM push_mirrors
+		WHERE push_mirror_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List returns all push mirrors of a repository.
+func (s *PushMirrorStore) List(ctx context.Context, repoID int64) ([]*types.PushMirror, error) {
+	stmt := database.Builder.
+		Select(pushMirrorColumns).
+		From("push_mirrors").
+		LeftJoin("secrets ON secret_id = push_mirror_secret_id").
+		Where("push_mirror_repo_id = ?", repoID).
+		OrderBy("push_mirror_identifier")
+
+	return s.list(ctx, stmt)
+}
+
+// ListDue returns up to limit enabled push mirrors of active repositories with the interval trigger
+// whose periodic push is due at the provided time.
+func (s *PushMirrorStore) ListDue(ctx context.Context, now int64, limit int) ([]*types.PushMirror, error) {
+	stmt := database.Builder.
+		Select(pushMirrorColumns).
+		From("push_mirrors").
+		LeftJoin("secrets ON secret_id = push_mirror_secret_id").
+		InnerJoin("repositories ON repo_id = push_mirror_repo_id").
+		Where("push_mirror_enabled = ?", true).
+		Where("push_mirror_trigger = ?", enum.PushMirrorTriggerInterval).
+		Where("push_mirror_next_sync <= ?", now).
+		Where("repo_deleted IS NULL").
+		Where("repo_state = ?", enum.RepoStateActive).
+		OrderBy("push_mirror_next_sync").
+		Limit(uint64(limit))
+
+	return s.list(ctx, stmt)
+}
+
+func (s *PushMirrorStore) list(ctx context.Context, stmt squirrel.SelectBuilder) ([]*types.PushMirror, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pushMirror
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list push mirrors")
+	}
+
+	res := make([]*types.PushMirror, len(dst))
+	for i := range dst {
+		res[i] = mapPushMirror(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapInternalPushMirror(m *types.PushMirror) *pushMirror {
+	return &pushMirror{
+		ID:          m.ID,
+		RepoID:      m.RepoID,
+		Identifier:  m.Identifier,
+		URL:         m.URL,
+		Username:    m.Username,
+		SecretID:    null.IntFromPtr(m.SecretID),
+		RefFilter:   m.RefFilter,
+		Trigger:     m.Trigger,
+		Interval:    m.Interval,
+		Enabled:     m.Enabled,
+		Status:      m.Status,
+		LastSuccess: m.LastSuccess,
+		LastError:   m.LastError,
+		NextSync:    m.NextSync,
+		CreatedBy:   m.CreatedBy,
+		Created:     m.Created,
+		Updated:     m.Updated,
+		Version:     m.Version,
+	}
+}
+
+func mapPushMirror(m *pushMirror) *types.PushMirror {
+	return &types.PushMirror{
+		ID:          m.ID,
+		RepoID:      m.RepoID,
+		Identifier:  m.Identifier,
+		URL:         m.URL,
+		Username:    m.Username,
+		SecretID:    m.SecretID.Ptr(),
+		Secret:      m.Secret,
+		RefFilter:   m.RefFilter,
+		Trigger:     m.Trigger,
+		Interval:    m.Interval,
+		Enabled:     m.Enabled,
+		Status:      m.Status,
+		LastSuccess: m.LastSuccess,
+		LastError:   m.LastError,
+		NextSync:    m.NextSync,
+		CreatedBy:   m.CreatedBy,
+		Created:     m.Created,
+		Updated:     m.Updated,
+		Version:     m.Version,
+	}
+}
@@ -719,6 +719,63 @@ type repoSize struct {
 	SizeUpdated int64  `db:"repo_size_updated"`
 }
 
+// CountForks returns the number of active forks of a repo.
+func (s *RepoStore) CountForks(
+	ctx context.Context,
+	repoID int64,
+	filter *types.RepoFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("repositories").
+		Where("repo_fork_id = ?", repoID)
+
+	stmt = applyQueryFilter(stmt, &types.RepoFilter{Query: filter.Query})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count forks query")
+	}
+	return count, nil
+}
+
+// ListForks returns a list of active forks of a repo, from all spaces.
+func (s *RepoStore) ListForks(
+	ctx context.Context,
+	repoID int64,
+	filter *types.RepoFilter,
+) ([]*types.Repository, error) {
+	stmt := database.Builder.
+		Select(repoColumnsForJoin).
+		From("repositories").
+		Where("repo_fork_id = ?", repoID)
+
+	stmt = applyQueryFilter(stmt, &types.RepoFilter{Query: filter.Query})
+	stmt = applySortFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list forks query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
 func (s *RepoStore) ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeInfo, error) {
 	stmt := database.Builder.
 		Select("repo_id", "repo_git_uid", "repo_size", "repo_size_updated").
@@ -61,6 +61,7 @@ const (
 	,stage_on_failure
 	,stage_depends_on
 	,stage_labels
+	,stage_concurrency_group
 	`
 )
 
@@ -93,6 +94,7 @@ type stage struct {
 	OnFailure     bool               `db:"stage_on_failure"`
 	DependsOn     sqlxtypes.JSONText `db:"stage_depends_on"`
 	Labels        sqlxtypes.JSONText `db:"stage_labels"`
+	Concurrency   string             `db:"stage_concurrency_group"`
 }
 
 // NewStageStore returns a new StageStore.
@@ -152,6 +154,7 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 			,stage_on_failure
 			,stage_depends_on
 			,stage_labels
+			,stage_concurrency_group
 		) VALUES (
 			:stage_execution_id
 			,:stage_repo_id
@@ -180,6 +183,7 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 			,:stage_on_failure
 			,:stage_depends_on
 			,:stage_labels
+			,:stage_concurrency_group
 
 		) RETURNING stage_id`
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -110,6 +110,8 @@ func mapInternalToStage(in *stage) (*types.Stage, error) {
 		OnFailure:   in.OnFailure,
 		DependsOn:   dependsOn,
 		Labels:      labels,
+
+		ConcurrencyGroup: in.Concurrency,
 	}, nil
 }
 
@@ -142,6 +144,7 @@ func mapStageToInternal(in *types.Stage) *stage {
 		OnFailure:   in.OnFailure,
 		DependsOn:   EncodeToSQLXJSON(in.DependsOn),
 		Labels:      EncodeToSQLXJSON(in.Labels),
+		Concurrency: in.ConcurrencyGroup,
 	}
 }
 
@@ -220,6 +223,7 @@ func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 		&stage.OnFailure,
 		&depJSON,
 		&labJSON,
+		&stage.ConcurrencyGroup,
 		&step.ID,
 		&step.StageID,
 		&step.Number,
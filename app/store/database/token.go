@@ -16,6 +16,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
 )
 
 var _ store.TokenStore = (*TokenStore)(nil)
@@ -42,23 +44,29 @@ type TokenStore struct {
 	db *sqlx.DB
 }
 
+// token is used to fetch token data from the database.
+type token struct {
+	types.Token
+	Scopes sqlxtypes.NullJSONText `db:"token_scopes"`
+}
+
 // Find finds the token by id.
 func (s *TokenStore) Find(ctx context.Context, id int64) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(ctx, dst, TokenSelectByID, id); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find token")
 	}
 
-	return dst, nil
+	return mapToken(dst)
 }
 
 // FindByIdentifier finds the token by principalId and token identifier.
 func (s *TokenStore) FindByIdentifier(ctx context.Context, principalID int64, identifier string) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(
 		ctx,
 		dst,
@@ -69,14 +77,19 @@ func (s *TokenStore) FindByIdentifier(ctx context.Context, principalID int64, id
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find token by identifier")
 	}
 
-	return dst, nil
+	return mapToken(dst)
 }
 
 // Create saves the token details.
 func (s *TokenStore) Create(ctx context.Context, token *types.Token) error {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	query, arg, err := db.BindNamed(tokenInsert, token)
+	dbToken, err := mapInternalToken(token)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(tokenInsert, dbToken)
 	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind token object")
 	}
@@ -99,6 +112,32 @@ func (s *TokenStore) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// DeleteForPrincipal deletes all tokens of the principal.
+func (s *TokenStore) DeleteForPrincipal(ctx context.Context, principalID int64) (int64, error) {
+	stmt := database.Builder.
+		Delete("tokens").
+		Where("token_principal_id = ?", principalID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete principal tokens query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to execute delete principal tokens query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to get number of deleted tokens")
+	}
+
+	return n, nil
+}
+
 // DeleteExpiredBefore deletes all tokens that expired before the provided time.
 // If tokenTypes are provided, then only tokens of that type are deleted.
 func (s *TokenStore) DeleteExpiredBefore(
@@ -153,7 +192,7 @@ func (s *TokenStore) List(ctx context.Context,
 	principalID int64, tokenType enum.TokenType) ([]*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := []*types.Token{}
+	dst := []*token{}
 
 	// TODO: custom filters / sorting for tokens.
 
@@ -161,7 +200,42 @@ func (s *TokenStore) List(ctx context.Context,
 	if err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing token list query")
 	}
-	return dst, nil
+
+	tokens := make([]*types.Token, len(dst))
+	for i := range dst {
+		if tokens[i], err = mapToken(dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return tokens, nil
+}
+
+func mapToken(in *token) (*types.Token, error) {
+	res := in.Token
+
+	if in.Scopes.Valid {
+		if err := in.Scopes.Unmarshal(&res.Scopes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal token scopes: %w", err)
+		}
+	}
+
+	return &res, nil
+}
+
+func mapInternalToken(in *types.Token) (*token, error) {
+	res := &token{Token: *in}
+
+	if len(in.Scopes) > 0 {
+		raw, err := json.Marshal(in.Scopes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal token scopes: %w", err)
+		}
+
+		res.Scopes = sqlxtypes.NullJSONText{JSONText: raw, Valid: true}
+	}
+
+	return res, nil
 }
 
 const tokenSelectBase = `
@@ -173,6 +247,7 @@ token_id
 ,token_expires_at
 ,token_issued_at
 ,token_created_by
+,token_scopes
 FROM tokens
 ` //#nosec G101
 
@@ -208,6 +283,7 @@ INSERT INTO tokens (
 	,token_expires_at
 	,token_issued_at
 	,token_created_by
+	,token_scopes
 ) values (
 	:token_type
 	,:token_uid
@@ -215,5 +291,6 @@ INSERT INTO tokens (
 	,:token_expires_at
 	,:token_issued_at
 	,:token_created_by
+	,:token_scopes
 ) RETURNING token_id
 `
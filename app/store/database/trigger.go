@@ -35,19 +35,21 @@ import (
 var _ store.TriggerStore = (*triggerStore)(nil)
 
 type trigger struct {
-	ID          int64              `db:"trigger_id"`
-	Identifier  string             `db:"trigger_uid"`
-	Description string             `db:"trigger_description"`
-	Type        string             `db:"trigger_type"`
-	Secret      string             `db:"trigger_secret"`
-	PipelineID  int64              `db:"trigger_pipeline_id"`
-	RepoID      int64              `db:"trigger_repo_id"`
-	CreatedBy   int64              `db:"trigger_created_by"`
-	Disabled    bool               `db:"trigger_disabled"`
-	Actions     sqlxtypes.JSONText `db:"trigger_actions"`
-	Created     int64              `db:"trigger_created"`
-	Updated     int64              `db:"trigger_updated"`
-	Version     int64              `db:"trigger_version"`
+	ID             int64              `db:"trigger_id"`
+	Identifier     string             `db:"trigger_uid"`
+	Description    string             `db:"trigger_description"`
+	Type           string             `db:"trigger_type"`
+	Secret         string             `db:"trigger_secret"`
+	PipelineID     int64              `db:"trigger_pipeline_id"`
+	RepoID         int64              `db:"trigger_repo_id"`
+	CreatedBy      int64              `db:"trigger_created_by"`
+	Disabled       bool               `db:"trigger_disabled"`
+	Actions        sqlxtypes.JSONText `db:"trigger_actions"`
+	CommentPattern string             `db:"trigger_comment_pattern"`
+	Paths          sqlxtypes.JSONText `db:"trigger_paths"`
+	Created        int64              `db:"trigger_created"`
+	Updated        int64              `db:"trigger_updated"`
+	Version        int64              `db:"trigger_version"`
 }
 
 func mapInternalToTrigger(trigger *trigger) (*types.Trigger, error) {
@@ -57,20 +59,30 @@ func mapInternalToTrigger(trigger *trigger) (*types.Trigger, error) {
 		return nil, errors.Wrap(err, "could not unmarshal trigger.actions")
 	}
 
+	var paths types.TriggerPathFilter
+	if len(trigger.Paths) > 0 {
+		err = json.Unmarshal(trigger.Paths, &paths)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal trigger.paths")
+		}
+	}
+
 	return &types.Trigger{
-		ID:          trigger.ID,
-		Description: trigger.Description,
-		Type:        trigger.Type,
-		Secret:      trigger.Secret,
-		PipelineID:  trigger.PipelineID,
-		RepoID:      trigger.RepoID,
-		CreatedBy:   trigger.CreatedBy,
-		Disabled:    trigger.Disabled,
-		Actions:     actions,
-		Identifier:  trigger.Identifier,
-		Created:     trigger.Created,
-		Updated:     trigger.Updated,
-		Version:     trigger.Version,
+		ID:             trigger.ID,
+		Description:    trigger.Description,
+		Type:           trigger.Type,
+		Secret:         trigger.Secret,
+		PipelineID:     trigger.PipelineID,
+		RepoID:         trigger.RepoID,
+		CreatedBy:      trigger.CreatedBy,
+		Disabled:       trigger.Disabled,
+		Actions:        actions,
+		Identifier:     trigger.Identifier,
+		CommentPattern: trigger.CommentPattern,
+		Paths:          paths,
+		Created:        trigger.Created,
+		Updated:        trigger.Updated,
+		Version:        trigger.Version,
 	}, nil
 }
 
@@ -88,19 +100,21 @@ func mapInternalToTriggerList(triggers []*trigger) ([]*types.Trigger, error) {
 
 func mapTriggerToInternal(t *types.Trigger) *trigger {
 	return &trigger{
-		ID:          t.ID,
-		Identifier:  t.Identifier,
-		Description: t.Description,
-		Type:        t.Type,
-		PipelineID:  t.PipelineID,
-		Secret:      t.Secret,
-		RepoID:      t.RepoID,
-		CreatedBy:   t.CreatedBy,
-		Disabled:    t.Disabled,
-		Actions:     EncodeToSQLXJSON(t.Actions),
-		Created:     t.Created,
-		Updated:     t.Updated,
-		Version:     t.Version,
+		ID:             t.ID,
+		Identifier:     t.Identifier,
+		Description:    t.Description,
+		Type:           t.Type,
+		PipelineID:     t.PipelineID,
+		Secret:         t.Secret,
+		RepoID:         t.RepoID,
+		CreatedBy:      t.CreatedBy,
+		Disabled:       t.Disabled,
+		Actions:        EncodeToSQLXJSON(t.Actions),
+		CommentPattern: t.CommentPattern,
+		Paths:          EncodeToSQLXJSON(t.Paths),
+		Created:        t.Created,
+		Updated:        t.Updated,
+		Version:        t.Version,
 	}
 }
 
@@ -121,6 +135,8 @@ const (
 		,trigger_uid
 		,trigger_disabled
 		,trigger_actions
+		,trigger_comment_pattern
+		,trigger_paths
 		,trigger_description
 		,trigger_pipeline_id
 		,trigger_created
@@ -155,6 +171,8 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		trigger_uid
 		,trigger_description
 		,trigger_actions
+		,trigger_comment_pattern
+		,trigger_paths
 		,trigger_disabled
 		,trigger_type
 		,trigger_secret
@@ -168,6 +186,8 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		:trigger_uid
 		,:trigger_description
 		,:trigger_actions
+		,:trigger_comment_pattern
+		,:trigger_paths
 		,:trigger_disabled
 		,:trigger_type
 		,:trigger_secret
@@ -203,6 +223,8 @@ func (s *triggerStore) Update(ctx context.Context, t *types.Trigger) error {
 		,trigger_disabled = :trigger_disabled
 		,trigger_updated = :trigger_updated
 		,trigger_actions = :trigger_actions
+		,trigger_comment_pattern = :trigger_comment_pattern
+		,trigger_paths = :trigger_paths
 		,trigger_version = :trigger_version
 	WHERE trigger_id = :trigger_id AND trigger_version = :trigger_version - 1`
 	updatedAt := time.Now()
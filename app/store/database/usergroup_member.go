@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserGroupMemberStore = (*UserGroupMemberStore)(nil)
+
+// NewUserGroupMemberStore returns a new UserGroupMemberStore.
+func NewUserGroupMemberStore(db *sqlx.DB) *UserGroupMemberStore {
+	return &UserGroupMemberStore{
+		db: db,
+	}
+}
+
+// UserGroupMemberStore implements a store.UserGroupMemberStore backed by a relational database.
+type UserGroupMemberStore struct {
+	db *sqlx.DB
+}
+
+// Add adds the principal to the user group. It's a no-op if the principal is already a member.
+func (s *UserGroupMemberStore) Add(ctx context.Context, userGroupID, principalID int64) error {
+	const sqlQuery = `
+		INSERT INTO usergroup_members (
+			 usergroup_member_usergroup_id
+			,usergroup_member_principal_id
+			,usergroup_member_created
+		) VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, userGroupID, principalID, time.Now().UnixMilli()); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to add user group member")
+	}
+
+	return nil
+}
+
+// Remove removes the principal from the user group.
+func (s *UserGroupMemberStore) Remove(ctx context.Context, userGroupID, principalID int64) error {
+	const sqlQuery = `
+		DELETE FROM usergroup_members
+		WHERE usergroup_member_usergroup_id = $1 AND usergroup_member_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, userGroupID, principalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to remove user group member")
+	}
+
+	return nil
+}
+
+// ListUserGroupIDs returns IDs of all user groups the principal is a member of.
+func (s *UserGroupMemberStore) ListUserGroupIDs(ctx context.Context, principalID int64) ([]int64, error) {
+	stmt := database.Builder.
+		Select("usergroup_member_usergroup_id").
+		From("usergroup_members").
+		Where("usergroup_member_principal_id = ?", principalID).
+		OrderBy("usergroup_member_usergroup_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []int64
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list user groups of principal")
+	}
+
+	return dst, nil
+}
+
+// ListPrincipalIDs returns IDs of all principals that are members of any of the user groups.
+func (s *UserGroupMemberStore) ListPrincipalIDs(ctx context.Context, userGroupIDs []int64) ([]int64, error) {
+	if len(userGroupIDs) == 0 {
+		return nil, nil
+	}
+
+	stmt := database.Builder.
+		Select("DISTINCT usergroup_member_principal_id").
+		From("usergroup_members").
+		Where(squirrel.Eq{"usergroup_member_usergroup_id": userGroupIDs}).
+		OrderBy("usergroup_member_principal_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []int64
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list user group members")
+	}
+
+	return dst, nil
+}
+
+// ListPrincipalUIDs returns UIDs of all principals that are members of the user group.
+func (s *UserGroupMemberStore) ListPrincipalUIDs(ctx context.Context, userGroupID int64) ([]string, error) {
+	stmt := database.Builder.
+		Select("principal_uid").
+		From("usergroup_members").
+		InnerJoin("principals ON principal_id = usergroup_member_principal_id").
+		Where("usergroup_member_usergroup_id = ?", userGroupID).
+		OrderBy("principal_uid")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []string
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list user group member UIDs")
+	}
+
+	return dst, nil
+}
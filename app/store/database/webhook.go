@@ -69,6 +69,12 @@ type webhook struct {
 	Insecure              bool        `db:"webhook_insecure"`
 	Triggers              string      `db:"webhook_triggers"`
 	LatestExecutionResult null.String `db:"webhook_latest_execution_result"`
+
+	PayloadFormat   enum.WebhookPayloadFormat `db:"webhook_payload_format"`
+	PayloadTemplate string                    `db:"webhook_payload_template"`
+
+	MaxRetries          int `db:"webhook_max_retries"`
+	ConsecutiveFailures int `db:"webhook_consecutive_failures"`
 }
 
 const (
@@ -90,7 +96,11 @@ const (
 		,webhook_triggers
 		,webhook_latest_execution_result
 		,webhook_type
-		,webhook_scope`
+		,webhook_scope
+		,webhook_payload_format
+		,webhook_payload_template
+		,webhook_max_retries
+		,webhook_consecutive_failures`
 
 	webhookSelectBase = `
 	SELECT` + webhookColumns + `
@@ -178,6 +188,10 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,webhook_latest_execution_result
 			,webhook_type
 			,webhook_scope
+			,webhook_payload_format
+			,webhook_payload_template
+			,webhook_max_retries
+			,webhook_consecutive_failures
 		) values (
 			:webhook_repo_id
 			,:webhook_space_id
@@ -195,6 +209,10 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,:webhook_latest_execution_result
 			,:webhook_type
 			,:webhook_scope
+			,:webhook_payload_format
+			,:webhook_payload_template
+			,:webhook_max_retries
+			,:webhook_consecutive_failures
 		) RETURNING webhook_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -232,6 +250,10 @@ func (s *WebhookStore) Update(ctx context.Context, hook *types.Webhook) error {
 			,webhook_insecure = :webhook_insecure
 			,webhook_triggers = :webhook_triggers
 			,webhook_latest_execution_result = :webhook_latest_execution_result
+			,webhook_payload_format = :webhook_payload_format
+			,webhook_payload_template = :webhook_payload_template
+			,webhook_max_retries = :webhook_max_retries
+			,webhook_consecutive_failures = :webhook_consecutive_failures
 		WHERE webhook_id = :webhook_id and webhook_version = :webhook_version - 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -457,6 +479,10 @@ func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 		Triggers:              triggersFromString(hook.Triggers),
 		LatestExecutionResult: (*enum.WebhookExecutionResult)(hook.LatestExecutionResult.Ptr()),
 		Type:                  hook.Type,
+		PayloadFormat:         hook.PayloadFormat,
+		PayloadTemplate:       hook.PayloadTemplate,
+		MaxRetries:            hook.MaxRetries,
+		ConsecutiveFailures:   hook.ConsecutiveFailures,
 	}
 
 	switch {
@@ -494,6 +520,10 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 		Triggers:              triggersToString(hook.Triggers),
 		LatestExecutionResult: null.StringFromPtr((*string)(hook.LatestExecutionResult)),
 		Type:                  hook.Type,
+		PayloadFormat:         hook.PayloadFormat,
+		PayloadTemplate:       hook.PayloadTemplate,
+		MaxRetries:            hook.MaxRetries,
+		ConsecutiveFailures:   hook.ConsecutiveFailures,
 	}
 
 	switch hook.ParentType {
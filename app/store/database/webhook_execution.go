@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 )
@@ -63,6 +64,16 @@ type webhookExecution struct {
 	ResponseStatus     string                      `db:"webhook_execution_response_status"`
 	ResponseHeaders    string                      `db:"webhook_execution_response_headers"`
 	ResponseBody       string                      `db:"webhook_execution_response_body"`
+	Attempt            int                         `db:"webhook_execution_attempt"`
+	NextRetryAt        null.Int                    `db:"webhook_execution_next_retry_at"`
+}
+
+// failedWebhookExecution is a webhook execution joined with the identifying data of its webhook.
+type failedWebhookExecution struct {
+	webhookExecution
+	WebhookIdentifier string   `db:"webhook_uid"`
+	WebhookRepoID     null.Int `db:"webhook_repo_id"`
+	WebhookSpaceID    null.Int `db:"webhook_space_id"`
 }
 
 const (
@@ -83,7 +94,9 @@ const (
 		,webhook_execution_response_status_code
 		,webhook_execution_response_status
 		,webhook_execution_response_headers
-		,webhook_execution_response_body`
+		,webhook_execution_response_body
+		,webhook_execution_attempt
+		,webhook_execution_next_retry_at`
 
 	webhookExecutionSelectBase = `
 	SELECT` + webhookExecutionColumns + `
@@ -125,6 +138,8 @@ func (s *WebhookExecutionStore) Create(ctx context.Context, execution *types.Web
 		,webhook_execution_response_status
 		,webhook_execution_response_headers
 		,webhook_execution_response_body
+		,webhook_execution_attempt
+		,webhook_execution_next_retry_at
 	) values (
 		 :webhook_execution_retrigger_of
 		,:webhook_execution_retriggerable
@@ -142,6 +157,8 @@ func (s *WebhookExecutionStore) Create(ctx context.Context, execution *types.Web
 		,:webhook_execution_response_status
 		,:webhook_execution_response_headers
 		,:webhook_execution_response_body
+		,:webhook_execution_attempt
+		,:webhook_execution_next_retry_at
 	) RETURNING webhook_execution_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -158,6 +175,22 @@ func (s *WebhookExecutionStore) Create(ctx context.Context, execution *types.Web
 	return nil
 }
 
+// ClearNextRetry marks the webhook execution as not being retried automatically anymore.
+func (s *WebhookExecutionStore) ClearNextRetry(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	UPDATE webhook_executions
+	SET webhook_execution_next_retry_at = NULL
+	WHERE webhook_execution_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Update query failed")
+	}
+
+	return nil
+}
+
 // DeleteOld removes all executions that are older than the provided time.
 func (s *WebhookExecutionStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
 	stmt := database.Builder.
@@ -238,6 +271,116 @@ func (s *WebhookExecutionStore) CountForWebhook(
 	return count, nil
 }
 
+// ListFailed lists the failed webhook executions of all external webhooks in the provided spaces
+// and in their repositories, that won't be redelivered automatically and haven't been retriggered.
+func (s *WebhookExecutionStore) ListFailed(
+	ctx context.Context,
+	spaceIDs []int64,
+	opts *types.WebhookFailedExecutionFilter,
+) ([]*types.WebhookFailedExecution, error) {
+	stmt := database.Builder.
+		Select(webhookExecutionColumns + `
+			,webhook_uid
+			,webhook_repo_id
+			,webhook_space_id`).
+		From("webhook_executions").
+		InnerJoin("webhooks ON webhook_id = webhook_execution_webhook_id")
+
+	stmt, err := applyFailedExecutionFilter(stmt, spaceIDs, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt = stmt.Limit(database.Limit(opts.Size))
+	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
+	stmt = stmt.OrderBy("webhook_execution_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*failedWebhookExecution{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	res := make([]*types.WebhookFailedExecution, len(dst))
+	for i, execution := range dst {
+		res[i] = mapToWebhookFailedExecution(execution)
+	}
+
+	return res, nil
+}
+
+// CountFailed counts the failed webhook executions of all external webhooks in the provided spaces
+// and in their repositories, that won't be redelivered automatically and haven't been retriggered.
+func (s *WebhookExecutionStore) CountFailed(
+	ctx context.Context,
+	spaceIDs []int64,
+	opts *types.WebhookFailedExecutionFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("webhook_executions").
+		InnerJoin("webhooks ON webhook_id = webhook_execution_webhook_id")
+
+	stmt, err := applyFailedExecutionFilter(stmt, spaceIDs, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.GetContext(ctx, &count, sql, args...); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Count query failed")
+	}
+
+	return count, nil
+}
+
+func applyFailedExecutionFilter(
+	stmt squirrel.SelectBuilder,
+	spaceIDs []int64,
+	opts *types.WebhookFailedExecutionFilter,
+) (squirrel.SelectBuilder, error) {
+	// the sub-query has to use the default placeholders, the outer query replaces them.
+	repoSQL, repoArgs, err := squirrel.
+		Select("repo_id").
+		From("repositories").
+		Where("repo_deleted IS NULL").
+		Where(squirrel.Eq{"repo_parent_id": spaceIDs}).
+		ToSql()
+	if err != nil {
+		return stmt, fmt.Errorf("failed to convert repository sub-query to sql: %w", err)
+	}
+
+	stmt = stmt.
+		Where(squirrel.Or{
+			squirrel.Eq{"webhook_space_id": spaceIDs},
+			squirrel.Expr("webhook_repo_id IN ("+repoSQL+")", repoArgs...),
+		}).
+		Where("webhook_type = ?", enum.WebhookTypeExternal).
+		Where("webhook_execution_result <> ?", enum.WebhookExecutionResultSuccess).
+		Where("webhook_execution_next_retry_at IS NULL").
+		Where(`NOT EXISTS (SELECT 1 FROM webhook_executions AS retriggered
+			WHERE retriggered.webhook_execution_retrigger_of = webhook_executions.webhook_execution_id)`)
+
+	if opts.Trigger != "" {
+		stmt = stmt.Where("webhook_execution_trigger_type = ?", opts.Trigger)
+	}
+
+	return stmt, nil
+}
+
 // ListForTrigger lists the webhook executions for a given trigger id.
 func (s *WebhookExecutionStore) ListForTrigger(ctx context.Context,
 	triggerID string) ([]*types.WebhookExecution, error) {
@@ -277,6 +420,8 @@ func mapToWebhookExecution(execution *webhookExecution) *types.WebhookExecution
 			Headers:    execution.ResponseHeaders,
 			Body:       execution.ResponseBody,
 		},
+		Attempt:     execution.Attempt,
+		NextRetryAt: execution.NextRetryAt.Ptr(),
 	}
 }
 
@@ -299,9 +444,28 @@ func mapToInternalWebhookExecution(execution *types.WebhookExecution) *webhookEx
 		ResponseStatus:     execution.Response.Status,
 		ResponseHeaders:    execution.Response.Headers,
 		ResponseBody:       execution.Response.Body,
+		Attempt:            execution.Attempt,
+		NextRetryAt:        null.IntFromPtr(execution.NextRetryAt),
 	}
 }
 
+func mapToWebhookFailedExecution(execution *failedWebhookExecution) *types.WebhookFailedExecution {
+	res := &types.WebhookFailedExecution{
+		WebhookExecution:  *mapToWebhookExecution(&execution.webhookExecution),
+		WebhookIdentifier: execution.WebhookIdentifier,
+	}
+
+	if execution.WebhookRepoID.Valid {
+		res.WebhookParentType = enum.WebhookParentRepo
+		res.WebhookParentID = execution.WebhookRepoID.Int64
+	} else {
+		res.WebhookParentType = enum.WebhookParentSpace
+		res.WebhookParentID = execution.WebhookSpaceID.Int64
+	}
+
+	return res
+}
+
 func mapToWebhookExecutions(executions []*webhookExecution) []*types.WebhookExecution {
 	m := make([]*types.WebhookExecution, len(executions))
 	for i, hook := range executions {
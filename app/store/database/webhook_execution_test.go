@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookExecutionStoreListFailed(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	webhookStore := database.NewWebhookStore(db)
+	executionStore := database.NewWebhookExecutionStore(db)
+
+	hooks := []*types.Webhook{
+		{Identifier: "space-hook", ParentType: enum.WebhookParentSpace, ParentID: 1, Type: enum.WebhookTypeExternal},
+		{Identifier: "repo-hook", ParentType: enum.WebhookParentRepo, ParentID: 1, Type: enum.WebhookTypeExternal},
+		{Identifier: "internal-hook", ParentType: enum.WebhookParentRepo, ParentID: 1, Type: enum.WebhookTypeInternal},
+	}
+	for _, hook := range hooks {
+		hook.URL = "https://example.com/" + hook.Identifier
+		hook.CreatedBy = userID
+		hook.Enabled = true
+		require.NoError(t, webhookStore.Create(ctx, hook))
+	}
+
+	executions := []*types.WebhookExecution{
+		{WebhookID: hooks[0].ID, Result: enum.WebhookExecutionResultFatalError},
+		{WebhookID: hooks[0].ID, Result: enum.WebhookExecutionResultSuccess},
+		{WebhookID: hooks[1].ID, Result: enum.WebhookExecutionResultRetriableError},
+		{WebhookID: hooks[2].ID, Result: enum.WebhookExecutionResultFatalError},
+	}
+	for _, execution := range executions {
+		execution.TriggerType = enum.WebhookTriggerBranchCreated
+		execution.Retriggerable = true
+		require.NoError(t, executionStore.Create(ctx, execution))
+	}
+
+	filter := &types.WebhookFailedExecutionFilter{Page: 1, Size: 10}
+
+	failed, err := executionStore.ListFailed(ctx, []int64{1}, filter)
+	require.NoError(t, err)
+
+	// the executions of internal webhooks must be neither listed nor retriggered in bulk
+	ids := make([]int64, len(failed))
+	for i, execution := range failed {
+		ids[i] = execution.ID
+	}
+	require.Equal(t, []int64{executions[2].ID, executions[0].ID}, ids)
+
+	count, err := executionStore.CountFailed(ctx, []int64{1}, filter)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count)
+}
@@ -74,6 +74,18 @@ var WireSet = wire.NewSet(
 	ProvideInfraProviderTemplateStore,
 	ProvideInfraProvisionedStore,
 	ProvideUsageMetricStore,
+	ProvideLFSObjectStore,
+	ProvideMergeQueueStore,
+	ProvideAutoMergeStore,
+	ProvideUserGroupMemberStore,
+	ProvideAuditEventStore,
+	ProvideNotificationChannelStore,
+	ProvideNotificationPreferenceStore,
+	ProvideNotificationDigestStore,
+	ProvidePipelineArtifactStore,
+	ProvidePipelineCacheStore,
+	ProvidePullMirrorStore,
+	ProvidePushMirrorStore,
 )
 
 // migrator is helper function to set up the database by performing automated
@@ -354,3 +366,63 @@ func ProvideInfraProvisionedStore(db *sqlx.DB) store.InfraProvisionedStore {
 func ProvideUsageMetricStore(db *sqlx.DB) store.UsageMetricStore {
 	return NewUsageMetricsStore(db)
 }
+
+// ProvideLFSObjectStore provides an lfs object store.
+func ProvideLFSObjectStore(db *sqlx.DB) store.LFSObjectStore {
+	return NewLFSObjectStore(db)
+}
+
+// ProvideMergeQueueStore provides a merge queue store.
+func ProvideMergeQueueStore(db *sqlx.DB) store.MergeQueueStore {
+	return NewMergeQueueStore(db)
+}
+
+// ProvideAutoMergeStore provides an auto-merge store.
+func ProvideAutoMergeStore(db *sqlx.DB) store.AutoMergeStore {
+	return NewAutoMergeStore(db)
+}
+
+// ProvideUserGroupMemberStore provides a user group member store.
+func ProvideUserGroupMemberStore(db *sqlx.DB) store.UserGroupMemberStore {
+	return NewUserGroupMemberStore(db)
+}
+
+// ProvideAuditEventStore provides an audit event store.
+func ProvideAuditEventStore(db *sqlx.DB) store.AuditEventStore {
+	return NewAuditEventStore(db)
+}
+
+// ProvideNotificationChannelStore provides a notification channel store.
+func ProvideNotificationChannelStore(db *sqlx.DB) store.NotificationChannelStore {
+	return NewNotificationChannelStore(db)
+}
+
+// ProvideNotificationPreferenceStore provides a notification preference store.
+func ProvideNotificationPreferenceStore(db *sqlx.DB) store.NotificationPreferenceStore {
+	return NewNotificationPreferenceStore(db)
+}
+
+// ProvideNotificationDigestStore provides a notification digest store.
+func ProvideNotificationDigestStore(db *sqlx.DB) store.NotificationDigestStore {
+	return NewNotificationDigestStore(db)
+}
+
+// ProvidePipelineArtifactStore provides a pipeline artifact store.
+func ProvidePipelineArtifactStore(db *sqlx.DB) store.PipelineArtifactStore {
+	return NewPipelineArtifactStore(db)
+}
+
+// ProvidePipelineCacheStore provides a pipeline build cache store.
+func ProvidePipelineCacheStore(db *sqlx.DB) store.PipelineCacheStore {
+	return NewPipelineCacheStore(db)
+}
+
+// ProvidePullMirrorStore provides a pull mirror store.
+func ProvidePullMirrorStore(db *sqlx.DB) store.PullMirrorStore {
+	return NewPullMirrorStore(db)
+}
+
+// ProvidePushMirrorStore provides a push mirror store.
+func ProvidePushMirrorStore(db *sqlx.DB) store.PushMirrorStore {
+	return NewPushMirrorStore(db)
+}
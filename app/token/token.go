@@ -61,6 +61,7 @@ func CreateUserSession(
 		principal,
 		identifier,
 		ptr.Duration(userSessionTokenLifeTime),
+		nil,
 	)
 }
 
@@ -71,6 +72,7 @@ func CreatePAT(
 	createdFor *types.User,
 	identifier string,
 	lifetime *time.Duration,
+	scopes []types.TokenScope,
 ) (*types.Token, string, error) {
 	return create(
 		ctx,
@@ -80,6 +82,7 @@ func CreatePAT(
 		createdFor.ToPrincipal(),
 		identifier,
 		lifetime,
+		scopes,
 	)
 }
 
@@ -99,6 +102,7 @@ func CreateSAT(
 		createdFor.ToPrincipal(),
 		identifier,
 		lifetime,
+		nil,
 	)
 }
 
@@ -110,6 +114,7 @@ func create(
 	createdFor *types.Principal,
 	identifier string,
 	lifetime *time.Duration,
+	scopes []types.TokenScope,
 ) (*types.Token, string, error) {
 	issuedAt := time.Now()
 
@@ -126,6 +131,7 @@ func create(
 		IssuedAt:    issuedAt.UnixMilli(),
 		ExpiresAt:   expiresAt,
 		CreatedBy:   createdBy.ID,
+		Scopes:      scopes,
 	}
 
 	err := tokenStore.Create(ctx, &token)
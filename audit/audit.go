@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/harness/gitness/types"
 )
@@ -47,20 +48,27 @@ const (
 	BypassActionMerged              = "merged"
 	BypassSHALabelFormat            = "%s @%s"
 	BypassPullReqLabelFormat        = "%s #%s"
+	DataRequestID                   = "requestID"
+	LoginMethod                     = "loginMethod"
+	LoginMethodPassword             = "password"
+	LoginMethodLDAP                 = "ldap"
+	LoginMethodOIDC                 = "oidc"
 )
 
 type Action string
 
 const (
-	ActionCreated  Action = "created"
-	ActionUpdated  Action = "updated" // update default branch, switching default branch, updating description
-	ActionDeleted  Action = "deleted"
-	ActionBypassed Action = "bypassed"
+	ActionCreated     Action = "created"
+	ActionUpdated     Action = "updated" // update default branch, switching default branch, updating description
+	ActionDeleted     Action = "deleted"
+	ActionBypassed    Action = "bypassed"
+	ActionLoggedIn    Action = "logged_in"
+	ActionLoginFailed Action = "login_failed"
 )
 
 func (a Action) Validate() error {
 	switch a {
-	case ActionCreated, ActionUpdated, ActionDeleted, ActionBypassed:
+	case ActionCreated, ActionUpdated, ActionDeleted, ActionBypassed, ActionLoggedIn, ActionLoginFailed:
 		return nil
 	default:
 		return ErrActionUndefined
@@ -72,24 +80,40 @@ type ResourceType string
 const (
 	ResourceTypeRepository            ResourceType = "repository"
 	ResourceTypeBranchRule            ResourceType = "branch_rule"
+	ResourceTypeTagRule               ResourceType = "tag_rule"
 	ResourceTypeBranch                ResourceType = "branch"
 	ResourceTypePullRequest           ResourceType = "pull_request"
 	ResourceTypeRepositorySettings    ResourceType = "repository_settings"
 	ResourceTypeRegistry              ResourceType = "registry"
 	ResourceTypeRegistryUpstreamProxy ResourceType = "registry_upstream_proxy"
 	ResourceTypeRegistryArtifact      ResourceType = "registry_artifact"
+	ResourceTypeSpace                 ResourceType = "space"
+	ResourceTypeMembership            ResourceType = "membership"
+	ResourceTypeToken                 ResourceType = "token"
+	ResourceTypeSecret                ResourceType = "secret"
+	ResourceTypeWebhook               ResourceType = "webhook"
+	ResourceTypeUser                  ResourceType = "user"
+	ResourceTypeNotificationChannel   ResourceType = "notification_channel"
 )
 
 func (a ResourceType) Validate() error {
 	switch a {
 	case ResourceTypeRepository,
 		ResourceTypeBranchRule,
+		ResourceTypeTagRule,
 		ResourceTypeBranch,
 		ResourceTypePullRequest,
 		ResourceTypeRepositorySettings,
 		ResourceTypeRegistry,
 		ResourceTypeRegistryUpstreamProxy,
-		ResourceTypeRegistryArtifact:
+		ResourceTypeRegistryArtifact,
+		ResourceTypeSpace,
+		ResourceTypeMembership,
+		ResourceTypeToken,
+		ResourceTypeSecret,
+		ResourceTypeWebhook,
+		ResourceTypeUser,
+		ResourceTypeNotificationChannel:
 		return nil
 
 	default:
@@ -97,6 +121,12 @@ func (a ResourceType) Validate() error {
 	}
 }
 
+// IsSystemLevel returns true if the resources of the type don't belong to a space,
+// so their audit events are recorded without a space path.
+func (a ResourceType) IsSystemLevel() bool {
+	return a == ResourceTypeUser || a == ResourceTypeToken
+}
+
 type Resource struct {
 	Type       ResourceType
 	Identifier string
@@ -159,7 +189,7 @@ func (e *Event) Validate() error {
 	if e.User.UID == "" {
 		return ErrUserIsRequired
 	}
-	if e.SpacePath == "" {
+	if e.SpacePath == "" && !e.Resource.Type.IsSystemLevel() {
 		return ErrSpacePathIsRequired
 	}
 	if err := e.Resource.Validate(); err != nil {
@@ -168,6 +198,40 @@ func (e *Event) Validate() error {
 	return nil
 }
 
+// NewEvent creates a new audit event. The request details are taken from the context, see Middleware.
+func NewEvent(
+	ctx context.Context,
+	user types.Principal,
+	resource Resource,
+	action Action,
+	spacePath string,
+	options ...Option,
+) (*Event, error) {
+	event := &Event{
+		Timestamp:     time.Now().UnixMilli(),
+		Action:        action,
+		User:          user,
+		SpacePath:     spacePath,
+		Resource:      resource,
+		ClientIP:      GetRealIP(ctx),
+		RequestMethod: GetRequestMethod(ctx),
+	}
+
+	if id := GetRequestID(ctx); id != "" {
+		WithData(DataRequestID, id).Apply(event)
+	}
+
+	for _, option := range options {
+		option.Apply(event)
+	}
+
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
 type Noop struct{}
 
 func New() *Noop {
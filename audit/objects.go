@@ -19,6 +19,7 @@ import (
 
 	registrytypes "github.com/harness/gitness/registry/types"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 // RepositoryObject is the object used for emitting repository related audits.
@@ -61,3 +62,97 @@ type RegistryUpstreamProxyConfigObject struct {
 	CreatedBy  int64
 	UpdatedBy  int64
 }
+
+// SpaceObject is the object used for emitting space related audits.
+type SpaceObject struct {
+	types.Space
+	IsPublic bool `yaml:"is_public"`
+}
+
+// MembershipObject is the object used for emitting space membership related audits.
+type MembershipObject struct {
+	PrincipalUID string              `yaml:"principal_uid"`
+	Role         enum.MembershipRole `yaml:"role"`
+}
+
+// TokenObject is the object used for emitting token related audits. It never contains the token itself.
+type TokenObject struct {
+	Identifier   string         `yaml:"identifier"`
+	Type         enum.TokenType `yaml:"type"`
+	PrincipalUID string         `yaml:"principal_uid"`
+	ExpiresAt    *int64         `yaml:"expires_at"`
+}
+
+// SecretObject is the object used for emitting secret related audits. It never contains the secret data.
+type SecretObject struct {
+	Identifier  string `yaml:"identifier"`
+	Description string `yaml:"description"`
+}
+
+// WebhookObject is the object used for emitting webhook related audits.
+// It never contains the webhook secret nor the URL, as URLs often contain credentials or tokens.
+type WebhookObject struct {
+	Identifier  string                `yaml:"identifier"`
+	DisplayName string                `yaml:"display_name"`
+	Description string                `yaml:"description"`
+	Enabled     bool                  `yaml:"enabled"`
+	Insecure    bool                  `yaml:"insecure"`
+	HasSecret   bool                  `yaml:"has_secret"`
+	Triggers    []enum.WebhookTrigger `yaml:"triggers"`
+	URLUpdated  bool                  `yaml:"url_updated,omitempty"`
+}
+
+// NewWebhookObject returns the audit object of the webhook.
+func NewWebhookObject(hook *types.Webhook) WebhookObject {
+	return WebhookObject{
+		Identifier:  hook.Identifier,
+		DisplayName: hook.DisplayName,
+		Description: hook.Description,
+		Enabled:     hook.Enabled,
+		Insecure:    hook.Insecure,
+		HasSecret:   hook.Secret != "",
+		Triggers:    hook.Triggers,
+	}
+}
+
+// NotificationChannelObject is the object used for emitting notification channel related audits.
+// It never contains the channel URL, as incoming webhook URLs are secrets.
+type NotificationChannelObject struct {
+	Identifier  string                            `yaml:"identifier"`
+	Description string                            `yaml:"description"`
+	Type        enum.NotificationChannelType      `yaml:"type"`
+	Enabled     bool                              `yaml:"enabled"`
+	Triggers    []enum.NotificationChannelTrigger `yaml:"triggers"`
+	URLUpdated  bool                              `yaml:"url_updated,omitempty"`
+}
+
+// NewNotificationChannelObject returns the audit object of the notification channel.
+func NewNotificationChannelObject(channel *types.NotificationChannel) NotificationChannelObject {
+	return NotificationChannelObject{
+		Identifier:  channel.Identifier,
+		Description: channel.Description,
+		Type:        channel.Type,
+		Enabled:     channel.Enabled,
+		Triggers:    channel.Triggers,
+	}
+}
+
+// UserObject is the object used for emitting user related audits.
+type UserObject struct {
+	UID         string `yaml:"uid"`
+	Email       string `yaml:"email"`
+	DisplayName string `yaml:"display_name"`
+	Admin       bool   `yaml:"admin"`
+	Blocked     bool   `yaml:"blocked"`
+}
+
+// NewUserObject returns the audit object of the user.
+func NewUserObject(user *types.User) UserObject {
+	return UserObject{
+		UID:         user.UID,
+		Email:       user.Email,
+		DisplayName: user.DisplayName,
+		Admin:       user.Admin,
+		Blocked:     user.Blocked,
+	}
+}
@@ -29,7 +29,9 @@ import (
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/gitspaceevent"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/pushmirror"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/blob"
@@ -49,11 +51,12 @@ import (
 )
 
 const (
-	schemeHTTP     = "http"
-	schemeHTTPS    = "https"
-	schemeSSH      = "ssh"
-	gitnessHomeDir = ".gitness"
-	blobDir        = "blob"
+	schemeHTTP       = "http"
+	schemeHTTPS      = "https"
+	schemeSSH        = "ssh"
+	gitnessHomeDir   = ".gitness"
+	blobDir          = "blob"
+	keywordSearchDir = "keywordsearch"
 )
 
 // LoadConfig returns the system configuration from the
@@ -331,6 +334,9 @@ func ProvideWebhookConfig(config *types.Config) webhook.Config {
 		MaxRetries:          config.Webhook.MaxRetries,
 		AllowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
 		AllowLoopback:       config.Webhook.AllowLoopback,
+		RetryBackoff:        config.Webhook.RetryBackoff,
+		RetryMaxBackoff:     config.Webhook.RetryMaxBackoff,
+		AutoDisableAfter:    config.Webhook.AutoDisableAfter,
 	}
 }
 
@@ -339,6 +345,11 @@ func ProvideNotificationConfig(config *types.Config) notification.Config {
 		EventReaderName: config.InstanceID,
 		Concurrency:     config.Notification.Concurrency,
 		MaxRetries:      config.Notification.MaxRetries,
+
+		ChannelAllowLoopback:       config.Notification.ChannelAllowLoopback,
+		ChannelAllowPrivateNetwork: config.Notification.ChannelAllowPrivateNetwork,
+
+		DigestCron: config.Notification.DigestCron,
 	}
 }
 
@@ -382,6 +393,23 @@ func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
 		WebhookExecutionsRetentionTime:   config.Webhook.RetentionTime,
 		DeletedRepositoriesRetentionTime: config.Repos.DeletedRetentionTime,
+		PipelineCacheRetentionTime:       config.CI.CacheRetention,
+	}
+}
+
+// ProvideMergeQueueConfig loads the merge queue service config from the main config.
+func ProvideMergeQueueConfig(config *types.Config) mergequeue.Config {
+	return mergequeue.Config{
+		CheckTimeout: config.MergeQueue.CheckTimeout,
+	}
+}
+
+// ProvidePushMirrorConfig loads the push mirror service config from the main config.
+func ProvidePushMirrorConfig(config *types.Config) pushmirror.Config {
+	return pushmirror.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.PushMirror.Concurrency,
+		MaxRetries:      config.PushMirror.MaxRetries,
 	}
 }
 
@@ -393,12 +421,24 @@ func ProvideCodeOwnerConfig(config *types.Config) codeowners.Config {
 }
 
 // ProvideKeywordSearchConfig loads the keyword search service config from the main config.
-func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
-	return keywordsearch.Config{
-		EventReaderName: config.InstanceID,
-		Concurrency:     config.KeywordSearch.Concurrency,
-		MaxRetries:      config.KeywordSearch.MaxRetries,
+func ProvideKeywordSearchConfig(config *types.Config) (keywordsearch.Config, error) {
+	if config.KeywordSearch.IndexPath == "" {
+		homedir, err := os.UserHomeDir()
+		if err != nil {
+			return keywordsearch.Config{}, err
+		}
+
+		config.KeywordSearch.IndexPath = filepath.Join(homedir, gitnessHomeDir, keywordSearchDir)
 	}
+
+	return keywordsearch.Config{
+		EventReaderName:  config.InstanceID,
+		Concurrency:      config.KeywordSearch.Concurrency,
+		MaxRetries:       config.KeywordSearch.MaxRetries,
+		IndexPath:        config.KeywordSearch.IndexPath,
+		MaxFileSize:      config.KeywordSearch.MaxFileSize,
+		MaxCachedIndexes: config.KeywordSearch.MaxCachedIndexes,
+	}, nil
 }
 
 func ProvideJobsConfig(config *types.Config) job.Config {
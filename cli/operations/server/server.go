@@ -105,6 +105,36 @@ func (c *command) run(*kingpin.ParseContext) error {
 			return err
 		}
 
+		if err := system.services.MergeQueue.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register merge queue service")
+			return err
+		}
+
+		if err := system.services.PullMirror.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register pull mirror service")
+			return err
+		}
+
+		if err := system.services.PushMirror.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register push mirror service")
+			return err
+		}
+
+		if err := system.services.LDAPSync.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register ldap sync service")
+			return err
+		}
+
+		if err := system.services.Notification.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register notification service")
+			return err
+		}
+
+		if err := system.services.Webhook.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register webhook service")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
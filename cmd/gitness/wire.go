@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/harness/gitness/app/api/controller/aiagent"
+	auditlogcontroller "github.com/harness/gitness/app/api/controller/auditlog"
 	"github.com/harness/gitness/app/api/controller/capabilities"
 	checkcontroller "github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
@@ -29,9 +30,11 @@ import (
 	gitspaceCtrl "github.com/harness/gitness/app/api/controller/gitspace"
 	infraproviderCtrl "github.com/harness/gitness/app/api/controller/infraprovider"
 	controllerkeywordsearch "github.com/harness/gitness/app/api/controller/keywordsearch"
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	controllerlogs "github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
+	"github.com/harness/gitness/app/api/controller/notificationpref"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
@@ -52,14 +55,17 @@ import (
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/oidc"
 	"github.com/harness/gitness/app/bootstrap"
 	connectorservice "github.com/harness/gitness/app/connector"
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
 	gitspaceevents "github.com/harness/gitness/app/events/gitspace"
 	gitspaceinfraevents "github.com/harness/gitness/app/events/gitspaceinfra"
 	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	webhookevents "github.com/harness/gitness/app/events/webhook"
 	infrastructure "github.com/harness/gitness/app/gitspace/infrastructure"
 	"github.com/harness/gitness/app/gitspace/logutil"
 	"github.com/harness/gitness/app/gitspace/orchestrator"
@@ -82,6 +88,8 @@ import (
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
 	aiagentservice "github.com/harness/gitness/app/services/aiagent"
+	"github.com/harness/gitness/app/services/auditlog"
+	"github.com/harness/gitness/app/services/automerge"
 	capabilitiesservice "github.com/harness/gitness/app/services/capabilities"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
@@ -93,7 +101,9 @@ import (
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	svclabel "github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/ldapsync"
 	locker "github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mergequeue"
 	messagingservice "github.com/harness/gitness/app/services/messaging"
 	"github.com/harness/gitness/app/services/metric"
 	migrateservice "github.com/harness/gitness/app/services/migrate"
@@ -102,7 +112,9 @@ import (
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/pullmirror"
 	pullreqservice "github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pushmirror"
 	"github.com/harness/gitness/app/services/refcache"
 	reposervice "github.com/harness/gitness/app/services/repo"
 	"github.com/harness/gitness/app/services/rules"
@@ -119,7 +131,6 @@ import (
 	"github.com/harness/gitness/app/store/database"
 	"github.com/harness/gitness/app/store/logs"
 	"github.com/harness/gitness/app/url"
-	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/blob"
 	cliserver "github.com/harness/gitness/cli/operations/server"
 	"github.com/harness/gitness/encrypt"
@@ -173,21 +184,25 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		serviceaccount.WireSet,
 		user.WireSet,
 		upload.WireSet,
+		lfs.WireSet,
 		service.WireSet,
 		principal.WireSet,
 		usergroupservice.WireSet,
 		system.WireSet,
 		authn.WireSet,
+		oidc.WireSet,
 		authz.WireSet,
 		infrastructure.WireSet,
 		infraproviderpkg.WireSet,
 		gitspaceevents.WireSet,
 		pipelineevents.WireSet,
+		checkevents.WireSet,
 		infraproviderCtrl.WireSet,
 		gitspaceCtrl.WireSet,
 		gitevents.WireSet,
 		pullreqevents.WireSet,
 		repoevents.WireSet,
+		webhookevents.WireSet,
 		storage.WireSet,
 		api.WireSet,
 		cliserver.ProvideGitConfig,
@@ -213,6 +228,13 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		job.WireSet,
 		cliserver.ProvideCleanupConfig,
 		cleanup.WireSet,
+		cliserver.ProvideMergeQueueConfig,
+		mergequeue.WireSet,
+		pullmirror.WireSet,
+		cliserver.ProvidePushMirrorConfig,
+		pushmirror.WireSet,
+		ldapsync.WireSet,
+		automerge.WireSet,
 		codecomments.WireSet,
 		protection.WireSet,
 		checkcontroller.WireSet,
@@ -254,7 +276,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		usergroup.WireSet,
 		openapi.WireSet,
 		repo.ProvideRepoCheck,
-		audit.WireSet,
+		auditlog.WireSet,
 		ssh.WireSet,
 		publickey.WireSet,
 		migrate.WireSet,
@@ -278,6 +300,8 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		aiagentservice.WireSet,
 		aiagent.WireSet,
 		capabilities.WireSet,
+		auditlogcontroller.WireSet,
+		notificationpref.WireSet,
 		capabilitiesservice.WireSet,
 		docker.ProvideReporter,
 		secretservice.WireSet,
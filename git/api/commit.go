@@ -849,6 +849,34 @@ func getCommitFromBatchReader(
 	}
 }
 
+// GetCommits returns the commits with the provided SHAs read directly from the object database.
+// Unlike GetCommit, the returned commits contain the commit signature (if the commit is signed).
+func GetCommits(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	commitSHAs []sha.SHA,
+) ([]*Commit, error) {
+	wr, rd, cancel := CatFileBatch(ctx, repoPath, alternateObjectDirs)
+	defer cancel()
+
+	commits := make([]*Commit, len(commitSHAs))
+	for i, commitSHA := range commitSHAs {
+		if _, err := wr.Write([]byte(commitSHA.String() + "\n")); err != nil {
+			return nil, fmt.Errorf("failed to write commit sha to cat-file stdin: %w", err)
+		}
+
+		commit, err := getCommitFromBatchReader(ctx, repoPath, rd, commitSHA.String())
+		if err != nil {
+			return nil, err
+		}
+
+		commits[i] = commit
+	}
+
+	return commits, nil
+}
+
 // CommitFromReader will generate a Commit from a provided reader
 // We need this to interpret commits from cat-file or cat-file --batch
 //
@@ -867,6 +895,7 @@ func CommitFromReader(commitSHA sha.SHA, reader io.Reader) (*Commit, error) {
 	messageSB := new(strings.Builder)
 	message := false
 	pgpsig := false
+	otherSig := false
 
 	bufReader, ok := reader.(*bufio.Reader)
 	if !ok {
@@ -893,6 +922,12 @@ readLoop:
 			}
 			pgpsig = false
 		}
+		if otherSig {
+			if len(line) > 0 && line[0] == ' ' {
+				continue
+			}
+			otherSig = false
+		}
 
 		if !message {
 			// This is probably not correct but is copied from go-gits interpretation...
@@ -931,6 +966,12 @@ readLoop:
 				_, _ = signatureSB.Write(data)
 				_ = signatureSB.WriteByte('\n')
 				pgpsig = true
+			case "gpgsig-sha256":
+				// signature for the sha256 representation of the commit isn't part of the payload.
+				otherSig = true
+			default:
+				// any other header (e.g. encoding, mergetag) is part of the signed payload.
+				_, _ = payloadSB.Write(line)
 			}
 		} else {
 			_, _ = messageSB.Write(line)
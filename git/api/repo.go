@@ -26,6 +26,7 @@ import (
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/sha"
 
 	"github.com/rs/zerolog/log"
 )
@@ -51,6 +52,9 @@ type PushOptions struct {
 	Env            []string
 	Timeout        time.Duration
 	Mirror         bool
+
+	// RefSpecs [OPTIONAL] are the refspecs pushed to the remote, they're used instead of the Branch.
+	RefSpecs []string
 }
 
 // ObjectCount represents the parsed information from the `git count-objects -v` command.
@@ -201,6 +205,39 @@ func (g *Git) GetRemoteDefaultBranch(
 	return match[1], nil
 }
 
+// ListRemoteReferences returns the full names of all branches and tags of a remote repository.
+func (g *Git) ListRemoteReferences(
+	ctx context.Context,
+	remoteURL string,
+) ([]string, error) {
+	cmd := command.New("ls-remote",
+		command.WithConfig("credential.helper", ""),
+		command.WithFlag("--heads"),
+		command.WithFlag("--tags"),
+		command.WithFlag("--refs"),
+		command.WithFlag("-q"),
+		command.WithArg(remoteURL),
+	)
+	output := &bytes.Buffer{}
+	if err := cmd.Run(ctx, command.WithStdout(output)); err != nil {
+		return nil, processGitErrorf(err, "failed to ls remote repo")
+	}
+
+	// git output looks as follows, one line per reference
+	// 		46963bc7f0b5e8c5f039d50ac9e6e51933c78cdf        refs/heads/main
+	var refs []string
+	for _, line := range strings.Split(output.String(), "\n") {
+		_, ref, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if !ok {
+			continue
+		}
+
+		refs = append(refs, ref)
+	}
+
+	return refs, nil
+}
+
 func (g *Git) Clone(
 	ctx context.Context,
 	from string,
@@ -285,6 +322,41 @@ func (g *Git) Sync(
 	return nil
 }
 
+// FetchObjects fetches the provided objects, and all objects reachable from them, from the source repository.
+// No references are updated, the objects are meant to be referenced by a subsequent reference update.
+// NOTE: This is a read operation and doesn't trigger any server side hooks.
+func (g *Git) FetchObjects(
+	ctx context.Context,
+	repoPath string,
+	source string,
+	objectSHAs []sha.SHA,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("fetch",
+		command.WithConfig("credential.helper", ""),
+		command.WithFlag(
+			"--quiet",
+			"--no-tags",
+			"--no-write-fetch-head",
+			"--no-show-forced-updates",
+		),
+		command.WithArg(source),
+	)
+	for _, objectSHA := range objectSHAs {
+		cmd.Add(command.WithArg(objectSHA.String()))
+	}
+
+	err := cmd.Run(ctx, command.WithDir(repoPath))
+	if err != nil {
+		return processGitErrorf(err, "failed to fetch objects")
+	}
+
+	return nil
+}
+
 func (g *Git) AddFiles(
 	ctx context.Context,
 	repoPath string,
@@ -364,7 +436,9 @@ func (g *Git) Push(
 	}
 	cmd.Add(command.WithPostSepArg(opts.Remote))
 
-	if len(opts.Branch) > 0 {
+	if len(opts.RefSpecs) > 0 {
+		cmd.Add(command.WithPostSepArg(opts.RefSpecs...))
+	} else if len(opts.Branch) > 0 {
 		cmd.Add(command.WithPostSepArg(opts.Branch))
 	}
 
@@ -135,6 +135,7 @@ func lsTree(
 	rev string,
 	treePath string,
 	fetchSizes bool,
+	recursive bool,
 ) ([]TreeNode, error) {
 	if repoPath == "" {
 		return nil, ErrRepositoryPathEmpty
@@ -147,6 +148,9 @@ func lsTree(
 	if fetchSizes {
 		cmd.Add(command.WithFlag("-l"))
 	}
+	if recursive {
+		cmd.Add(command.WithFlag("-r"))
+	}
 
 	output := &bytes.Buffer{}
 	err := cmd.Run(ctx,
@@ -164,6 +168,10 @@ func lsTree(
 	}
 
 	if output.Len() == 0 {
+		if recursive {
+			// the tree is empty
+			return []TreeNode{}, nil
+		}
 		return nil, errors.NotFound("path '%s' wasn't found in the repo", treePath)
 	}
 
@@ -237,7 +245,7 @@ func lsDirectory(
 		treePath += "/"
 	}
 
-	nodes, err := lsTree(ctx, repoPath, rev, treePath, fetchSizes)
+	nodes, err := lsTree(ctx, repoPath, rev, treePath, fetchSizes, false)
 	if err != nil {
 		return nil, err
 	}
@@ -267,7 +275,7 @@ func lsFile(
 ) (TreeNode, error) {
 	treePath = cleanTreePath(treePath)
 
-	list, err := lsTree(ctx, repoPath, rev, treePath, fetchSize)
+	list, err := lsTree(ctx, repoPath, rev, treePath, fetchSize, false)
 	if err != nil {
 		return TreeNode{}, fmt.Errorf("failed to ls file: %w", err)
 	}
@@ -290,7 +298,7 @@ func flattenDirectory(
 
 	// Go in depth for as long as there are subdirectories with just one subdirectory.
 	for len(nodes) == 1 && nodes[0].NodeType == TreeNodeTypeTree {
-		nodesTemp, err := lsTree(ctx, repoPath, rev, nodes[0].Path+"/", fetchSizes)
+		nodesTemp, err := lsTree(ctx, repoPath, rev, nodes[0].Path+"/", fetchSizes, false)
 		if err != nil {
 			return fmt.Errorf("failed to peek dir entries for flattening: %w", err)
 		}
@@ -381,6 +389,21 @@ func ListTreeNodes(
 	return list, nil
 }
 
+// ListFiles lists all files of the tree reachable from ref recursively.
+// Submodules are listed as well, as nodes of type TreeNodeTypeCommit.
+func (g *Git) ListFiles(
+	ctx context.Context,
+	repoPath, rev string,
+	fetchSizes bool,
+) ([]TreeNode, error) {
+	list, err := lsTree(ctx, repoPath, rev, ".", fetchSizes, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	return list, nil
+}
+
 func (g *Git) ReadTree(
 	ctx context.Context,
 	repoPath string,
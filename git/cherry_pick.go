@@ -0,0 +1,200 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CherryPickParams is input structure object for the cherry-pick operation.
+type CherryPickParams struct {
+	WriteParams
+
+	// CommitSHAs is the list of commits that are applied, in the provided order.
+	CommitSHAs []sha.SHA
+	// Mainline is the 1-based number of the parent of a merge commit relative to which the commit is applied.
+	// It's required if any of the commits is a merge commit.
+	Mainline int
+
+	// Branch is the branch on top of which the commits are applied (optional, default: default branch).
+	Branch string
+	// NewBranch is the name of the branch that is created for the new commits (optional, default: Branch).
+	NewBranch string
+
+	// Committer overwrites the git committer used for the new commits
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for the new commits
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+}
+
+func (p *CherryPickParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if len(p.CommitSHAs) == 0 {
+		return errors.InvalidArgument("at least one commit SHA is mandatory")
+	}
+
+	for _, commitSHA := range p.CommitSHAs {
+		if commitSHA.IsEmpty() {
+			return errors.InvalidArgument("commit SHA can't be empty")
+		}
+	}
+
+	return nil
+}
+
+// CherryPickOutput is result object of the cherry-pick operation.
+type CherryPickOutput struct {
+	// TargetSHA is the commit on top of which the commits were applied.
+	TargetSHA sha.SHA
+	// CommitSHA is the last of the newly created commits. It's empty if there are conflicts.
+	CommitSHA sha.SHA
+	// CommitCount is the number of the new commits. Commits that would be empty are skipped.
+	CommitCount int
+	// ConflictCommitSHA is the commit that couldn't be applied because of conflicts.
+	ConflictCommitSHA sha.SHA
+	// ConflictFiles is the list of files which prevented the cherry-pick.
+	ConflictFiles []string
+}
+
+// CherryPick applies the changes of the provided commits one by one on top of a branch.
+// The commits are created in a shared repository without a checkout. Each new commit preserves
+// the author and the message of the original commit. Commits that would be empty are skipped.
+// In case of conflicts nothing is committed and the list of conflicting files is returned.
+func (s *Service) CherryPick(ctx context.Context, params *CherryPickParams) (CherryPickOutput, error) {
+	if err := params.Validate(); err != nil {
+		return CherryPickOutput{}, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	target, err := s.preparePickTarget(ctx, repoPath, params.Branch, params.NewBranch)
+	if err != nil {
+		return CherryPickOutput{}, err
+	}
+
+	commits := make([]*api.Commit, len(params.CommitSHAs))
+	parents := make([]sha.SHA, len(params.CommitSHAs))
+	for i, commitSHA := range params.CommitSHAs {
+		commits[i], err = s.git.GetCommit(ctx, repoPath, commitSHA.String())
+		if err != nil {
+			return CherryPickOutput{}, fmt.Errorf("failed to get commit %s: %w", commitSHA, err)
+		}
+
+		parents[i], err = pickParent(commits[i], params.Mainline)
+		if err != nil {
+			return CherryPickOutput{}, err
+		}
+	}
+
+	committer := api.Signature{Identity: api.Identity(params.Actor), When: time.Now().UTC()}
+	if params.Committer != nil {
+		committer.Identity = api.Identity(*params.Committer)
+	}
+	if params.CommitterDate != nil {
+		committer.When = *params.CommitterDate
+	}
+
+	refUpdater, err := hook.CreateRefUpdater(s.hookClientFactory, params.EnvVars, repoPath, target.BranchRef)
+	if err != nil {
+		return CherryPickOutput{}, fmt.Errorf("failed to create ref updater: %w", err)
+	}
+
+	var commitCount int
+	var conflictCommitSHA sha.SHA
+	var conflicts []string
+
+	lastCommitSHA := target.TargetSHA
+
+	err = sharedrepo.Run(ctx, refUpdater, s.tmpDir, repoPath, func(r *sharedrepo.SharedRepo) error {
+		lastTreeSHA, err := r.GetTreeSHA(ctx, target.TargetSHA.String())
+		if err != nil {
+			return fmt.Errorf("failed to get tree sha for target: %w", err)
+		}
+
+		for i, commit := range commits {
+			var treeSHA sha.SHA
+
+			// use the parent of the commit as the merge base to only apply the changes introduced by the commit.
+			treeSHA, conflicts, err = r.MergeTree(ctx, parents[i], lastCommitSHA, commit.SHA)
+			if err != nil {
+				return fmt.Errorf("failed to merge tree of commit %s: %w", commit.SHA, err)
+			}
+
+			if len(conflicts) > 0 {
+				conflictCommitSHA = commit.SHA
+				return errPickConflict
+			}
+
+			if treeSHA.Equal(lastTreeSHA) {
+				log.Ctx(ctx).Debug().Msgf("skipping commit %s as it's empty after cherry-pick", commit.SHA)
+				continue
+			}
+
+			message := commit.Title
+			if commit.Message != "" {
+				message += "\n\n" + commit.Message
+			}
+
+			lastCommitSHA, err = r.CommitTree(ctx, &commit.Author, &committer, treeSHA, message, false, lastCommitSHA)
+			if err != nil {
+				return fmt.Errorf("failed to commit tree of commit %s: %w", commit.SHA, err)
+			}
+
+			lastTreeSHA = treeSHA
+			commitCount++
+		}
+
+		if commitCount == 0 {
+			return errors.InvalidArgument("No effective changes.")
+		}
+
+		if err := refUpdater.Init(ctx, target.OldSHA, lastCommitSHA); err != nil {
+			return fmt.Errorf("failed to init ref updater: %w", err)
+		}
+
+		return nil
+	})
+	if errors.Is(err, errPickConflict) {
+		return CherryPickOutput{
+			TargetSHA:         target.TargetSHA,
+			ConflictCommitSHA: conflictCommitSHA,
+			ConflictFiles:     conflicts,
+		}, nil
+	}
+	if err != nil {
+		return CherryPickOutput{}, fmt.Errorf("failed to cherry-pick commits: %w", err)
+	}
+
+	return CherryPickOutput{
+		TargetSHA:   target.TargetSHA,
+		CommitSHA:   lastCommitSHA,
+		CommitCount: commitCount,
+	}, nil
+}
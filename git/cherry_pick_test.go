@@ -0,0 +1,181 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testCommitterIdentity = Identity{Name: "Gitness", Email: "system@example.com"}
+
+// skipWithoutMergeTreeMergeBase skips the test if the installed git doesn't support
+// the --merge-base option of merge-tree (git 2.40 or later), which is used to apply and revert commits.
+func skipWithoutMergeTreeMergeBase(t *testing.T) {
+	t.Helper()
+
+	out, _ := exec.Command("git", "merge-tree", "-h").CombinedOutput()
+	if !strings.Contains(string(out), "--merge-base") {
+		t.Skip("git merge-tree doesn't support --merge-base")
+	}
+}
+
+func updateTestFile(
+	ctx context.Context,
+	t *testing.T,
+	s *Service,
+	repoUID, branch, path, content string,
+) sha.SHA {
+	t.Helper()
+
+	out, err := s.CommitFiles(ctx, &CommitFilesParams{
+		WriteParams: WriteParams{RepoUID: repoUID, Actor: testIdentity},
+		Message:     "change " + path,
+		Branch:      branch,
+		Actions: []CommitFileAction{
+			{Action: UpdateAction, Path: path, Payload: []byte(content)},
+		},
+	})
+	require.NoError(t, err)
+
+	return out.CommitID
+}
+
+func createTestBranch(ctx context.Context, t *testing.T, s *Service, repoUID, branch, target string) {
+	t.Helper()
+
+	_, err := s.CreateBranch(ctx, &CreateBranchParams{
+		WriteParams: WriteParams{RepoUID: repoUID, Actor: testIdentity},
+		BranchName:  branch,
+		Target:      target,
+	})
+	require.NoError(t, err)
+}
+
+func getTestCommit(ctx context.Context, t *testing.T, s *Service, repoUID string, commitSHA sha.SHA) *Commit {
+	t.Helper()
+
+	out, err := s.GetCommit(ctx, &GetCommitParams{
+		ReadParams: ReadParams{RepoUID: repoUID},
+		Revision:   commitSHA.String(),
+	})
+	require.NoError(t, err)
+
+	return &out.Commit
+}
+
+func TestCherryPick(t *testing.T) {
+	skipWithoutMergeTreeMergeBase(t)
+
+	ctx := context.Background()
+	s := newTestService(t)
+
+	repoUID := createTestRepo(ctx, t, s, File{Path: "README.md", Content: []byte("readme")})
+	createTestBranch(ctx, t, s, repoUID, "feature", "main")
+
+	pick1SHA := commitTestFile(ctx, t, s, repoUID, "feature", "a.txt", "a")
+	pick2SHA := commitTestFile(ctx, t, s, repoUID, "feature", "b.txt", "b")
+	mainSHA := commitTestFile(ctx, t, s, repoUID, "main", "main.txt", "main")
+
+	out, err := s.CherryPick(ctx, &CherryPickParams{
+		WriteParams: WriteParams{RepoUID: repoUID, Actor: testIdentity},
+		CommitSHAs:  []sha.SHA{pick1SHA, pick2SHA},
+		Branch:      "main",
+		NewBranch:   "picked",
+		Committer:   &testCommitterIdentity,
+	})
+	require.NoError(t, err)
+	require.Empty(t, out.ConflictFiles)
+	require.Equal(t, mainSHA, out.TargetSHA)
+	require.Equal(t, 2, out.CommitCount)
+
+	// the commits are created on the new branch, the source branch is unchanged.
+	require.Equal(t, out.CommitSHA, getTestBranchSHA(ctx, t, s, repoUID, "picked"))
+	require.Equal(t, mainSHA, getTestBranchSHA(ctx, t, s, repoUID, "main"))
+
+	original := getTestCommit(ctx, t, s, repoUID, pick2SHA)
+	picked := getTestCommit(ctx, t, s, repoUID, out.CommitSHA)
+	require.Equal(t, original.Title, picked.Title)
+	require.Equal(t, original.Author, picked.Author, "the author of the original commit is preserved")
+	require.Equal(t, testCommitterIdentity, picked.Committer.Identity)
+
+	ancestor, err := s.IsAncestor(ctx, IsAncestorParams{
+		ReadParams:          ReadParams{RepoUID: repoUID},
+		AncestorCommitSHA:   mainSHA,
+		DescendantCommitSHA: out.CommitSHA,
+	})
+	require.NoError(t, err)
+	require.True(t, ancestor.Ancestor)
+
+	// all changes are already on the branch.
+	_, err = s.CherryPick(ctx, &CherryPickParams{
+		WriteParams: WriteParams{RepoUID: repoUID, Actor: testIdentity},
+		CommitSHAs:  []sha.SHA{pick1SHA},
+		Branch:      "picked",
+	})
+	require.True(t, errors.IsInvalidArgument(err), "expected invalid argument error, got: %v", err)
+	require.Equal(t, out.CommitSHA, getTestBranchSHA(ctx, t, s, repoUID, "picked"))
+}
+
+func TestCherryPickConflict(t *testing.T) {
+	skipWithoutMergeTreeMergeBase(t)
+
+	ctx := context.Background()
+	s := newTestService(t)
+
+	repoUID := createTestRepo(ctx, t, s, File{Path: "README.md", Content: []byte("readme")})
+	createTestBranch(ctx, t, s, repoUID, "feature", "main")
+
+	okSHA := commitTestFile(ctx, t, s, repoUID, "feature", "ok.txt", "ok")
+	conflictSHA := commitTestFile(ctx, t, s, repoUID, "feature", "conflict.txt", "feature")
+	mainSHA := commitTestFile(ctx, t, s, repoUID, "main", "conflict.txt", "main")
+
+	out, err := s.CherryPick(ctx, &CherryPickParams{
+		WriteParams: WriteParams{RepoUID: repoUID, Actor: testIdentity},
+		CommitSHAs:  []sha.SHA{okSHA, conflictSHA},
+	})
+	require.NoError(t, err)
+	require.Equal(t, conflictSHA, out.ConflictCommitSHA)
+	require.Equal(t, []string{"conflict.txt"}, out.ConflictFiles)
+	require.True(t, out.CommitSHA.IsEmpty())
+
+	// nothing is committed, not even the commits before the conflicting one.
+	require.Equal(t, mainSHA, getTestBranchSHA(ctx, t, s, repoUID, "main"))
+}
+
+func TestCherryPickNewBranchExists(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	repoUID := createTestRepo(ctx, t, s, File{Path: "README.md", Content: []byte("readme")})
+	createTestBranch(ctx, t, s, repoUID, "feature", "main")
+	pickSHA := commitTestFile(ctx, t, s, repoUID, "feature", "a.txt", "a")
+
+	_, err := s.CherryPick(ctx, &CherryPickParams{
+		WriteParams: WriteParams{RepoUID: repoUID, Actor: testIdentity},
+		CommitSHAs:  []sha.SHA{pickSHA},
+		Branch:      "main",
+		NewBranch:   "feature",
+	})
+	require.True(t, errors.IsConflict(err), "expected conflict error, got: %v", err)
+	require.Equal(t, pickSHA, getTestBranchSHA(ctx, t, s, repoUID, "feature"))
+}
@@ -98,6 +98,145 @@ func (s *Service) GetCommit(ctx context.Context, params *GetCommitParams) (*GetC
 	}, nil
 }
 
+type GetCommitSignaturesParams struct {
+	ReadParams
+	CommitSHAs []string
+}
+
+func (p *GetCommitSignaturesParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	return p.ReadParams.Validate()
+}
+
+// CommitSignature contains the signature of a commit and the commit data that has been signed.
+// Signature and SignedData are empty if the commit isn't signed.
+type CommitSignature struct {
+	CommitSHA  sha.SHA
+	Committer  Signature
+	Signature  []byte
+	SignedData []byte
+}
+
+func (s *CommitSignature) IsSigned() bool {
+	return len(s.Signature) > 0
+}
+
+type GetCommitSignaturesOutput struct {
+	Signatures []CommitSignature
+}
+
+// GetCommitSignatures returns the raw signatures of the provided commits.
+// The signatures are returned in the same order as the commit SHAs in the params.
+func (s *Service) GetCommitSignatures(
+	ctx context.Context,
+	params *GetCommitSignaturesParams,
+) (*GetCommitSignaturesOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	var err error
+	commitSHAs := make([]sha.SHA, len(params.CommitSHAs))
+	for i, commitSHA := range params.CommitSHAs {
+		commitSHAs[i], err = sha.New(commitSHA)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	commits, err := api.GetCommits(ctx, repoPath, params.AlternateObjectDirs, commitSHAs)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make([]CommitSignature, len(commits))
+	for i, commit := range commits {
+		var committer *Signature
+		committer, err = mapSignature(&commit.Committer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map rpc committer: %w", err)
+		}
+
+		signatures[i] = CommitSignature{
+			CommitSHA: commit.SHA,
+			Committer: *committer,
+		}
+
+		if commit.Signature != nil {
+			signatures[i].Signature = []byte(commit.Signature.Signature)
+			signatures[i].SignedData = []byte(commit.Signature.Payload)
+		}
+	}
+
+	return &GetCommitSignaturesOutput{
+		Signatures: signatures,
+	}, nil
+}
+
+type ListCommitSHAsParams struct {
+	ReadParams
+	// GitREF is a git reference (branch / tag / commit SHA)
+	GitREF string
+	// After is a git reference (branch / tag / commit SHA)
+	// If provided, commits only up to that reference will be returned (exlusive)
+	After string
+}
+
+func (p *ListCommitSHAsParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.GitREF == "" {
+		return errors.InvalidArgument("git ref cannot be empty")
+	}
+
+	return nil
+}
+
+type ListCommitSHAsOutput struct {
+	CommitSHAs []string
+}
+
+// ListCommitSHAs lists SHAs of all commits reachable from GitREF that aren't reachable from After.
+// Unlike ListCommits it takes the alternate object directories into account, which makes it usable in git hooks.
+func (s *Service) ListCommitSHAs(
+	ctx context.Context,
+	params *ListCommitSHAsParams,
+) (*ListCommitSHAsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	commitSHAs, err := s.git.ListCommitSHAs(
+		ctx,
+		repoPath,
+		params.AlternateObjectDirs,
+		params.GitREF,
+		0,
+		0,
+		api.CommitFilter{AfterRef: params.After},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListCommitSHAsOutput{
+		CommitSHAs: commitSHAs,
+	}, nil
+}
+
 type ListCommitsParams struct {
 	ReadParams
 	// GitREF is a git reference (branch / tag / commit SHA)
@@ -0,0 +1,134 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/sha"
+)
+
+type ForkRepositoryParams struct {
+	WriteParams
+	// SourceRepoUID is the UID of the repository that is forked.
+	SourceRepoUID string
+	// DefaultBranch [OPTIONAL] allows to override the default branch of the fork.
+	// If empty, the default branch will be set to match the source repository's default branch.
+	DefaultBranch string
+}
+
+func (p *ForkRepositoryParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.SourceRepoUID == "" {
+		return errors.InvalidArgument("source repository UID is mandatory")
+	}
+
+	if p.SourceRepoUID == p.RepoUID {
+		return errors.InvalidArgument("a repository can't be forked into itself")
+	}
+
+	return nil
+}
+
+type ForkRepositoryOutput struct {
+	DefaultBranch string
+}
+
+// ForkRepository creates a new repository with all branches and tags of the source repository.
+// The fork has its own copy of the objects, so it's independent of the source repository.
+func (s *Service) ForkRepository(
+	ctx context.Context,
+	params *ForkRepositoryParams,
+) (*ForkRepositoryOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	out, err := s.SyncRepository(ctx, &SyncRepositoryParams{
+		WriteParams:       params.WriteParams,
+		Source:            getFullPathForRepo(s.reposRoot, params.SourceRepoUID),
+		CreateIfNotExists: true,
+		RefSpecs:          []string{"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"},
+		DefaultBranch:     params.DefaultBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fork repository: %w", err)
+	}
+
+	return &ForkRepositoryOutput{
+		DefaultBranch: out.DefaultBranch,
+	}, nil
+}
+
+type FetchObjectsParams struct {
+	WriteParams
+	// SourceRepoUID is the UID of the repository the objects are fetched from.
+	SourceRepoUID string
+	ObjectSHAs    []sha.SHA
+}
+
+func (p *FetchObjectsParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.SourceRepoUID == "" {
+		return errors.InvalidArgument("source repository UID is mandatory")
+	}
+
+	if len(p.ObjectSHAs) == 0 {
+		return errors.InvalidArgument("at least one object SHA is required")
+	}
+
+	return nil
+}
+
+// FetchObjects copies the provided objects, and all objects reachable from them, from another repository.
+// It is used to make commits of a fork available in its upstream repository (and vice versa),
+// for example to create the head reference of a pull request opened from a fork.
+// No references are updated: an object that isn't referenced afterward gets removed by the garbage collection.
+func (s *Service) FetchObjects(ctx context.Context, params *FetchObjectsParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	// nothing to do for objects that are already in the repository.
+	if params.SourceRepoUID == params.RepoUID {
+		return nil
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	sourcePath := getFullPathForRepo(s.reposRoot, params.SourceRepoUID)
+
+	err := s.git.FetchObjects(ctx, repoPath, sourcePath, params.ObjectSHAs)
+	if err != nil {
+		return fmt.Errorf("failed to fetch objects from repository %q: %w", params.SourceRepoUID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/storage"
+	"github.com/harness/gitness/git/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+type noopHookClientFactory struct{}
+
+func (noopHookClientFactory) NewClient(map[string]string) (hook.Client, error) {
+	return hook.NewNoopClient(nil), nil
+}
+
+var testIdentity = Identity{Name: "Tester", Email: "tester@example.com"}
+
+// newTestService returns a git service that stores its repositories in a temporary directory.
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+
+	root := t.TempDir()
+
+	// the server hooks of the test repositories always succeed.
+	hookPath := filepath.Join(root, "hook.sh")
+	require.NoError(t, os.WriteFile(hookPath, []byte("#!/bin/sh\nexit 0\n"), 0o700))
+
+	config := types.Config{
+		Root:     root,
+		TmpDir:   filepath.Join(root, "tmp"),
+		HookPath: hookPath,
+	}
+
+	adapter, err := api.New(config, nil, noopHookClientFactory{})
+	require.NoError(t, err)
+
+	s, err := New(config, adapter, noopHookClientFactory{}, storage.NewLocalStore())
+	require.NoError(t, err)
+
+	return s
+}
+
+func createTestRepo(ctx context.Context, t *testing.T, s *Service, files ...File) string {
+	t.Helper()
+
+	out, err := s.CreateRepository(ctx, &CreateRepositoryParams{
+		Actor:         testIdentity,
+		DefaultBranch: "main",
+		Files:         files,
+	})
+	require.NoError(t, err)
+
+	return out.UID
+}
+
+func commitTestFile(
+	ctx context.Context,
+	t *testing.T,
+	s *Service,
+	repoUID, branch, path, content string,
+) sha.SHA {
+	t.Helper()
+
+	out, err := s.CommitFiles(ctx, &CommitFilesParams{
+		WriteParams: WriteParams{RepoUID: repoUID, Actor: testIdentity},
+		Message:     "update " + path,
+		Branch:      branch,
+		Actions: []CommitFileAction{
+			{Action: CreateAction, Path: path, Payload: []byte(content)},
+		},
+	})
+	require.NoError(t, err)
+
+	return out.CommitID
+}
+
+func getTestBranchSHA(ctx context.Context, t *testing.T, s *Service, repoUID, branch string) sha.SHA {
+	t.Helper()
+
+	out, err := s.GetBranch(ctx, &GetBranchParams{
+		ReadParams: ReadParams{RepoUID: repoUID},
+		BranchName: branch,
+	})
+	require.NoError(t, err)
+
+	return out.Branch.SHA
+}
+
+func TestForkRepository(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	upstreamUID := createTestRepo(ctx, t, s, File{Path: "README.md", Content: []byte("upstream")})
+	upstreamSHA := getTestBranchSHA(ctx, t, s, upstreamUID, "main")
+
+	forkUID, err := NewRepositoryUID()
+	require.NoError(t, err)
+
+	out, err := s.ForkRepository(ctx, &ForkRepositoryParams{
+		WriteParams:   WriteParams{RepoUID: forkUID, Actor: testIdentity},
+		SourceRepoUID: upstreamUID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "main", out.DefaultBranch)
+	require.Equal(t, upstreamSHA, getTestBranchSHA(ctx, t, s, forkUID, "main"))
+
+	// the fork is independent of the upstream repository.
+	forkSHA := commitTestFile(ctx, t, s, forkUID, "main", "fork.txt", "fork")
+	require.Equal(t, upstreamSHA, getTestBranchSHA(ctx, t, s, upstreamUID, "main"))
+	require.NotEqual(t, upstreamSHA, forkSHA)
+
+	_, err = s.ForkRepository(ctx, &ForkRepositoryParams{
+		WriteParams:   WriteParams{RepoUID: upstreamUID, Actor: testIdentity},
+		SourceRepoUID: upstreamUID,
+	})
+	require.Error(t, err, "a repository can't be forked into itself")
+}
+
+// TestFetchObjects covers the creation of a pull request from a fork (commits of the fork are copied
+// to the upstream repository) and the sync of a fork (commits of the upstream are copied to the fork).
+func TestFetchObjects(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	upstreamUID := createTestRepo(ctx, t, s, File{Path: "README.md", Content: []byte("upstream")})
+	forkUID, err := NewRepositoryUID()
+	require.NoError(t, err)
+	_, err = s.ForkRepository(ctx, &ForkRepositoryParams{
+		WriteParams:   WriteParams{RepoUID: forkUID, Actor: testIdentity},
+		SourceRepoUID: upstreamUID,
+	})
+	require.NoError(t, err)
+
+	baseSHA := getTestBranchSHA(ctx, t, s, upstreamUID, "main")
+	forkSHA := commitTestFile(ctx, t, s, forkUID, "main", "fork.txt", "fork")
+
+	_, err = s.IsAncestor(ctx, IsAncestorParams{
+		ReadParams:          ReadParams{RepoUID: upstreamUID},
+		AncestorCommitSHA:   baseSHA,
+		DescendantCommitSHA: forkSHA,
+	})
+	require.Error(t, err, "the commit of the fork must not be in the upstream repository yet")
+
+	err = s.FetchObjects(ctx, &FetchObjectsParams{
+		WriteParams:   WriteParams{RepoUID: upstreamUID, Actor: testIdentity},
+		SourceRepoUID: forkUID,
+		ObjectSHAs:    []sha.SHA{forkSHA},
+	})
+	require.NoError(t, err)
+
+	ancestor, err := s.IsAncestor(ctx, IsAncestorParams{
+		ReadParams:          ReadParams{RepoUID: upstreamUID},
+		AncestorCommitSHA:   baseSHA,
+		DescendantCommitSHA: forkSHA,
+	})
+	require.NoError(t, err)
+	require.True(t, ancestor.Ancestor)
+
+	// no references of the upstream repository are changed.
+	require.Equal(t, baseSHA, getTestBranchSHA(ctx, t, s, upstreamUID, "main"))
+
+	// sync of the fork: the upstream moved ahead, the fork branch can be fast-forwarded.
+	upstreamSHA := commitTestFile(ctx, t, s, upstreamUID, "main", "upstream.txt", "upstream")
+	err = s.FetchObjects(ctx, &FetchObjectsParams{
+		WriteParams:   WriteParams{RepoUID: forkUID, Actor: testIdentity},
+		SourceRepoUID: upstreamUID,
+		ObjectSHAs:    []sha.SHA{upstreamSHA},
+	})
+	require.NoError(t, err)
+
+	ancestor, err = s.IsAncestor(ctx, IsAncestorParams{
+		ReadParams:          ReadParams{RepoUID: forkUID},
+		AncestorCommitSHA:   forkSHA,
+		DescendantCommitSHA: upstreamSHA,
+	})
+	require.NoError(t, err)
+	require.False(t, ancestor.Ancestor, "the fork branch has diverged and can't be fast-forwarded")
+
+	ancestor, err = s.IsAncestor(ctx, IsAncestorParams{
+		ReadParams:          ReadParams{RepoUID: forkUID},
+		AncestorCommitSHA:   baseSHA,
+		DescendantCommitSHA: upstreamSHA,
+	})
+	require.NoError(t, err)
+	require.True(t, ancestor.Ancestor)
+}
+
+// TestMergeFromFork covers the merge of a pull request opened from a fork.
+// The fast-forward method is used as it doesn't depend on the version of the git binary (unlike merge-tree).
+func TestMergeFromFork(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	upstreamUID := createTestRepo(ctx, t, s, File{Path: "README.md", Content: []byte("upstream")})
+	forkUID, err := NewRepositoryUID()
+	require.NoError(t, err)
+	_, err = s.ForkRepository(ctx, &ForkRepositoryParams{
+		WriteParams:   WriteParams{RepoUID: forkUID, Actor: testIdentity},
+		SourceRepoUID: upstreamUID,
+	})
+	require.NoError(t, err)
+
+	_, err = s.CreateBranch(ctx, &CreateBranchParams{
+		WriteParams: WriteParams{RepoUID: forkUID, Actor: testIdentity},
+		BranchName:  "feature",
+		Target:      "main",
+	})
+	require.NoError(t, err)
+
+	baseSHA := getTestBranchSHA(ctx, t, s, upstreamUID, "main")
+	headSHA := commitTestFile(ctx, t, s, forkUID, "feature", "feature.txt", "feature")
+
+	out, err := s.Merge(ctx, &MergeParams{
+		WriteParams:     WriteParams{RepoUID: upstreamUID, Actor: testIdentity},
+		BaseBranch:      "main",
+		HeadRepoUID:     forkUID,
+		HeadBranch:      "feature",
+		HeadExpectedSHA: headSHA,
+		RefType:         enum.RefTypeBranch,
+		RefName:         "main",
+		Method:          enum.MergeMethodFastForward,
+	})
+	require.NoError(t, err)
+	require.Empty(t, out.ConflictFiles)
+	require.Equal(t, baseSHA, out.BaseSHA)
+	require.Equal(t, headSHA, out.HeadSHA)
+	require.Equal(t, baseSHA, out.MergeBaseSHA)
+	require.Equal(t, headSHA, out.MergeSHA)
+	require.Equal(t, headSHA, getTestBranchSHA(ctx, t, s, upstreamUID, "main"))
+
+	// the head branch of the fork is left untouched.
+	require.Equal(t, headSHA, getTestBranchSHA(ctx, t, s, forkUID, "feature"))
+}
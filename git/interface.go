@@ -27,6 +27,7 @@ type Interface interface {
 	GetTreeNode(ctx context.Context, params *GetTreeNodeParams) (*GetTreeNodeOutput, error)
 	ListTreeNodes(ctx context.Context, params *ListTreeNodeParams) (*ListTreeNodeOutput, error)
 	ListPaths(ctx context.Context, params *ListPathsParams) (*ListPathsOutput, error)
+	ListFiles(ctx context.Context, params *ListFilesParams) (*ListFilesOutput, error)
 	GetSubmodule(ctx context.Context, params *GetSubmoduleParams) (*GetSubmoduleOutput, error)
 	GetBlob(ctx context.Context, params *GetBlobParams) (*GetBlobOutput, error)
 	CreateBranch(ctx context.Context, params *CreateBranchParams) (*CreateBranchOutput, error)
@@ -49,6 +50,11 @@ type Interface interface {
 
 	SyncRepository(ctx context.Context, params *SyncRepositoryParams) (*SyncRepositoryOutput, error)
 
+	// ForkRepository creates a new repository with all branches and tags of an existing one.
+	ForkRepository(ctx context.Context, params *ForkRepositoryParams) (*ForkRepositoryOutput, error)
+	// FetchObjects copies objects from another repository, without updating any references.
+	FetchObjects(ctx context.Context, params *FetchObjectsParams) error
+
 	MatchFiles(ctx context.Context, params *MatchFilesParams) (*MatchFilesOutput, error)
 
 	/*
@@ -56,6 +62,8 @@ type Interface interface {
 	 */
 	GetCommit(ctx context.Context, params *GetCommitParams) (*GetCommitOutput, error)
 	ListCommits(ctx context.Context, params *ListCommitsParams) (*ListCommitsOutput, error)
+	GetCommitSignatures(ctx context.Context, params *GetCommitSignaturesParams) (*GetCommitSignaturesOutput, error)
+	ListCommitSHAs(ctx context.Context, params *ListCommitSHAsParams) (*ListCommitSHAsOutput, error)
 	ListCommitTags(ctx context.Context, params *ListCommitTagsParams) (*ListCommitTagsOutput, error)
 	GetCommitDivergences(ctx context.Context, params *GetCommitDivergencesParams) (*GetCommitDivergencesOutput, error)
 	CommitFiles(ctx context.Context, params *CommitFilesParams) (CommitFilesResponse, error)
@@ -89,6 +97,8 @@ type Interface interface {
 	 * Merge services
 	 */
 	Merge(ctx context.Context, in *MergeParams) (MergeOutput, error)
+	Revert(ctx context.Context, params *RevertParams) (RevertOutput, error)
+	CherryPick(ctx context.Context, params *CherryPickParams) (CherryPickOutput, error)
 
 	/*
 	 * Blame services
@@ -173,6 +173,7 @@ func mapTreeNode(n *api.TreeNode) (TreeNode, error) {
 		SHA:  n.SHA.String(),
 		Name: n.Name,
 		Path: n.Path,
+		Size: n.Size,
 	}, nil
 }
 
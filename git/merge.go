@@ -36,7 +36,7 @@ type MergeParams struct {
 	BaseBranch string
 
 	// HeadRepoUID specifies the UID of the repo that contains the head branch (required for forking).
-	// WARNING: This field is currently not supported yet!
+	// If it's a different repository, the head commit is fetched into the base repository prior to merging.
 	HeadRepoUID string
 	HeadBranch  string
 
@@ -184,7 +184,12 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		}
 	}
 
-	headCommitSHA, err := s.git.GetFullCommitID(ctx, repoPath, params.HeadBranch)
+	headRepoPath := repoPath
+	if params.HeadRepoUID != "" && params.HeadRepoUID != params.RepoUID {
+		headRepoPath = getFullPathForRepo(s.reposRoot, params.HeadRepoUID)
+	}
+
+	headCommitSHA, err := s.git.GetFullCommitID(ctx, headRepoPath, params.HeadBranch)
 	if err != nil {
 		return MergeOutput{}, fmt.Errorf("failed to get head branch commit SHA: %w", err)
 	}
@@ -197,6 +202,14 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 			params.HeadExpectedSHA)
 	}
 
+	// the commits of the head branch of a fork must be available in the base repository.
+	if headRepoPath != repoPath {
+		err = s.git.FetchObjects(ctx, repoPath, headRepoPath, []sha.SHA{headCommitSHA})
+		if err != nil {
+			return MergeOutput{}, fmt.Errorf("failed to fetch head branch commits from the head repository: %w", err)
+		}
+	}
+
 	mergeBaseCommitSHA, _, err := s.git.GetMergeBase(ctx, repoPath, "origin",
 		baseCommitSHA.String(), headCommitSHA.String())
 	if err != nil {
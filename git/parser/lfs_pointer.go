@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// LFSPointerMaxSize is the maximum size of a blob that can be an LFS pointer.
+	// https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md
+	LFSPointerMaxSize = 1024
+
+	lfsPointerVersionPrefix = "version "
+	lfsPointerOIDPrefix     = "oid sha256:"
+	lfsPointerSizePrefix    = "size "
+)
+
+var (
+	lfsPointerVersions = []string{
+		"https://git-lfs.github.com/spec/v1",
+		"https://hawser.github.com/spec/v1",
+	}
+
+	regexpLFSObjectID = regexp.MustCompile("^[0-9a-f]{64}$")
+)
+
+// LFSPointer contains the information stored in a git LFS pointer file.
+type LFSPointer struct {
+	OID  string
+	Size int64
+}
+
+// IsValidLFSObjectID returns true if the provided string is a valid (sha256) LFS object ID.
+func IsValidLFSObjectID(oid string) bool {
+	return regexpLFSObjectID.MatchString(oid)
+}
+
+// ParseLFSPointer tries to parse the provided blob content as an LFS pointer file.
+// The second return value is false if the content isn't a valid LFS pointer.
+func ParseLFSPointer(content []byte) (LFSPointer, bool) {
+	if len(content) == 0 || len(content) > LFSPointerMaxSize {
+		return LFSPointer{}, false
+	}
+
+	var pointer LFSPointer
+	var hasOID, hasSize bool
+
+	scan := bufio.NewScanner(bytes.NewReader(content))
+
+	// the version line must always come first
+	if !scan.Scan() {
+		return LFSPointer{}, false
+	}
+
+	version, ok := strings.CutPrefix(scan.Text(), lfsPointerVersionPrefix)
+	if !ok || !isSupportedLFSPointerVersion(version) {
+		return LFSPointer{}, false
+	}
+
+	for scan.Scan() {
+		line := scan.Text()
+
+		switch {
+		case strings.HasPrefix(line, lfsPointerOIDPrefix):
+			oid := line[len(lfsPointerOIDPrefix):]
+			if !IsValidLFSObjectID(oid) {
+				return LFSPointer{}, false
+			}
+			pointer.OID = oid
+			hasOID = true
+		case strings.HasPrefix(line, lfsPointerSizePrefix):
+			size, err := strconv.ParseInt(line[len(lfsPointerSizePrefix):], 10, 64)
+			if err != nil || size < 0 {
+				return LFSPointer{}, false
+			}
+			pointer.Size = size
+			hasSize = true
+		case line == "":
+			continue
+		default:
+			// extensions ("ext-0-name sha256:...") and unknown keys are ignored.
+			if !strings.Contains(line, " ") {
+				return LFSPointer{}, false
+			}
+		}
+	}
+
+	if scan.Err() != nil || !hasOID || !hasSize {
+		return LFSPointer{}, false
+	}
+
+	return pointer, true
+}
+
+func isSupportedLFSPointerVersion(version string) bool {
+	for _, v := range lfsPointerVersions {
+		if version == v {
+			return true
+		}
+	}
+	return false
+}
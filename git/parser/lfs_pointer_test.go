@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLFSPointer(t *testing.T) {
+	const oid = "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+
+	tests := []struct {
+		name    string
+		input   string
+		expOK   bool
+		expOID  string
+		expSize int64
+	}{
+		{
+			name: "valid",
+			input: "" +
+				"version https://git-lfs.github.com/spec/v1\n" +
+				"oid sha256:" + oid + "\n" +
+				"size 12345\n",
+			expOK:   true,
+			expOID:  oid,
+			expSize: 12345,
+		},
+		{
+			name: "valid_with_extension",
+			input: "" +
+				"version https://git-lfs.github.com/spec/v1\n" +
+				"ext-0-foo sha256:" + oid + "\n" +
+				"oid sha256:" + oid + "\n" +
+				"size 1\n",
+			expOK:   true,
+			expOID:  oid,
+			expSize: 1,
+		},
+		{
+			name: "legacy_version",
+			input: "" +
+				"version https://hawser.github.com/spec/v1\n" +
+				"oid sha256:" + oid + "\n" +
+				"size 0\n",
+			expOK:   true,
+			expOID:  oid,
+			expSize: 0,
+		},
+		{
+			name: "missing_version",
+			input: "" +
+				"oid sha256:" + oid + "\n" +
+				"size 12345\n",
+		},
+		{
+			name: "unknown_version",
+			input: "" +
+				"version https://example.com/spec/v9\n" +
+				"oid sha256:" + oid + "\n" +
+				"size 12345\n",
+		},
+		{
+			name: "missing_size",
+			input: "" +
+				"version https://git-lfs.github.com/spec/v1\n" +
+				"oid sha256:" + oid + "\n",
+		},
+		{
+			name: "invalid_oid",
+			input: "" +
+				"version https://git-lfs.github.com/spec/v1\n" +
+				"oid sha256:abc\n" +
+				"size 12345\n",
+		},
+		{
+			name: "invalid_size",
+			input: "" +
+				"version https://git-lfs.github.com/spec/v1\n" +
+				"oid sha256:" + oid + "\n" +
+				"size -1\n",
+		},
+		{
+			name:  "regular_file",
+			input: "package main\n\nfunc main() {}\n",
+		},
+		{
+			name: "too_large",
+			input: "" +
+				"version https://git-lfs.github.com/spec/v1\n" +
+				"oid sha256:" + oid + "\n" +
+				"size 12345\n" +
+				strings.Repeat("x", LFSPointerMaxSize),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pointer, ok := ParseLFSPointer([]byte(test.input))
+			if ok != test.expOK {
+				t.Fatalf("expected ok=%t, got ok=%t", test.expOK, ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			if pointer.OID != test.expOID {
+				t.Errorf("expected oid=%q, got oid=%q", test.expOID, pointer.OID)
+			}
+
+			if pointer.Size != test.expSize {
+				t.Errorf("expected size=%d, got size=%d", test.expSize, pointer.Size)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/sha"
+)
+
+// errPickConflict is used to error out of sharedrepo Run method in case of conflicts.
+var errPickConflict = errors.New("conflict")
+
+// pickTarget describes the branch that receives the commits created by the revert and cherry-pick operations.
+type pickTarget struct {
+	// BranchRef is the full reference name of the branch that is updated or created.
+	BranchRef string
+	// OldSHA is the current commit of the updated branch, or sha.Nil if a new branch is created.
+	OldSHA sha.SHA
+	// TargetSHA is the commit on top of which the new commits are created.
+	TargetSHA sha.SHA
+}
+
+// preparePickTarget resolves the branch on top of which the new commits are created.
+// If newBranch is provided and differs from branch, the new commits are written to a new branch
+// that must not exist yet. Empty branch name defaults to the default branch of the repository.
+func (s *Service) preparePickTarget(
+	ctx context.Context,
+	repoPath string,
+	branch, newBranch string,
+) (pickTarget, error) {
+	if branch == "" {
+		defaultBranch, err := s.git.GetDefaultBranch(ctx, repoPath)
+		if err != nil {
+			return pickTarget{}, fmt.Errorf("failed to get default branch: %w", err)
+		}
+		branch = defaultBranch
+	}
+
+	branch = strings.TrimPrefix(strings.TrimSpace(branch), gitReferenceNamePrefixBranch)
+	newBranch = strings.TrimPrefix(strings.TrimSpace(newBranch), gitReferenceNamePrefixBranch)
+
+	targetBranch, err := s.git.GetBranch(ctx, repoPath, branch)
+	if err != nil {
+		return pickTarget{}, fmt.Errorf("failed to get branch '%s': %w", branch, err)
+	}
+
+	if newBranch == "" || newBranch == branch {
+		return pickTarget{
+			BranchRef: api.GetReferenceFromBranchName(branch),
+			OldSHA:    targetBranch.SHA,
+			TargetSHA: targetBranch.SHA,
+		}, nil
+	}
+
+	existingBranch, err := s.git.GetBranch(ctx, repoPath, newBranch)
+	if existingBranch != nil {
+		return pickTarget{}, errors.Conflict("branch %s already exists", existingBranch.Name)
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		return pickTarget{}, fmt.Errorf("failed to get branch '%s': %w", newBranch, err)
+	}
+
+	return pickTarget{
+		BranchRef: api.GetReferenceFromBranchName(newBranch),
+		OldSHA:    sha.Nil,
+		TargetSHA: targetBranch.SHA,
+	}, nil
+}
+
+// pickParent returns the parent of the commit relative to which the changes of the commit are computed.
+// Mainline is the 1-based number of the parent and it's required for merge commits.
+func pickParent(commit *api.Commit, mainline int) (sha.SHA, error) {
+	if mainline < 0 {
+		return sha.None, errors.InvalidArgument("Mainline parent number can't be negative.")
+	}
+
+	switch {
+	case len(commit.ParentSHAs) == 0:
+		return sha.None, errors.InvalidArgument("Commit %s has no parent.", commit.SHA)
+	case len(commit.ParentSHAs) > 1 && mainline == 0:
+		return sha.None, errors.InvalidArgument(
+			"Commit %s is a merge commit but no mainline parent number is provided.", commit.SHA)
+	case mainline > len(commit.ParentSHAs):
+		return sha.None, errors.InvalidArgument(
+			"Commit %s doesn't have the parent number %d.", commit.SHA, mainline)
+	case mainline == 0:
+		return commit.ParentSHAs[0], nil
+	default:
+		return commit.ParentSHAs[mainline-1], nil
+	}
+}
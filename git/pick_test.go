@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testCommitSHA  = sha.Must("1111111111111111111111111111111111111111")
+	testParent1SHA = sha.Must("2222222222222222222222222222222222222222")
+	testParent2SHA = sha.Must("3333333333333333333333333333333333333333")
+)
+
+func Test_pickParent(t *testing.T) {
+	tests := []struct {
+		name     string
+		parents  []sha.SHA
+		mainline int
+		wantErr  string
+		want     sha.SHA
+	}{
+		{
+			name:    "test root commit",
+			wantErr: "has no parent",
+		},
+		{
+			name:    "test single parent",
+			parents: []sha.SHA{testParent1SHA},
+			want:    testParent1SHA,
+		},
+		{
+			name:     "test single parent with mainline 1",
+			parents:  []sha.SHA{testParent1SHA},
+			mainline: 1,
+			want:     testParent1SHA,
+		},
+		{
+			name:     "test single parent with mainline 2",
+			parents:  []sha.SHA{testParent1SHA},
+			mainline: 2,
+			wantErr:  "doesn't have the parent number 2",
+		},
+		{
+			name:    "test merge commit without mainline",
+			parents: []sha.SHA{testParent1SHA, testParent2SHA},
+			wantErr: "no mainline parent number is provided",
+		},
+		{
+			name:     "test merge commit with mainline 2",
+			parents:  []sha.SHA{testParent1SHA, testParent2SHA},
+			mainline: 2,
+			want:     testParent2SHA,
+		},
+		{
+			name:     "test negative mainline",
+			parents:  []sha.SHA{testParent1SHA},
+			mainline: -1,
+			wantErr:  "can't be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pickParent(&api.Commit{SHA: testCommitSHA, ParentSHAs: tt.parents}, tt.mainline)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_revertMessage(t *testing.T) {
+	commit := &api.Commit{
+		SHA:        testCommitSHA,
+		Title:      `Add "quoted" feature`,
+		ParentSHAs: []sha.SHA{testParent1SHA},
+	}
+
+	assert.Equal(t,
+		"Revert \"Add \"quoted\" feature\"\n\n"+
+			"This reverts commit 1111111111111111111111111111111111111111.",
+		revertMessage(commit, testParent1SHA))
+
+	commit.ParentSHAs = []sha.SHA{testParent1SHA, testParent2SHA}
+
+	assert.Equal(t,
+		"Revert \"Add \"quoted\" feature\"\n\n"+
+			"This reverts commit 1111111111111111111111111111111111111111, reversing\n"+
+			"changes made to 2222222222222222222222222222222222222222.",
+		revertMessage(commit, testParent1SHA))
+}
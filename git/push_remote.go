@@ -17,6 +17,8 @@ package git
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/api"
@@ -25,6 +27,11 @@ import (
 type PushRemoteParams struct {
 	ReadParams
 	RemoteURL string
+
+	// RefFilter [OPTIONAL] switches from mirroring all references of the repository to pushing only
+	// the branches and tags whose full reference name (e.g. refs/heads/main) matches the regular expression.
+	// Matching branches and tags that don't exist in the repository are deleted from the remote repository.
+	RefFilter string
 }
 
 func (p *PushRemoteParams) Validate() error {
@@ -39,6 +46,11 @@ func (p *PushRemoteParams) Validate() error {
 	if p.RemoteURL == "" {
 		return errors.InvalidArgument("remote url cannot be empty")
 	}
+
+	if _, err := regexp.Compile(p.RefFilter); err != nil {
+		return errors.InvalidArgument("invalid reference filter: %s", err)
+	}
+
 	return nil
 }
 
@@ -48,6 +60,11 @@ func (s *Service) PushRemote(ctx context.Context, params *PushRemoteParams) erro
 	}
 
 	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	if params.RefFilter != "" {
+		return s.pushRemoteFiltered(ctx, repoPath, params.RemoteURL, regexp.MustCompile(params.RefFilter))
+	}
+
 	isEmpty, err := s.git.HasBranches(ctx, repoPath)
 	if err != nil {
 		return errors.Internal(err, "push to repo failed")
@@ -67,3 +84,66 @@ func (s *Service) PushRemote(ctx context.Context, params *PushRemoteParams) erro
 	}
 	return nil
 }
+
+// pushRemoteFiltered force pushes the matching branches and tags to the remote repository
+// and deletes the matching remote branches and tags that don't exist locally.
+func (s *Service) pushRemoteFiltered(
+	ctx context.Context,
+	repoPath string,
+	remoteURL string,
+	refFilter *regexp.Regexp,
+) error {
+	localRefs := make(map[string]struct{})
+	err := s.git.WalkReferences(ctx, repoPath, func(e api.WalkReferencesEntry) error {
+		ref, ok := e[api.GitReferenceFieldRefName]
+		if !ok {
+			return fmt.Errorf("entry missing reference name")
+		}
+
+		if refFilter.MatchString(ref) {
+			localRefs[ref] = struct{}{}
+		}
+
+		return nil
+	}, &api.WalkReferencesOptions{
+		Patterns: []string{gitReferenceNamePrefixBranch, gitReferenceNamePrefixTag},
+		Fields:   []api.GitReferenceField{api.GitReferenceFieldRefName},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to walk references: %w", err)
+	}
+
+	remoteRefs, err := s.git.ListRemoteReferences(ctx, remoteURL)
+	if err != nil {
+		return fmt.Errorf("failed to list references of the remote repository: %w", err)
+	}
+
+	refSpecs := make([]string, 0, len(localRefs))
+	for ref := range localRefs {
+		refSpecs = append(refSpecs, "+"+ref+":"+ref)
+	}
+
+	for _, ref := range remoteRefs {
+		if _, ok := localRefs[ref]; ok || !refFilter.MatchString(ref) {
+			continue
+		}
+
+		refSpecs = append(refSpecs, ":"+ref)
+	}
+
+	if len(refSpecs) == 0 {
+		return nil
+	}
+
+	sort.Strings(refSpecs)
+
+	err = s.git.Push(ctx, repoPath, api.PushOptions{
+		Remote:   remoteURL,
+		RefSpecs: refSpecs,
+	})
+	if err != nil {
+		return fmt.Errorf("PushRemote: failed to push to remote repository: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/parser"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
+)
+
+// RevertParams is input structure object for the revert operation.
+type RevertParams struct {
+	WriteParams
+
+	// CommitSHA is the commit whose changes are reverted.
+	CommitSHA sha.SHA
+	// Mainline is the 1-based number of the parent of a merge commit relative to which the commit is reverted.
+	// It's required for merge commits and ignored if BaseCommitSHA is provided.
+	Mainline int
+	// BaseCommitSHA overrides the commit relative to which the changes are reverted (optional).
+	// All changes between BaseCommitSHA and CommitSHA are reverted, for example all commits of a pull request.
+	BaseCommitSHA sha.SHA
+
+	// Branch is the branch on top of which the revert commit is created (optional, default: default branch).
+	Branch string
+	// NewBranch is the name of the branch that is created for the revert commit (optional, default: Branch).
+	NewBranch string
+
+	// Message is the message of the revert commit (optional, default: generated from the reverted commit).
+	Message string
+
+	// Committer overwrites the git committer used for the revert commit
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for the revert commit
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+	// Author overwrites the git author used for the revert commit
+	// (optional, default: committer)
+	Author *Identity
+	// AuthorDate overwrites the git author date used for the revert commit
+	// (optional, default: committer date)
+	AuthorDate *time.Time
+}
+
+func (p *RevertParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.CommitSHA.IsEmpty() {
+		return errors.InvalidArgument("commit SHA is mandatory")
+	}
+
+	return nil
+}
+
+// RevertOutput is result object of the revert operation.
+type RevertOutput struct {
+	// TargetSHA is the commit on top of which the revert commit was created.
+	TargetSHA sha.SHA
+	// CommitSHA is the revert commit. It's empty if there are conflicts.
+	CommitSHA sha.SHA
+	// ConflictFiles is the list of files which prevented the revert.
+	ConflictFiles []string
+}
+
+// Revert creates a new commit that reverts the changes of an existing commit.
+// The commit is created in a shared repository without a checkout.
+// In case of conflicts no commit is created and the list of conflicting files is returned.
+func (s *Service) Revert(ctx context.Context, params *RevertParams) (RevertOutput, error) {
+	if err := params.Validate(); err != nil {
+		return RevertOutput{}, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	target, err := s.preparePickTarget(ctx, repoPath, params.Branch, params.NewBranch)
+	if err != nil {
+		return RevertOutput{}, err
+	}
+
+	commit, err := s.git.GetCommit(ctx, repoPath, params.CommitSHA.String())
+	if err != nil {
+		return RevertOutput{}, fmt.Errorf("failed to get commit %s: %w", params.CommitSHA, err)
+	}
+
+	baseSHA := params.BaseCommitSHA
+	if baseSHA.IsEmpty() {
+		baseSHA, err = pickParent(commit, params.Mainline)
+		if err != nil {
+			return RevertOutput{}, err
+		}
+	}
+
+	message := params.Message
+	if message == "" {
+		message = revertMessage(commit, baseSHA)
+	}
+	message = parser.CleanUpWhitespace(message)
+
+	now := time.Now().UTC()
+
+	committer := api.Signature{Identity: api.Identity(params.Actor), When: now}
+	if params.Committer != nil {
+		committer.Identity = api.Identity(*params.Committer)
+	}
+	if params.CommitterDate != nil {
+		committer.When = *params.CommitterDate
+	}
+
+	author := committer
+	if params.Author != nil {
+		author.Identity = api.Identity(*params.Author)
+	}
+	if params.AuthorDate != nil {
+		author.When = *params.AuthorDate
+	}
+
+	refUpdater, err := hook.CreateRefUpdater(s.hookClientFactory, params.EnvVars, repoPath, target.BranchRef)
+	if err != nil {
+		return RevertOutput{}, fmt.Errorf("failed to create ref updater: %w", err)
+	}
+
+	var commitSHA sha.SHA
+	var conflicts []string
+
+	err = sharedrepo.Run(ctx, refUpdater, s.tmpDir, repoPath, func(r *sharedrepo.SharedRepo) error {
+		targetTreeSHA, err := r.GetTreeSHA(ctx, target.TargetSHA.String())
+		if err != nil {
+			return fmt.Errorf("failed to get tree sha for target: %w", err)
+		}
+
+		// Reverting is merging in the base commit using the reverted commit as the merge base:
+		// the changes between the reverted commit and its base get applied on top of the target.
+		var treeSHA sha.SHA
+		treeSHA, conflicts, err = r.MergeTree(ctx, commit.SHA, target.TargetSHA, baseSHA)
+		if err != nil {
+			return fmt.Errorf("failed to merge tree: %w", err)
+		}
+
+		if len(conflicts) > 0 {
+			return errPickConflict
+		}
+
+		if treeSHA.Equal(targetTreeSHA) {
+			return errors.InvalidArgument("No effective changes.")
+		}
+
+		commitSHA, err = r.CommitTree(ctx, &author, &committer, treeSHA, message, false, target.TargetSHA)
+		if err != nil {
+			return fmt.Errorf("failed to commit tree: %w", err)
+		}
+
+		if err := refUpdater.Init(ctx, target.OldSHA, commitSHA); err != nil {
+			return fmt.Errorf("failed to init ref updater: %w", err)
+		}
+
+		return nil
+	})
+	if errors.Is(err, errPickConflict) {
+		return RevertOutput{
+			TargetSHA:     target.TargetSHA,
+			ConflictFiles: conflicts,
+		}, nil
+	}
+	if err != nil {
+		return RevertOutput{}, fmt.Errorf("failed to revert commit %s: %w", params.CommitSHA, err)
+	}
+
+	return RevertOutput{
+		TargetSHA: target.TargetSHA,
+		CommitSHA: commitSHA,
+	}, nil
+}
+
+// revertMessage returns the default message of the commit that reverts the provided commit.
+// The message matches the one generated by `git revert`.
+func revertMessage(commit *api.Commit, baseSHA sha.SHA) string {
+	if len(commit.ParentSHAs) == 1 && commit.ParentSHAs[0].Equal(baseSHA) {
+		return fmt.Sprintf("Revert \"%s\"\n\nThis reverts commit %s.", commit.Title, commit.SHA)
+	}
+
+	return fmt.Sprintf("Revert \"%s\"\n\nThis reverts commit %s, reversing\nchanges made to %s.",
+		commit.Title, commit.SHA, baseSHA)
+}
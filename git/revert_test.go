@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevert(t *testing.T) {
+	skipWithoutMergeTreeMergeBase(t)
+
+	ctx := context.Background()
+	s := newTestService(t)
+
+	repoUID := createTestRepo(ctx, t, s, File{Path: "README.md", Content: []byte("readme")})
+	revertedSHA := commitTestFile(ctx, t, s, repoUID, "main", "a.txt", "a")
+	mainSHA := commitTestFile(ctx, t, s, repoUID, "main", "b.txt", "b")
+
+	author := Identity{Name: "Author", Email: "author@example.com"}
+
+	out, err := s.Revert(ctx, &RevertParams{
+		WriteParams: WriteParams{RepoUID: repoUID, Actor: testIdentity},
+		CommitSHA:   revertedSHA,
+		NewBranch:   "revert",
+		Committer:   &testCommitterIdentity,
+		Author:      &author,
+	})
+	require.NoError(t, err)
+	require.Empty(t, out.ConflictFiles)
+	require.Equal(t, mainSHA, out.TargetSHA)
+
+	// the revert commit is created on the new branch, the source branch is unchanged.
+	require.Equal(t, out.CommitSHA, getTestBranchSHA(ctx, t, s, repoUID, "revert"))
+	require.Equal(t, mainSHA, getTestBranchSHA(ctx, t, s, repoUID, "main"))
+
+	commit := getTestCommit(ctx, t, s, repoUID, out.CommitSHA)
+	require.Equal(t, `Revert "update a.txt"`, commit.Title)
+	require.Equal(t, author, commit.Author.Identity)
+	require.Equal(t, testCommitterIdentity, commit.Committer.Identity)
+
+	_, err = s.GetTreeNode(ctx, &GetTreeNodeParams{
+		ReadParams: ReadParams{RepoUID: repoUID},
+		GitREF:     "revert",
+		Path:       "a.txt",
+	})
+	require.True(t, errors.IsNotFound(err), "the file added by the reverted commit must be removed, got: %v", err)
+
+	// the changes of the commit are already reverted on the branch.
+	_, err = s.Revert(ctx, &RevertParams{
+		WriteParams: WriteParams{RepoUID: repoUID, Actor: testIdentity},
+		CommitSHA:   revertedSHA,
+		Branch:      "revert",
+	})
+	require.True(t, errors.IsInvalidArgument(err), "expected invalid argument error, got: %v", err)
+	require.Equal(t, out.CommitSHA, getTestBranchSHA(ctx, t, s, repoUID, "revert"))
+}
+
+func TestRevertConflict(t *testing.T) {
+	skipWithoutMergeTreeMergeBase(t)
+
+	ctx := context.Background()
+	s := newTestService(t)
+
+	repoUID := createTestRepo(ctx, t, s, File{Path: "README.md", Content: []byte("readme")})
+	revertedSHA := commitTestFile(ctx, t, s, repoUID, "main", "a.txt", "a")
+	mainSHA := updateTestFile(ctx, t, s, repoUID, "main", "a.txt", "changed")
+
+	out, err := s.Revert(ctx, &RevertParams{
+		WriteParams: WriteParams{RepoUID: repoUID, Actor: testIdentity},
+		CommitSHA:   revertedSHA,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.txt"}, out.ConflictFiles)
+	require.True(t, out.CommitSHA.IsEmpty())
+	require.Equal(t, mainSHA, getTestBranchSHA(ctx, t, s, repoUID, "main"))
+}
+
+func TestRevertNewBranchExists(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	repoUID := createTestRepo(ctx, t, s, File{Path: "README.md", Content: []byte("readme")})
+	revertedSHA := commitTestFile(ctx, t, s, repoUID, "main", "a.txt", "a")
+	createTestBranch(ctx, t, s, repoUID, "existing", "main")
+
+	_, err := s.Revert(ctx, &RevertParams{
+		WriteParams: WriteParams{RepoUID: repoUID, Actor: testIdentity},
+		CommitSHA:   revertedSHA,
+		NewBranch:   "existing",
+	})
+	require.True(t, errors.IsConflict(err), "expected conflict error, got: %v", err)
+}
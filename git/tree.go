@@ -47,6 +47,8 @@ type TreeNode struct {
 	SHA  string // TODO: make sha.SHA
 	Name string
 	Path string
+	// Size is the size of the blob. It's only set if explicitly requested.
+	Size int64
 }
 
 type ListTreeNodeParams struct {
@@ -146,6 +148,54 @@ func (s *Service) ListTreeNodes(ctx context.Context, params *ListTreeNodeParams)
 	}, nil
 }
 
+type ListFilesParams struct {
+	ReadParams
+	// GitREF is a git reference (branch / tag / commit SHA)
+	GitREF string
+	// IncludeSizes tells whether the sizes of the files should be returned.
+	IncludeSizes bool
+}
+
+func (p *ListFilesParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	return p.ReadParams.Validate()
+}
+
+type ListFilesOutput struct {
+	Files []TreeNode
+}
+
+// ListFiles lists all files of the tree reachable from the provided git reference recursively.
+func (s *Service) ListFiles(ctx context.Context, params *ListFilesParams) (*ListFilesOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	res, err := s.git.ListFiles(ctx, repoPath, params.GitREF, params.IncludeSizes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	files := make([]TreeNode, len(res))
+	for i := range res {
+		n, err := mapTreeNode(&res[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to map rpc node: %w", err)
+		}
+
+		files[i] = n
+	}
+
+	return &ListFilesOutput{
+		Files: files,
+	}, nil
+}
+
 type ListPathsParams struct {
 	ReadParams
 	// GitREF is a git reference (branch / tag / commit SHA)
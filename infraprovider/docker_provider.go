@@ -59,6 +59,7 @@ func (d DockerProvider) Provision(
 	_ int,
 	requiredGitspacePorts []types.GitspacePort,
 	inputParameters []types.InfraProviderParameter,
+	hostRequirements *types.HostRequirements,
 ) error {
 	dockerClient, err := d.dockerClientFactory.NewDockerClient(ctx, types.Infrastructure{
 		ProviderType:    enum.InfraProviderTypeDocker,
@@ -80,6 +81,10 @@ func (d DockerProvider) Provision(
 		return err
 	}
 
+	if err = d.validateHostRequirements(ctx, dockerClient, hostRequirements); err != nil {
+		return err
+	}
+
 	infrastructure.SpaceID = spaceID
 	infrastructure.SpacePath = spacePath
 	infrastructure.GitspaceConfigIdentifier = gitspaceConfigIdentifier
@@ -305,6 +310,42 @@ func (d DockerProvider) dockerHostInfo(
 	}, nil
 }
 
+// validateHostRequirements checks that the docker host has enough CPUs and memory for the gitspace.
+// The storage requirement can't be verified through the docker engine, it's only logged.
+func (d DockerProvider) validateHostRequirements(
+	ctx context.Context,
+	dockerClient *client.Client,
+	hostRequirements *types.HostRequirements,
+) error {
+	if hostRequirements == nil {
+		return nil
+	}
+
+	info, err := dockerClient.Info(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to connect to docker engine: %w", err)
+	}
+
+	if hostRequirements.CPUs > info.NCPU {
+		return fmt.Errorf("docker host has %d CPUs, the gitspace requires %d", info.NCPU, hostRequirements.CPUs)
+	}
+
+	memory, err := hostRequirements.MemoryBytes()
+	if err != nil {
+		return err
+	}
+	if memory > info.MemTotal {
+		return fmt.Errorf("docker host has %d bytes of memory, the gitspace requires %d", info.MemTotal, memory)
+	}
+
+	if hostRequirements.Storage != "" {
+		log.Ctx(ctx).Info().Msgf("unable to verify the storage requirement %s of the gitspace on the docker host",
+			hostRequirements.Storage)
+	}
+
+	return nil
+}
+
 func (d DockerProvider) createNamedVolume(
 	ctx context.Context,
 	spacePath string,
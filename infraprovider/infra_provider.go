@@ -23,6 +23,7 @@ import (
 
 type InfraProvider interface {
 	// Provision provisions infrastructure against a gitspace with the provided parameters.
+	// It fails if the infrastructure can't satisfy the host requirements of the gitspace, if any.
 	Provision(
 		ctx context.Context,
 		spaceID int64,
@@ -32,6 +33,7 @@ type InfraProvider interface {
 		agentPort int,
 		requiredGitspacePorts []types.GitspacePort,
 		inputParameters []types.InfraProviderParameter,
+		hostRequirements *types.HostRequirements,
 	) error
 
 	// Find finds infrastructure provisioned against a gitspace.
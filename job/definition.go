@@ -25,6 +25,9 @@ type Definition struct {
 	MaxRetries int
 	Timeout    time.Duration
 	Data       string
+
+	// Delay postpones the first execution of the job.
+	Delay time.Duration
 }
 
 func (def *Definition) Validate() error {
@@ -44,6 +47,10 @@ func (def *Definition) Validate() error {
 		return errors.New("job Timeout too short")
 	}
 
+	if def.Delay < 0 {
+		return errors.New("job Delay must not be negative")
+	}
+
 	return nil
 }
 
@@ -60,7 +67,7 @@ func (def *Definition) toNewJob() *Job {
 		MaxDurationSeconds:  int(def.Timeout / time.Second),
 		MaxRetries:          def.MaxRetries,
 		State:               JobStateScheduled,
-		Scheduled:           nowMilli,
+		Scheduled:           nowMilli + def.Delay.Milliseconds(),
 		TotalExecutions:     0,
 		RunBy:               "",
 		RunDeadline:         nowMilli,
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "encoding/json"
+
+// AuditEvent is a security-relevant action recorded in the audit trail.
+type AuditEvent struct {
+	ID                 int64             `json:"id"`
+	Timestamp          int64             `json:"timestamp"`
+	Action             string            `json:"action"`
+	Principal          PrincipalInfo     `json:"principal"`
+	SpacePath          string            `json:"space_path,omitempty"`
+	ResourceType       string            `json:"resource_type"`
+	ResourceIdentifier string            `json:"resource_identifier"`
+	ResourceData       map[string]string `json:"resource_data,omitempty"`
+	OldObject          json.RawMessage   `json:"old_object,omitempty"`
+	NewObject          json.RawMessage   `json:"new_object,omitempty"`
+	ClientIP           string            `json:"client_ip,omitempty"`
+	RequestMethod      string            `json:"request_method,omitempty"`
+	Data               map[string]string `json:"data,omitempty"`
+}
+
+// AuditEventFilter stores audit event query parameters.
+type AuditEventFilter struct {
+	Page          int      `json:"page"`
+	Size          int      `json:"size"`
+	SpacePath     string   `json:"space_path"`
+	Recursive     bool     `json:"recursive"`
+	Actions       []string `json:"actions"`
+	ResourceTypes []string `json:"resource_types"`
+	PrincipalUID  string   `json:"principal_uid"`
+	From          int64    `json:"from"`
+	To            int64    `json:"to"`
+}
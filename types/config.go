@@ -174,6 +174,17 @@ type Config struct {
 		// In that case, GITNESS_URL_CONTAINER should also be changed
 		// (eg to http://<gitness_container_name>:<port>).
 		ContainerNetworks []string `envconfig:"GITNESS_CI_CONTAINER_NETWORKS"`
+
+		// ArtifactRetention is the default duration for which pipeline artifacts are kept.
+		ArtifactRetention time.Duration `envconfig:"GITNESS_CI_ARTIFACT_RETENTION" default:"720h"`
+		// ArtifactMaxRetention is the maximum retention that can be requested when uploading an artifact.
+		ArtifactMaxRetention time.Duration `envconfig:"GITNESS_CI_ARTIFACT_MAX_RETENTION" default:"2160h"`
+		// ArtifactMaxSize is the maximum size of a single pipeline artifact in bytes.
+		ArtifactMaxSize int64 `envconfig:"GITNESS_CI_ARTIFACT_MAX_SIZE" default:"1073741824"`
+		// CacheRetention is the duration after which unused pipeline build caches are purged.
+		CacheRetention time.Duration `envconfig:"GITNESS_CI_CACHE_RETENTION" default:"168h"`
+		// CacheMaxSize is the maximum size of a single pipeline build cache entry in bytes.
+		CacheMaxSize int64 `envconfig:"GITNESS_CI_CACHE_MAX_SIZE" default:"5368709120"`
 	}
 
 	// Database defines the database configuration parameters.
@@ -205,6 +216,72 @@ type Config struct {
 		Expire     time.Duration `envconfig:"GITNESS_TOKEN_EXPIRE" default:"720h"`
 	}
 
+	// OIDC defines the configuration of the OpenID Connect single sign-on.
+	OIDC struct {
+		Enabled bool `envconfig:"GITNESS_OIDC_ENABLED" default:"false"`
+
+		// Issuer is the URL of the identity provider, used for the discovery (e.g. https://idp.example.com).
+		Issuer       string `envconfig:"GITNESS_OIDC_ISSUER"`
+		ClientID     string `envconfig:"GITNESS_OIDC_CLIENT_ID"`
+		ClientSecret string `envconfig:"GITNESS_OIDC_CLIENT_SECRET"`
+
+		// RedirectURL is the URL the identity provider redirects the user to after the authentication.
+		// Value is derived from URL.API unless explicitly specified (e.g. http://localhost:3000/api/v1/login/oidc/callback).
+		RedirectURL string `envconfig:"GITNESS_OIDC_REDIRECT_URL"`
+
+		Scopes []string `envconfig:"GITNESS_OIDC_SCOPES" default:"openid,profile,email"`
+
+		// GroupsClaim is the name of the ID token claim that contains the groups of the user.
+		GroupsClaim string `envconfig:"GITNESS_OIDC_GROUPS_CLAIM" default:"groups"`
+
+		// UserGroupMappings map groups of the identity provider onto user groups.
+		// Each mapping has the format <group>=<space path>:<user group identifier>.
+		UserGroupMappings []string `envconfig:"GITNESS_OIDC_USER_GROUP_MAPPINGS"`
+
+		// MembershipMappings map groups of the identity provider onto space memberships.
+		// Each mapping has the format <group>=<space path>:<membership role>.
+		MembershipMappings []string `envconfig:"GITNESS_OIDC_MEMBERSHIP_MAPPINGS"`
+	}
+
+	LDAP struct {
+		Enabled bool `envconfig:"GITNESS_LDAP_ENABLED" default:"false"`
+
+		// URL of the directory server (e.g. ldaps://ldap.example.com:636 or ldap://ldap.example.com:389).
+		URL                string        `envconfig:"GITNESS_LDAP_URL"`
+		StartTLS           bool          `envconfig:"GITNESS_LDAP_START_TLS" default:"false"`
+		InsecureSkipVerify bool          `envconfig:"GITNESS_LDAP_INSECURE_SKIP_VERIFY" default:"false"`
+		Timeout            time.Duration `envconfig:"GITNESS_LDAP_TIMEOUT" default:"10s"`
+
+		// BindDN and BindPassword are the credentials of the account used to search the directory.
+		BindDN       string `envconfig:"GITNESS_LDAP_BIND_DN"`
+		BindPassword string `envconfig:"GITNESS_LDAP_BIND_PASSWORD"`
+
+		UserBaseDN string `envconfig:"GITNESS_LDAP_USER_BASE_DN"`
+
+		// UserFilter is the filter used to find a user, %s is replaced with the login identifier.
+		UserFilter string `envconfig:"GITNESS_LDAP_USER_FILTER" default:"(&(objectClass=person)(uid=%s))"`
+
+		// UserDisabledFilter is an optional filter that matches disabled users,
+		// e.g. (userAccountControl:1.2.840.113556.1.4.803:=2) for Active Directory.
+		UserDisabledFilter string `envconfig:"GITNESS_LDAP_USER_DISABLED_FILTER"`
+
+		UserAttributeUID         string `envconfig:"GITNESS_LDAP_USER_ATTRIBUTE_UID" default:"uid"`
+		UserAttributeEmail       string `envconfig:"GITNESS_LDAP_USER_ATTRIBUTE_EMAIL" default:"mail"`
+		UserAttributeDisplayName string `envconfig:"GITNESS_LDAP_USER_ATTRIBUTE_DISPLAY_NAME" default:"cn"`
+
+		GroupBaseDN          string `envconfig:"GITNESS_LDAP_GROUP_BASE_DN"`
+		GroupFilter          string `envconfig:"GITNESS_LDAP_GROUP_FILTER" default:"(objectClass=groupOfNames)"`
+		GroupAttributeName   string `envconfig:"GITNESS_LDAP_GROUP_ATTRIBUTE_NAME" default:"cn"`
+		GroupAttributeMember string `envconfig:"GITNESS_LDAP_GROUP_ATTRIBUTE_MEMBER" default:"member"`
+
+		// GroupSpace is the path of the space the directory groups are synchronized into as user groups.
+		// Group synchronization is disabled if it's not provided.
+		GroupSpace string `envconfig:"GITNESS_LDAP_GROUP_SPACE"`
+
+		// SyncCron is the schedule of the synchronization of the directory groups and disabled users.
+		SyncCron string `envconfig:"GITNESS_LDAP_SYNC_CRON" default:"0 * * * *"`
+	}
+
 	Logs struct {
 		// S3 provides optional storage option for logs.
 		S3 struct {
@@ -340,6 +417,13 @@ type Config struct {
 		AllowLoopback       bool   `envconfig:"GITNESS_WEBHOOK_ALLOW_LOOPBACK" default:"false"`
 		// RetentionTime is the duration after which webhook executions will be purged from the DB.
 		RetentionTime time.Duration `envconfig:"GITNESS_WEBHOOK_RETENTION_TIME" default:"168h"` // 7 days
+		// RetryBackoff is the delay before the first automatic redelivery of a failed webhook execution.
+		// The delay doubles with every further attempt, up to RetryMaxBackoff.
+		RetryBackoff    time.Duration `envconfig:"GITNESS_WEBHOOK_RETRY_BACKOFF" default:"30s"`
+		RetryMaxBackoff time.Duration `envconfig:"GITNESS_WEBHOOK_RETRY_MAX_BACKOFF" default:"1h"`
+		// AutoDisableAfter is the number of consecutive failed deliveries after which a webhook gets disabled.
+		// Zero means webhooks are never disabled automatically.
+		AutoDisableAfter int `envconfig:"GITNESS_WEBHOOK_AUTO_DISABLE_AFTER" default:"0"`
 	}
 
 	Trigger struct {
@@ -376,11 +460,36 @@ type Config struct {
 	Notification struct {
 		MaxRetries  int `envconfig:"GITNESS_NOTIFICATION_MAX_RETRIES" default:"3"`
 		Concurrency int `envconfig:"GITNESS_NOTIFICATION_CONCURRENCY" default:"4"`
+
+		// ChannelAllowPrivateNetwork allows notification channels to post to private network addresses.
+		ChannelAllowPrivateNetwork bool `envconfig:"GITNESS_NOTIFICATION_CHANNEL_ALLOW_PRIVATE_NETWORK" default:"false"`
+		// ChannelAllowLoopback allows notification channels to post to loopback addresses.
+		ChannelAllowLoopback bool `envconfig:"GITNESS_NOTIFICATION_CHANNEL_ALLOW_LOOPBACK" default:"false"`
+
+		// DigestCron is the schedule of the job that sends the daily notification digest emails.
+		DigestCron string `envconfig:"GITNESS_NOTIFICATION_DIGEST_CRON" default:"0 8 * * *"`
 	}
 
 	KeywordSearch struct {
 		Concurrency int `envconfig:"GITNESS_KEYWORD_SEARCH_CONCURRENCY" default:"4"`
 		MaxRetries  int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_RETRIES" default:"3"`
+
+		// IndexPath is the directory where the search index is stored. Defaults to a directory in the gitness home.
+		IndexPath string `envconfig:"GITNESS_KEYWORD_SEARCH_INDEX_PATH"`
+		// MaxFileSize is the maximum size of a file (in bytes) that gets indexed.
+		MaxFileSize int64 `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_FILE_SIZE" default:"1048576"`
+		// MaxCachedIndexes is the maximum number of repository indexes kept in memory.
+		MaxCachedIndexes int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_CACHED_INDEXES" default:"32"`
+	}
+
+	MergeQueue struct {
+		// CheckTimeout is the maximum time the required status checks can take for a queued pull request.
+		CheckTimeout time.Duration `envconfig:"GITNESS_MERGE_QUEUE_CHECK_TIMEOUT" default:"2h"`
+	}
+
+	PushMirror struct {
+		Concurrency int `envconfig:"GITNESS_PUSH_MIRROR_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_PUSH_MIRROR_MAX_RETRIES" default:"3"`
 	}
 
 	Repos struct {
@@ -24,6 +24,7 @@ import (
 
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/docker/go-units"
 	"oras.land/oras-go/v2/registry"
 )
 
@@ -47,6 +48,93 @@ type DevcontainerConfig struct {
 	CapAdd                      []string                         `json:"capAdd,omitempty"`
 	SecurityOpt                 []string                         `json:"securityOpt,omitempty"`
 	Mounts                      []*Mount                         `json:"mounts,omitempty"`
+
+	// Build and the Docker Compose properties are alternatives to Image.
+	Build             *DevcontainerBuild `json:"build,omitempty"`
+	DockerComposeFile StringOrArray      `json:"dockerComposeFile,omitempty"`
+	Service           string             `json:"service,omitempty"`
+	RunServices       []string           `json:"runServices,omitempty"`
+
+	// The lifecycle commands run in the order: onCreateCommand, updateContentCommand, postCreateCommand,
+	// postStartCommand and postAttachCommand. The gitspace is ready once the command named by WaitFor
+	// has completed, the remaining ones continue in the background. The initializeCommand runs on the host
+	// machine and is skipped, as gitspaces don't run commands on their host.
+	InitializeCommand    LifecycleCommand `json:"initializeCommand,omitempty"`
+	OnCreateCommand      LifecycleCommand `json:"onCreateCommand,omitempty"`
+	UpdateContentCommand LifecycleCommand `json:"updateContentCommand,omitempty"`
+	PostAttachCommand    LifecycleCommand `json:"postAttachCommand,omitempty"`
+	WaitFor              string           `json:"waitFor,omitempty"`
+
+	HostRequirements *HostRequirements `json:"hostRequirements,omitempty"`
+	WorkspaceFolder  string            `json:"workspaceFolder,omitempty"`
+	WorkspaceMount   string            `json:"workspaceMount,omitempty"`
+}
+
+// HostRequirements holds the minimum resources the infrastructure of a dev container must provide.
+// Memory and storage are sizes with a tb, gb, mb or kb suffix, for example "4gb".
+type HostRequirements struct {
+	CPUs    int    `json:"cpus,omitempty"`
+	Memory  string `json:"memory,omitempty"`
+	Storage string `json:"storage,omitempty"`
+}
+
+// MemoryBytes returns the required memory in bytes, or zero if there's no memory requirement.
+func (r *HostRequirements) MemoryBytes() (int64, error) {
+	return parseHostRequirementSize("memory", r.Memory)
+}
+
+// StorageBytes returns the required storage in bytes, or zero if there's no storage requirement.
+func (r *HostRequirements) StorageBytes() (int64, error) {
+	return parseHostRequirementSize("storage", r.Storage)
+}
+
+func parseHostRequirementSize(name string, size string) (int64, error) {
+	if size == "" {
+		return 0, nil
+	}
+
+	bytes, err := units.RAMInBytes(size)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s requirement %q: %w", name, size, err)
+	}
+
+	return bytes, nil
+}
+
+// IsCompose returns true if the dev container is defined with Docker Compose files.
+func (c DevcontainerConfig) IsCompose() bool {
+	return len(c.DockerComposeFile) > 0
+}
+
+// DevcontainerBuild holds the properties for building the dev container image from a Dockerfile.
+// The paths are relative to the location of the devcontainer.json file.
+//
+//nolint:tagliatelle
+type DevcontainerBuild struct {
+	Dockerfile string            `json:"dockerfile,omitempty"`
+	Context    string            `json:"context,omitempty"`
+	Args       map[string]string `json:"args,omitempty"`
+	Target     string            `json:"target,omitempty"`
+	CacheFrom  StringOrArray     `json:"cacheFrom,omitempty"`
+}
+
+// StringOrArray is a list of strings that can be provided either as a single string or as an array of strings.
+type StringOrArray []string
+
+func (s *StringOrArray) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*s = StringOrArray{str}
+		return nil
+	}
+
+	var arr []string
+	if err := json.Unmarshal(data, &arr); err != nil {
+		return fmt.Errorf("invalid format: must be string or []string")
+	}
+
+	*s = arr
+	return nil
 }
 
 // Constants for discriminator values.
@@ -318,6 +406,8 @@ func stringToObject(mountStr string) (*Mount, error) {
 			}
 		case "target", "dst", "destination":
 			newMount.Target = val
+		case "consistency":
+			// Only relevant to docker desktop on macOS, ignored.
 		default:
 			return nil, fmt.Errorf("unexpected key '%s' in '%s'", key, field)
 		}
@@ -39,6 +39,10 @@ type DevcontainerFeatureConfig struct {
 	Mounts            []*Mount          `json:"mounts,omitempty"`
 	PostCreateCommand LifecycleCommand  `json:"postCreateCommand,omitempty"`
 	PostStartCommand  LifecycleCommand  `json:"postStartCommand,omitempty"`
+
+	OnCreateCommand      LifecycleCommand `json:"onCreateCommand,omitempty"`
+	UpdateContentCommand LifecycleCommand `json:"updateContentCommand,omitempty"`
+	PostAttachCommand    LifecycleCommand `json:"postAttachCommand,omitempty"`
 }
 
 type Options map[string]*OptionDefinition
@@ -33,3 +33,28 @@ var executionSorts = sortEnum([]ExecutionSort{
 	ExecutionSortStarted,
 	ExecutionSortFinished,
 })
+
+// ConcurrencyPolicy defines how an execution is handled if other executions of the same concurrency group
+// are already in progress.
+type ConcurrencyPolicy string
+
+func (ConcurrencyPolicy) Enum() []interface{} { return toInterfaceSlice(concurrencyPolicies) }
+func (p ConcurrencyPolicy) Sanitize() (ConcurrencyPolicy, bool) {
+	return Sanitize(p, GetAllConcurrencyPolicies)
+}
+func GetAllConcurrencyPolicies() ([]ConcurrencyPolicy, ConcurrencyPolicy) {
+	return concurrencyPolicies, ConcurrencyPolicyQueue
+}
+
+// ConcurrencyPolicy enumeration.
+const (
+	// ConcurrencyPolicyQueue means the execution waits until the executions ahead of it in the group are completed.
+	ConcurrencyPolicyQueue ConcurrencyPolicy = "queue"
+	// ConcurrencyPolicyCancelInProgress means the executions in progress in the group are canceled.
+	ConcurrencyPolicyCancelInProgress ConcurrencyPolicy = "cancel_in_progress"
+)
+
+var concurrencyPolicies = sortEnum([]ConcurrencyPolicy{
+	ConcurrencyPolicyQueue,
+	ConcurrencyPolicyCancelInProgress,
+})
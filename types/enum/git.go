@@ -114,3 +114,27 @@ func ParseGitServiceType(s string) (GitServiceType, error) {
 		return "", fmt.Errorf("unknown git service type provided: %q", s)
 	}
 }
+
+// CommitSignatureStatus defines the result of the verification of a commit signature.
+type CommitSignatureStatus string
+
+const (
+	// CommitSignatureStatusVerified means that the commit is signed by a key registered by the committer.
+	CommitSignatureStatusVerified CommitSignatureStatus = "verified"
+	// CommitSignatureStatusUnverified means that the commit isn't signed,
+	// or that it's signed with a key that doesn't belong to the committer.
+	CommitSignatureStatusUnverified CommitSignatureStatus = "unverified"
+	// CommitSignatureStatusUnknownKey means that the commit is signed with a key that isn't registered.
+	CommitSignatureStatusUnknownKey CommitSignatureStatus = "unknown_key"
+	// CommitSignatureStatusBadSignature means that the commit signature is invalid.
+	CommitSignatureStatusBadSignature CommitSignatureStatus = "bad_signature"
+)
+
+var commitSignatureStatuses = sortEnum([]CommitSignatureStatus{
+	CommitSignatureStatusVerified,
+	CommitSignatureStatusUnverified,
+	CommitSignatureStatusUnknownKey,
+	CommitSignatureStatusBadSignature,
+})
+
+func (CommitSignatureStatus) Enum() []interface{} { return toInterfaceSlice(commitSignatureStatuses) }
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// GitLFSOperationType represents the operation types of the git LFS batch API.
+// See https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md for more details.
+type GitLFSOperationType string
+
+const (
+	// GitLFSOperationTypeDownload is sent by git LFS clients that fetch objects.
+	GitLFSOperationTypeDownload GitLFSOperationType = "download"
+	// GitLFSOperationTypeUpload is sent by git LFS clients that push objects.
+	GitLFSOperationTypeUpload GitLFSOperationType = "upload"
+)
+
+var gitLFSOperationTypes = sortEnum([]GitLFSOperationType{
+	GitLFSOperationTypeDownload,
+	GitLFSOperationTypeUpload,
+})
+
+func (GitLFSOperationType) Enum() []interface{} { return toInterfaceSlice(gitLFSOperationTypes) }
+func (t GitLFSOperationType) Sanitize() (GitLFSOperationType, bool) {
+	return Sanitize(t, GetAllGitLFSOperationTypes)
+}
+func GetAllGitLFSOperationTypes() ([]GitLFSOperationType, GitLFSOperationType) {
+	return gitLFSOperationTypes, ""
+}
+
+// GitLFSTransferType represents the transfer adapters supported by the git LFS batch API.
+type GitLFSTransferType string
+
+const (
+	// GitLFSTransferTypeBasic is the basic transfer adapter, using plain HTTP requests for each object.
+	GitLFSTransferTypeBasic GitLFSTransferType = "basic"
+)
@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// NotificationChannelType defines the type of a chat-ops notification channel.
+type NotificationChannelType string
+
+func (NotificationChannelType) Enum() []interface{} {
+	return toInterfaceSlice(notificationChannelTypes)
+}
+func (t NotificationChannelType) Sanitize() (NotificationChannelType, bool) {
+	return Sanitize(t, GetAllNotificationChannelTypes)
+}
+func GetAllNotificationChannelTypes() ([]NotificationChannelType, NotificationChannelType) {
+	return notificationChannelTypes, "" // No default value
+}
+
+// NotificationChannelType enumeration.
+const (
+	// NotificationChannelTypeSlack posts messages to a Slack incoming webhook.
+	NotificationChannelTypeSlack NotificationChannelType = "slack"
+	// NotificationChannelTypeTeams posts messages to a Microsoft Teams incoming webhook.
+	NotificationChannelTypeTeams NotificationChannelType = "teams"
+	// NotificationChannelTypeGeneric posts plain JSON messages to an arbitrary URL.
+	NotificationChannelTypeGeneric NotificationChannelType = "generic"
+)
+
+var notificationChannelTypes = sortEnum([]NotificationChannelType{
+	NotificationChannelTypeSlack,
+	NotificationChannelTypeTeams,
+	NotificationChannelTypeGeneric,
+})
+
+// NotificationChannelTrigger defines the pull request events a notification channel can subscribe to.
+type NotificationChannelTrigger string
+
+func (NotificationChannelTrigger) Enum() []interface{} {
+	return toInterfaceSlice(notificationChannelTriggers)
+}
+func (t NotificationChannelTrigger) Sanitize() (NotificationChannelTrigger, bool) {
+	return Sanitize(t, GetAllNotificationChannelTriggers)
+}
+func GetAllNotificationChannelTriggers() ([]NotificationChannelTrigger, NotificationChannelTrigger) {
+	return notificationChannelTriggers, "" // No default value
+}
+
+// NotificationChannelTrigger enumeration.
+const (
+	// NotificationChannelTriggerReviewerAdded gets triggered when a reviewer is added to a pull request.
+	NotificationChannelTriggerReviewerAdded NotificationChannelTrigger = "reviewer_added"
+	// NotificationChannelTriggerReviewSubmitted gets triggered when a pull request review is submitted.
+	NotificationChannelTriggerReviewSubmitted NotificationChannelTrigger = "review_submitted"
+	// NotificationChannelTriggerCommentMention gets triggered when a pull request comment mentions users.
+	NotificationChannelTriggerCommentMention NotificationChannelTrigger = "comment_mention"
+	// NotificationChannelTriggerStateChanged gets triggered when a pull request is merged, closed or reopened.
+	NotificationChannelTriggerStateChanged NotificationChannelTrigger = "state_changed"
+)
+
+var notificationChannelTriggers = sortEnum([]NotificationChannelTrigger{
+	NotificationChannelTriggerReviewerAdded,
+	NotificationChannelTriggerReviewSubmitted,
+	NotificationChannelTriggerCommentMention,
+	NotificationChannelTriggerStateChanged,
+})
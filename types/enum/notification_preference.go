@@ -0,0 +1,135 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// NotificationEvent defines the pull request events users receive email notifications for.
+type NotificationEvent string
+
+func (NotificationEvent) Enum() []interface{} {
+	return toInterfaceSlice(notificationEvents)
+}
+func (e NotificationEvent) Sanitize() (NotificationEvent, bool) {
+	return Sanitize(e, GetAllNotificationEvents)
+}
+func GetAllNotificationEvents() ([]NotificationEvent, NotificationEvent) {
+	return notificationEvents, "" // No default value
+}
+
+// NotificationEvent enumeration.
+const (
+	// NotificationEventCommentCreated is sent to the pull request author, thread participants and watchers
+	// when a comment is created.
+	NotificationEventCommentCreated NotificationEvent = "comment_created"
+	// NotificationEventCommentMention is sent to the users mentioned in a pull request comment.
+	NotificationEventCommentMention NotificationEvent = "comment_mention"
+	// NotificationEventReviewerAdded is sent when a reviewer is added to a pull request.
+	NotificationEventReviewerAdded NotificationEvent = "reviewer_added"
+	// NotificationEventReviewSubmitted is sent when a pull request review is submitted.
+	NotificationEventReviewSubmitted NotificationEvent = "review_submitted"
+	// NotificationEventBranchUpdated is sent when new commits are pushed to the source branch of a pull request.
+	NotificationEventBranchUpdated NotificationEvent = "branch_updated"
+	// NotificationEventStateChanged is sent when a pull request is merged, closed or reopened.
+	NotificationEventStateChanged NotificationEvent = "state_changed"
+)
+
+var notificationEvents = sortEnum([]NotificationEvent{
+	NotificationEventCommentCreated,
+	NotificationEventCommentMention,
+	NotificationEventReviewerAdded,
+	NotificationEventReviewSubmitted,
+	NotificationEventBranchUpdated,
+	NotificationEventStateChanged,
+})
+
+// NotificationDelivery defines when the email notifications are delivered to a user.
+type NotificationDelivery string
+
+func (NotificationDelivery) Enum() []interface{} {
+	return toInterfaceSlice(notificationDeliveries)
+}
+func (d NotificationDelivery) Sanitize() (NotificationDelivery, bool) {
+	return Sanitize(d, GetAllNotificationDeliveries)
+}
+func GetAllNotificationDeliveries() ([]NotificationDelivery, NotificationDelivery) {
+	return notificationDeliveries, NotificationDeliveryImmediate
+}
+
+// NotificationDelivery enumeration.
+const (
+	// NotificationDeliveryImmediate sends an email for every notification as soon as the event happens.
+	NotificationDeliveryImmediate NotificationDelivery = "immediate"
+	// NotificationDeliveryDigest batches the notifications into a single daily email.
+	NotificationDeliveryDigest NotificationDelivery = "digest"
+)
+
+var notificationDeliveries = sortEnum([]NotificationDelivery{
+	NotificationDeliveryImmediate,
+	NotificationDeliveryDigest,
+})
+
+// NotificationLevel defines which notifications a user receives for a space, repository or pull request.
+type NotificationLevel string
+
+func (NotificationLevel) Enum() []interface{} {
+	return toInterfaceSlice(notificationLevels)
+}
+func (l NotificationLevel) Sanitize() (NotificationLevel, bool) {
+	return Sanitize(l, GetAllNotificationLevels)
+}
+func GetAllNotificationLevels() ([]NotificationLevel, NotificationLevel) {
+	return notificationLevels, NotificationLevelParticipating
+}
+
+// NotificationLevel enumeration.
+const (
+	// NotificationLevelParticipating notifies the user only about pull requests they are involved in.
+	NotificationLevelParticipating NotificationLevel = "participating"
+	// NotificationLevelWatching notifies the user about all pull requests, even if they aren't involved.
+	NotificationLevelWatching NotificationLevel = "watching"
+	// NotificationLevelMuted doesn't notify the user at all.
+	NotificationLevelMuted NotificationLevel = "muted"
+)
+
+var notificationLevels = sortEnum([]NotificationLevel{
+	NotificationLevelParticipating,
+	NotificationLevelWatching,
+	NotificationLevelMuted,
+})
+
+// NotificationScope defines the type of the resource a notification subscription applies to.
+type NotificationScope string
+
+func (NotificationScope) Enum() []interface{} {
+	return toInterfaceSlice(notificationScopes)
+}
+func (s NotificationScope) Sanitize() (NotificationScope, bool) {
+	return Sanitize(s, GetAllNotificationScopes)
+}
+func GetAllNotificationScopes() ([]NotificationScope, NotificationScope) {
+	return notificationScopes, "" // No default value
+}
+
+// NotificationScope enumeration.
+const (
+	NotificationScopeSpace   NotificationScope = "space"
+	NotificationScopeRepo    NotificationScope = "repo"
+	NotificationScopePullReq NotificationScope = "pullreq"
+)
+
+var notificationScopes = sortEnum([]NotificationScope{
+	NotificationScopeSpace,
+	NotificationScopeRepo,
+	NotificationScopePullReq,
+})
@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// PullMirrorStatus defines the sync status of a pull mirror.
+type PullMirrorStatus string
+
+func (PullMirrorStatus) Enum() []interface{} { return toInterfaceSlice(pullMirrorStatuses) }
+func (s PullMirrorStatus) Sanitize() (PullMirrorStatus, bool) {
+	return Sanitize(s, GetAllPullMirrorStatuses)
+}
+func GetAllPullMirrorStatuses() ([]PullMirrorStatus, PullMirrorStatus) {
+	return pullMirrorStatuses, PullMirrorStatusPending
+}
+
+// PullMirrorStatus enumeration.
+const (
+	// PullMirrorStatusPending means the sync has been requested but hasn't started yet.
+	PullMirrorStatusPending PullMirrorStatus = "pending"
+	// PullMirrorStatusRunning means the repository is being synced from the remote.
+	PullMirrorStatusRunning PullMirrorStatus = "running"
+	// PullMirrorStatusSuccess means the last sync has completed successfully.
+	PullMirrorStatusSuccess PullMirrorStatus = "success"
+	// PullMirrorStatusFailed means the last sync has failed.
+	PullMirrorStatusFailed PullMirrorStatus = "failed"
+)
+
+var pullMirrorStatuses = sortEnum([]PullMirrorStatus{
+	PullMirrorStatusPending,
+	PullMirrorStatusRunning,
+	PullMirrorStatusSuccess,
+	PullMirrorStatusFailed,
+})
@@ -78,18 +78,21 @@ func GetAllPullReqActivityTypes() ([]PullReqActivityType, PullReqActivityType) {
 
 // PullReqActivityType enumeration.
 const (
-	PullReqActivityTypeComment        PullReqActivityType = "comment"
-	PullReqActivityTypeCodeComment    PullReqActivityType = "code-comment"
-	PullReqActivityTypeTitleChange    PullReqActivityType = "title-change"
-	PullReqActivityTypeStateChange    PullReqActivityType = "state-change"
-	PullReqActivityTypeReviewSubmit   PullReqActivityType = "review-submit"
-	PullReqActivityTypeReviewerAdd    PullReqActivityType = "reviewer-add"
-	PullReqActivityTypeReviewerDelete PullReqActivityType = "reviewer-delete"
-	PullReqActivityTypeBranchUpdate   PullReqActivityType = "branch-update"
-	PullReqActivityTypeBranchDelete   PullReqActivityType = "branch-delete"
-	PullReqActivityTypeBranchRestore  PullReqActivityType = "branch-restore"
-	PullReqActivityTypeMerge          PullReqActivityType = "merge"
-	PullReqActivityTypeLabelModify    PullReqActivityType = "label-modify"
+	PullReqActivityTypeComment          PullReqActivityType = "comment"
+	PullReqActivityTypeCodeComment      PullReqActivityType = "code-comment"
+	PullReqActivityTypeTitleChange      PullReqActivityType = "title-change"
+	PullReqActivityTypeStateChange      PullReqActivityType = "state-change"
+	PullReqActivityTypeReviewSubmit     PullReqActivityType = "review-submit"
+	PullReqActivityTypeReviewerAdd      PullReqActivityType = "reviewer-add"
+	PullReqActivityTypeReviewerDelete   PullReqActivityType = "reviewer-delete"
+	PullReqActivityTypeBranchUpdate     PullReqActivityType = "branch-update"
+	PullReqActivityTypeBranchDelete     PullReqActivityType = "branch-delete"
+	PullReqActivityTypeBranchRestore    PullReqActivityType = "branch-restore"
+	PullReqActivityTypeMerge            PullReqActivityType = "merge"
+	PullReqActivityTypeLabelModify      PullReqActivityType = "label-modify"
+	PullReqActivityTypeMergeQueueEject  PullReqActivityType = "merge-queue-eject"
+	PullReqActivityTypeAutoMergeEnable  PullReqActivityType = "auto-merge-enable"
+	PullReqActivityTypeAutoMergeDisable PullReqActivityType = "auto-merge-disable"
 )
 
 var pullReqActivityTypes = sortEnum([]PullReqActivityType{
@@ -105,6 +108,9 @@ var pullReqActivityTypes = sortEnum([]PullReqActivityType{
 	PullReqActivityTypeBranchRestore,
 	PullReqActivityTypeMerge,
 	PullReqActivityTypeLabelModify,
+	PullReqActivityTypeMergeQueueEject,
+	PullReqActivityTypeAutoMergeEnable,
+	PullReqActivityTypeAutoMergeDisable,
 })
 
 // PullReqActivityKind defines kind of pull request activity system message.
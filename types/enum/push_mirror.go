@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// PushMirrorTrigger defines what starts a push to a push mirror.
+type PushMirrorTrigger string
+
+func (PushMirrorTrigger) Enum() []interface{} { return toInterfaceSlice(pushMirrorTriggers) }
+func (t PushMirrorTrigger) Sanitize() (PushMirrorTrigger, bool) {
+	return Sanitize(t, GetAllPushMirrorTriggers)
+}
+func GetAllPushMirrorTriggers() ([]PushMirrorTrigger, PushMirrorTrigger) {
+	return pushMirrorTriggers, PushMirrorTriggerPush
+}
+
+// PushMirrorTrigger enumeration.
+const (
+	// PushMirrorTriggerPush means the remote is updated whenever a matching branch or tag changes.
+	PushMirrorTriggerPush PushMirrorTrigger = "push"
+	// PushMirrorTriggerInterval means the remote is updated periodically.
+	PushMirrorTriggerInterval PushMirrorTrigger = "interval"
+)
+
+var pushMirrorTriggers = sortEnum([]PushMirrorTrigger{
+	PushMirrorTriggerPush,
+	PushMirrorTriggerInterval,
+})
+
+// PushMirrorStatus defines the status of the last push to a push mirror.
+type PushMirrorStatus string
+
+func (PushMirrorStatus) Enum() []interface{} { return toInterfaceSlice(pushMirrorStatuses) }
+func (s PushMirrorStatus) Sanitize() (PushMirrorStatus, bool) {
+	return Sanitize(s, GetAllPushMirrorStatuses)
+}
+func GetAllPushMirrorStatuses() ([]PushMirrorStatus, PushMirrorStatus) {
+	return pushMirrorStatuses, PushMirrorStatusPending
+}
+
+// PushMirrorStatus enumeration.
+const (
+	// PushMirrorStatusPending means the push has been requested but hasn't started yet.
+	PushMirrorStatusPending PushMirrorStatus = "pending"
+	// PushMirrorStatusRunning means the repository is being pushed to the remote.
+	PushMirrorStatusRunning PushMirrorStatus = "running"
+	// PushMirrorStatusSuccess means the last push has completed successfully.
+	PushMirrorStatusSuccess PushMirrorStatus = "success"
+	// PushMirrorStatusFailed means the last push has failed, it's retried a few times.
+	PushMirrorStatusFailed PushMirrorStatus = "failed"
+)
+
+var pushMirrorStatuses = sortEnum([]PushMirrorStatus{
+	PushMirrorStatusPending,
+	PushMirrorStatusRunning,
+	PushMirrorStatusSuccess,
+	PushMirrorStatusFailed,
+})
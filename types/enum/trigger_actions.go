@@ -40,6 +40,17 @@ const (
 	TriggerActionPullReqClosed TriggerAction = "pullreq_closed"
 	// TriggerActionPullReqMerged gets triggered when a pull request is merged.
 	TriggerActionPullReqMerged TriggerAction = "pullreq_merged"
+	// TriggerActionPullReqMergeQueue gets triggered when a speculative merge commit is created for
+	// a pull request in the merge queue.
+	TriggerActionPullReqMergeQueue TriggerAction = "pullreq_merge_queue"
+	// TriggerActionPullReqCommentCreated gets triggered when a comment gets created on a pull request.
+	TriggerActionPullReqCommentCreated TriggerAction = "pullreq_comment_created"
+	// TriggerActionPullReqReviewSubmitted gets triggered when a review gets submitted for a pull request.
+	TriggerActionPullReqReviewSubmitted TriggerAction = "pullreq_review_submitted"
+	// TriggerActionPullReqLabelAssigned gets triggered when a label gets assigned to a pull request.
+	TriggerActionPullReqLabelAssigned TriggerAction = "pullreq_label_assigned"
+	// TriggerActionPullReqLabelUnassigned gets triggered when a label gets unassigned from a pull request.
+	TriggerActionPullReqLabelUnassigned TriggerAction = "pullreq_label_unassigned"
 )
 
 func (TriggerAction) Enum() []interface{}               { return toInterfaceSlice(triggerActions) }
@@ -49,7 +60,12 @@ func (t TriggerAction) GetTriggerEvent() TriggerEvent {
 		t == TriggerActionPullReqBranchUpdated ||
 		t == TriggerActionPullReqReopened ||
 		t == TriggerActionPullReqClosed ||
-		t == TriggerActionPullReqMerged {
+		t == TriggerActionPullReqMerged ||
+		t == TriggerActionPullReqMergeQueue ||
+		t == TriggerActionPullReqCommentCreated ||
+		t == TriggerActionPullReqReviewSubmitted ||
+		t == TriggerActionPullReqLabelAssigned ||
+		t == TriggerActionPullReqLabelUnassigned {
 		return TriggerEventPullRequest
 	}
 	if t == TriggerActionTagCreated || t == TriggerActionTagUpdated {
@@ -75,6 +91,11 @@ var triggerActions = sortEnum([]TriggerAction{
 	TriggerActionPullReqBranchUpdated,
 	TriggerActionPullReqClosed,
 	TriggerActionPullReqMerged,
+	TriggerActionPullReqMergeQueue,
+	TriggerActionPullReqCommentCreated,
+	TriggerActionPullReqReviewSubmitted,
+	TriggerActionPullReqLabelAssigned,
+	TriggerActionPullReqLabelUnassigned,
 })
 
 // Trigger types.
@@ -143,6 +143,39 @@ var webhookTypes = sortEnum([]WebhookType{
 	WebhookTypeJira,
 })
 
+// WebhookPayloadFormat defines the format of the request body sent by a webhook.
+type WebhookPayloadFormat string
+
+func (WebhookPayloadFormat) Enum() []interface{} { return toInterfaceSlice(webhookPayloadFormats) }
+func (f WebhookPayloadFormat) Sanitize() (WebhookPayloadFormat, bool) {
+	return Sanitize(f, GetAllWebhookPayloadFormats)
+}
+
+func GetAllWebhookPayloadFormats() ([]WebhookPayloadFormat, WebhookPayloadFormat) {
+	return webhookPayloadFormats, WebhookPayloadFormatDefault
+}
+
+const (
+	// WebhookPayloadFormatDefault sends the native JSON payload of the trigger.
+	WebhookPayloadFormatDefault WebhookPayloadFormat = "default"
+	// WebhookPayloadFormatSlack sends a message accepted by Slack incoming webhooks.
+	WebhookPayloadFormatSlack WebhookPayloadFormat = "slack"
+	// WebhookPayloadFormatTeams sends a message card accepted by Microsoft Teams incoming webhooks.
+	WebhookPayloadFormatTeams WebhookPayloadFormat = "teams"
+	// WebhookPayloadFormatDiscord sends a message accepted by Discord webhooks.
+	WebhookPayloadFormatDiscord WebhookPayloadFormat = "discord"
+	// WebhookPayloadFormatCustom sends the JSON rendered from the user-defined payload template of the webhook.
+	WebhookPayloadFormatCustom WebhookPayloadFormat = "custom"
+)
+
+var webhookPayloadFormats = sortEnum([]WebhookPayloadFormat{
+	WebhookPayloadFormatDefault,
+	WebhookPayloadFormatSlack,
+	WebhookPayloadFormatTeams,
+	WebhookPayloadFormatDiscord,
+	WebhookPayloadFormatCustom,
+})
+
 // WebhookTrigger defines the different types of webhook triggers available.
 type WebhookTrigger string
 
@@ -64,6 +64,11 @@ type Execution struct {
 	Version      int64              `json:"-"`
 	Stages       []*Stage           `json:"stages,omitempty"`
 
+	// ConcurrencyGroup is the resolved key of the concurrency group of the execution and
+	// SupersededBy the number of the execution that canceled this one in the group, if any.
+	ConcurrencyGroup string `json:"concurrency_group,omitempty"`
+	SupersededBy     int64  `json:"superseded_by,omitempty"`
+
 	// Pipeline specific information not stored with executions
 	PipelineUID string `json:"pipeline_uid,omitempty"`
 
@@ -96,6 +96,16 @@ type Commit struct {
 	Author     Signature    `json:"author"`
 	Committer  Signature    `json:"committer"`
 	Stats      *CommitStats `json:"stats,omitempty"`
+
+	Verification *CommitVerification `json:"verification,omitempty"`
+}
+
+// CommitVerification contains the result of the verification of a commit signature.
+type CommitVerification struct {
+	Status         enum.CommitSignatureStatus `json:"status"`
+	KeyScheme      enum.PublicKeyScheme       `json:"key_scheme,omitempty"`
+	KeyFingerprint string                     `json:"key_fingerprint,omitempty"`
+	Signer         *PrincipalInfo             `json:"signer,omitempty"`
 }
 
 type Signature struct {
@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// MergeQueueEntry represents a pull request waiting in the merge queue of its target branch.
+type MergeQueueEntry struct {
+	ID            int64                     `json:"id"`
+	RepoID        int64                     `json:"repo_id"`
+	PullReqID     int64                     `json:"pullreq_id"`
+	PullReqNumber int64                     `json:"pullreq_number"`
+	TargetBranch  string                    `json:"target_branch"`
+	State         enum.MergeQueueEntryState `json:"state"`
+
+	Method             enum.MergeMethod `json:"method"`
+	Title              string           `json:"title"`
+	Message            string           `json:"message"`
+	RulesBypassed      bool             `json:"rules_bypassed"`
+	DeleteSourceBranch bool             `json:"delete_source_branch"`
+
+	// SourceSHA is the pull request source branch commit that got enqueued.
+	SourceSHA string `json:"source_sha"`
+	// BaseSHA is the commit the speculative merge commit was built on top of.
+	BaseSHA string `json:"base_sha,omitempty"`
+	// MergeBaseSHA is the merge base of the SourceSHA and the BaseSHA.
+	MergeBaseSHA string `json:"merge_base_sha,omitempty"`
+	// MergeSHA is the speculative merge commit against which the required status checks are run.
+	MergeSHA string `json:"merge_sha,omitempty"`
+	// CheckStarted is the time when the status checks for the MergeSHA were started.
+	CheckStarted int64 `json:"check_started,omitempty"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+	Version   int64 `json:"-"`
+}
+
+// MergeQueue identifies a single merge queue - the queue of a branch in a repository.
+type MergeQueue struct {
+	RepoID       int64
+	TargetBranch string
+}
+
+// MergeQueueFilter stores merge queue entry query parameters.
+type MergeQueueFilter struct {
+	TargetBranch string `json:"target_branch"`
+}
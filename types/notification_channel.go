@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// NotificationChannel is a chat-ops channel of a space to which pull request notifications are posted.
+// It receives the notifications of all repositories in the space and its subspaces.
+type NotificationChannel struct {
+	ID          int64                             `json:"-"`
+	SpaceID     int64                             `json:"space_id"`
+	Identifier  string                            `json:"identifier"`
+	Description string                            `json:"description"`
+	Type        enum.NotificationChannelType      `json:"type"`
+	URL         string                            `json:"-"` // encrypted, the incoming webhook URL is a secret
+	Enabled     bool                              `json:"enabled"`
+	Triggers    []enum.NotificationChannelTrigger `json:"triggers"`
+	CreatedBy   int64                             `json:"created_by"`
+	Created     int64                             `json:"created"`
+	Updated     int64                             `json:"updated"`
+	Version     int64                             `json:"-"`
+}
+
+// NotificationChannelCreateInput is used for creating a notification channel.
+type NotificationChannelCreateInput struct {
+	Identifier  string                            `json:"identifier"`
+	Description string                            `json:"description"`
+	Type        enum.NotificationChannelType      `json:"type"`
+	URL         string                            `json:"url"`
+	Enabled     bool                              `json:"enabled"`
+	Triggers    []enum.NotificationChannelTrigger `json:"triggers"`
+}
+
+// NotificationChannelUpdateInput is used for updating a notification channel.
+type NotificationChannelUpdateInput struct {
+	Identifier  *string                           `json:"identifier"`
+	Description *string                           `json:"description"`
+	URL         *string                           `json:"url"`
+	Enabled     *bool                             `json:"enabled"`
+	Triggers    []enum.NotificationChannelTrigger `json:"triggers"`
+}
+
+// HasTrigger returns true if the channel is subscribed to the provided trigger.
+func (c *NotificationChannel) HasTrigger(trigger enum.NotificationChannelTrigger) bool {
+	for _, t := range c.Triggers {
+		if t == trigger {
+			return true
+		}
+	}
+	return false
+}
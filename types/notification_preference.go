@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// NotificationSettings are the user level email notification preferences of a principal.
+type NotificationSettings struct {
+	PrincipalID    int64                     `json:"-"`
+	Delivery       enum.NotificationDelivery `json:"delivery"`
+	DisabledEvents []enum.NotificationEvent  `json:"disabled_events"`
+	Updated        int64                     `json:"updated"`
+}
+
+// NotificationSubscription overrides the user level notification settings of a principal
+// for a single space (including its subspaces), repository or pull request.
+// The most specific subscription wins: pull request over repository over the closest space.
+type NotificationSubscription struct {
+	ID             int64                    `json:"-"`
+	PrincipalID    int64                    `json:"-"`
+	Scope          enum.NotificationScope   `json:"scope"`
+	ScopeID        int64                    `json:"scope_id"`
+	Level          enum.NotificationLevel   `json:"level"`
+	DisabledEvents []enum.NotificationEvent `json:"disabled_events"`
+	Created        int64                    `json:"created"`
+	Updated        int64                    `json:"updated"`
+}
+
+// NotificationPreferences contains all notification preferences of a principal.
+type NotificationPreferences struct {
+	Settings      *NotificationSettings       `json:"settings"`
+	Subscriptions []*NotificationSubscription `json:"subscriptions"`
+}
+
+// NotificationSettingsInput is used for updating the user level notification settings.
+type NotificationSettingsInput struct {
+	Delivery       *enum.NotificationDelivery `json:"delivery"`
+	DisabledEvents []enum.NotificationEvent   `json:"disabled_events"`
+}
+
+// NotificationSubscriptionInput is used for setting the notification subscription of a space, repository
+// or pull request.
+type NotificationSubscriptionInput struct {
+	Level          enum.NotificationLevel   `json:"level"`
+	DisabledEvents []enum.NotificationEvent `json:"disabled_events"`
+}
+
+// NotificationDigestItem is a notification waiting to be sent to a principal as part of the daily digest email.
+type NotificationDigestItem struct {
+	ID          int64
+	PrincipalID int64
+	// EventID identifies the event that triggered the notification, a principal gets one item per event.
+	EventID string
+	Subject string
+	Body    string
+	Created int64
+}
+
+// IsEventDisabled returns true if the provided event is disabled by the settings.
+func (s *NotificationSettings) IsEventDisabled(event enum.NotificationEvent) bool {
+	return containsNotificationEvent(s.DisabledEvents, event)
+}
+
+// IsEventDisabled returns true if the provided event is disabled by the subscription.
+func (s *NotificationSubscription) IsEventDisabled(event enum.NotificationEvent) bool {
+	return containsNotificationEvent(s.DisabledEvents, event)
+}
+
+func containsNotificationEvent(events []enum.NotificationEvent, event enum.NotificationEvent) bool {
+	for _, e := range events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
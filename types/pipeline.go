@@ -14,7 +14,11 @@
 
 package types
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
 
 type Pipeline struct {
 	ID          int64  `db:"pipeline_id"              json:"id"`
@@ -29,6 +33,12 @@ type Pipeline struct {
 	ConfigPath    string `db:"pipeline_config_path"     json:"config_path"`
 	Created       int64  `db:"pipeline_created"         json:"created"`
 
+	// ConcurrencyGroup is the template of the concurrency group key of the pipeline executions,
+	// at most one execution of a concurrency group runs at a time within a repository.
+	// ConcurrencyPolicy defines what happens with the executions already in progress in the group.
+	ConcurrencyGroup  string                 `db:"pipeline_concurrency_group"  json:"concurrency_group,omitempty"`
+	ConcurrencyPolicy enum.ConcurrencyPolicy `db:"pipeline_concurrency_policy" json:"concurrency_policy,omitempty"`
+
 	// Execution contains information about the latest execution if available
 	Execution      *Execution       `db:"-" json:"execution,omitempty"`
 	LastExecutions []*ExecutionInfo `db:"-" json:"last_executions,omitempty"`
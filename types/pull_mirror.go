@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PullMirror represents a repository that is kept in sync with a remote git repository.
+// All branches and tags of the repository are owned by the remote and can't be modified locally.
+type PullMirror struct {
+	ID     int64 `json:"-"`
+	RepoID int64 `json:"repo_id"`
+
+	// URL is the clone URL of the remote repository.
+	URL      string `json:"url"`
+	Username string `json:"username,omitempty"`
+	// SecretID is the secret holding the password or the access token used to authenticate with the remote.
+	SecretID *int64 `json:"-"`
+	// Secret is the identifier of the secret, it's populated when the pull mirror is read.
+	Secret string `json:"secret,omitempty"`
+	// Interval is the time between two periodic syncs, in minutes.
+	Interval int64 `json:"interval"`
+	Enabled  bool  `json:"enabled"`
+
+	Status enum.PullMirrorStatus `json:"status"`
+	// LastSync is the time when the last sync has completed, either successfully or not.
+	LastSync  int64  `json:"last_sync,omitempty"`
+	LastError string `json:"last_error,omitempty"`
+	// NextSync is the time of the next periodic sync.
+	NextSync int64 `json:"next_sync"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+	Version   int64 `json:"-"`
+}
@@ -257,6 +257,9 @@ type MergeResponse struct {
 	BranchDeleted  bool             `json:"branch_deleted,omitempty"`
 	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
 
+	// MergeQueued is true if instead of being merged the pull request has been added to the merge queue.
+	MergeQueued bool `json:"merge_queued,omitempty"`
+
 	// values only returned on dryrun
 	DryRunRules                         bool               `json:"dry_run_rules,omitempty"`
 	DryRun                              bool               `json:"dry_run,omitempty"`
@@ -269,6 +272,7 @@ type MergeResponse struct {
 	RequiresCodeOwnersApprovalLatest    bool               `json:"requires_code_owners_approval_latest,omitempty"`
 	RequiresCommentResolution           bool               `json:"requires_comment_resolution,omitempty"`
 	RequiresNoChangeRequests            bool               `json:"requires_no_change_requests,omitempty"`
+	RequiresMergeQueue                  bool               `json:"requires_merge_queue,omitempty"`
 }
 
 type MergeViolations struct {
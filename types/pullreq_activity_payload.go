@@ -61,6 +61,9 @@ var allPullReqActivityPayloads = func(
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchUpdate{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchDelete{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchRestore{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadMergeQueueEject{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadAutoMergeEnable{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadAutoMergeDisable{} },
 })
 
 // newPayloadForActivity returns a new payload instance for the requested activity type.
@@ -178,6 +181,33 @@ func (a *PullRequestActivityPayloadBranchRestore) ActivityType() enum.PullReqAct
 	return enum.PullReqActivityTypeBranchRestore
 }
 
+type PullRequestActivityPayloadMergeQueueEject struct {
+	TargetBranch string `json:"target_branch"`
+	MergeSHA     string `json:"merge_sha,omitempty"`
+	Reason       string `json:"reason"`
+}
+
+func (a *PullRequestActivityPayloadMergeQueueEject) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeMergeQueueEject
+}
+
+type PullRequestActivityPayloadAutoMergeEnable struct {
+	MergeMethod enum.MergeMethod `json:"merge_method"`
+}
+
+func (a *PullRequestActivityPayloadAutoMergeEnable) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeAutoMergeEnable
+}
+
+type PullRequestActivityPayloadAutoMergeDisable struct {
+	// Reason is empty if the auto-merge has been disabled by a user.
+	Reason string `json:"reason,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadAutoMergeDisable) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeAutoMergeDisable
+}
+
 type PullRequestActivityLabel struct {
 	Label         string                        `json:"label"`
 	LabelColor    enum.LabelColor               `json:"label_color"`
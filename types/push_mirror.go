@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PushMirror represents a remote git repository that is kept up to date with the branches and tags of a repository.
+type PushMirror struct {
+	ID         int64  `json:"id"`
+	RepoID     int64  `json:"repo_id"`
+	Identifier string `json:"identifier"`
+
+	// URL is the clone URL of the remote repository.
+	URL      string `json:"url"`
+	Username string `json:"username,omitempty"`
+	// SecretID is the secret holding the password or the access token used to authenticate with the remote.
+	SecretID *int64 `json:"-"`
+	// Secret is the identifier of the secret, it's populated when the push mirror is read.
+	Secret string `json:"secret,omitempty"`
+	// RefFilter is a regular expression that selects the pushed branches and tags by their full reference name,
+	// for example "^refs/heads/release/". If empty, all branches and tags are pushed.
+	RefFilter string `json:"ref_filter,omitempty"`
+
+	Trigger enum.PushMirrorTrigger `json:"trigger"`
+	// Interval is the time between two periodic pushes, in minutes. Used only with the interval trigger.
+	Interval int64 `json:"interval,omitempty"`
+	Enabled  bool  `json:"enabled"`
+
+	Status enum.PushMirrorStatus `json:"status"`
+	// LastSuccess is the time when the last successful push has completed.
+	LastSuccess int64  `json:"last_success,omitempty"`
+	LastError   string `json:"last_error,omitempty"`
+	// NextSync is the time of the next periodic push. Used only with the interval trigger.
+	NextSync int64 `json:"next_sync,omitempty"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+	Version   int64 `json:"-"`
+}
@@ -0,0 +1,36 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/git/sha"
+
+type RevertResponse struct {
+	CommitSHA      sha.SHA          `json:"commit_sha"`
+	Branch         string           `json:"branch"`
+	PullReq        *PullReq         `json:"pull_request,omitempty"`
+	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
+
+	DryRunRules bool `json:"dry_run_rules,omitempty"`
+}
+
+type CherryPickResponse struct {
+	CommitSHA      sha.SHA          `json:"commit_sha"`
+	CommitCount    int              `json:"commit_count"`
+	Branch         string           `json:"branch"`
+	PullReq        *PullReq         `json:"pull_request,omitempty"`
+	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
+
+	DryRunRules bool `json:"dry_run_rules,omitempty"`
+}
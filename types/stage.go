@@ -45,4 +45,7 @@ type Stage struct {
 	DependsOn   []string          `json:"depends_on,omitempty"`
 	Labels      map[string]string `json:"labels,omitempty"`
 	Steps       []*Step           `json:"steps,omitempty"`
+
+	// ConcurrencyGroup is the concurrency group of the execution the stage belongs to.
+	ConcurrencyGroup string `json:"concurrency_group,omitempty"`
 }
@@ -32,6 +32,19 @@ type Token struct {
 	// IssuedAt is the unix time at which the token was issued.
 	IssuedAt  int64 `db:"token_issued_at"          json:"issued_at"`
 	CreatedBy int64 `db:"token_created_by"         json:"created_by"`
+	// Scopes is an optional list of restrictions of the token. A token without scopes
+	// has all permissions of its principal, otherwise it's restricted to the scopes.
+	Scopes []TokenScope `db:"-"                        json:"scopes,omitempty"`
+}
+
+// TokenScope restricts a token to a space (including all its subspaces and repositories) or a repository,
+// and to a subset of permissions within it. The permissions are only granted if the principal has them as well.
+type TokenScope struct {
+	SpaceID int64 `json:"space_id,omitempty"`
+	RepoID  int64 `json:"repo_id,omitempty"`
+	// Path is the path of the space or repository, provided for convenience when the token is returned.
+	Path        string            `json:"path,omitempty"`
+	Permissions []enum.Permission `json:"permissions"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
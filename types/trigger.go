@@ -30,10 +30,28 @@ type Trigger struct {
 	CreatedBy   int64                `json:"created_by"`
 	Disabled    bool                 `json:"disabled"`
 	Actions     []enum.TriggerAction `json:"actions"`
-	Identifier  string               `json:"identifier"`
-	Created     int64                `json:"created"`
-	Updated     int64                `json:"updated"`
-	Version     int64                `json:"-"`
+	// CommentPattern is the regular expression the text of a pull request comment has to match
+	// for the pullreq_comment_created action. An empty pattern matches all comments.
+	CommentPattern string `json:"comment_pattern,omitempty"`
+	// Paths restricts the trigger to changes of files matching the path filter.
+	Paths      TriggerPathFilter `json:"paths"`
+	Identifier string            `json:"identifier"`
+	Created    int64             `json:"created"`
+	Updated    int64             `json:"updated"`
+	Version    int64             `json:"-"`
+}
+
+// TriggerPathFilter holds glob patterns of file paths that are evaluated against the files changed
+// by a push or a pull request. The trigger fires if at least one changed file matches any of the include
+// patterns (or if there are none) and doesn't match any of the exclude patterns.
+type TriggerPathFilter struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// IsEmpty returns true if the path filter doesn't restrict the trigger.
+func (f TriggerPathFilter) IsEmpty() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
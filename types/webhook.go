@@ -45,6 +45,14 @@ type Webhook struct {
 	Insecure              bool                         `json:"insecure"`
 	Triggers              []enum.WebhookTrigger        `json:"triggers"`
 	LatestExecutionResult *enum.WebhookExecutionResult `json:"latest_execution_result,omitempty"`
+
+	PayloadFormat   enum.WebhookPayloadFormat `json:"payload_format"`
+	PayloadTemplate string                    `json:"payload_template,omitempty"`
+
+	// MaxRetries is the number of automatic redeliveries of a failed execution (0 disables automatic retries).
+	MaxRetries int `json:"max_retries"`
+	// ConsecutiveFailures is the number of deliveries that failed in a row, after all retries.
+	ConsecutiveFailures int `json:"consecutive_failures"`
 }
 
 // MarshalJSON overrides the default json marshaling for `Webhook` allowing us to inject the `HasSecret` field.
@@ -81,6 +89,11 @@ type WebhookCreateInput struct {
 	Enabled     bool                  `json:"enabled"`
 	Insecure    bool                  `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+
+	PayloadFormat   enum.WebhookPayloadFormat `json:"payload_format"`
+	PayloadTemplate string                    `json:"payload_template"`
+
+	MaxRetries int `json:"max_retries"`
 }
 
 type WebhookSignatureMetadata struct {
@@ -100,6 +113,20 @@ type WebhookUpdateInput struct {
 	Enabled     *bool                 `json:"enabled"`
 	Insecure    *bool                 `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+
+	PayloadFormat   *enum.WebhookPayloadFormat `json:"payload_format"`
+	PayloadTemplate *string                    `json:"payload_template"`
+
+	MaxRetries *int `json:"max_retries"`
+}
+
+// WebhookTestInput is the input for a test delivery of a webhook.
+// The payload format and template can be overridden to preview changes before updating the webhook.
+type WebhookTestInput struct {
+	Trigger         enum.WebhookTrigger        `json:"trigger"`
+	DryRun          bool                       `json:"dry_run"`
+	PayloadFormat   *enum.WebhookPayloadFormat `json:"payload_format"`
+	PayloadTemplate *string                    `json:"payload_template"`
 }
 
 // WebhookExecution represents a single execution of a webhook.
@@ -116,6 +143,24 @@ type WebhookExecution struct {
 	Error         string                      `json:"error,omitempty"`
 	Request       WebhookExecutionRequest     `json:"request"`
 	Response      WebhookExecutionResponse    `json:"response"`
+
+	// Attempt is the number of the automatic delivery attempt, starting with 1.
+	Attempt int `json:"attempt"`
+	// NextRetryAt is the time of the scheduled automatic redelivery of a failed execution.
+	NextRetryAt *int64 `json:"next_retry_at,omitempty"`
+}
+
+// WebhookFailedExecution is a failed webhook execution that won't be redelivered automatically.
+type WebhookFailedExecution struct {
+	WebhookExecution
+	WebhookIdentifier string             `json:"webhook_identifier"`
+	WebhookParentType enum.WebhookParent `json:"webhook_parent_type"`
+	WebhookParentID   int64              `json:"webhook_parent_id"`
+}
+
+// WebhookRetriggerExecutionsInput is the input for the bulk redelivery of failed webhook executions.
+type WebhookRetriggerExecutionsInput struct {
+	ExecutionIDs []int64 `json:"execution_ids"`
 }
 
 // WebhookExecutionRequest represents the request of a webhook execution.
@@ -149,6 +194,13 @@ type WebhookExecutionFilter struct {
 	Size int `json:"size"`
 }
 
+// WebhookFailedExecutionFilter stores WebhookFailedExecution query parameters for listing.
+type WebhookFailedExecutionFilter struct {
+	Page    int                 `json:"page"`
+	Size    int                 `json:"size"`
+	Trigger enum.WebhookTrigger `json:"trigger"`
+}
+
 type WebhookParentInfo struct {
 	Type enum.WebhookParent
 	ID   int64